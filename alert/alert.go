@@ -0,0 +1,258 @@
+// Package alert watches addresses, typically hot wallets, and raises alerts on
+// single transfers above a threshold and on outflows exceeding a rate limit.
+// It is fed by the indexer and measures windows in block time, so replaying
+// blocks raises the same alerts with the same ids.
+package alert
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/indexer"
+	"github.com/binance-chain/go-sdk/indexer/webhook"
+)
+
+type Kind string
+
+const (
+	LargeTransfer Kind = "large_transfer"
+	OutflowRate   Kind = "outflow_rate"
+)
+
+type Direction string
+
+const (
+	Incoming Direction = "incoming"
+	Outgoing Direction = "outgoing"
+)
+
+// Rule sets the limits of a denom, amounts in units of 1e-8. Zero values
+// disable a limit.
+type Rule struct {
+	Denom string
+	// LargeTransfer is the amount above which a single transfer to or from a
+	// watched address raises an alert.
+	LargeTransfer int64
+	// MaxOutflow is the amount a watched address may send within Window, the
+	// limit is off without a Window.
+	MaxOutflow int64
+	Window     time.Duration
+}
+
+// Alert is raised once per transfer above the threshold, and once when the
+// outflow of an address exceeds the limit, until it falls back under it. ID
+// is stable across replays.
+type Alert struct {
+	ID        string        `json:"id"`
+	Kind      Kind          `json:"kind"`
+	Address   string        `json:"address"`
+	Direction Direction     `json:"direction"`
+	Denom     string        `json:"denom"`
+	Amount    int64         `json:"amount"`
+	Limit     int64         `json:"limit"`
+	Window    time.Duration `json:"window,omitempty"`
+	Height    int64         `json:"height"`
+	Time      time.Time     `json:"time"`
+	TxHash    string        `json:"tx_hash"`
+	MsgIndex  int           `json:"msg_index"`
+}
+
+type Config struct {
+	Rules []Rule
+	// OnAlert gets every alert, an error stops the indexer before the block is
+	// checkpointed.
+	OnAlert func(Alert) error
+}
+
+// Monitor raises the alerts of its addresses. It is safe for concurrent use.
+type Monitor struct {
+	cfg   Config
+	rules map[string]Rule
+
+	mtx      sync.Mutex
+	watched  map[string]bool
+	outflows map[outflowKey]*outflow
+}
+
+type outflowKey struct {
+	addr  string
+	denom string
+}
+
+type outflow struct {
+	sent []sent
+	// exceededBy is the msg that took the outflow over the limit, until it
+	// falls back under it.
+	exceededBy string
+}
+
+type sent struct {
+	msg    string
+	at     time.Time
+	amount int64
+}
+
+func NewMonitor(cfg Config, addrs ...types.AccAddress) *Monitor {
+	m := &Monitor{cfg: cfg, rules: map[string]Rule{}, watched: map[string]bool{}, outflows: map[outflowKey]*outflow{}}
+	for _, rule := range cfg.Rules {
+		m.rules[rule.Denom] = rule
+	}
+	m.Watch(addrs...)
+	return m
+}
+
+func (m *Monitor) Watch(addrs ...types.AccAddress) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, addr := range addrs {
+		m.watched[string(addr)] = true
+	}
+}
+
+// Unwatch stops watching addrs and forgets their outflows.
+func (m *Monitor) Unwatch(addrs ...types.AccAddress) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, addr := range addrs {
+		delete(m.watched, string(addr))
+		for key := range m.outflows {
+			if key.addr == addr.String() {
+				delete(m.outflows, key)
+			}
+		}
+	}
+}
+
+// Handlers returns the indexer handlers that feed the monitor.
+func (m *Monitor) Handlers() indexer.Handlers {
+	return indexer.Handlers{OnTransfer: m.Process}
+}
+
+// Process checks a transfer against the rules. Failed txs moved nothing and
+// are skipped.
+func (m *Monitor) Process(e indexer.TransferEvent) error {
+	if !e.Success() {
+		return nil
+	}
+	alerts := m.check(e)
+	if m.cfg.OnAlert == nil {
+		return nil
+	}
+	for _, a := range alerts {
+		if err := m.cfg.OnAlert(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Monitor) check(e indexer.TransferEvent) []Alert {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	var flows []flow
+	for _, input := range e.Inputs {
+		if m.watched[string(input.Address)] {
+			flows = addFlow(flows, input.Address.String(), Outgoing, input.Coins)
+		}
+	}
+	for _, output := range e.Outputs {
+		if m.watched[string(output.Address)] {
+			flows = addFlow(flows, output.Address.String(), Incoming, output.Coins)
+		}
+	}
+
+	var alerts []Alert
+	for _, f := range flows {
+		rule, ok := m.rules[f.denom]
+		if !ok {
+			continue
+		}
+		a := Alert{
+			Address: f.addr, Direction: f.direction, Denom: f.denom, Amount: f.amount,
+			Height: e.Height, Time: e.Time, TxHash: e.TxHash, MsgIndex: e.MsgIndex,
+		}
+		if rule.LargeTransfer > 0 && f.amount > rule.LargeTransfer {
+			a.Kind, a.Limit = LargeTransfer, rule.LargeTransfer
+			a.ID = fmt.Sprintf("%s:%s:%d:%s:%s:%s", a.Kind, e.TxHash, e.MsgIndex, f.addr, f.direction, f.denom)
+			alerts = append(alerts, a)
+		}
+		if f.direction == Outgoing && rule.MaxOutflow > 0 && rule.Window > 0 {
+			msgID := fmt.Sprintf("%s:%d", e.TxHash, e.MsgIndex)
+			if total, exceeded := m.addOutflow(outflowKey{f.addr, f.denom}, rule, msgID, e.Time, f.amount); exceeded {
+				a.Kind, a.Amount, a.Limit, a.Window = OutflowRate, total, rule.MaxOutflow, rule.Window
+				a.ID = fmt.Sprintf("%s:%s:%d:%s:%s", a.Kind, e.TxHash, e.MsgIndex, f.addr, f.denom)
+				alerts = append(alerts, a)
+			}
+		}
+	}
+	return alerts
+}
+
+// flow is what an address sent or received of a denom in one msg.
+type flow struct {
+	addr      string
+	direction Direction
+	denom     string
+	amount    int64
+}
+
+// addFlow adds coins to flows, merging the addresses listed in several inputs
+// or outputs of a msg.
+func addFlow(flows []flow, addr string, direction Direction, coins types.Coins) []flow {
+	for _, coin := range coins {
+		merged := false
+		for i := range flows {
+			if f := &flows[i]; f.addr == addr && f.direction == direction && f.denom == coin.Denom {
+				f.amount += coin.Amount
+				merged = true
+			}
+		}
+		if !merged {
+			flows = append(flows, flow{addr: addr, direction: direction, denom: coin.Denom, amount: coin.Amount})
+		}
+	}
+	return flows
+}
+
+// addOutflow records amount sent by msgID at and returns the outflow within
+// the window of rule, and whether msgID took it over the limit. A msg seen
+// before, when the indexer retries a block, is not counted twice.
+func (m *Monitor) addOutflow(key outflowKey, rule Rule, msgID string, at time.Time, amount int64) (int64, bool) {
+	o, ok := m.outflows[key]
+	if !ok {
+		o = &outflow{}
+		m.outflows[key] = o
+	}
+	seen := false
+	for _, s := range o.sent {
+		seen = seen || s.msg == msgID
+	}
+	if !seen {
+		o.sent = append(o.sent, sent{msg: msgID, at: at, amount: amount})
+	}
+	for len(o.sent) > 0 && !o.sent[0].at.After(at.Add(-rule.Window)) {
+		o.sent = o.sent[1:]
+	}
+	var total int64
+	for _, s := range o.sent {
+		total += s.amount
+	}
+	if total <= rule.MaxOutflow {
+		o.exceededBy = ""
+		return total, false
+	}
+	if o.exceededBy == "" {
+		o.exceededBy = msgID
+	}
+	return total, o.exceededBy == msgID
+}
+
+// Webhook returns an OnAlert that publishes alerts to the endpoints of d
+// subscribed to webhook.EventAlert.
+func Webhook(d *webhook.Dispatcher) func(Alert) error {
+	return func(a Alert) error {
+		return d.Publish(webhook.Payload{ID: a.ID, Type: webhook.EventAlert, Height: a.Height, Time: a.Time, Data: a})
+	}
+}
@@ -0,0 +1,85 @@
+package alert
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/indexer"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func addr(s string) types.AccAddress {
+	return types.AccAddress([]byte(s + "-address-bytes-padding")[:types.AddrLen])
+}
+
+var start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func transfer(hash string, minutes int, from, to types.AccAddress, amount int64) indexer.TransferEvent {
+	coins := types.Coins{{Denom: "BNB", Amount: amount}}
+	return indexer.TransferEvent{
+		TxContext: indexer.TxContext{Height: int64(minutes), Time: start.Add(time.Duration(minutes) * time.Minute), TxHash: hash},
+		Inputs:    []msg.Input{{Address: from, Coins: coins}},
+		Outputs:   []msg.Output{{Address: to, Coins: coins}},
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	hot, alice := addr("hot"), addr("alice")
+	var alerts []Alert
+	m := NewMonitor(Config{
+		Rules:   []Rule{{Denom: "BNB", LargeTransfer: 500, MaxOutflow: 1000, Window: time.Hour}},
+		OnAlert: func(a Alert) error { alerts = append(alerts, a); return nil },
+	}, hot)
+
+	assert.NoError(t, m.Process(transfer("A", 0, alice, hot, 600)))
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, LargeTransfer, alerts[0].Kind)
+	assert.Equal(t, Incoming, alerts[0].Direction)
+
+	assert.NoError(t, m.Process(transfer("B", 10, hot, alice, 400)))
+	assert.NoError(t, m.Process(transfer("C", 20, hot, alice, 400)))
+	assert.Len(t, alerts, 1)
+	assert.NoError(t, m.Process(transfer("D", 30, hot, alice, 300)))
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, OutflowRate, alerts[1].Kind)
+	assert.Equal(t, int64(1100), alerts[1].Amount)
+	assert.Equal(t, "outflow_rate:D:0:"+hot.String()+":BNB", alerts[1].ID)
+
+	// raised once while the outflow stays over the limit
+	assert.NoError(t, m.Process(transfer("E", 40, hot, alice, 100)))
+	assert.Len(t, alerts, 2)
+
+	// B and C left the window
+	assert.NoError(t, m.Process(transfer("F", 75, hot, alice, 100)))
+	assert.NoError(t, m.Process(transfer("G", 80, hot, alice, 700)))
+	assert.Len(t, alerts, 4)
+	assert.Equal(t, LargeTransfer, alerts[2].Kind)
+	assert.Equal(t, OutflowRate, alerts[3].Kind)
+	assert.Equal(t, int64(1200), alerts[3].Amount)
+}
+
+func TestMonitorRetriedBlock(t *testing.T) {
+	hot := addr("hot")
+	fail := true
+	var alerts []Alert
+	m := NewMonitor(Config{
+		Rules: []Rule{{Denom: "BNB", MaxOutflow: 100, Window: time.Hour}},
+		OnAlert: func(a Alert) error {
+			if fail {
+				return errors.New("unavailable")
+			}
+			alerts = append(alerts, a)
+			return nil
+		},
+	}, hot)
+	e := transfer("A", 0, hot, addr("bob"), 150)
+	assert.Error(t, m.Process(e))
+	fail = false
+	assert.NoError(t, m.Process(e))
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, int64(150), alerts[0].Amount, "the retried msg is not counted twice")
+}
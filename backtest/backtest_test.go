@@ -0,0 +1,116 @@
+package backtest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/tape"
+)
+
+// fakeTrades serves trades like the api server, newest first.
+type fakeTrades struct {
+	trades []types.Trade
+}
+
+func (f *fakeTrades) GetTrades(q *types.TradesQuery) (*types.Trades, error) {
+	var matched []types.Trade
+	for i := len(f.trades) - 1; i >= 0; i-- {
+		tr := f.trades[i]
+		if (q.Symbol == "" || tr.Symbol == q.Symbol) && tr.Time >= *q.Start && tr.Time <= *q.End {
+			matched = append(matched, tr)
+		}
+	}
+	offset, limit := int(*q.Offset), int(*q.Limit)
+	if offset > len(matched) {
+		offset = len(matched)
+	}
+	if offset+limit < len(matched) {
+		matched = matched[:offset+limit]
+	}
+	return &types.Trades{Trade: matched[offset:], Total: -1}, nil
+}
+
+func apiTrade(height int64, id, symbol, price, tick string) types.Trade {
+	return types.Trade{BlockHeight: height, Time: height * 10000, TradeID: id, Symbol: symbol, Price: price,
+		Quantity: "0.00000005", BuyerOrderID: "b-" + id, SellerOrderID: "s-" + id, TickType: tick}
+}
+
+func TestReplay(t *testing.T) {
+	depths := []Event{
+		{Time: time.Unix(15, 0), Symbol: "BNB_BTC", Depth: &websocket.MarketDepthEvent{
+			Symbol: "BNB_BTC", Bids: [][]types.Fixed8{{1e8, 2e8}}, Asks: [][]types.Fixed8{{2e8, 1e8}}}},
+		{Time: time.Unix(25, 0), Symbol: "ETH_BTC", Depth: &websocket.MarketDepthEvent{Symbol: "ETH_BTC"}},
+	}
+	var file bytes.Buffer
+	assert.NoError(t, WriteEvents(&file, depths))
+
+	client := &fakeTrades{trades: []types.Trade{
+		apiTrade(2, "2-0", "BNB_BTC", "1.00000000", "BuyTaker"),
+		apiTrade(2, "2-1", "ETH_BTC", "1.00000000", "BuyTaker"),
+		apiTrade(2, "2-2", "BNB_BTC", "1.10000000", "SellTaker"),
+		apiTrade(3, "3-0", "BNB_BTC", "1.20000000", "SellTaker"),
+	}}
+	trades, err := NewTapeSource(tape.NewTape(client, 15*time.Second), "", time.Unix(10, 0), time.Unix(30, 0))
+	assert.NoError(t, err)
+	r := NewReplayer(Merge(NewFileSource(&file), trades))
+
+	// a strategy written against the live client
+	var ws websocket.WSClient = r
+	var got []string
+	closed := 0
+	quit := make(chan struct{})
+	assert.NoError(t, ws.SubscribeMarketDepthEvent("BNB", "BTC", quit, func(ev *websocket.MarketDepthEvent) {
+		got = append(got, r.Now().Format("05")+" depth "+ev.Bids[0][0].String())
+		websocket.ReleaseMarketDepthEvent(ev)
+	}, nil, func() { closed++ }))
+	assert.NoError(t, ws.SubscribeTradeEvent("BNB", "BTC", quit, func(events []*websocket.TradeEvent) {
+		for _, ev := range events {
+			got = append(got, r.Now().Format("05")+" trade "+ev.TradeID+" "+ev.Price.String())
+		}
+	}, nil, func() { closed++ }))
+	assert.Error(t, ws.SubscribeAccountEvent("bnb1", quit, nil, nil, nil))
+
+	assert.NoError(t, r.Run(context.Background()))
+	assert.Equal(t, []string{
+		"15 depth 1.00000000",
+		"20 trade 2-0 1.00000000",
+		"20 trade 2-2 1.10000000",
+		"30 trade 3-0 1.20000000",
+	}, got)
+	assert.Equal(t, 2, closed)
+}
+
+type failingSource struct{}
+
+func (failingSource) Next() (*Event, error) {
+	return nil, errors.New("broken")
+}
+
+func TestReplayStops(t *testing.T) {
+	r := NewReplayer(NewSliceSource([]Event{
+		{Time: time.Unix(1, 0), Symbol: "BNB_BTC", Delta: &websocket.MarketDeltaEvent{}},
+		{Time: time.Unix(2, 0), Symbol: "BNB_BTC", Delta: &websocket.MarketDeltaEvent{}},
+	}))
+	quit := make(chan struct{})
+	deltas := 0
+	assert.NoError(t, r.SubscribeMarketDiffEvent("BNB", "BTC", quit, func(*websocket.MarketDeltaEvent) {
+		deltas++
+		close(quit)
+	}, nil, func() { t.Error("closed after quit") }))
+	assert.NoError(t, r.Run(context.Background()))
+	assert.Equal(t, 1, deltas)
+
+	var errs []error
+	r = NewReplayer(failingSource{})
+	assert.NoError(t, r.SubscribeMarketDiffEvent("BNB", "BTC", make(chan struct{}), nil,
+		func(err error) { errs = append(errs, err) }, nil))
+	assert.Error(t, r.Run(context.Background()))
+	assert.Len(t, errs, 1)
+}
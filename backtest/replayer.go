@@ -0,0 +1,238 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/common"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// Replayer is a websocket.WSClient serving the depth, diff and trade streams
+// from a Source. Subscribe as against the live client, then call Run: events
+// are handed to the callbacks in time order on the goroutine of Run, each
+// callback returning before the next event. Handlers get their own copy of an
+// event and may release it to the websocket pools.
+//
+// Streams that are not recorded fail to subscribe with an error.
+type Replayer struct {
+	source Source
+
+	mtx  sync.Mutex
+	subs []*subscription
+	now  time.Time
+}
+
+var _ websocket.WSClient = (*Replayer)(nil)
+
+type subscription struct {
+	symbol   string
+	quit     chan struct{}
+	onDepth  func(*websocket.MarketDepthEvent)
+	onDelta  func(*websocket.MarketDeltaEvent)
+	onTrades func([]*websocket.TradeEvent)
+	onError  func(error)
+	onClose  func()
+}
+
+func (s *subscription) quitted() bool {
+	select {
+	case <-s.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewReplayer replays the events of source.
+func NewReplayer(source Source) *Replayer {
+	return &Replayer{source: source}
+}
+
+// Now is the time of the event being replayed, the clock of the backtest.
+func (r *Replayer) Now() time.Time {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.now
+}
+
+// Run replays the source to its end, or until ctx is done. The streams are
+// closed when it returns. An error of the source is also given to onError.
+func (r *Replayer) Run(ctx context.Context) error {
+	defer r.closeAll()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ev, err := r.source.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			for _, sub := range r.subscriptions() {
+				if sub.onError != nil && !sub.quitted() {
+					sub.onError(err)
+				}
+			}
+			return err
+		}
+		r.mtx.Lock()
+		r.now = ev.Time
+		r.mtx.Unlock()
+		r.deliver(ev)
+	}
+}
+
+func (r *Replayer) deliver(ev *Event) {
+	for _, sub := range r.subscriptions() {
+		if sub.symbol != ev.Symbol || sub.quitted() {
+			continue
+		}
+		switch {
+		case ev.Depth != nil && sub.onDepth != nil:
+			sub.onDepth(copyDepth(ev.Depth))
+		case ev.Delta != nil && sub.onDelta != nil:
+			sub.onDelta(copyDelta(ev.Delta))
+		case len(ev.Trades) > 0 && sub.onTrades != nil:
+			sub.onTrades(copyTrades(ev.Trades))
+		}
+	}
+}
+
+func (r *Replayer) subscriptions() []*subscription {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	live := r.subs[:0]
+	for _, sub := range r.subs {
+		if !sub.quitted() {
+			live = append(live, sub)
+		}
+	}
+	r.subs = live
+	return append([]*subscription(nil), live...)
+}
+
+func (r *Replayer) closeAll() {
+	r.mtx.Lock()
+	subs := r.subs
+	r.subs = nil
+	r.mtx.Unlock()
+	for _, sub := range subs {
+		if sub.onClose != nil && !sub.quitted() {
+			sub.onClose()
+		}
+	}
+}
+
+func (r *Replayer) subscribe(sub *subscription) error {
+	if sub.quit == nil {
+		return fmt.Errorf("quit channel is required")
+	}
+	r.mtx.Lock()
+	r.subs = append(r.subs, sub)
+	r.mtx.Unlock()
+	return nil
+}
+
+func (r *Replayer) SubscribeMarketDepthEvent(baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onReceive func(event *websocket.MarketDepthEvent), onError func(err error), onClose func()) error {
+	return r.subscribe(&subscription{symbol: common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol), quit: quit,
+		onDepth: onReceive, onError: onError, onClose: onClose})
+}
+
+func (r *Replayer) SubscribeMarketDiffEvent(baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onReceive func(event *websocket.MarketDeltaEvent), onError func(err error), onClose func()) error {
+	return r.subscribe(&subscription{symbol: common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol), quit: quit,
+		onDelta: onReceive, onError: onError, onClose: onClose})
+}
+
+func (r *Replayer) SubscribeTradeEvent(baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onReceive func(events []*websocket.TradeEvent), onError func(err error), onClose func()) error {
+	return r.subscribe(&subscription{symbol: common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol), quit: quit,
+		onTrades: onReceive, onError: onError, onClose: onClose})
+}
+
+func notReplayed(stream string) error {
+	return fmt.Errorf("the %s stream is not replayed in backtests", stream)
+}
+
+func (r *Replayer) SubscribeAccountEvent(userAddr string, quit chan struct{}, onReceive func(event *websocket.AccountEvent), onError func(err error), onClose func()) error {
+	return notReplayed("account")
+}
+
+func (r *Replayer) SubscribeBlockHeightEvent(quit chan struct{}, onReceive func(event *websocket.BlockHeightEvent), onError func(err error), onClose func()) error {
+	return notReplayed("block height")
+}
+
+func (r *Replayer) SubscribeKlineEvent(baseAssetSymbol, quoteAssetSymbol string, interval websocket.KlineInterval, quit chan struct{}, onReceive func(event *websocket.KlineEvent), onError func(err error), onClose func()) error {
+	return notReplayed("kline")
+}
+
+func (r *Replayer) SubscribeOrderEvent(userAddr string, quit chan struct{}, onReceive func(event []*websocket.OrderEvent), onError func(err error), onClose func()) error {
+	return notReplayed("order")
+}
+
+func (r *Replayer) SubscribeTickerEvent(baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onReceive func(event *websocket.TickerEvent), onError func(err error), onClose func()) error {
+	return notReplayed("ticker")
+}
+
+func (r *Replayer) SubscribeAllTickerEvent(quit chan struct{}, onReceive func(event []*websocket.TickerEvent), onError func(err error), onClose func()) error {
+	return notReplayed("ticker")
+}
+
+func (r *Replayer) SubscribeMiniTickerEvent(baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onReceive func(event *websocket.MiniTickerEvent), onError func(err error), onClose func()) error {
+	return notReplayed("mini ticker")
+}
+
+func (r *Replayer) SubscribeAllMiniTickersEvent(quit chan struct{}, onReceive func(events []*websocket.MiniTickerEvent), onError func(err error), onClose func()) error {
+	return notReplayed("mini ticker")
+}
+
+func (r *Replayer) SubscribeUserDataEvent(userAddr string, quit chan struct{}, handlers websocket.UserDataHandlers) error {
+	return notReplayed("user data")
+}
+
+func (r *Replayer) CreateListenKey(userAddr string) (string, error) {
+	return "", notReplayed("user data")
+}
+
+func (r *Replayer) KeepAliveListenKey(listenKey string) error {
+	return notReplayed("user data")
+}
+
+func (r *Replayer) CloseListenKey(listenKey string) error {
+	return notReplayed("user data")
+}
+
+func copyLevels(levels [][]types.Fixed8) [][]types.Fixed8 {
+	if levels == nil {
+		return nil
+	}
+	out := make([][]types.Fixed8, len(levels))
+	for i, level := range levels {
+		out[i] = append([]types.Fixed8(nil), level...)
+	}
+	return out
+}
+
+func copyDepth(ev *websocket.MarketDepthEvent) *websocket.MarketDepthEvent {
+	out := *ev
+	out.Bids, out.Asks = copyLevels(ev.Bids), copyLevels(ev.Asks)
+	return &out
+}
+
+func copyDelta(ev *websocket.MarketDeltaEvent) *websocket.MarketDeltaEvent {
+	out := *ev
+	out.Bids, out.Asks = copyLevels(ev.Bids), copyLevels(ev.Asks)
+	return &out
+}
+
+func copyTrades(events []*websocket.TradeEvent) []*websocket.TradeEvent {
+	out := make([]*websocket.TradeEvent, len(events))
+	for i, ev := range events {
+		trade := *ev
+		out[i] = &trade
+	}
+	return out
+}
@@ -0,0 +1,199 @@
+// Package backtest replays recorded market data through the websocket client
+// interface, so a strategy that subscribes to depth and trade streams with a
+// websocket.WSClient can be run against history unchanged.
+package backtest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/tape"
+)
+
+// Event is one message of a stream. Exactly one of Depth, Delta and Trades is
+// set, as it was delivered by SubscribeMarketDepthEvent, SubscribeMarketDiffEvent
+// or SubscribeTradeEvent. Symbol is the pair, like "BNB_BTC".
+type Event struct {
+	Time   time.Time                   `json:"time"`
+	Symbol string                      `json:"symbol"`
+	Depth  *websocket.MarketDepthEvent `json:"depth,omitempty"`
+	Delta  *websocket.MarketDeltaEvent `json:"delta,omitempty"`
+	Trades []*websocket.TradeEvent     `json:"trades,omitempty"`
+}
+
+// Source yields events in time order. Next returns io.EOF after the last one.
+type Source interface {
+	Next() (*Event, error)
+}
+
+type sliceSource struct {
+	events []Event
+}
+
+// NewSliceSource replays events, which must be sorted by Time.
+func NewSliceSource(events []Event) Source {
+	return &sliceSource{events: events}
+}
+
+func (s *sliceSource) Next() (*Event, error) {
+	if len(s.events) == 0 {
+		return nil, io.EOF
+	}
+	ev := &s.events[0]
+	s.events = s.events[1:]
+	return ev, nil
+}
+
+type fileSource struct {
+	decoder *json.Decoder
+	line    int
+}
+
+// NewFileSource reads events exported as JSON documents, one Event per line.
+func NewFileSource(r io.Reader) Source {
+	return &fileSource{decoder: json.NewDecoder(bufio.NewReader(r))}
+}
+
+func (s *fileSource) Next() (*Event, error) {
+	var ev Event
+	if err := s.decoder.Decode(&ev); err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, fmt.Errorf("decode event %d: %v", s.line+1, err)
+	}
+	s.line++
+	return &ev, nil
+}
+
+// WriteEvents exports events in the format NewFileSource reads.
+func WriteEvents(w io.Writer, events []Event) error {
+	encoder := json.NewEncoder(w)
+	for i := range events {
+		if err := encoder.Encode(&events[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type tapeSource struct {
+	tape    *tape.Tape
+	symbol  string
+	from    time.Time
+	to      time.Time
+	pending []*Event
+}
+
+// NewTapeSource replays the trades the api server recorded from from to to
+// inclusive, one event per pair and block, like the trade stream batches them.
+// The history is read a window of the tape at a time, as the replay reaches it.
+func NewTapeSource(t *tape.Tape, symbol string, from, to time.Time) (Source, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid time range [%s, %s]", from, to)
+	}
+	return &tapeSource{tape: t, symbol: symbol, from: from, to: to}, nil
+}
+
+func (s *tapeSource) Next() (*Event, error) {
+	for len(s.pending) == 0 {
+		if s.from.After(s.to) {
+			return nil, io.EOF
+		}
+		end := s.from.Add(s.tape.WindowSize() - time.Millisecond)
+		if end.After(s.to) {
+			end = s.to
+		}
+		trades, err := s.tape.Window(s.symbol, s.from, end)
+		if err != nil {
+			return nil, err
+		}
+		s.from = end.Add(time.Millisecond)
+		var height int64
+		var bySymbol map[string]*Event
+		for _, trade := range trades {
+			if bySymbol == nil || trade.Height != height {
+				height, bySymbol = trade.Height, make(map[string]*Event)
+			}
+			ev, ok := bySymbol[trade.Symbol]
+			if !ok {
+				ev = &Event{Time: trade.Time, Symbol: trade.Symbol}
+				bySymbol[trade.Symbol] = ev
+				s.pending = append(s.pending, ev)
+			}
+			ev.Trades = append(ev.Trades, tradeEvent(trade))
+		}
+	}
+	ev := s.pending[0]
+	s.pending = s.pending[1:]
+	return ev, nil
+}
+
+func tradeEvent(t tape.Trade) *websocket.TradeEvent {
+	ms := t.Time.UnixNano() / int64(time.Millisecond)
+	return &websocket.TradeEvent{
+		EventType:     "trade",
+		EventTime:     ms,
+		Symbol:        t.Symbol,
+		TradeID:       t.ID,
+		Price:         types.Fixed8(t.Price),
+		Qty:           types.Fixed8(t.Quantity),
+		BuyerOrderID:  t.BuyerOrderID,
+		SellerOrderID: t.SellerOrderID,
+		TradeTime:     ms,
+		SellerAddress: t.Seller,
+		BuyerAddress:  t.Buyer,
+	}
+}
+
+type mergedSource struct {
+	sources []Source
+	heads   []*Event
+	started bool
+}
+
+// Merge interleaves sources by time, e.g. depth snapshots from a file with the
+// trades of the tape. Events of the same time keep the order of sources.
+func Merge(sources ...Source) Source {
+	return &mergedSource{sources: sources, heads: make([]*Event, len(sources))}
+}
+
+func (m *mergedSource) Next() (*Event, error) {
+	if !m.started {
+		for i := range m.sources {
+			if err := m.advance(i); err != nil {
+				return nil, err
+			}
+		}
+		m.started = true
+	}
+	next := -1
+	for i, head := range m.heads {
+		if head != nil && (next < 0 || head.Time.Before(m.heads[next].Time)) {
+			next = i
+		}
+	}
+	if next < 0 {
+		return nil, io.EOF
+	}
+	ev := m.heads[next]
+	if err := m.advance(next); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+func (m *mergedSource) advance(i int) error {
+	ev, err := m.sources[i].Next()
+	if err == io.EOF {
+		m.heads[i] = nil
+		return nil
+	}
+	m.heads[i] = ev
+	return err
+}
@@ -0,0 +1,406 @@
+package bep3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	gtypes "github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+const (
+	DefaultLockDuration = 24 * time.Hour
+	DefaultSafetyMargin = 4 * time.Hour
+	DefaultClaimMargin  = 15 * time.Minute
+	DefaultBNBBlockTime = 400 * time.Millisecond
+	DefaultPollInterval = 5 * time.Second
+)
+
+var (
+	UnsafeCounterLockError   = fmt.Errorf("the counterparty lock does not leave enough time to claim safely")
+	CounterLockMismatchError = fmt.Errorf("the counterparty lock does not match the swap")
+)
+
+// Chain is the part of the Binance Chain rpc client the coordinator needs. *rpc.HTTP satisfies it.
+type Chain interface {
+	HTLT(recipient types.AccAddress, recipientOtherChain, senderOtherChain string, randomNumberHash []byte, timestamp int64,
+		amount types.Coins, expectedIncome string, heightSpan int64, crossChain bool, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error)
+	ClaimHTLT(swapID []byte, randomNumber []byte, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error)
+	RefundHTLT(swapID []byte, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error)
+	GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error)
+	Status() (*ctypes.ResultStatus, error)
+}
+
+type Direction int
+
+const (
+	// BNBToETH locks on Binance Chain and claims the counterparty lock on Ethereum.
+	BNBToETH Direction = iota
+	// ETHToBNB locks on Ethereum and claims the counterparty HTLT on Binance Chain.
+	ETHToBNB
+)
+
+// Config holds the accounts of the coordinator and its timing. Zero durations take the defaults.
+type Config struct {
+	// Address must be the account of the key the Chain client signs with.
+	Address    types.AccAddress
+	EthAddress string
+
+	// LockDuration is how long the lock created by the coordinator lasts.
+	LockDuration time.Duration
+	// SafetyMargin is how much earlier than ours the counterparty lock must expire,
+	// it is the time the counterparty has to claim our lock once we revealed the secret.
+	SafetyMargin time.Duration
+	// ClaimMargin is the least time the counterparty lock must have left for us to claim it.
+	ClaimMargin time.Duration
+	// BNBBlockTime converts between Binance Chain heights and durations, see
+	// EstimateBlockTime to measure it.
+	BNBBlockTime time.Duration
+	PollInterval time.Duration
+	// Deputies, if set, gives the limits the HTLT of a BNBToETH swap is
+	// checked against before it is created, see CheckHTLT.
+	Deputies DeputyInfoSource
+}
+
+// Swap describes one cross-chain swap. Amount is the Binance Chain leg and
+// EthAmount the Ethereum one, whichever side the coordinator locks.
+type Swap struct {
+	Direction              Direction
+	CounterpartyAddress    types.AccAddress
+	CounterpartyEthAddress string
+	Amount                 types.Coins
+	EthAmount              *big.Int
+	// ExpectedIncome goes into the HTLT of BNBToETH swaps, it defaults to EthAmount.
+	ExpectedIncome string
+}
+
+func (s Swap) expectedIncome() string {
+	if s.ExpectedIncome == "" && s.EthAmount != nil {
+		return s.EthAmount.String()
+	}
+	return s.ExpectedIncome
+}
+
+type Outcome int
+
+const (
+	// OutcomeClaimed means the coordinator claimed the counterparty lock.
+	OutcomeClaimed Outcome = iota
+	// OutcomeRefunded means the counterparty did not lock in time and the coordinator got its lock refunded.
+	OutcomeRefunded
+)
+
+type Result struct {
+	Outcome          Outcome
+	RandomNumber     []byte
+	RandomNumberHash []byte
+	Timestamp        int64
+	BNBSwapID        []byte
+	EthSwapID        []byte
+}
+
+// Coordinator runs both legs of a BEP3 swap between Binance Chain and Ethereum.
+// It generates the secret, creates the lock on the chain the swap starts from,
+// waits for the counterparty lock on the other chain, checks that it expires early
+// enough, and claims it. If the counterparty lock does not show up in time or is
+// unsafe, the coordinator waits for its own lock to expire and refunds it.
+type Coordinator struct {
+	chain  Chain
+	eth    EthBackend
+	cfg    Config
+	logger log.Logger
+}
+
+func NewCoordinator(chain Chain, eth EthBackend, cfg Config) *Coordinator {
+	if cfg.LockDuration <= 0 {
+		cfg.LockDuration = DefaultLockDuration
+	}
+	if cfg.SafetyMargin <= 0 {
+		cfg.SafetyMargin = DefaultSafetyMargin
+	}
+	if cfg.ClaimMargin <= 0 {
+		cfg.ClaimMargin = DefaultClaimMargin
+	}
+	if cfg.BNBBlockTime <= 0 {
+		cfg.BNBBlockTime = DefaultBNBBlockTime
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	return &Coordinator{chain: chain, eth: eth, cfg: cfg, logger: log.NewNopLogger()}
+}
+
+func (c *Coordinator) SetLogger(logger log.Logger) {
+	c.logger = logger
+}
+
+// Run carries out the swap and blocks until it is claimed or refunded. If ctx is
+// done first the locks stay as they are, Result tells which ones were created.
+func (c *Coordinator) Run(ctx context.Context, swap Swap) (*Result, error) {
+	if _, err := PlanDeadlines(time.Now(), c.cfg.LockDuration, c.cfg.SafetyMargin, c.cfg.ClaimMargin); err != nil {
+		return nil, err
+	}
+	if swap.Direction == BNBToETH && c.cfg.Deputies != nil {
+		if err := CheckHTLT(ctx, c.cfg.Deputies, swap.CounterpartyAddress, swap.Amount, swap.expectedIncome()); err != nil {
+			return nil, err
+		}
+	}
+	timestamp := time.Now().Unix()
+	randomNumber, randomNumberHash, err := msg.GenerateRandomNumberHash(timestamp)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{
+		RandomNumber:     randomNumber,
+		RandomNumberHash: randomNumberHash,
+		Timestamp:        timestamp,
+	}
+	switch swap.Direction {
+	case BNBToETH:
+		return res, c.runBNBToETH(ctx, swap, res)
+	case ETHToBNB:
+		return res, c.runETHToBNB(ctx, swap, res)
+	}
+	return nil, fmt.Errorf("unknown swap direction %d", swap.Direction)
+}
+
+func (c *Coordinator) runBNBToETH(ctx context.Context, swap Swap, res *Result) error {
+	heightSpan, err := HeightSpan(c.cfg.LockDuration, c.cfg.BNBBlockTime)
+	if err != nil {
+		return err
+	}
+	result, err := c.chain.HTLT(swap.CounterpartyAddress, c.cfg.EthAddress, swap.CounterpartyEthAddress, res.RandomNumberHash,
+		res.Timestamp, swap.Amount, swap.expectedIncome(), heightSpan, true, rpc.Commit)
+	if err := broadcastError(result, err); err != nil {
+		return err
+	}
+	res.BNBSwapID = msg.CalculateSwapID(res.RandomNumberHash, c.cfg.Address, swap.CounterpartyEthAddress)
+	c.logger.Info("created htlt", "swap_id", fmt.Sprintf("%X", res.BNBSwapID))
+
+	var counter *EthHTLC
+	err = c.poll(ctx, func() (bool, error) {
+		ourLeft, err := c.bnbTimeLeft(res.BNBSwapID)
+		if err != nil {
+			return false, err
+		}
+		htlc, err := c.eth.FindHTLC(ctx, res.RandomNumberHash)
+		if err != nil {
+			return false, err
+		}
+		if htlc != nil && htlc.Status == EthSwapOpen {
+			now, err := c.eth.Now(ctx)
+			if err != nil {
+				return false, err
+			}
+			if err := c.checkEthLock(htlc, swap, ourLeft, htlc.ExpireTime.Sub(now)); err != nil {
+				c.logger.Error("rejected counterparty lock", "err", err)
+				return true, nil
+			}
+			counter = htlc
+			return true, nil
+		}
+		// from here on no counterparty lock can be safe, give up waiting
+		return ourLeft <= c.cfg.SafetyMargin+c.cfg.ClaimMargin, nil
+	})
+	if err != nil {
+		return err
+	}
+	if counter != nil {
+		res.EthSwapID = counter.SwapID
+		if err := c.eth.Claim(ctx, counter.SwapID, res.RandomNumber); err != nil {
+			return err
+		}
+		res.Outcome = OutcomeClaimed
+		return nil
+	}
+	return c.refundBNB(ctx, res)
+}
+
+func (c *Coordinator) runETHToBNB(ctx context.Context, swap Swap, res *Result) error {
+	now, err := c.eth.Now(ctx)
+	if err != nil {
+		return err
+	}
+	expireTime := now.Add(c.cfg.LockDuration)
+	res.EthSwapID, err = c.eth.Lock(ctx, EthLockRequest{
+		RandomNumberHash: res.RandomNumberHash,
+		Timestamp:        res.Timestamp,
+		Recipient:        swap.CounterpartyEthAddress,
+		BNBRecipient:     c.cfg.Address.String(),
+		Amount:           swap.EthAmount,
+		ExpireTime:       expireTime,
+	})
+	if err != nil {
+		return err
+	}
+	c.logger.Info("created eth htlc", "swap_id", fmt.Sprintf("%X", res.EthSwapID))
+
+	counterID := msg.CalculateSwapID(res.RandomNumberHash, swap.CounterpartyAddress, c.cfg.EthAddress)
+	found := false
+	err = c.poll(ctx, func() (bool, error) {
+		now, err := c.eth.Now(ctx)
+		if err != nil {
+			return false, err
+		}
+		ourLeft := expireTime.Sub(now)
+		counter, err := c.chain.GetSwapByID(counterID)
+		if err == rpc.ZeroRecordsError {
+			return ourLeft <= c.cfg.SafetyMargin+c.cfg.ClaimMargin, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		counterLeft, err := c.bnbTimeLeft(counterID)
+		if err != nil {
+			return false, err
+		}
+		if err := c.checkBNBLock(counter, swap, ourLeft, counterLeft); err != nil {
+			c.logger.Error("rejected counterparty lock", "err", err)
+			return true, nil
+		}
+		found = true
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	if found {
+		res.BNBSwapID = counterID
+		result, err := c.chain.ClaimHTLT(counterID, res.RandomNumber, rpc.Commit)
+		if err := broadcastError(result, err); err != nil {
+			return err
+		}
+		res.Outcome = OutcomeClaimed
+		return nil
+	}
+	return c.refundEth(ctx, res, expireTime)
+}
+
+func (c *Coordinator) checkEthLock(htlc *EthHTLC, swap Swap, ourLeft, counterLeft time.Duration) error {
+	if htlc.Recipient != c.cfg.EthAddress || htlc.Amount == nil || swap.EthAmount == nil || htlc.Amount.Cmp(swap.EthAmount) < 0 {
+		return CounterLockMismatchError
+	}
+	return c.checkTimes(ourLeft, counterLeft)
+}
+
+func (c *Coordinator) checkBNBLock(counter types.AtomicSwap, swap Swap, ourLeft, counterLeft time.Duration) error {
+	if counter.Status != types.Open || !bytes.Equal(counter.To, c.cfg.Address) {
+		return CounterLockMismatchError
+	}
+	for _, coin := range swap.Amount {
+		if counter.OutAmount.AmountOf(coin.Denom) < coin.Amount {
+			return CounterLockMismatchError
+		}
+	}
+	return c.checkTimes(ourLeft, counterLeft)
+}
+
+func (c *Coordinator) checkTimes(ourLeft, counterLeft time.Duration) error {
+	if counterLeft < c.cfg.ClaimMargin || counterLeft+c.cfg.SafetyMargin > ourLeft {
+		return UnsafeCounterLockError
+	}
+	return nil
+}
+
+// bnbTimeLeft estimates how long the HTLT has until it expires.
+func (c *Coordinator) bnbTimeLeft(swapID []byte) (time.Duration, error) {
+	swap, err := c.chain.GetSwapByID(swapID)
+	if err != nil {
+		return 0, err
+	}
+	height, err := c.height()
+	if err != nil {
+		return 0, err
+	}
+	return HeightSpanDuration(swap.ExpireHeight-height, c.cfg.BNBBlockTime), nil
+}
+
+func (c *Coordinator) height() (int64, error) {
+	status, err := c.chain.Status()
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+func (c *Coordinator) refundBNB(ctx context.Context, res *Result) error {
+	c.logger.Info("waiting to refund htlt", "swap_id", fmt.Sprintf("%X", res.BNBSwapID))
+	err := c.poll(ctx, func() (bool, error) {
+		swap, err := c.chain.GetSwapByID(res.BNBSwapID)
+		if err != nil {
+			return false, err
+		}
+		height, err := c.height()
+		if err != nil {
+			return false, err
+		}
+		return height >= swap.ExpireHeight, nil
+	})
+	if err != nil {
+		return err
+	}
+	result, err := c.chain.RefundHTLT(res.BNBSwapID, rpc.Commit)
+	if err := broadcastError(result, err); err != nil {
+		return err
+	}
+	res.Outcome = OutcomeRefunded
+	return nil
+}
+
+func (c *Coordinator) refundEth(ctx context.Context, res *Result, expireTime time.Time) error {
+	c.logger.Info("waiting to refund eth htlc", "swap_id", fmt.Sprintf("%X", res.EthSwapID))
+	err := c.poll(ctx, func() (bool, error) {
+		now, err := c.eth.Now(ctx)
+		if err != nil {
+			return false, err
+		}
+		return !now.Before(expireTime), nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.eth.Refund(ctx, res.EthSwapID); err != nil {
+		return err
+	}
+	res.Outcome = OutcomeRefunded
+	return nil
+}
+
+// poll calls check every poll interval until it is done. Errors of check are
+// logged and retried, only ctx ends the polling early.
+func (c *Coordinator) poll(ctx context.Context, check func() (bool, error)) error {
+	ticker := time.NewTicker(c.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		done, err := check()
+		if err != nil {
+			c.logger.Error("swap check failed", "err", err)
+		} else if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func broadcastError(res *ctypes.ResultBroadcastTx, err error) error {
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return gtypes.NewABCIError(res.Code, res.Log)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package bep3
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type fakeChain struct {
+	mtx     sync.Mutex
+	from    types.AccAddress
+	height  int64
+	swaps   map[string]types.AtomicSwap
+	claimed []byte
+	refund  []byte
+}
+
+func (f *fakeChain) HTLT(recipient types.AccAddress, recipientOtherChain, senderOtherChain string, randomNumberHash []byte, timestamp int64,
+	amount types.Coins, expectedIncome string, heightSpan int64, crossChain bool, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	id := msg.CalculateSwapID(randomNumberHash, f.from, senderOtherChain)
+	f.swaps[hex.EncodeToString(id)] = types.AtomicSwap{From: f.from, To: recipient, OutAmount: amount, ExpireHeight: f.height + heightSpan, Status: types.Open}
+	return &ctypes.ResultBroadcastTx{}, nil
+}
+
+func (f *fakeChain) ClaimHTLT(swapID []byte, randomNumber []byte, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.claimed = randomNumber
+	return &ctypes.ResultBroadcastTx{}, nil
+}
+
+func (f *fakeChain) RefundHTLT(swapID []byte, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.refund = swapID
+	return &ctypes.ResultBroadcastTx{}, nil
+}
+
+func (f *fakeChain) GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	swap, ok := f.swaps[hex.EncodeToString(swapID)]
+	if !ok {
+		return swap, rpc.ZeroRecordsError
+	}
+	return swap, nil
+}
+
+// Status advances the chain by an hour worth of blocks on every call.
+func (f *fakeChain) Status() (*ctypes.ResultStatus, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.height += int64(time.Hour / DefaultBNBBlockTime)
+	status := &ctypes.ResultStatus{}
+	status.SyncInfo.LatestBlockHeight = f.height
+	return status, nil
+}
+
+type fakeEth struct {
+	mtx     sync.Mutex
+	now     time.Time
+	htlc    *EthHTLC
+	claimed []byte
+	refund  []byte
+}
+
+func (f *fakeEth) Lock(ctx context.Context, req EthLockRequest) ([]byte, error) {
+	return []byte("eth-swap"), nil
+}
+
+func (f *fakeEth) FindHTLC(ctx context.Context, randomNumberHash []byte) (*EthHTLC, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.htlc != nil {
+		f.htlc.RandomNumberHash = randomNumberHash
+		f.htlc.ExpireTime = f.now.Add(6 * time.Hour)
+	}
+	return f.htlc, nil
+}
+
+func (f *fakeEth) Claim(ctx context.Context, swapID []byte, randomNumber []byte) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.claimed = randomNumber
+	return nil
+}
+
+func (f *fakeEth) Refund(ctx context.Context, swapID []byte) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.refund = swapID
+	return nil
+}
+
+// Now advances the chain by an hour on every call.
+func (f *fakeEth) Now(ctx context.Context) (time.Time, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.now = f.now.Add(time.Hour)
+	return f.now, nil
+}
+
+var (
+	testAddr        = types.AccAddress([]byte("coordinator-address-"))
+	testCounterAddr = types.AccAddress([]byte("counterparty-address"))
+)
+
+func newTestCoordinator() (*Coordinator, *fakeChain, *fakeEth) {
+	chain := &fakeChain{from: testAddr, height: 1, swaps: map[string]types.AtomicSwap{}}
+	eth := &fakeEth{now: time.Unix(0, 0)}
+	c := NewCoordinator(chain, eth, Config{Address: testAddr, EthAddress: "0xme", PollInterval: time.Millisecond})
+	return c, chain, eth
+}
+
+func TestCoordinatorClaimsEthLock(t *testing.T) {
+	c, _, eth := newTestCoordinator()
+	eth.htlc = &EthHTLC{SwapID: []byte("eth-swap"), Recipient: "0xme", Amount: big.NewInt(100), Status: EthSwapOpen}
+
+	res, err := c.Run(context.Background(), Swap{
+		Direction:              BNBToETH,
+		CounterpartyAddress:    testCounterAddr,
+		CounterpartyEthAddress: "0xother",
+		Amount:                 types.Coins{{Denom: "BNB", Amount: 100}},
+		EthAmount:              big.NewInt(100),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, OutcomeClaimed, res.Outcome)
+	assert.Equal(t, res.RandomNumber, eth.claimed)
+	assert.Equal(t, msg.CalculateRandomHash(res.RandomNumber, res.Timestamp), res.RandomNumberHash)
+}
+
+func TestCoordinatorRefundsWithoutCounterLock(t *testing.T) {
+	c, chain, eth := newTestCoordinator()
+
+	res, err := c.Run(context.Background(), Swap{
+		Direction:              ETHToBNB,
+		CounterpartyAddress:    testCounterAddr,
+		CounterpartyEthAddress: "0xother",
+		Amount:                 types.Coins{{Denom: "BNB", Amount: 100}},
+		EthAmount:              big.NewInt(100),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, OutcomeRefunded, res.Outcome)
+	assert.Equal(t, []byte("eth-swap"), eth.refund)
+	assert.Nil(t, chain.claimed)
+}
@@ -0,0 +1,162 @@
+package bep3
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+const DefaultNearExpiryBlocks = 1000
+
+// SwapQuerier is the part of the rpc client the deputy monitor needs. *rpc.HTTP satisfies it.
+type SwapQuerier interface {
+	ForEachSwap(addr string, role types.SwapRole, status types.SwapStatus, fn func(swapID types.SwapBytes, swap types.AtomicSwap) error) error
+	Status() (*ctypes.ResultStatus, error)
+}
+
+type DeputyConfig struct {
+	// NearExpiryBlocks is how close to its expire height an open swap is reported.
+	NearExpiryBlocks int64
+	// MaxImbalance is, per asset, how far the amounts locked by and for the deputy
+	// may drift apart before an alert is raised. Assets not listed are not checked.
+	MaxImbalance map[string]int64
+}
+
+type AlertKind int
+
+const (
+	AlertNearExpiry AlertKind = iota
+	AlertExpired
+	AlertImbalance
+)
+
+func (k AlertKind) String() string {
+	switch k {
+	case AlertNearExpiry:
+		return "near_expiry"
+	case AlertExpired:
+		return "expired"
+	case AlertImbalance:
+		return "imbalance"
+	}
+	return "unknown"
+}
+
+type DeputyAlert struct {
+	Kind    AlertKind
+	Symbol  string
+	SwapID  types.SwapBytes
+	Message string
+}
+
+// DeputySwap is an open swap of the deputy and how many blocks it has left.
+type DeputySwap struct {
+	SwapID     types.SwapBytes
+	Swap       types.AtomicSwap
+	BlocksLeft int64
+}
+
+// DeputyReport is the state of the open swaps of a deputy at Height.
+type DeputyReport struct {
+	Deputy types.AccAddress
+	Height int64
+	// Outgoing sums per asset the open swaps the deputy created, Incoming the open
+	// swaps that pay the deputy.
+	Outgoing   map[string]int64
+	Incoming   map[string]int64
+	NearExpiry []DeputySwap
+	Alerts     []DeputyAlert
+}
+
+// Imbalance is Outgoing minus Incoming for the asset.
+func (r *DeputyReport) Imbalance(symbol string) int64 {
+	return r.Outgoing[symbol] - r.Incoming[symbol]
+}
+
+// CheckDeputy collects the open swaps of the deputy in both roles and raises alerts
+// for swaps close to or past expiry and for assets out of balance.
+func CheckDeputy(q SwapQuerier, deputy types.AccAddress, cfg DeputyConfig) (*DeputyReport, error) {
+	if cfg.NearExpiryBlocks <= 0 {
+		cfg.NearExpiryBlocks = DefaultNearExpiryBlocks
+	}
+	status, err := q.Status()
+	if err != nil {
+		return nil, err
+	}
+	report := &DeputyReport{
+		Deputy:   deputy,
+		Height:   status.SyncInfo.LatestBlockHeight,
+		Outgoing: map[string]int64{},
+		Incoming: map[string]int64{},
+	}
+	sum := func(totals map[string]int64) func(types.SwapBytes, types.AtomicSwap) error {
+		return func(swapID types.SwapBytes, swap types.AtomicSwap) error {
+			for _, coin := range swap.OutAmount {
+				totals[coin.Denom] += coin.Amount
+			}
+			report.checkExpiry(swapID, swap, cfg.NearExpiryBlocks)
+			return nil
+		}
+	}
+	if err := q.ForEachSwap(deputy.String(), types.SwapRoleCreator, types.Open, sum(report.Outgoing)); err != nil {
+		return nil, err
+	}
+	if err := q.ForEachSwap(deputy.String(), types.SwapRoleRecipient, types.Open, sum(report.Incoming)); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]string, 0, len(cfg.MaxImbalance))
+	for symbol := range cfg.MaxImbalance {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	for _, symbol := range symbols {
+		imbalance := report.Imbalance(symbol)
+		if imbalance > cfg.MaxImbalance[symbol] || -imbalance > cfg.MaxImbalance[symbol] {
+			report.Alerts = append(report.Alerts, DeputyAlert{
+				Kind:    AlertImbalance,
+				Symbol:  symbol,
+				Message: fmt.Sprintf("outgoing %d and incoming %d differ by more than %d", report.Outgoing[symbol], report.Incoming[symbol], cfg.MaxImbalance[symbol]),
+			})
+		}
+	}
+	return report, nil
+}
+
+func (r *DeputyReport) checkExpiry(swapID types.SwapBytes, swap types.AtomicSwap, nearExpiryBlocks int64) {
+	left := swap.ExpireHeight - r.Height
+	if left > nearExpiryBlocks {
+		return
+	}
+	kind, message := AlertNearExpiry, fmt.Sprintf("expires in %d blocks", left)
+	if left <= 0 {
+		kind, message = AlertExpired, fmt.Sprintf("expired %d blocks ago and is not refunded", -left)
+	} else {
+		r.NearExpiry = append(r.NearExpiry, DeputySwap{SwapID: swapID, Swap: swap, BlocksLeft: left})
+	}
+	symbol := ""
+	if len(swap.OutAmount) > 0 {
+		symbol = swap.OutAmount[0].Denom
+	}
+	r.Alerts = append(r.Alerts, DeputyAlert{Kind: kind, Symbol: symbol, SwapID: swapID, Message: message})
+}
+
+// MonitorDeputy runs CheckDeputy every interval until ctx is done and hands each
+// report, or the error of the check, to onReport.
+func MonitorDeputy(ctx context.Context, q SwapQuerier, deputy types.AccAddress, cfg DeputyConfig, interval time.Duration, onReport func(*DeputyReport, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		onReport(CheckDeputy(q, deputy, cfg))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package bep3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+type fakeSwapQuerier struct {
+	created, received []types.AtomicSwap
+}
+
+func (f *fakeSwapQuerier) ForEachSwap(addr string, role types.SwapRole, status types.SwapStatus, fn func(types.SwapBytes, types.AtomicSwap) error) error {
+	swaps := f.created
+	if role == types.SwapRoleRecipient {
+		swaps = f.received
+	}
+	for i, swap := range swaps {
+		if err := fn(types.SwapBytes{byte(role), byte(i)}, swap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeSwapQuerier) Status() (*ctypes.ResultStatus, error) {
+	status := &ctypes.ResultStatus{}
+	status.SyncInfo.LatestBlockHeight = 10000
+	return status, nil
+}
+
+func TestCheckDeputy(t *testing.T) {
+	q := &fakeSwapQuerier{
+		created: []types.AtomicSwap{
+			{OutAmount: types.Coins{{Denom: "ETH.B-261", Amount: 500}}, ExpireHeight: 50000},
+			{OutAmount: types.Coins{{Denom: "ETH.B-261", Amount: 300}}, ExpireHeight: 10500},
+		},
+		received: []types.AtomicSwap{
+			{OutAmount: types.Coins{{Denom: "ETH.B-261", Amount: 100}}, ExpireHeight: 9000},
+		},
+	}
+	report, err := CheckDeputy(q, testAddr, DeputyConfig{MaxImbalance: map[string]int64{"ETH.B-261": 600}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(800), report.Outgoing["ETH.B-261"])
+	assert.Equal(t, int64(100), report.Incoming["ETH.B-261"])
+	assert.Len(t, report.NearExpiry, 1)
+	assert.Equal(t, int64(500), report.NearExpiry[0].BlocksLeft)
+
+	kinds := make([]AlertKind, 0, len(report.Alerts))
+	for _, alert := range report.Alerts {
+		kinds = append(kinds, alert.Kind)
+	}
+	assert.Equal(t, []AlertKind{AlertNearExpiry, AlertExpired, AlertImbalance}, kinds)
+}
@@ -0,0 +1,53 @@
+package bep3
+
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
+type EthSwapStatus int
+
+const (
+	EthSwapNone EthSwapStatus = iota
+	EthSwapOpen
+	EthSwapClaimed
+	EthSwapRefunded
+)
+
+// EthHTLC is a hash timelock on Ethereum as reported by the backend.
+type EthHTLC struct {
+	SwapID           []byte
+	RandomNumberHash []byte
+	Sender           string
+	Recipient        string
+	Amount           *big.Int
+	ExpireTime       time.Time
+	Status           EthSwapStatus
+}
+
+// EthLockRequest describes the Ethereum side of a swap started by the coordinator.
+type EthLockRequest struct {
+	RandomNumberHash []byte
+	Timestamp        int64
+	Recipient        string
+	// BNBRecipient is the Binance Chain address of the recipient, it is recorded in
+	// the lock like recipientOtherChain in an HTLT.
+	BNBRecipient string
+	Amount       *big.Int
+	ExpireTime   time.Time
+}
+
+// EthBackend is how the coordinator talks to the HTLC contract on Ethereum. The sdk
+// does not ship an implementation, wrap the contract bindings and the signer of
+// your choice. Methods should block until the transaction is mined.
+type EthBackend interface {
+	// Lock creates an HTLC and returns its swap ID.
+	Lock(ctx context.Context, req EthLockRequest) (swapID []byte, err error)
+	// FindHTLC returns the HTLC locked with the random number hash, or nil if there is none yet.
+	FindHTLC(ctx context.Context, randomNumberHash []byte) (*EthHTLC, error)
+	Claim(ctx context.Context, swapID []byte, randomNumber []byte) error
+	Refund(ctx context.Context, swapID []byte) error
+	// Now returns the time of the latest block, lock expiries are measured against it.
+	Now(ctx context.Context) (time.Time, error)
+}
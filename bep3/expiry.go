@@ -0,0 +1,83 @@
+package bep3
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/binance-chain/go-sdk/blocktime"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+const DefaultBlockTimeWindow = blocktime.DefaultWindow
+
+// BlockTimer is the part of the rpc client EstimateBlockTime needs. *rpc.HTTP satisfies it.
+type BlockTimer = blocktime.Client
+
+// EstimateBlockTime averages the block time over the last window blocks.
+func EstimateBlockTime(c BlockTimer, window int64) (time.Duration, error) {
+	estimate, err := blocktime.Observe(c, window)
+	if err != nil {
+		return 0, err
+	}
+	return estimate.BlockTime, nil
+}
+
+// HeightSpan converts a lock duration into the height span of an HTLT, rounding
+// up. It fails if the span is outside the range the chain accepts.
+func HeightSpan(duration, blockTime time.Duration) (int64, error) {
+	if blockTime <= 0 {
+		return 0, fmt.Errorf("block time must be positive")
+	}
+	span := int64((duration + blockTime - 1) / blockTime)
+	if span < msg.MinimumHeightSpan || span > msg.MaximumHeightSpan {
+		return 0, fmt.Errorf("lock duration of %d blocks out of range [%d, %d]", span, msg.MinimumHeightSpan, msg.MaximumHeightSpan)
+	}
+	return span, nil
+}
+
+// HeightSpanDuration is how long a height span lasts at the given block time.
+func HeightSpanDuration(span int64, blockTime time.Duration) time.Duration {
+	return time.Duration(span) * blockTime
+}
+
+// Deadlines are the points in time the two parties of a swap must act by. The
+// initiator knows the secret and locks first, the participant locks second for a
+// shorter time so it can still claim after the initiator reveals the secret.
+type Deadlines struct {
+	InitiatorExpire time.Time
+	// ParticipantExpire is the latest the participant lock may expire.
+	ParticipantExpire time.Time
+	// InitiatorClaimBy is the latest the initiator should claim the participant lock.
+	InitiatorClaimBy time.Time
+	// ParticipantClaimBy is the latest the participant should claim the initiator lock.
+	ParticipantClaimBy time.Time
+}
+
+// InitiatorRefundAfter is when the initiator can refund, the same as its lock expiry.
+func (d Deadlines) InitiatorRefundAfter() time.Time {
+	return d.InitiatorExpire
+}
+
+func (d Deadlines) ParticipantRefundAfter() time.Time {
+	return d.ParticipantExpire
+}
+
+// PlanDeadlines computes the deadlines of a swap whose initiator locks at start
+// for lockDuration. The participant lock expires safetyMargin before the initiator
+// one, and both parties keep claimMargin to get their claim included.
+func PlanDeadlines(start time.Time, lockDuration, safetyMargin, claimMargin time.Duration) (Deadlines, error) {
+	if safetyMargin <= claimMargin {
+		return Deadlines{}, fmt.Errorf("the safety margin must be longer than the claim margin")
+	}
+	if safetyMargin+claimMargin >= lockDuration {
+		return Deadlines{}, fmt.Errorf("the lock duration must be longer than the safety and claim margins")
+	}
+	initiatorExpire := start.Add(lockDuration)
+	participantExpire := initiatorExpire.Add(-safetyMargin)
+	return Deadlines{
+		InitiatorExpire:    initiatorExpire,
+		ParticipantExpire:  participantExpire,
+		InitiatorClaimBy:   participantExpire.Add(-claimMargin),
+		ParticipantClaimBy: initiatorExpire.Add(-claimMargin),
+	}, nil
+}
@@ -0,0 +1,62 @@
+package bep3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+type fakeBlockTimer struct {
+	latest int64
+}
+
+func (f fakeBlockTimer) Status() (*ctypes.ResultStatus, error) {
+	status := &ctypes.ResultStatus{}
+	status.SyncInfo.LatestBlockHeight = f.latest
+	return status, nil
+}
+
+func (f fakeBlockTimer) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	meta := &tmtypes.BlockMeta{}
+	meta.Header.Height = minHeight
+	meta.Header.Time = time.Unix(0, 0).Add(time.Duration(minHeight) * 500 * time.Millisecond)
+	return &ctypes.ResultBlockchainInfo{LastHeight: f.latest, BlockMetas: []*tmtypes.BlockMeta{meta}}, nil
+}
+
+func TestEstimateBlockTime(t *testing.T) {
+	blockTime, err := EstimateBlockTime(fakeBlockTimer{latest: 5000}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, blockTime)
+
+	_, err = EstimateBlockTime(fakeBlockTimer{latest: 1}, 100)
+	assert.Error(t, err)
+}
+
+func TestHeightSpan(t *testing.T) {
+	span, err := HeightSpan(time.Hour, 400*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9000), span)
+
+	span, err = HeightSpan(time.Hour+time.Millisecond, 400*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9001), span)
+
+	_, err = HeightSpan(time.Minute, 400*time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestPlanDeadlines(t *testing.T) {
+	start := time.Unix(0, 0)
+	d, err := PlanDeadlines(start, 24*time.Hour, 4*time.Hour, 15*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, start.Add(24*time.Hour), d.InitiatorExpire)
+	assert.Equal(t, start.Add(20*time.Hour), d.ParticipantExpire)
+	assert.Equal(t, start.Add(20*time.Hour-15*time.Minute), d.InitiatorClaimBy)
+	assert.Equal(t, start.Add(24*time.Hour-15*time.Minute), d.ParticipantClaimBy)
+
+	_, err = PlanDeadlines(start, time.Hour, 4*time.Hour, 15*time.Minute)
+	assert.Error(t, err)
+}
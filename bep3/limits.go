@@ -0,0 +1,157 @@
+package bep3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// DeputyInfo is what the deputy of an asset advertises about the swaps it
+// takes. Amounts are in units of 1e-8 of the Binance Chain asset.
+type DeputyInfo struct {
+	Symbol string
+	// Deputy is the Binance Chain address HTLTs of the asset are sent to.
+	Deputy types.AccAddress
+	// MinSwapAmount and MaxSwapAmount bound the amount of an HTLT, a zero
+	// MaxSwapAmount leaves it unbounded.
+	MinSwapAmount int64
+	MaxSwapAmount int64
+	// FixedFee is what the deputy keeps of every swap, the counterparty lock
+	// holds the amount less the fee.
+	FixedFee int64
+	// OtherChainDecimals are the decimals of the asset on the other chain, the
+	// unit of the expected income of an HTLT. Zero means 8, like on Binance
+	// Chain.
+	OtherChainDecimals int
+}
+
+// Payout is what the deputy locks on the other chain for an HTLT of amount,
+// the amount less the fee in units of the other chain.
+func (info DeputyInfo) Payout(amount int64) *big.Int {
+	payout := big.NewInt(amount - info.FixedFee)
+	decimals := info.OtherChainDecimals
+	if decimals == 0 {
+		decimals = 8
+	}
+	if decimals >= 8 {
+		return payout.Mul(payout, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-8)), nil))
+	}
+	return payout.Quo(payout, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(8-decimals)), nil))
+}
+
+// DeputyInfoSource gives the advertised limits of the deputy of an asset, e.g.
+// from the api of the deputy or a config file. Get returns a nil info for an
+// asset no deputy swaps.
+type DeputyInfoSource interface {
+	Get(ctx context.Context, symbol string) (*DeputyInfo, error)
+}
+
+// DeputyInfoFunc is a DeputyInfoSource of a function.
+type DeputyInfoFunc func(ctx context.Context, symbol string) (*DeputyInfo, error)
+
+func (f DeputyInfoFunc) Get(ctx context.Context, symbol string) (*DeputyInfo, error) {
+	return f(ctx, symbol)
+}
+
+// StaticDeputyInfo is a DeputyInfoSource of fixed limits, by asset.
+type StaticDeputyInfo map[string]DeputyInfo
+
+func (s StaticDeputyInfo) Get(_ context.Context, symbol string) (*DeputyInfo, error) {
+	info, ok := s[symbol]
+	if !ok {
+		return nil, nil
+	}
+	return &info, nil
+}
+
+// SwapAmountError rejects the amount of an HTLT before it is broadcast, with
+// the limit it breaks and the amount that would be accepted.
+type SwapAmountError struct {
+	Symbol string
+	Amount int64
+	Info   DeputyInfo
+	Reason string
+}
+
+func (e *SwapAmountError) Error() string {
+	return fmt.Sprintf("swap of %s %s to deputy %s rejected: %s", types.Fixed8(e.Amount), e.Symbol, e.Info.Deputy, e.Reason)
+}
+
+// CheckSwapAmount validates the HTLT amount sent to recipient against the
+// limits of the deputies of its assets. HTLTs to an address that is not the
+// deputy of an asset are not checked for it, it is a swap between users.
+func CheckSwapAmount(ctx context.Context, source DeputyInfoSource, recipient types.AccAddress, amount types.Coins) error {
+	_, err := checkSwap(ctx, source, recipient, amount)
+	return err
+}
+
+// CheckHTLT is what the coordinator checks before it creates the HTLT of a
+// swap, for callers that create HTLTs themselves: CheckSwapAmount and, for an
+// HTLT to a deputy, that expectedIncome, in units of the other chain, is no
+// more than the deputy pays out once it took its fee. An empty expectedIncome
+// is not checked.
+func CheckHTLT(ctx context.Context, source DeputyInfoSource, recipient types.AccAddress, amount types.Coins, expectedIncome string) error {
+	swaps, err := checkSwap(ctx, source, recipient, amount)
+	if err != nil || len(swaps) == 0 || expectedIncome == "" {
+		return err
+	}
+	if len(swaps) > 1 {
+		return fmt.Errorf("a swap with a deputy carries a single coin, got %s", amount)
+	}
+	income, ok := new(big.Int).SetString(expectedIncome, 10)
+	if !ok || income.Sign() < 0 {
+		return fmt.Errorf("invalid expected income %q", expectedIncome)
+	}
+	swap := swaps[0]
+	if payout := swap.info.Payout(swap.coin.Amount); income.Cmp(payout) > 0 {
+		return &SwapAmountError{Symbol: swap.coin.Denom, Amount: swap.coin.Amount, Info: swap.info,
+			Reason: fmt.Sprintf("the expected income %s is more than the %s the deputy pays out after its fee", income, payout)}
+	}
+	return nil
+}
+
+type deputySwap struct {
+	coin types.Coin
+	info DeputyInfo
+}
+
+// checkSwap checks the coins of amount against the limits of their deputies and
+// returns the ones sent to a deputy.
+func checkSwap(ctx context.Context, source DeputyInfoSource, recipient types.AccAddress, amount types.Coins) ([]deputySwap, error) {
+	if len(amount) == 0 {
+		return nil, fmt.Errorf("the swap amount is empty")
+	}
+	var swaps []deputySwap
+	for _, coin := range amount {
+		info, err := source.Get(ctx, coin.Denom)
+		if err != nil {
+			return nil, fmt.Errorf("get the deputy of %s: %v", coin.Denom, err)
+		}
+		if info == nil || !bytes.Equal(info.Deputy, recipient) {
+			continue
+		}
+		if err := checkSwapCoin(coin, *info); err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, deputySwap{coin: coin, info: *info})
+	}
+	return swaps, nil
+}
+
+func checkSwapCoin(coin types.Coin, info DeputyInfo) error {
+	reject := func(format string, args ...interface{}) error {
+		return &SwapAmountError{Symbol: coin.Denom, Amount: coin.Amount, Info: info, Reason: fmt.Sprintf(format, args...)}
+	}
+	switch {
+	case coin.Amount < info.MinSwapAmount:
+		return reject("below the minimum of %s, send at least that much", types.Fixed8(info.MinSwapAmount))
+	case info.MaxSwapAmount > 0 && coin.Amount > info.MaxSwapAmount:
+		return reject("above the maximum of %s, split it into smaller swaps", types.Fixed8(info.MaxSwapAmount))
+	case coin.Amount <= info.FixedFee:
+		return reject("it does not cover the deputy fee of %s, send more than that", types.Fixed8(info.FixedFee))
+	}
+	return nil
+}
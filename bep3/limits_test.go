@@ -0,0 +1,101 @@
+package bep3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+func TestCheckSwapAmount(t *testing.T) {
+	deputies := StaticDeputyInfo{"BNB": {Symbol: "BNB", Deputy: testCounterAddr, MinSwapAmount: 100, MaxSwapAmount: 1000, FixedFee: 150}}
+	check := func(amount int64) error {
+		return CheckSwapAmount(context.Background(), deputies, testCounterAddr, types.Coins{{Denom: "BNB", Amount: amount}})
+	}
+	assert.NoError(t, check(500))
+	assert.NoError(t, check(1000))
+
+	for amount, reason := range map[int64]string{
+		50:   "below the minimum",
+		1001: "above the maximum",
+		150:  "does not cover the deputy fee",
+	} {
+		err := check(amount)
+		if assert.IsType(t, &SwapAmountError{}, err) {
+			assert.Contains(t, err.Error(), reason)
+			assert.Equal(t, amount, err.(*SwapAmountError).Amount)
+		}
+	}
+
+	// swaps between users and assets without a deputy are not checked
+	assert.NoError(t, CheckSwapAmount(context.Background(), deputies, testAddr, types.Coins{{Denom: "BNB", Amount: 1}}))
+	assert.NoError(t, CheckSwapAmount(context.Background(), deputies, testCounterAddr, types.Coins{{Denom: "XYZ", Amount: 1}}))
+
+	failing := DeputyInfoFunc(func(context.Context, string) (*DeputyInfo, error) {
+		return nil, fmt.Errorf("deputy api down")
+	})
+	assert.EqualError(t, CheckSwapAmount(context.Background(), failing, testCounterAddr, types.Coins{{Denom: "BNB", Amount: 500}}), "get the deputy of BNB: deputy api down")
+}
+
+func TestCoordinatorChecksDeputyLimits(t *testing.T) {
+	c, chain, _ := newTestCoordinator()
+	c.cfg.Deputies = StaticDeputyInfo{"BNB": {Symbol: "BNB", Deputy: testCounterAddr, MinSwapAmount: 1000}}
+
+	_, err := c.Run(context.Background(), Swap{
+		Direction:              BNBToETH,
+		CounterpartyAddress:    testCounterAddr,
+		CounterpartyEthAddress: "0xother",
+		Amount:                 types.Coins{{Denom: "BNB", Amount: 100}},
+	})
+	assert.IsType(t, &SwapAmountError{}, err)
+
+	c.cfg.Deputies = StaticDeputyInfo{"BNB": {Symbol: "BNB", Deputy: testCounterAddr, FixedFee: 100}}
+	_, err = c.Run(context.Background(), Swap{
+		Direction:              BNBToETH,
+		CounterpartyAddress:    testCounterAddr,
+		CounterpartyEthAddress: "0xother",
+		Amount:                 types.Coins{{Denom: "BNB", Amount: 2000}},
+		EthAmount:              big.NewInt(1950),
+	})
+	assert.IsType(t, &SwapAmountError{}, err, "the eth amount is the expected income")
+	assert.Empty(t, chain.swaps)
+}
+
+func TestCheckHTLT(t *testing.T) {
+	deputies := StaticDeputyInfo{
+		"BNB":     {Symbol: "BNB", Deputy: testCounterAddr, MinSwapAmount: 100, FixedFee: 150},
+		"ETH-1C9": {Symbol: "ETH-1C9", Deputy: testCounterAddr, MinSwapAmount: 100, FixedFee: 1e6, OtherChainDecimals: 18},
+	}
+	check := func(coins types.Coins, income string) error {
+		return CheckHTLT(context.Background(), deputies, testCounterAddr, coins, income)
+	}
+	bnb := types.Coins{{Denom: "BNB", Amount: 1000}}
+	assert.NoError(t, check(bnb, "850"))
+	assert.NoError(t, check(bnb, "1"))
+	assert.NoError(t, check(bnb, ""), "no expected income")
+	err := check(bnb, "851")
+	if assert.IsType(t, &SwapAmountError{}, err) {
+		assert.Contains(t, err.Error(), "more than the 850 the deputy pays out")
+	}
+	assert.Error(t, check(bnb, "0x10"))
+	assert.Error(t, check(bnb, "-1"))
+	assert.IsType(t, &SwapAmountError{}, check(types.Coins{{Denom: "BNB", Amount: 150}}, "1"), "the amount checks come first")
+
+	// 0.99 ETH of a 1 ETH swap, in wei
+	eth := types.Coins{{Denom: "ETH-1C9", Amount: 1e8}}
+	assert.NoError(t, check(eth, "990000000000000000"))
+	assert.Error(t, check(eth, "990000000000000001"))
+
+	assert.Error(t, check(types.Coins{{Denom: "BNB", Amount: 1000}, {Denom: "ETH-1C9", Amount: 1e8}}, "1"))
+	assert.NoError(t, CheckHTLT(context.Background(), deputies, testAddr, bnb, "1000000"), "swaps between users are not checked")
+}
+
+func TestPayout(t *testing.T) {
+	assert.Equal(t, "850", DeputyInfo{FixedFee: 150}.Payout(1000).String())
+	assert.Equal(t, "8500000000000", DeputyInfo{FixedFee: 150, OtherChainDecimals: 18}.Payout(1000).String())
+	assert.Equal(t, "85", DeputyInfo{FixedFee: 150, OtherChainDecimals: 7}.Payout(1000).String())
+}
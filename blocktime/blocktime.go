@@ -0,0 +1,133 @@
+// Package blocktime converts between heights and times from the block intervals
+// recently observed on the chain, for timelocks, swap expiries and scheduled
+// order expiry.
+package blocktime
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+const DefaultWindow = 1000
+
+// Client is the part of the rpc client the estimation needs. *rpc.HTTP satisfies it.
+type Client interface {
+	Status() (*ctypes.ResultStatus, error)
+	BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+}
+
+// Estimate anchors the average block time at a known block.
+type Estimate struct {
+	Height    int64
+	Time      time.Time
+	BlockTime time.Duration
+}
+
+// TimeAt estimates when the block at height is, or was, produced.
+func (e Estimate) TimeAt(height int64) time.Time {
+	return e.Time.Add(time.Duration(height-e.Height) * e.BlockTime)
+}
+
+// HeightAt estimates the height of the last block produced at or before t.
+func (e Estimate) HeightAt(t time.Time) int64 {
+	d := t.Sub(e.Time)
+	blocks := int64(d / e.BlockTime)
+	if d < 0 && d%e.BlockTime != 0 {
+		blocks--
+	}
+	return e.Height + blocks
+}
+
+// Observe averages the block time over the last window blocks, DefaultWindow if
+// window is not positive, and anchors it at the latest block.
+func Observe(c Client, window int64) (Estimate, error) {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	status, err := c.Status()
+	if err != nil {
+		return Estimate{}, err
+	}
+	latest := status.SyncInfo.LatestBlockHeight
+	from := latest - window
+	if from < 1 {
+		from = 1
+	}
+	if from >= latest {
+		return Estimate{}, fmt.Errorf("not enough blocks to estimate the block time")
+	}
+	fromTime, err := headerTime(c, from)
+	if err != nil {
+		return Estimate{}, err
+	}
+	latestTime, err := headerTime(c, latest)
+	if err != nil {
+		return Estimate{}, err
+	}
+	blockTime := latestTime.Sub(fromTime) / time.Duration(latest-from)
+	if blockTime <= 0 {
+		return Estimate{}, fmt.Errorf("block times between heights %d and %d do not increase", from, latest)
+	}
+	return Estimate{Height: latest, Time: latestTime, BlockTime: blockTime}, nil
+}
+
+func headerTime(c Client, height int64) (time.Time, error) {
+	info, err := c.BlockchainInfo(height, height)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(info.BlockMetas) == 0 {
+		return time.Time{}, fmt.Errorf("no header at height %d", height)
+	}
+	return info.BlockMetas[0].Header.Time, nil
+}
+
+// Estimator caches an Estimate and observes the chain again once it is older than
+// the refresh interval.
+type Estimator struct {
+	client  Client
+	window  int64
+	refresh time.Duration
+
+	mtx        sync.Mutex
+	estimate   Estimate
+	observedAt time.Time
+}
+
+func NewEstimator(client Client, window int64, refresh time.Duration) *Estimator {
+	return &Estimator{client: client, window: window, refresh: refresh}
+}
+
+// Estimate returns the cached estimate, observing the chain if it is stale.
+func (e *Estimator) Estimate() (Estimate, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if !e.observedAt.IsZero() && time.Since(e.observedAt) < e.refresh {
+		return e.estimate, nil
+	}
+	estimate, err := Observe(e.client, e.window)
+	if err != nil {
+		return Estimate{}, err
+	}
+	e.estimate, e.observedAt = estimate, time.Now()
+	return estimate, nil
+}
+
+func (e *Estimator) TimeAt(height int64) (time.Time, error) {
+	estimate, err := e.Estimate()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return estimate.TimeAt(height), nil
+}
+
+func (e *Estimator) HeightAt(t time.Time) (int64, error) {
+	estimate, err := e.Estimate()
+	if err != nil {
+		return 0, err
+	}
+	return estimate.HeightAt(t), nil
+}
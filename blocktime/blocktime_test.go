@@ -0,0 +1,79 @@
+package blocktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// fakeChain produces a block every 400ms from the unix epoch on.
+type fakeChain struct {
+	latest int64
+}
+
+func (f *fakeChain) Status() (*ctypes.ResultStatus, error) {
+	status := &ctypes.ResultStatus{}
+	status.SyncInfo.LatestBlockHeight = f.latest
+	return status, nil
+}
+
+func (f *fakeChain) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	meta := &types.BlockMeta{}
+	meta.Header.Height = minHeight
+	meta.Header.Time = time.Unix(0, 0).Add(time.Duration(minHeight) * 400 * time.Millisecond)
+	return &ctypes.ResultBlockchainInfo{LastHeight: f.latest, BlockMetas: []*types.BlockMeta{meta}}, nil
+}
+
+func TestObserve(t *testing.T) {
+	estimate, err := Observe(&fakeChain{latest: 5000}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, Estimate{Height: 5000, Time: time.Unix(2000, 0), BlockTime: 400 * time.Millisecond}, estimate)
+
+	assert.Equal(t, time.Unix(2004, 0), estimate.TimeAt(5010))
+	assert.Equal(t, int64(5010), estimate.HeightAt(time.Unix(2004, 300*int64(time.Millisecond))))
+	assert.Equal(t, int64(4988), estimate.HeightAt(time.Unix(1995, 500*int64(time.Millisecond))))
+
+	_, err = Observe(&fakeChain{latest: 1}, 10)
+	assert.Error(t, err)
+}
+
+func TestEstimatorCaches(t *testing.T) {
+	chain := &fakeChain{latest: 100}
+	e := NewEstimator(chain, 10, time.Hour)
+	at, err := e.TimeAt(100)
+	assert.NoError(t, err)
+	chain.latest = 200
+	height, err := e.HeightAt(at)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), height)
+}
+
+type fakeStatus struct {
+	blockTime  time.Time
+	catchingUp bool
+}
+
+func (f fakeStatus) Status() (*ctypes.ResultStatus, error) {
+	status := &ctypes.ResultStatus{}
+	status.SyncInfo.LatestBlockTime = f.blockTime
+	status.SyncInfo.CatchingUp = f.catchingUp
+	return status, nil
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	skew, err := CheckClockSkew(fakeStatus{blockTime: time.Now().Add(-time.Second)}, 0)
+	assert.NoError(t, err)
+	assert.True(t, skew >= time.Second)
+
+	_, err = CheckClockSkew(fakeStatus{blockTime: time.Now().Add(time.Minute)}, 0)
+	skewErr, ok := err.(*ClockSkewError)
+	assert.True(t, ok)
+	assert.True(t, skewErr.Skew < -50*time.Second)
+	assert.Contains(t, err.Error(), "behind the chain")
+
+	_, err = CheckClockSkew(fakeStatus{blockTime: time.Now(), catchingUp: true}, 0)
+	assert.Error(t, err)
+}
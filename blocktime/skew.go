@@ -0,0 +1,64 @@
+package blocktime
+
+import (
+	"fmt"
+	"time"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// DefaultMaxClockSkew leaves room for the age of the latest block, which is up to
+// a block interval plus the propagation delay even with a perfect clock.
+const DefaultMaxClockSkew = 10 * time.Second
+
+// StatusClient is the part of the rpc client the skew check needs. *rpc.HTTP satisfies it.
+type StatusClient interface {
+	Status() (*ctypes.ResultStatus, error)
+}
+
+// ClockSkewError reports a local clock too far from the time of the chain. HTLT
+// timestamps and order timing derived from such a clock are off.
+type ClockSkewError struct {
+	Skew    time.Duration
+	MaxSkew time.Duration
+}
+
+func (e *ClockSkewError) Error() string {
+	direction := "ahead of"
+	skew := e.Skew
+	if skew < 0 {
+		direction, skew = "behind", -skew
+	}
+	return fmt.Sprintf("local clock is %v %s the chain, more than the allowed %v", skew, direction, e.MaxSkew)
+}
+
+// MeasureClockSkew returns how far the local clock is ahead of the time of the
+// latest block of the node, negative if it is behind. A node that is catching up
+// reports an old block and cannot be measured against.
+func MeasureClockSkew(c StatusClient) (time.Duration, error) {
+	status, err := c.Status()
+	if err != nil {
+		return 0, err
+	}
+	if status.SyncInfo.CatchingUp {
+		return 0, fmt.Errorf("node is catching up, its latest block time is not current")
+	}
+	return time.Since(status.SyncInfo.LatestBlockTime), nil
+}
+
+// CheckClockSkew fails with a *ClockSkewError if the local clock is more than
+// maxSkew, DefaultMaxClockSkew if not positive, away from the chain. Callers that
+// only want to warn can log the error and carry on.
+func CheckClockSkew(c StatusClient, maxSkew time.Duration) (time.Duration, error) {
+	if maxSkew <= 0 {
+		maxSkew = DefaultMaxClockSkew
+	}
+	skew, err := MeasureClockSkew(c)
+	if err != nil {
+		return 0, err
+	}
+	if skew > maxSkew || -skew > maxSkew {
+		return skew, &ClockSkewError{Skew: skew, MaxSkew: maxSkew}
+	}
+	return skew, nil
+}
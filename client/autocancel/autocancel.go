@@ -0,0 +1,168 @@
+// Package autocancel gives orders a client side expiry: orders are scheduled with
+// a lifetime and cancelled once they outlive it. Pending cancels are persisted so
+// a restart does not leave orders resting in the book.
+package autocancel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// Canceler is the part of the rpc client the scheduler needs. *rpc.HTTP satisfies it.
+type Canceler interface {
+	CancelOrder(baseAssetSymbol, quoteAssetSymbol, refId string, syncType rpc.SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+}
+
+// PendingCancel is an order waiting for its expiry.
+type PendingCancel struct {
+	BaseAsset  string    `json:"base_asset"`
+	QuoteAsset string    `json:"quote_asset"`
+	OrderID    string    `json:"order_id"`
+	ExpireAt   time.Time `json:"expire_at"`
+}
+
+// Scheduler cancels orders when they expire. It is safe for concurrent use.
+type Scheduler struct {
+	canceler Canceler
+	store    Store
+
+	mtx     sync.Mutex
+	pending map[string]PendingCancel
+}
+
+// NewScheduler resumes the pending cancels found in store.
+func NewScheduler(canceler Canceler, store Store) (*Scheduler, error) {
+	saved, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load pending cancels: %v", err)
+	}
+	s := &Scheduler{canceler: canceler, store: store, pending: make(map[string]PendingCancel, len(saved))}
+	for _, p := range saved {
+		s.pending[p.OrderID] = p
+	}
+	return s, nil
+}
+
+// Schedule cancels the order once ttl has passed.
+func (s *Scheduler) Schedule(baseAsset, quoteAsset, orderID string, ttl time.Duration) error {
+	return s.ScheduleAt(baseAsset, quoteAsset, orderID, time.Now().Add(ttl))
+}
+
+// ScheduleAt cancels the order at expireAt, replacing an earlier schedule of it.
+func (s *Scheduler) ScheduleAt(baseAsset, quoteAsset, orderID string, expireAt time.Time) error {
+	if baseAsset == "" || quoteAsset == "" || orderID == "" {
+		return fmt.Errorf("base asset, quote asset and order id are required")
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.pending[orderID] = PendingCancel{BaseAsset: baseAsset, QuoteAsset: quoteAsset, OrderID: orderID, ExpireAt: expireAt}
+	return s.save()
+}
+
+// Forget drops the schedule of an order that was filled or cancelled otherwise.
+func (s *Scheduler) Forget(orderID string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.pending[orderID]; !ok {
+		return nil
+	}
+	delete(s.pending, orderID)
+	return s.save()
+}
+
+// Pending lists the scheduled cancels by expiry.
+func (s *Scheduler) Pending() []PendingCancel {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.sorted()
+}
+
+// CancelDue cancels every order expired at now. Orders that are already gone
+// count as cancelled. Failed cancels stay scheduled and are retried on the next
+// call, each failure is handed to onError if it is not nil.
+func (s *Scheduler) CancelDue(now time.Time, onError func(PendingCancel, error)) error {
+	s.mtx.Lock()
+	var due []PendingCancel
+	for _, p := range s.sorted() {
+		if !p.ExpireAt.After(now) {
+			due = append(due, p)
+		}
+	}
+	s.mtx.Unlock()
+
+	var done []string
+	for _, p := range due {
+		if err := s.cancel(p); err != nil {
+			if onError != nil {
+				onError(p, err)
+			}
+			continue
+		}
+		done = append(done, p.OrderID)
+	}
+	if len(done) == 0 {
+		return nil
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, id := range done {
+		delete(s.pending, id)
+	}
+	return s.save()
+}
+
+func (s *Scheduler) cancel(p PendingCancel) error {
+	res, err := s.canceler.CancelOrder(p.BaseAsset, p.QuoteAsset, p.OrderID, rpc.Sync)
+	if err != nil {
+		return err
+	}
+	if rpc.IsOrderGone(res) {
+		return nil
+	}
+	if res.Code != 0 {
+		return rpc.BroadcastError(res)
+	}
+	return nil
+}
+
+// Run calls CancelDue every interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration, onError func(PendingCancel, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.CancelDue(time.Now(), onError); err != nil && onError != nil {
+			onError(PendingCancel{}, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) sorted() []PendingCancel {
+	out := make([]PendingCancel, 0, len(s.pending))
+	for _, p := range s.pending {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].ExpireAt.Equal(out[j].ExpireAt) {
+			return out[i].ExpireAt.Before(out[j].ExpireAt)
+		}
+		return out[i].OrderID < out[j].OrderID
+	})
+	return out
+}
+
+func (s *Scheduler) save() error {
+	return s.store.Save(s.sorted())
+}
@@ -0,0 +1,74 @@
+package autocancel
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type fakeCanceler struct {
+	mtx       sync.Mutex
+	cancelled []string
+	results   map[string]*core_types.ResultBroadcastTx
+	errs      map[string]error
+}
+
+func (f *fakeCanceler) CancelOrder(base, quote, refID string, syncType rpc.SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.cancelled = append(f.cancelled, refID)
+	if err := f.errs[refID]; err != nil {
+		return nil, err
+	}
+	if res := f.results[refID]; res != nil {
+		return res, nil
+	}
+	return &core_types.ResultBroadcastTx{}, nil
+}
+
+func TestSchedulerCancelsExpiredOrders(t *testing.T) {
+	dir, err := ioutil.TempDir("", "autocancel")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store := NewFileStore(filepath.Join(dir, "pending.json"))
+
+	canceler := &fakeCanceler{
+		results: map[string]*core_types.ResultBroadcastTx{"filled": {Code: 393222, Log: `{"codespace":6,"code":6,"message":"Failed to find order [filled]"}`}},
+		errs:    map[string]error{"flaky": errors.New("connection reset")},
+	}
+	s, err := NewScheduler(canceler, store)
+	assert.NoError(t, err)
+	now := time.Now()
+	assert.NoError(t, s.ScheduleAt("BTC-86A", "BNB", "expired", now.Add(-time.Second)))
+	assert.NoError(t, s.ScheduleAt("BTC-86A", "BNB", "filled", now.Add(-time.Second)))
+	assert.NoError(t, s.ScheduleAt("BTC-86A", "BNB", "flaky", now))
+	assert.NoError(t, s.Schedule("BTC-86A", "BNB", "resting", time.Hour))
+	assert.NoError(t, s.ScheduleAt("BTC-86A", "BNB", "forgotten", now))
+	assert.NoError(t, s.Forget("forgotten"))
+
+	// a restart picks up what was scheduled
+	s, err = NewScheduler(canceler, store)
+	assert.NoError(t, err)
+	assert.Len(t, s.Pending(), 4)
+
+	var failed []string
+	assert.NoError(t, s.CancelDue(now, func(p PendingCancel, err error) { failed = append(failed, p.OrderID) }))
+	assert.ElementsMatch(t, []string{"expired", "filled", "flaky"}, canceler.cancelled)
+	assert.Equal(t, []string{"flaky"}, failed)
+
+	saved, err := store.Load()
+	assert.NoError(t, err)
+	assert.Len(t, saved, 2)
+	assert.Equal(t, "flaky", saved[0].OrderID)
+	assert.Equal(t, "resting", saved[1].OrderID)
+}
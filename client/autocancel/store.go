@@ -0,0 +1,84 @@
+package autocancel
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists the pending cancels of a scheduler.
+type Store interface {
+	// Load returns the saved pending cancels, none if nothing was saved yet.
+	Load() ([]PendingCancel, error)
+	Save([]PendingCancel) error
+}
+
+type MemoryStore struct {
+	mtx     sync.Mutex
+	pending []PendingCancel
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Load() ([]PendingCancel, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]PendingCancel(nil), s.pending...), nil
+}
+
+func (s *MemoryStore) Save(pending []PendingCancel) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.pending = append([]PendingCancel(nil), pending...)
+	return nil
+}
+
+// FileStore keeps the pending cancels as JSON in a single file, replaced
+// atomically on every save.
+type FileStore struct {
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Load() ([]PendingCancel, error) {
+	bz, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var pending []PendingCancel
+	if err := json.Unmarshal(bz, &pending); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+func (s *FileStore) Save(pending []PendingCancel) error {
+	bz, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -1,14 +1,19 @@
 package basic
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"gopkg.in/resty.v1"
 
+	"github.com/binance-chain/go-sdk/common/jsonscan"
 	"github.com/binance-chain/go-sdk/types"
 	"github.com/binance-chain/go-sdk/types/tx"
 	"github.com/gorilla/websocket"
@@ -18,58 +23,272 @@ const (
 	MaxReadWaitTime = 30 * time.Second
 )
 
+// ErrWSUnsupported is returned by WsGet in js/wasm builds: gorilla websocket
+// needs raw sockets, which browsers do not give. Requests to the api go
+// through fetch and work.
+var ErrWSUnsupported = errors.New("websocket subscriptions are not supported in js/wasm builds")
+
 type BasicClient interface {
 	Get(path string, qp map[string]string) ([]byte, int, error)
 	Post(path string, body interface{}, param map[string]string) ([]byte, error)
+	Put(path string, param map[string]string) ([]byte, error)
+	Delete(path string, param map[string]string) ([]byte, error)
 
 	GetTx(txHash string) (*tx.TxResult, error)
 	PostTx(hexTx []byte, param map[string]string) ([]tx.TxCommitResult, error)
 	WsGet(path string, constructMsg func([]byte) (interface{}, error), closeCh <-chan struct{}) (<-chan interface{}, error)
+
+	// SetMaxResponseSize caps the bytes read for a response body or websocket
+	// message. n <= 0 restores types.DefaultMaxResponseSize.
+	SetMaxResponseSize(n int64)
+	// SetCache enables conditional GET requests, responses with an ETag or
+	// Last-Modified header are kept in cache and revalidated. nil disables it.
+	SetCache(cache ResponseCache)
+	// SetOnThrottle registers a callback run when the api answers 429, or 503
+	// with Retry-After. Until the wait is over requests fail with a
+	// *types.ThrottledError without reaching the api.
+	SetOnThrottle(fn func(path string, retryAfter time.Duration))
+	// SetRequestIDFunc sets how the id sent in types.RequestIDHeader with every
+	// request is made, to reuse the correlation ids of the caller. Errors carry
+	// the id, see types.RequestID. nil restores types.NewRequestID.
+	SetRequestIDFunc(fn func() string)
 }
 
 type client struct {
-	baseUrl string
-	apiUrl  string
-	apiKey  string
+	baseUrl         string
+	apiUrl          string
+	apiKey          string
+	maxResponseSize int64
+	cache           ResponseCache
+
+	throttleMtx    sync.Mutex
+	throttledUntil time.Time
+	onThrottle     func(path string, retryAfter time.Duration)
+
+	requestID func() string
 }
 
 func NewClient(baseUrl string, apiKey string) BasicClient {
-	return &client{baseUrl: baseUrl, apiUrl: fmt.Sprintf("%s://%s", types.DefaultApiSchema, baseUrl+types.DefaultAPIVersionPrefix),apiKey:apiKey}
+	return &client{baseUrl: baseUrl, apiUrl: fmt.Sprintf("%s://%s", types.DefaultApiSchema, baseUrl+types.DefaultAPIVersionPrefix), apiKey: apiKey, maxResponseSize: types.DefaultMaxResponseSize}
+}
+
+func (c *client) SetMaxResponseSize(n int64) {
+	if n <= 0 {
+		n = types.DefaultMaxResponseSize
+	}
+	c.maxResponseSize = n
+}
+
+func (c *client) SetCache(cache ResponseCache) {
+	c.cache = cache
+}
+
+func (c *client) SetOnThrottle(fn func(path string, retryAfter time.Duration)) {
+	c.throttleMtx.Lock()
+	defer c.throttleMtx.Unlock()
+	c.onThrottle = fn
+}
+
+func (c *client) SetRequestIDFunc(fn func() string) {
+	c.requestID = fn
+}
+
+func (c *client) newRequestID() string {
+	if c.requestID != nil {
+		return c.requestID()
+	}
+	return types.NewRequestID()
+}
+
+// checkThrottle fails fast while the api asked us to back off.
+func (c *client) checkThrottle() error {
+	c.throttleMtx.Lock()
+	defer c.throttleMtx.Unlock()
+	wait := time.Until(c.throttledUntil)
+	if wait <= 0 {
+		return nil
+	}
+	return &types.ThrottledError{StatusCode: http.StatusTooManyRequests, RetryAfter: wait, Err: fmt.Errorf("backing off after the api throttled requests")}
+}
+
+// responseError turns a non 2xx response into an error and starts the back off
+// the api asks for.
+func (c *client) responseError(path string, resp *resty.Response, body []byte) error {
+	err := statusError(resp.StatusCode(), body)
+	throttled := types.NewThrottledError(resp.StatusCode(), resp.Header(), err, time.Now())
+	if throttled == nil {
+		return err
+	}
+	c.throttleMtx.Lock()
+	if until := time.Now().Add(throttled.RetryAfter); until.After(c.throttledUntil) {
+		c.throttledUntil = until
+	}
+	onThrottle := c.onThrottle
+	c.throttleMtx.Unlock()
+	if onThrottle != nil {
+		onThrottle(path, throttled.RetryAfter)
+	}
+	return throttled
+}
+
+// readBody reads at most maxResponseSize bytes of a response body. The body
+// is handed to the caller, it is not read into a pooled buffer: a body of
+// known length is read into a slice of that length, others grow one.
+func (c *client) readBody(resp *resty.Response) ([]byte, error) {
+	body := resp.RawBody()
+	defer body.Close()
+	tooLarge := types.NewError(types.ErrorClassInvalidRequest, fmt.Errorf("the response exceed max size %d", c.maxResponseSize))
+	if resp.RawResponse != nil && resp.RawResponse.ContentLength >= 0 {
+		if resp.RawResponse.ContentLength > c.maxResponseSize {
+			return nil, tooLarge
+		}
+		data := make([]byte, resp.RawResponse.ContentLength)
+		if _, err := io.ReadFull(body, data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(io.LimitReader(body, c.maxResponseSize+1)); err != nil {
+		return nil, err
+	}
+	if int64(buf.Len()) > c.maxResponseSize {
+		return nil, tooLarge
+	}
+	return buf.Bytes(), nil
 }
 
 func (c *client) Get(path string, qp map[string]string) ([]byte, int, error) {
-	request := resty.R().SetQueryParams(qp)
+	id := c.newRequestID()
+	body, code, err := c.get(path, qp, id)
+	return body, code, types.WithRequestID(err, id)
+}
+
+func (c *client) get(path string, qp map[string]string, id string) ([]byte, int, error) {
+	if err := c.checkThrottle(); err != nil {
+		return nil, 0, err
+	}
+	request := resty.R().SetQueryParams(qp).SetDoNotParseResponse(true).SetHeader(types.RequestIDHeader, id)
 	if c.apiKey != "" {
 		request.SetHeader("apikey", c.apiKey)
 	}
+	var key string
+	var cached *CachedResponse
+	if c.cache != nil {
+		key = cacheKey(c.apiUrl+path, qp)
+		if cached, _ = c.cache.Get(key); cached != nil {
+			if cached.ETag != "" {
+				request.SetHeader("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				request.SetHeader("If-Modified-Since", cached.LastModified)
+			}
+		}
+	}
 	resp, err := request.Get(c.apiUrl + path)
 	if err != nil {
 		return nil, 0, err
 	}
+	body, err := c.readBody(resp)
+	if err != nil {
+		return nil, resp.StatusCode(), err
+	}
+	if resp.StatusCode() == http.StatusNotModified && cached != nil {
+		return cached.Body, http.StatusOK, nil
+	}
 	if resp.StatusCode() >= http.StatusMultipleChoices || resp.StatusCode() < http.StatusOK {
-		err = fmt.Errorf("bad response, status code %d, response: %s", resp.StatusCode(), string(resp.Body()))
+		return body, resp.StatusCode(), c.responseError(path, resp, body)
+	}
+	if c.cache != nil {
+		etag, lastModified := resp.Header().Get("ETag"), resp.Header().Get("Last-Modified")
+		if etag != "" || lastModified != "" {
+			c.cache.Set(key, &CachedResponse{ETag: etag, LastModified: lastModified, Body: body})
+		}
 	}
-	return resp.Body(), resp.StatusCode(), err
+	return body, resp.StatusCode(), nil
 }
 
 // Post generic method
 func (c *client) Post(path string, body interface{}, param map[string]string) ([]byte, error) {
+	id := c.newRequestID()
+	respBody, err := c.post(path, body, param, id)
+	return respBody, types.WithRequestID(err, id)
+}
+
+func (c *client) post(path string, body interface{}, param map[string]string, id string) ([]byte, error) {
+	if err := c.checkThrottle(); err != nil {
+		return nil, err
+	}
 	request := resty.R().
 		SetHeader("Content-Type", "text/plain").
+		SetHeader(types.RequestIDHeader, id).
 		SetBody(body).
-		SetQueryParams(param)
+		SetQueryParams(param).
+		SetDoNotParseResponse(true)
 	if c.apiKey != "" {
 		request.SetHeader("apikey", c.apiKey)
 	}
 	resp, err := request.Post(c.apiUrl + path)
 
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := c.readBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() >= http.StatusMultipleChoices {
+		err = c.responseError(path, resp, respBody)
+	}
+	return respBody, err
+}
+
+// Put sends a PUT without body, as the keepalive of a listen key.
+func (c *client) Put(path string, param map[string]string) ([]byte, error) {
+	return c.send(http.MethodPut, path, param)
+}
+
+// Delete sends a DELETE without body.
+func (c *client) Delete(path string, param map[string]string) ([]byte, error) {
+	return c.send(http.MethodDelete, path, param)
+}
+
+func (c *client) send(method, path string, param map[string]string) ([]byte, error) {
+	id := c.newRequestID()
+	respBody, err := c.execute(method, path, param, id)
+	return respBody, types.WithRequestID(err, id)
+}
+
+func (c *client) execute(method, path string, param map[string]string, id string) ([]byte, error) {
+	if err := c.checkThrottle(); err != nil {
+		return nil, err
+	}
+	request := resty.R().SetQueryParams(param).SetDoNotParseResponse(true).SetHeader(types.RequestIDHeader, id)
+	if c.apiKey != "" {
+		request.SetHeader("apikey", c.apiKey)
+	}
+	resp, err := request.Execute(method, c.apiUrl+path)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := c.readBody(resp)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode() >= http.StatusMultipleChoices {
-		err = fmt.Errorf("bad response, status code %d, response: %s", resp.StatusCode(), string(resp.Body()))
+		err = c.responseError(path, resp, respBody)
+	}
+	return respBody, err
+}
+
+// statusError classifies a non 2xx response: 429 and 5xx are worth retrying.
+func statusError(code int, body []byte) error {
+	err := fmt.Errorf("bad response, status code %d, response: %s", code, string(body))
+	class := types.ErrorClassInvalidRequest
+	if code == http.StatusTooManyRequests || code >= http.StatusInternalServerError {
+		class = types.ErrorClassUnavailable
 	}
-	return resp.Body(), err
+	return types.NewError(class, err)
 }
 
 // GetTx returns transaction details
@@ -113,10 +332,11 @@ func (c *client) PostTx(hexTx []byte, param map[string]string) ([]tx.TxCommitRes
 
 func (c *client) WsGet(path string, constructMsg func([]byte) (interface{}, error), closeCh <-chan struct{}) (<-chan interface{}, error) {
 	u := url.URL{Scheme: types.DefaultWSSchema, Host: c.baseUrl, Path: fmt.Sprintf("%s/%s", types.DefaultWSPrefix, path)}
-	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	conn, err := dialWS(u.String())
 	if err != nil {
 		return nil, err
 	}
+	conn.SetReadLimit(c.maxResponseSize)
 	conn.SetPingHandler(nil)
 	conn.SetPongHandler(
 		func(string) error {
@@ -140,6 +360,8 @@ func (c *client) WsGet(path string, constructMsg func([]byte) (interface{}, erro
 		}
 	}()
 	go func() {
+		var frame bytes.Buffer
+		var scanner jsonscan.Scanner
 		writeMsg := func(m interface{}) bool {
 			select {
 			case <-closeCh:
@@ -162,15 +384,7 @@ func (c *client) WsGet(path string, constructMsg func([]byte) (interface{}, erro
 			case <-pingTicker.C:
 				conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second))
 			default:
-				response := WSResponse{}
-				err := conn.ReadJSON(&response)
-				if err != nil {
-					if closed := writeMsg(err); !closed {
-						close(finish)
-					}
-					return
-				}
-				bz, err := json.Marshal(response.Data)
+				bz, err := readWSData(conn, &frame, &scanner)
 				if err != nil {
 					if closed := writeMsg(err); !closed {
 						close(finish)
@@ -200,3 +414,51 @@ type WSResponse struct {
 	Stream string
 	Data   interface{}
 }
+
+// readWSData reads the next message, {"stream": ..., "data": ...}, into frame
+// and returns a copy of its data, which constructMsg may keep. Unlike decoding
+// into WSResponse and marshalling Data again it builds no intermediate values,
+// and numbers are passed on as sent instead of through float64.
+func readWSData(conn *websocket.Conn, frame *bytes.Buffer, s *jsonscan.Scanner) ([]byte, error) {
+	_, r, err := conn.NextReader()
+	if err != nil {
+		return nil, err
+	}
+	frame.Reset()
+	if _, err := frame.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	data, err := wsData(s, frame.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), data...), nil
+}
+
+var jsonNull = []byte("null")
+
+// wsData returns the data field of a message, matched like encoding/json
+// matches WSResponse.Data, null when there is none.
+func wsData(s *jsonscan.Scanner, message []byte) ([]byte, error) {
+	s.Reset(message)
+	if err := s.BeginObject(); err != nil {
+		return nil, err
+	}
+	data := jsonNull
+	for {
+		key, ok, err := s.NextKey()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return data, s.End()
+		}
+		value, err := s.Skip()
+		if err != nil {
+			return nil, err
+		}
+		if bytes.EqualFold(key, []byte("data")) {
+			data = value
+		}
+	}
+}
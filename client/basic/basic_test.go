@@ -0,0 +1,108 @@
+package basic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/jsonscan"
+	"github.com/binance-chain/go-sdk/types"
+)
+
+func TestGetBacksOffWhenThrottled(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := &client{apiUrl: srv.URL, maxResponseSize: types.DefaultMaxResponseSize}
+	var throttledPath string
+	c.SetOnThrottle(func(path string, retryAfter time.Duration) {
+		throttledPath = path
+		assert.Equal(t, time.Minute, retryAfter)
+	})
+	_, code, err := c.Get("/fees", nil)
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	assert.IsType(t, &types.ThrottledError{}, err)
+	assert.Equal(t, "/fees", throttledPath)
+
+	// the next request does not reach the api until the wait is over
+	_, err = c.Post("/broadcast", nil, nil)
+	wait, ok := types.RetryAfter(err)
+	assert.True(t, ok)
+	assert.True(t, wait > 0 && wait <= time.Minute)
+	assert.Equal(t, 1, requests)
+}
+
+func TestWSData(t *testing.T) {
+	var s jsonscan.Scanner
+	data, err := wsData(&s, []byte(`{"stream":"marketDiff","data":{"e":"depthUpdate","E":1568362462591123456}}`))
+	assert.NoError(t, err)
+	// numbers pass through untouched, not rounded to a float64
+	assert.Equal(t, `{"e":"depthUpdate","E":1568362462591123456}`, string(data))
+
+	data, err = wsData(&s, []byte(`{"Stream":"x","Data":[1,2]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2]`, string(data))
+
+	data, err = wsData(&s, []byte(`{"stream":"x"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+
+	_, err = wsData(&s, []byte(`[{"data":1}]`))
+	assert.Error(t, err)
+}
+
+func TestRequestID(t *testing.T) {
+	var sent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = r.Header.Get(types.RequestIDHeader)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := &client{apiUrl: srv.URL, maxResponseSize: types.DefaultMaxResponseSize}
+	_, _, err := c.Get("/account/x", nil)
+	assert.Error(t, err)
+	assert.NotEmpty(t, sent)
+	assert.Equal(t, sent, types.RequestID(err))
+
+	c.SetRequestIDFunc(func() string { return "trade-42" })
+	_, err = c.Post("/broadcast", nil, nil)
+	assert.Equal(t, "trade-42", sent)
+	assert.Equal(t, "trade-42", types.RequestID(err))
+	assert.Equal(t, types.ErrorClassInvalidRequest, types.Classify(err))
+}
+
+func TestReadBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// flushing before the end leaves the length unknown
+			w.Write([]byte(`{"a":`))
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(`{"symbol":"BNB"}`))
+	}))
+	defer srv.Close()
+
+	c := &client{apiUrl: srv.URL, maxResponseSize: types.DefaultMaxResponseSize}
+	body, _, err := c.Get("/tokens", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"symbol":"BNB"}`, string(body))
+	assert.Equal(t, len(body), cap(body), "a body of known length is read into an exact slice")
+	body, _, err = c.Get("/chunked", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{"symbol":"BNB"}`, string(body))
+
+	c.SetMaxResponseSize(8)
+	for _, path := range []string{"/tokens", "/chunked"} {
+		_, _, err = c.Get(path, nil)
+		assert.Equal(t, types.ErrorClassInvalidRequest, types.Classify(err), path)
+	}
+}
@@ -0,0 +1,79 @@
+package basic
+
+import (
+	"container/list"
+	"net/url"
+	"sync"
+)
+
+// CachedResponse is a GET response kept for revalidation with its validators.
+type CachedResponse struct {
+	ETag         string
+	LastModified string
+	Body         []byte
+}
+
+// ResponseCache stores responses of GET requests by url. Responses are only
+// reused after the server confirmed them with 304 Not Modified, so a cache never
+// serves stale data, it saves the transfer and the rate limit weight.
+type ResponseCache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+}
+
+// MemoryCache is a ResponseCache keeping the most recently used entries in memory.
+type MemoryCache struct {
+	mtx        sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type cacheEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewMemoryCache keeps up to maxEntries responses, 0 means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{maxEntries: maxEntries, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).resp, true
+}
+
+func (c *MemoryCache) Set(key string, resp *CachedResponse) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*cacheEntry).resp = resp
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, resp: resp})
+	if c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// cacheKey identifies a GET request, query parameters are sorted by url.Values.
+func cacheKey(u string, qp map[string]string) string {
+	values := url.Values{}
+	for k, v := range qp {
+		values.Set(k, v)
+	}
+	if len(values) == 0 {
+		return u
+	}
+	return u + "?" + values.Encode()
+}
@@ -0,0 +1,54 @@
+package basic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/types"
+)
+
+func TestGetRevalidatesCachedResponse(t *testing.T) {
+	var full, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"symbol":"BNB"}]`))
+	}))
+	defer srv.Close()
+
+	c := &client{apiUrl: srv.URL, maxResponseSize: types.DefaultMaxResponseSize}
+	c.SetCache(NewMemoryCache(10))
+	for i := 0; i < 3; i++ {
+		body, code, err := c.Get("/tokens", map[string]string{"limit": "10"})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, `[{"symbol":"BNB"}]`, string(body))
+	}
+	assert.Equal(t, 1, full)
+	assert.Equal(t, 2, notModified)
+
+	// other query parameters are another resource
+	_, _, err := c.Get("/tokens", map[string]string{"limit": "20"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, full)
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", &CachedResponse{ETag: "a"})
+	c.Set("b", &CachedResponse{ETag: "b"})
+	c.Get("a")
+	c.Set("c", &CachedResponse{ETag: "c"})
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+}
@@ -0,0 +1,11 @@
+//go:build !js
+// +build !js
+
+package basic
+
+import "github.com/gorilla/websocket"
+
+func dialWS(u string) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(u, nil)
+	return conn, err
+}
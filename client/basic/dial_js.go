@@ -0,0 +1,7 @@
+package basic
+
+import "github.com/gorilla/websocket"
+
+func dialWS(u string) (*websocket.Conn, error) {
+	return nil, ErrWSUnsupported
+}
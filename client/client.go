@@ -33,26 +33,39 @@ func init() {
 
 func NewDexClientWithApiKey(baseUrl string, network types.ChainNetwork, keyManager keys.KeyManager, apiKey string) (DexClient, error) {
 	types.Network = network
-	c := basic.NewClient(baseUrl+"/internal", apiKey)
-	w := websocket.NewClient(c)
-	q := query.NewClient(c)
-	n, err := q.GetNodeInfo()
-	if err != nil {
-		return nil, err
-	}
-	t := transaction.NewClient(n.NodeInfo.Network, keyManager, q, c)
-	return &dexClient{BasicClient: c, QueryClient: q, TransactionClient: t, WSClient: w}, nil
+	return newDexClient(baseUrl+"/internal", apiKey, keyManager, nil)
 }
 
 func NewDexClient(baseUrl string, network types.ChainNetwork, keyManager keys.KeyManager) (DexClient, error) {
 	types.Network = network
-	c := basic.NewClient(baseUrl, "")
+	return newDexClient(baseUrl, "", keyManager, nil)
+}
+
+// NewIsolatedDexClient is NewDexClient, or NewDexClientWithApiKey if apiKey is
+// set, leaving types.Network alone so clients of different networks can share a
+// process. Txs are signed for the chain of the node, with the addresses of
+// network, and the account of the key is queried with its prefix.
+func NewIsolatedDexClient(baseUrl string, network types.ChainNetwork, keyManager keys.KeyManager, apiKey string) (DexClient, error) {
+	if apiKey != "" {
+		baseUrl += "/internal"
+	}
+	return newDexClient(baseUrl, apiKey, keyManager, &network)
+}
+
+// newDexClient makes a client of network, nil for types.Network.
+func newDexClient(baseUrl, apiKey string, keyManager keys.KeyManager, network *types.ChainNetwork) (DexClient, error) {
+	c := basic.NewClient(baseUrl, apiKey)
 	w := websocket.NewClient(c)
 	q := query.NewClient(c)
 	n, err := q.GetNodeInfo()
 	if err != nil {
 		return nil, err
 	}
-	t := transaction.NewClient(n.NodeInfo.Network, keyManager, q, c)
+	var t transaction.TransactionClient
+	if network != nil {
+		t = transaction.NewNetworkClient(n.NodeInfo.Network, *network, keyManager, q, c)
+	} else {
+		t = transaction.NewClient(n.NodeInfo.Network, keyManager, q, c)
+	}
 	return &dexClient{BasicClient: c, QueryClient: q, TransactionClient: t, WSClient: w}, nil
 }
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/resty.v1"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	gtypes "github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// fakeAPI is an api server of the chain of chainID, with the addresses of its
+// network.
+type fakeAPI struct {
+	*httptest.Server
+	chainID  string
+	network  types.ChainNetwork
+	owner    string
+	accounts []string
+	txs      []tx.StdTx
+}
+
+func newFakeAPI(t *testing.T, chainID string, network types.ChainNetwork, addr types.AccAddress) *fakeAPI {
+	owner := network.FormatAddress(addr)
+	api := &fakeAPI{chainID: chainID, network: network, owner: owner}
+	api.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var res interface{}
+		switch path := strings.TrimPrefix(r.URL.Path, gtypes.DefaultAPIVersionPrefix); {
+		case path == "/node-info":
+			res = map[string]interface{}{"node_info": map[string]string{"network": chainID}}
+		case path == "/tokens":
+			res = []map[string]string{{"symbol": "XYZ-000", "total_supply": "1.00000000", "owner": owner}}
+		case strings.HasPrefix(path, "/account/"):
+			api.accounts = append(api.accounts, strings.TrimPrefix(path, "/account/"))
+			res = types.BalanceAccount{Number: 1, Address: owner, Sequence: 2}
+		case path == "/broadcast":
+			body, _ := ioutil.ReadAll(r.Body)
+			raw, err := hex.DecodeString(string(body))
+			assert.NoError(t, err)
+			var stdTx tx.StdTx
+			assert.NoError(t, tx.Cdc.UnmarshalBinaryLengthPrefixed(raw, &stdTx))
+			api.txs = append(api.txs, stdTx)
+			res = []tx.TxCommitResult{{Ok: true, Hash: "AB"}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(res)
+	}))
+	return api
+}
+
+func (api *fakeAPI) host() string {
+	return strings.TrimPrefix(api.URL, "https://")
+}
+
+func TestIsolatedClientsShareProcess(t *testing.T) {
+	defer func(network types.ChainNetwork) { types.Network = network }(types.Network)
+	types.Network = types.ProdNetwork
+	km, err := keys.NewPrivateKeyManager("9579fff0cab07a4379e845a890105004ba4c8276f8ad9d22082b2acbf02d884b")
+	assert.NoError(t, err)
+	addr := km.GetAddr()
+
+	prod := newFakeAPI(t, "Binance-Chain-Tigris", types.ProdNetwork, addr)
+	defer prod.Close()
+	testnet := newFakeAPI(t, "Binance-Chain-Ganges", types.TestNetwork, addr)
+	defer testnet.Close()
+	defer func(transport http.RoundTripper) { resty.DefaultClient.SetTransport(transport) }(resty.DefaultClient.GetClient().Transport)
+	resty.DefaultClient.SetTransport(prod.Client().Transport)
+
+	for _, api := range []*fakeAPI{prod, testnet} {
+		c, err := NewIsolatedDexClient(api.host(), api.network, km, "")
+		assert.NoError(t, err)
+		assert.Equal(t, types.ProdNetwork, types.Network, "the process wide network is left alone")
+
+		tokens, err := c.GetTokens(types.NewTokensQuery())
+		assert.NoError(t, err)
+		if assert.Len(t, tokens, 1) {
+			assert.Equal(t, addr, tokens[0].Owner)
+		}
+
+		_, err = c.SendToken([]msg.Transfer{{ToAddr: addr, Coins: types.Coins{{Denom: "BNB", Amount: 1}}}}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{api.owner}, api.accounts, "the account is queried with the prefix of the chain")
+		if assert.Len(t, api.txs, 1) {
+			stdTx := api.txs[0]
+			sig := stdTx.Signatures[0]
+			signMsg := tx.StdSignMsg{ChainID: api.chainID, AccountNumber: 1, Sequence: 2, Msgs: stdTx.Msgs, Memo: stdTx.Memo, Source: stdTx.Source, Data: stdTx.Data, Network: &api.network}
+			signBytes := string(signMsg.Bytes())
+			assert.Equal(t, 2, strings.Count(signBytes, api.owner), "the sign bytes have the addresses of the chain")
+			assert.True(t, sig.PubKey.VerifyBytes([]byte(signBytes), sig.Signature))
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Package deadman cancels the open orders of an account after it lost its market
+// data or node connection for too long, so a market maker does not keep quoting
+// prices it could not update while it was blind.
+package deadman
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// OrderClient is the part of the rpc client the switch needs. *rpc.HTTP satisfies it.
+type OrderClient interface {
+	GetOpenOrders(addr types.AccAddress, pair string) ([]types.OpenOrder, error)
+	CancelOrder(baseAssetSymbol, quoteAssetSymbol, refId string, syncType rpc.SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+}
+
+// StatusClient is what WatchNode probes. *rpc.HTTP satisfies it.
+type StatusClient interface {
+	Status() (*core_types.ResultStatus, error)
+}
+
+type Config struct {
+	Account types.AccAddress
+	// Pairs, as "BASE_QUOTE", whose open orders are cancelled.
+	Pairs []string
+	// Timeout is how long the connection may be silent before the switch trips.
+	Timeout time.Duration
+	// OnTrip is called once when the switch trips, with the silence so far.
+	OnTrip func(silence time.Duration)
+	// OnCancel is called for every order the switch tries to cancel.
+	OnCancel func(pair, orderID string, err error)
+}
+
+// Switch trips when no Beat arrived for longer than the timeout. The orders
+// cannot be cancelled while the connection is down, so the first Beat after a
+// trip cancels them, and the switch stays tripped until all cancels went through.
+type Switch struct {
+	client OrderClient
+	cfg    Config
+
+	mtx        sync.Mutex
+	lastBeat   time.Time
+	tripped    bool
+	cancelling bool
+}
+
+func NewSwitch(client OrderClient, cfg Config) (*Switch, error) {
+	if cfg.Timeout <= 0 {
+		return nil, fmt.Errorf("the timeout must be positive")
+	}
+	for _, pair := range cfg.Pairs {
+		if err := rpc.ValidatePair(pair); err != nil {
+			return nil, err
+		}
+	}
+	return &Switch{client: client, cfg: cfg, lastBeat: time.Now()}, nil
+}
+
+// Tripped reports whether the orders of the account may still be stale. Quoting
+// should wait until it is false again.
+func (s *Switch) Tripped() bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.tripped
+}
+
+// Beat records that the connection is alive, call it on every market data event
+// or successful node call. After a trip it starts cancelling the open orders.
+func (s *Switch) Beat() {
+	s.mtx.Lock()
+	s.lastBeat = time.Now()
+	start := s.tripped && !s.cancelling
+	if start {
+		s.cancelling = true
+	}
+	s.mtx.Unlock()
+	if start {
+		go s.recover()
+	}
+}
+
+// Check trips the switch if the last beat is older than the timeout at now.
+func (s *Switch) Check(now time.Time) {
+	s.mtx.Lock()
+	silence := now.Sub(s.lastBeat)
+	trip := !s.tripped && silence > s.cfg.Timeout
+	if trip {
+		s.tripped = true
+	}
+	s.mtx.Unlock()
+	if trip && s.cfg.OnTrip != nil {
+		s.cfg.OnTrip(silence)
+	}
+}
+
+func (s *Switch) recover() {
+	err := s.CancelAll()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.cancelling = false
+	if err == nil {
+		s.tripped = false
+	}
+}
+
+// CancelAll cancels every open order of the account on the configured pairs and
+// returns the first error. Orders gone in the meantime are not an error.
+func (s *Switch) CancelAll() error {
+	var firstErr error
+	for _, pair := range s.cfg.Pairs {
+		orders, err := s.client.GetOpenOrders(s.cfg.Account, pair)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		assets := strings.SplitN(pair, "_", 2)
+		for _, order := range orders {
+			err := s.cancel(assets[0], assets[1], order.Id)
+			if s.cfg.OnCancel != nil {
+				s.cfg.OnCancel(pair, order.Id, err)
+			}
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (s *Switch) cancel(base, quote, orderID string) error {
+	res, err := s.client.CancelOrder(base, quote, orderID, rpc.Sync)
+	if err != nil {
+		return err
+	}
+	if rpc.IsOrderGone(res) {
+		return nil
+	}
+	if res.Code != 0 {
+		return rpc.BroadcastError(res)
+	}
+	return nil
+}
+
+// Run checks the switch every interval until ctx is done.
+func (s *Switch) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			s.Check(now)
+		}
+	}
+}
+
+// WatchNode probes the node every interval and beats on every answer, for
+// callers without a stream of their own to beat on. It returns when ctx is done.
+func (s *Switch) WatchNode(ctx context.Context, node StatusClient, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := node.Status(); err == nil {
+			s.Beat()
+		}
+		s.Check(time.Now())
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
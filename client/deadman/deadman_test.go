@@ -0,0 +1,73 @@
+package deadman
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type fakeOrders struct {
+	mtx       sync.Mutex
+	open      map[string][]types.OpenOrder
+	cancelled []string
+}
+
+func (f *fakeOrders) GetOpenOrders(addr types.AccAddress, pair string) ([]types.OpenOrder, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.open[pair], nil
+}
+
+func (f *fakeOrders) CancelOrder(base, quote, refID string, syncType rpc.SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.cancelled = append(f.cancelled, base+"_"+quote+"/"+refID)
+	return &core_types.ResultBroadcastTx{}, nil
+}
+
+func (f *fakeOrders) cancels() []string {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return append([]string(nil), f.cancelled...)
+}
+
+func TestSwitchCancelsAfterReconnect(t *testing.T) {
+	orders := &fakeOrders{open: map[string][]types.OpenOrder{
+		"BTC-86A_BNB": {{Id: "order-1"}, {Id: "order-2"}},
+		"ETH-1C9_BNB": {{Id: "order-3"}},
+	}}
+	var tripped []time.Duration
+	s, err := NewSwitch(orders, Config{
+		Pairs:   []string{"BTC-86A_BNB", "ETH-1C9_BNB"},
+		Timeout: time.Second,
+		OnTrip:  func(silence time.Duration) { tripped = append(tripped, silence) },
+	})
+	assert.NoError(t, err)
+
+	s.Check(time.Now())
+	assert.False(t, s.Tripped())
+
+	s.Check(time.Now().Add(2 * time.Second))
+	s.Check(time.Now().Add(3 * time.Second))
+	assert.True(t, s.Tripped())
+	assert.Len(t, tripped, 1)
+	assert.Empty(t, orders.cancels(), "nothing is cancelled while disconnected")
+
+	s.Beat()
+	assert.Eventually(t, func() bool { return !s.Tripped() }, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, []string{"BTC-86A_BNB/order-1", "BTC-86A_BNB/order-2", "ETH-1C9_BNB/order-3"}, orders.cancels())
+}
+
+func TestNewSwitchValidatesConfig(t *testing.T) {
+	_, err := NewSwitch(&fakeOrders{}, Config{Pairs: []string{"BTC-86A_BNB"}})
+	assert.Error(t, err)
+	_, err = NewSwitch(&fakeOrders{}, Config{Pairs: []string{"BTC"}, Timeout: time.Second})
+	assert.Error(t, err)
+}
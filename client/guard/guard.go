@@ -0,0 +1,204 @@
+// Package guard implements pre-broadcast sanity checks on new orders to catch
+// fat-finger mistakes before they reach the chain.
+package guard
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+type RejectReason int
+
+const (
+	RejectHalted RejectReason = iota
+	RejectPriceDeviation
+	RejectNotional
+	RejectDuplicate
+)
+
+func (r RejectReason) String() string {
+	switch r {
+	case RejectHalted:
+		return "halted"
+	case RejectPriceDeviation:
+		return "price_deviation"
+	case RejectNotional:
+		return "notional"
+	case RejectDuplicate:
+		return "duplicate"
+	}
+	return "unknown"
+}
+
+// Rejection is the error returned for an order the guard refuses to let through.
+type Rejection struct {
+	Reason  RejectReason
+	Symbol  string
+	Message string
+}
+
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("order on %s rejected by the sanity guard (%s): %s", r.Symbol, r.Reason, r.Message)
+}
+
+// MidPriceFunc returns the mid price of a trade pair, or 0 if its book is empty.
+type MidPriceFunc func(symbol string) (int64, error)
+
+// DepthClient is the part of the rpc client DepthMidPrice needs. *rpc.HTTP satisfies it.
+type DepthClient interface {
+	GetDepth(tradePair string, level int) (*types.OrderBook, error)
+}
+
+// DepthMidPrice reads the mid price from the top of the order book. With one
+// side of the book empty the best price of the other side is used.
+func DepthMidPrice(c DepthClient) MidPriceFunc {
+	return func(symbol string) (int64, error) {
+		book, err := c.GetDepth(symbol, 1)
+		if err != nil {
+			return 0, err
+		}
+		if len(book.Levels) == 0 {
+			return 0, nil
+		}
+		bid, ask := book.Levels[0].BuyPrice.ToInt64(), book.Levels[0].SellPrice.ToInt64()
+		switch {
+		case bid > 0 && ask > 0:
+			return (bid + ask) / 2, nil
+		case bid > 0:
+			return bid, nil
+		}
+		return ask, nil
+	}
+}
+
+type Config struct {
+	// MaxDeviation is how far, in basis points, the order price may be from the
+	// mid price. 0 disables the check, as does a nil MidPrice.
+	MaxDeviation int64
+	MidPrice     MidPriceFunc
+	// MaxNotional is, per quote asset, the largest price * quantity of an order.
+	// Quote assets not listed are not checked.
+	MaxNotional map[string]int64
+	// DuplicateWindow rejects an order equal in symbol, side, price and quantity
+	// to one accepted within the window. 0 disables the check.
+	DuplicateWindow time.Duration
+}
+
+type orderKey struct {
+	symbol   string
+	side     int8
+	price    int64
+	quantity int64
+}
+
+// Guard checks orders against a Config. It can also be halted, which rejects
+// every order until it is resumed.
+type Guard struct {
+	cfg Config
+	now func() time.Time
+
+	mtx    sync.Mutex
+	halted bool
+	recent map[orderKey]time.Time
+}
+
+func NewGuard(cfg Config) *Guard {
+	return &Guard{cfg: cfg, now: time.Now, recent: map[orderKey]time.Time{}}
+}
+
+// Halt makes the guard reject every order, as a kill switch.
+func (g *Guard) Halt() {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.halted = true
+}
+
+func (g *Guard) Resume() {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.halted = false
+}
+
+func (g *Guard) Halted() bool {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return g.halted
+}
+
+// Check returns a *Rejection if the order fails a check, or the error of the mid
+// price lookup. Accepted orders are remembered for duplicate detection.
+func (g *Guard) Check(symbol string, side int8, price, quantity int64) error {
+	if g.Halted() {
+		return &Rejection{Reason: RejectHalted, Symbol: symbol, Message: "order placement is halted"}
+	}
+	if err := g.checkNotional(symbol, price, quantity); err != nil {
+		return err
+	}
+	if err := g.checkDeviation(symbol, price); err != nil {
+		return err
+	}
+	return g.checkDuplicate(orderKey{symbol: symbol, side: side, price: price, quantity: quantity})
+}
+
+func (g *Guard) checkNotional(symbol string, price, quantity int64) error {
+	parts := strings.SplitN(symbol, "_", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	limit, ok := g.cfg.MaxNotional[parts[1]]
+	if !ok {
+		return nil
+	}
+	notional := new(big.Int).Mul(big.NewInt(price), big.NewInt(quantity))
+	notional.Quo(notional, big.NewInt(int64(types.Fixed8Decimals)))
+	if notional.Cmp(big.NewInt(limit)) > 0 {
+		return &Rejection{Reason: RejectNotional, Symbol: symbol,
+			Message: fmt.Sprintf("notional exceeds %s %s", types.Fixed8(limit), parts[1])}
+	}
+	return nil
+}
+
+func (g *Guard) checkDeviation(symbol string, price int64) error {
+	if g.cfg.MaxDeviation <= 0 || g.cfg.MidPrice == nil {
+		return nil
+	}
+	mid, err := g.cfg.MidPrice(symbol)
+	if err != nil {
+		return err
+	}
+	if mid <= 0 {
+		return nil
+	}
+	diff := big.NewInt(price - mid)
+	diff.Abs(diff).Mul(diff, big.NewInt(10000))
+	if diff.Cmp(new(big.Int).Mul(big.NewInt(mid), big.NewInt(g.cfg.MaxDeviation))) > 0 {
+		return &Rejection{Reason: RejectPriceDeviation, Symbol: symbol,
+			Message: fmt.Sprintf("price %s is more than %d bps away from mid %s", types.Fixed8(price), g.cfg.MaxDeviation, types.Fixed8(mid))}
+	}
+	return nil
+}
+
+func (g *Guard) checkDuplicate(key orderKey) error {
+	if g.cfg.DuplicateWindow <= 0 {
+		return nil
+	}
+	now := g.now()
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	for k, t := range g.recent {
+		if now.Sub(t) >= g.cfg.DuplicateWindow {
+			delete(g.recent, k)
+		}
+	}
+	if _, ok := g.recent[key]; ok {
+		return &Rejection{Reason: RejectDuplicate, Symbol: key.symbol,
+			Message: fmt.Sprintf("same order placed within %s", g.cfg.DuplicateWindow)}
+	}
+	g.recent[key] = now
+	return nil
+}
@@ -0,0 +1,66 @@
+package guard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+type fakeDepth struct {
+	book types.OrderBook
+}
+
+func (f *fakeDepth) GetDepth(tradePair string, level int) (*types.OrderBook, error) {
+	return &f.book, nil
+}
+
+func reason(t *testing.T, err error) RejectReason {
+	rejection, ok := err.(*Rejection)
+	if !assert.True(t, ok, "expected a rejection, got %v", err) {
+		return -1
+	}
+	return rejection.Reason
+}
+
+func TestGuardChecks(t *testing.T) {
+	depth := &fakeDepth{book: types.OrderBook{Levels: []types.OrderBookLevel{{BuyPrice: 99e8, SellPrice: 101e8}}}}
+	g := NewGuard(Config{
+		MaxDeviation:    500,
+		MidPrice:        DepthMidPrice(depth),
+		MaxNotional:     map[string]int64{"BNB": 1000e8},
+		DuplicateWindow: time.Minute,
+	})
+	now := time.Unix(0, 0)
+	g.now = func() time.Time { return now }
+
+	assert.NoError(t, g.Check("XYZ_BNB", msg.OrderSide.BUY, 104e8, 1e8))
+	assert.Equal(t, RejectPriceDeviation, reason(t, g.Check("XYZ_BNB", msg.OrderSide.BUY, 106e8, 1e8)))
+	assert.Equal(t, RejectNotional, reason(t, g.Check("XYZ_BNB", msg.OrderSide.BUY, 100e8, 11e8)))
+	assert.NoError(t, g.Check("XYZ_BUSD", msg.OrderSide.BUY, 100e8, 11e8))
+
+	assert.Equal(t, RejectDuplicate, reason(t, g.Check("XYZ_BNB", msg.OrderSide.BUY, 104e8, 1e8)))
+	assert.NoError(t, g.Check("XYZ_BNB", msg.OrderSide.SELL, 104e8, 1e8))
+	now = now.Add(time.Minute)
+	assert.NoError(t, g.Check("XYZ_BNB", msg.OrderSide.BUY, 104e8, 1e8))
+
+	g.Halt()
+	assert.Equal(t, RejectHalted, reason(t, g.Check("XYZ_BNB", msg.OrderSide.BUY, 100e8, 2e8)))
+	g.Resume()
+	assert.NoError(t, g.Check("XYZ_BNB", msg.OrderSide.BUY, 100e8, 2e8))
+}
+
+func TestDepthMidPriceOneSided(t *testing.T) {
+	depth := &fakeDepth{book: types.OrderBook{Levels: []types.OrderBookLevel{{SellPrice: 5e8}}}}
+	mid, err := DepthMidPrice(depth)("XYZ_BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5e8), mid)
+
+	depth.book.Levels = nil
+	mid, err = DepthMidPrice(depth)("XYZ_BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), mid)
+}
@@ -0,0 +1,364 @@
+// Package history fetches market history from the api server as continuous,
+// validated series, for backtesting strategies on the same data they will
+// trade on.
+package history
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// DefaultPageSize is the most klines or trades the api server returns per call.
+const DefaultPageSize = 1000
+
+// Client is the part of the query client the fetcher needs,
+// query.QueryClient satisfies it.
+type Client interface {
+	GetKlines(query *types.KlineQuery) ([]types.Kline, error)
+	GetTrades(query *types.TradesQuery) (*types.Trades, error)
+}
+
+// GapFill is how a missing kline was filled in.
+type GapFill int
+
+const (
+	// FilledFromTrades is a kline rebuilt from the trades of its interval.
+	FilledFromTrades GapFill = iota
+	// FilledFlat is an interval without trades, filled at the previous close.
+	FilledFlat
+	// Trimmed is an interval before the first trade of the range, which has
+	// no price to fill it with. It is left out, the series starts later.
+	Trimmed
+)
+
+func (f GapFill) String() string {
+	switch f {
+	case FilledFromTrades:
+		return "trades"
+	case FilledFlat:
+		return "flat"
+	}
+	return "trimmed"
+}
+
+// Gap is a kline the api server did not return, or returned invalid.
+type Gap struct {
+	OpenTime time.Time
+	Fill     GapFill
+	// Invalid is why the kline returned was rejected, nil if none was.
+	Invalid error
+}
+
+type Config struct {
+	// PageSize is the number of klines and trades requested per call,
+	// DefaultPageSize if 0.
+	PageSize uint32
+	// OnGap, if set, is called for every gap found, in order.
+	OnGap func(Gap)
+}
+
+// Fetcher fetches klines, rebuilding the ones missing from trades.
+type Fetcher struct {
+	client Client
+	cfg    Config
+}
+
+func NewFetcher(client Client, cfg Config) *Fetcher {
+	if cfg.PageSize == 0 {
+		cfg.PageSize = DefaultPageSize
+	}
+	return &Fetcher{client: client, cfg: cfg}
+}
+
+// GetKlines returns the klines of pair, like BNB_BTCB-1DE, opening in
+// [from, to), one per interval without holes: klines the api server misses
+// or returns inconsistent are rebuilt from trades, intervals without trades
+// are flat at the previous close. Intervals before the first trade of the
+// range are left out.
+func (f *Fetcher) GetKlines(pair, interval string, from, to time.Time) ([]types.Kline, error) {
+	step, err := parseInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from %s is not before to %s", from, to)
+	}
+	fetched, err := f.fetchKlines(pair, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byOpen := make(map[int64]types.Kline, len(fetched))
+	invalid := map[int64]error{}
+	for _, k := range fetched {
+		if err := validateKline(k, step); err != nil {
+			invalid[k.OpenTime] = err
+			continue
+		}
+		byOpen[k.OpenTime] = k
+	}
+
+	var anchor time.Time
+	if len(fetched) > 0 {
+		anchor = msTime(fetched[0].OpenTime)
+	} else {
+		anchor = step.align(from)
+	}
+	var series []types.Kline
+	var missing []time.Time
+	flush := func() error {
+		if len(missing) == 0 {
+			return nil
+		}
+		filled, err := f.fill(pair, step, missing, series, invalid)
+		series = append(series, filled...)
+		missing = missing[:0]
+		return err
+	}
+	for open := step.first(anchor, from); open.Before(to); open = step.next(open) {
+		if k, ok := byOpen[toMs(open)]; ok {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			series = append(series, k)
+		} else {
+			missing = append(missing, open)
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (f *Fetcher) fetchKlines(pair, interval string, from, to time.Time) ([]types.Kline, error) {
+	var klines []types.Kline
+	end := toMs(to) - 1
+	for cursor := toMs(from); cursor <= end; {
+		q := &types.KlineQuery{Symbol: pair, Interval: interval}
+		q.WithStartTime(cursor).WithEndTime(end).WithLimit(f.cfg.PageSize)
+		page, err := f.client.GetKlines(q)
+		if err != nil {
+			return nil, err
+		}
+		next := cursor
+		for _, k := range page {
+			if k.OpenTime >= toMs(from) && k.OpenTime <= end {
+				klines = append(klines, k)
+			}
+			if k.OpenTime >= next {
+				next = k.OpenTime + 1
+			}
+		}
+		if uint32(len(page)) < f.cfg.PageSize || next == cursor {
+			break
+		}
+		cursor = next
+	}
+	sort.SliceStable(klines, func(i, j int) bool { return klines[i].OpenTime < klines[j].OpenTime })
+	// pages may overlap on their bounds
+	deduped := klines[:0]
+	for _, k := range klines {
+		if len(deduped) > 0 && deduped[len(deduped)-1].OpenTime == k.OpenTime {
+			continue
+		}
+		deduped = append(deduped, k)
+	}
+	return deduped, nil
+}
+
+// fill rebuilds the klines of a run of missing intervals from their trades.
+func (f *Fetcher) fill(pair string, step interval, missing []time.Time, series []types.Kline, invalid map[int64]error) ([]types.Kline, error) {
+	trades, err := f.fetchTrades(pair, missing[0], step.next(missing[len(missing)-1]))
+	if err != nil {
+		return nil, err
+	}
+	var filled []types.Kline
+	prevClose, hasPrev := 0.0, false
+	if len(series) > 0 {
+		prevClose, hasPrev = series[len(series)-1].Close, true
+	}
+	for _, open := range missing {
+		closeTime := step.next(open)
+		var bucket []types.Trade
+		for len(trades) > 0 && trades[0].Time < toMs(closeTime) {
+			if trades[0].Time >= toMs(open) {
+				bucket = append(bucket, trades[0])
+			}
+			trades = trades[1:]
+		}
+		gap := Gap{OpenTime: open, Invalid: invalid[toMs(open)]}
+		k := types.Kline{OpenTime: toMs(open), CloseTime: toMs(closeTime) - 1}
+		switch {
+		case len(bucket) > 0:
+			if err := klineFromTrades(&k, bucket); err != nil {
+				return filled, err
+			}
+			gap.Fill = FilledFromTrades
+		case hasPrev:
+			k.Open, k.High, k.Low, k.Close = prevClose, prevClose, prevClose, prevClose
+			gap.Fill = FilledFlat
+		default:
+			gap.Fill = Trimmed
+		}
+		if f.cfg.OnGap != nil {
+			f.cfg.OnGap(gap)
+		}
+		if gap.Fill == Trimmed {
+			continue
+		}
+		filled = append(filled, k)
+		prevClose, hasPrev = k.Close, true
+	}
+	return filled, nil
+}
+
+// fetchTrades returns the trades of pair in [from, to), oldest first.
+func (f *Fetcher) fetchTrades(pair string, from, to time.Time) ([]types.Trade, error) {
+	var trades []types.Trade
+	for offset := uint32(0); ; offset += f.cfg.PageSize {
+		q := types.NewTradesQuery(false)
+		q.Symbol = pair
+		start, end := toMs(from), toMs(to)-1
+		q.Start, q.End = &start, &end
+		q.Offset, q.Limit = &offset, &f.cfg.PageSize
+		page, err := f.client.GetTrades(q)
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, page.Trade...)
+		if uint32(len(page.Trade)) < f.cfg.PageSize {
+			break
+		}
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		if trades[i].Time != trades[j].Time {
+			return trades[i].Time < trades[j].Time
+		}
+		return trades[i].BlockHeight < trades[j].BlockHeight
+	})
+	return trades, nil
+}
+
+func klineFromTrades(k *types.Kline, trades []types.Trade) error {
+	for i, t := range trades {
+		price, err := strconv.ParseFloat(t.Price, 64)
+		if err != nil {
+			return fmt.Errorf("trade %s: invalid price %q", t.TradeID, t.Price)
+		}
+		qty, err := strconv.ParseFloat(t.Quantity, 64)
+		if err != nil {
+			return fmt.Errorf("trade %s: invalid quantity %q", t.TradeID, t.Quantity)
+		}
+		if i == 0 {
+			k.Open, k.High, k.Low = price, price, price
+		}
+		k.High = math.Max(k.High, price)
+		k.Low = math.Min(k.Low, price)
+		k.Close = price
+		k.Volume += qty
+		k.QuoteAssetVolume += qty * price
+		k.NumberOfTrades++
+	}
+	return nil
+}
+
+func validateKline(k types.Kline, step interval) error {
+	switch {
+	case k.Low > k.High:
+		return fmt.Errorf("low %v above high %v", k.Low, k.High)
+	case k.Open < k.Low || k.Open > k.High, k.Close < k.Low || k.Close > k.High:
+		return fmt.Errorf("open %v or close %v outside [%v, %v]", k.Open, k.Close, k.Low, k.High)
+	case k.Low < 0 || k.Volume < 0 || k.QuoteAssetVolume < 0 || k.NumberOfTrades < 0:
+		return fmt.Errorf("negative price or volume")
+	case k.CloseTime != toMs(step.next(msTime(k.OpenTime)))-1:
+		return fmt.Errorf("close time %d does not end the interval opening at %d", k.CloseTime, k.OpenTime)
+	}
+	return nil
+}
+
+func toMs(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func msTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+// interval is a kline interval, months are calendar months.
+type interval struct {
+	d      time.Duration
+	months int
+}
+
+func parseInterval(s string) (interval, error) {
+	if s == "1M" {
+		return interval{months: 1}, nil
+	}
+	if len(s) >= 2 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err == nil && n > 0 {
+			switch s[len(s)-1] {
+			case 'm':
+				return interval{d: time.Duration(n) * time.Minute}, nil
+			case 'h':
+				return interval{d: time.Duration(n) * time.Hour}, nil
+			case 'd':
+				return interval{d: time.Duration(n) * 24 * time.Hour}, nil
+			case 'w':
+				return interval{d: time.Duration(n) * 7 * 24 * time.Hour}, nil
+			}
+		}
+	}
+	return interval{}, fmt.Errorf("invalid kline interval %q", s)
+}
+
+func (i interval) next(t time.Time) time.Time {
+	if i.months > 0 {
+		return t.AddDate(0, i.months, 0)
+	}
+	return t.Add(i.d)
+}
+
+// align returns the open time of the interval containing t, for ranges the
+// api server returned nothing for. Intervals are counted from the unix epoch,
+// weeks open on Monday.
+func (i interval) align(t time.Time) time.Time {
+	t = t.UTC()
+	if i.months > 0 {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	var offset int64
+	if i.d%(7*24*time.Hour) == 0 {
+		// the epoch is a Thursday
+		offset = toMs(time.Unix(0, 0).Add(4 * 24 * time.Hour))
+	}
+	d := int64(i.d / time.Millisecond)
+	ms := toMs(t) - offset
+	ms -= ((ms % d) + d) % d
+	return msTime(ms + offset)
+}
+
+// first returns the first open time at or after from on the grid through anchor.
+func (i interval) first(anchor, from time.Time) time.Time {
+	if i.months > 0 {
+		t := anchor
+		for t.After(from) {
+			t = t.AddDate(0, -i.months, 0)
+		}
+		for t.Before(from) {
+			t = t.AddDate(0, i.months, 0)
+		}
+		return t
+	}
+	steps := from.Sub(anchor) / i.d
+	t := anchor.Add(steps * i.d)
+	if t.Before(from) {
+		t = t.Add(i.d)
+	}
+	return t
+}
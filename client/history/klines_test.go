@@ -0,0 +1,115 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+type fakeClient struct {
+	klines      []types.Kline
+	trades      []types.Trade
+	klineCalls  int
+	tradeCalls  int
+	tradeRanges [][2]int64
+}
+
+func (c *fakeClient) GetKlines(q *types.KlineQuery) ([]types.Kline, error) {
+	c.klineCalls++
+	var page []types.Kline
+	for _, k := range c.klines {
+		if k.OpenTime >= *q.StartTime && k.OpenTime <= *q.EndTime && uint32(len(page)) < *q.Limit {
+			page = append(page, k)
+		}
+	}
+	return page, nil
+}
+
+func (c *fakeClient) GetTrades(q *types.TradesQuery) (*types.Trades, error) {
+	c.tradeCalls++
+	c.tradeRanges = append(c.tradeRanges, [2]int64{*q.Start, *q.End})
+	var matched []types.Trade
+	for _, t := range c.trades {
+		if t.Time >= *q.Start && t.Time <= *q.End {
+			matched = append(matched, t)
+		}
+	}
+	offset := int(*q.Offset)
+	if offset > len(matched) {
+		offset = len(matched)
+	}
+	page := matched[offset:]
+	if len(page) > int(*q.Limit) {
+		page = page[:*q.Limit]
+	}
+	return &types.Trades{Trade: page}, nil
+}
+
+func kline(open int64, o, h, l, c float64) types.Kline {
+	return types.Kline{OpenTime: open, CloseTime: open + 59999, Open: o, High: h, Low: l, Close: c, Volume: 1, NumberOfTrades: 1}
+}
+
+func TestGetKlinesFillsGaps(t *testing.T) {
+	t0 := time.Date(2020, 3, 2, 10, 0, 0, 0, time.UTC)
+	m := int64(60000)
+	base := toMs(t0)
+	client := &fakeClient{
+		klines: []types.Kline{
+			kline(base+m, 10, 11, 9, 10.5),
+			kline(base+2*m, 10, 9, 11, 10), // low above high
+			kline(base+4*m, 12, 13, 12, 12.5),
+		},
+		trades: []types.Trade{
+			{Time: base + 5*m + 10, Price: "13", Quantity: "2", TradeID: "b"},
+			{Time: base + 2*m + 5, Price: "11", Quantity: "1"},
+			{Time: base + 2*m + 1, Price: "10", Quantity: "3"},
+		},
+	}
+	var gaps []Gap
+	f := NewFetcher(client, Config{PageSize: 2, OnGap: func(g Gap) { gaps = append(gaps, g) }})
+	series, err := f.GetKlines("BNB_BTCB-1DE", "1m", t0, t0.Add(6*time.Minute))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, client.klineCalls, "paged by 2")
+	opens := make([]int64, len(series))
+	for i, k := range series {
+		opens[i] = (k.OpenTime - base) / m
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, opens)
+
+	assert.Equal(t, types.Kline{OpenTime: base + 2*m, CloseTime: base + 3*m - 1, Open: 10, High: 11, Low: 10, Close: 11, Volume: 4, QuoteAssetVolume: 41, NumberOfTrades: 2}, series[1])
+	assert.Equal(t, types.Kline{OpenTime: base + 3*m, CloseTime: base + 4*m - 1, Open: 11, High: 11, Low: 11, Close: 11}, series[2])
+	assert.Equal(t, 13.0, series[4].Close)
+
+	fills := make([]GapFill, len(gaps))
+	for i, g := range gaps {
+		fills[i] = g.Fill
+	}
+	assert.Equal(t, []GapFill{Trimmed, FilledFromTrades, FilledFlat, FilledFromTrades}, fills)
+	assert.Error(t, gaps[1].Invalid)
+	assert.Nil(t, gaps[2].Invalid)
+	// one trades query per run of missing klines, the second fills a page
+	// and takes another
+	assert.Equal(t, [][2]int64{{base, base + m - 1}, {base + 2*m, base + 4*m - 1}, {base + 2*m, base + 4*m - 1}, {base + 5*m, base + 6*m - 1}}, client.tradeRanges)
+}
+
+func TestIntervals(t *testing.T) {
+	_, err := parseInterval("7x")
+	assert.Error(t, err)
+
+	week, err := parseInterval("1w")
+	assert.NoError(t, err)
+	thursday := time.Date(2020, 3, 5, 13, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC), week.align(thursday))
+
+	month, err := parseInterval("1M")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), month.align(thursday))
+	assert.Equal(t, time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), month.first(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), thursday))
+
+	hour, _ := parseInterval("1h")
+	assert.Equal(t, time.Date(2020, 3, 5, 14, 0, 0, 0, time.UTC), hour.first(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), thursday.Add(time.Minute)))
+}
@@ -0,0 +1,444 @@
+// Package hybrid serves reads from the accelerated node api and falls back to
+// abci queries against a full node while the api is unavailable, so callers get
+// the same answer whichever backend produced it.
+package hybrid
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	gtypes "github.com/binance-chain/go-sdk/types"
+)
+
+const (
+	// DefaultCooldown is how long the api is skipped after it failed.
+	DefaultCooldown = 30 * time.Second
+
+	defaultDepthLimit  = 100
+	defaultMarketLimit = 500
+	defaultTokenLimit  = 500
+)
+
+// Backend identifies who served a request.
+type Backend int
+
+const (
+	BackendREST Backend = iota
+	BackendRPC
+)
+
+func (b Backend) String() string {
+	switch b {
+	case BackendREST:
+		return "rest"
+	case BackendRPC:
+		return "rpc"
+	}
+	return "unknown"
+}
+
+// RESTClient is the part of the api client the hybrid client reads from.
+// query.QueryClient satisfies it.
+type RESTClient interface {
+	GetDepth(query *types.DepthQuery) (*types.MarketDepth, error)
+	GetKlines(query *types.KlineQuery) ([]types.Kline, error)
+	GetTrades(query *types.TradesQuery) (*types.Trades, error)
+	GetMarkets(query *types.MarketsQuery) ([]types.TradingPair, error)
+	GetTokens(query *types.TokensQuery) ([]types.Token, error)
+	GetAccount(address string) (*types.BalanceAccount, error)
+	GetOpenOrders(query *types.OpenOrdersQuery) (*types.OpenOrders, error)
+}
+
+// RPCClient is the part of the rpc client the hybrid client falls back to.
+// *rpc.HTTP satisfies it.
+type RPCClient interface {
+	GetDepth(tradePair string, level int) (*types.OrderBook, error)
+	GetTradingPairs(offset int, limit int) ([]types.TradingPair, error)
+	ListAllTokens(offset int, limit int) ([]types.Token, error)
+	GetAccount(addr types.AccAddress) (types.Account, error)
+	GetOpenOrders(addr types.AccAddress, pair string) ([]types.OpenOrder, error)
+}
+
+// Client reads market and account data from whichever backend is available.
+// Klines and trade history only exist on the api, they are always read from it
+// and fail with the api error when it is down.
+type Client interface {
+	GetDepth(query *types.DepthQuery) (*types.MarketDepth, error)
+	GetKlines(query *types.KlineQuery) ([]types.Kline, error)
+	GetTrades(query *types.TradesQuery) (*types.Trades, error)
+	GetMarkets(query *types.MarketsQuery) ([]types.TradingPair, error)
+	GetTokens(query *types.TokensQuery) ([]types.Token, error)
+	GetAccount(address string) (*types.BalanceAccount, error)
+	GetOpenOrders(query *types.OpenOrdersQuery) (*types.OpenOrders, error)
+
+	// SetCooldown sets how long the api is skipped after a failure, 0 tries it every time.
+	SetCooldown(cooldown time.Duration)
+	// SetOnFallback registers a callback run whenever a request is served by the
+	// rpc node because of apiErr, nil when the api was skipped during its cooldown.
+	SetOnFallback(fn func(method string, apiErr error))
+	// LastBackend returns the backend that served the last successful request.
+	LastBackend() Backend
+}
+
+type client struct {
+	rest RESTClient
+	node RPCClient
+
+	mtx        sync.Mutex
+	cooldown   time.Duration
+	downUntil  time.Time
+	onFallback func(method string, apiErr error)
+	last       Backend
+	now        func() time.Time
+}
+
+// NewClient prefers rest and falls back to node. Either may be nil, the client
+// then only uses the other one.
+func NewClient(rest RESTClient, node RPCClient) Client {
+	return &client{rest: rest, node: node, cooldown: DefaultCooldown, now: time.Now}
+}
+
+func (c *client) SetCooldown(cooldown time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.cooldown = cooldown
+}
+
+func (c *client) SetOnFallback(fn func(method string, apiErr error)) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.onFallback = fn
+}
+
+func (c *client) LastBackend() Backend {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.last
+}
+
+// useREST reports whether the api should be tried, it is skipped while cooling down.
+func (c *client) useREST() bool {
+	if c.rest == nil {
+		return false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return !c.now().Before(c.downUntil)
+}
+
+func (c *client) served(backend Backend) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.last = backend
+	if backend == BackendREST {
+		c.downUntil = time.Time{}
+	}
+}
+
+// fallback decides whether a request that failed on the api with apiErr goes to
+// the node. Only failures to reach the api do, a rejected request would be
+// rejected by the node as well.
+func (c *client) fallback(method string, apiErr error) bool {
+	if c.node == nil {
+		return false
+	}
+	if apiErr != nil && !unavailable(apiErr) {
+		return false
+	}
+	c.mtx.Lock()
+	if apiErr != nil {
+		c.downUntil = c.now().Add(c.cooldown)
+	}
+	onFallback := c.onFallback
+	c.mtx.Unlock()
+	if onFallback != nil {
+		onFallback(method, apiErr)
+	}
+	return true
+}
+
+func unavailable(err error) bool {
+	switch gtypes.Classify(err) {
+	case gtypes.ErrorClassNetwork, gtypes.ErrorClassTimeout, gtypes.ErrorClassUnavailable:
+		return true
+	}
+	return false
+}
+
+// noBackend is returned when the api is skipped and there is no node to fall back to.
+func noBackend(method string) error {
+	return gtypes.NewError(gtypes.ErrorClassUnavailable, fmt.Errorf("%s: no backend available", method))
+}
+
+func (c *client) GetDepth(query *types.DepthQuery) (*types.MarketDepth, error) {
+	if err := query.Check(); err != nil {
+		return nil, err
+	}
+	var apiErr error
+	if c.useREST() {
+		depth, err := c.rest.GetDepth(query)
+		if err == nil {
+			c.served(BackendREST)
+			return depth, nil
+		}
+		apiErr = err
+	}
+	if !c.fallback("GetDepth", apiErr) {
+		return nil, orNoBackend(apiErr, "GetDepth")
+	}
+	limit := defaultDepthLimit
+	if query.Limit != nil {
+		limit = int(*query.Limit)
+	}
+	book, err := c.node.GetDepth(query.Symbol, limit)
+	if err != nil {
+		return nil, err
+	}
+	c.served(BackendRPC)
+	return depthFromOrderBook(book), nil
+}
+
+func (c *client) GetKlines(query *types.KlineQuery) ([]types.Kline, error) {
+	if c.rest == nil {
+		return nil, noBackend("GetKlines")
+	}
+	klines, err := c.rest.GetKlines(query)
+	if err != nil {
+		return nil, err
+	}
+	c.served(BackendREST)
+	return klines, nil
+}
+
+func (c *client) GetTrades(query *types.TradesQuery) (*types.Trades, error) {
+	if c.rest == nil {
+		return nil, noBackend("GetTrades")
+	}
+	trades, err := c.rest.GetTrades(query)
+	if err != nil {
+		return nil, err
+	}
+	c.served(BackendREST)
+	return trades, nil
+}
+
+func (c *client) GetMarkets(query *types.MarketsQuery) ([]types.TradingPair, error) {
+	if err := query.Check(); err != nil {
+		return nil, err
+	}
+	var apiErr error
+	if c.useREST() {
+		markets, err := c.rest.GetMarkets(query)
+		if err == nil {
+			c.served(BackendREST)
+			return markets, nil
+		}
+		apiErr = err
+	}
+	if !c.fallback("GetMarkets", apiErr) {
+		return nil, orNoBackend(apiErr, "GetMarkets")
+	}
+	offset, limit := page(query.Offset, query.Limit, defaultMarketLimit)
+	pairs, err := c.node.GetTradingPairs(offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	c.served(BackendRPC)
+	return pairs, nil
+}
+
+func (c *client) GetTokens(query *types.TokensQuery) ([]types.Token, error) {
+	if err := query.Check(); err != nil {
+		return nil, err
+	}
+	var apiErr error
+	if c.useREST() {
+		tokens, err := c.rest.GetTokens(query)
+		if err == nil {
+			c.served(BackendREST)
+			return tokens, nil
+		}
+		apiErr = err
+	}
+	if !c.fallback("GetTokens", apiErr) {
+		return nil, orNoBackend(apiErr, "GetTokens")
+	}
+	offset, limit := page(query.Offset, query.Limit, defaultTokenLimit)
+	tokens, err := c.node.ListAllTokens(offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	c.served(BackendRPC)
+	return tokens, nil
+}
+
+func (c *client) GetAccount(address string) (*types.BalanceAccount, error) {
+	if address == "" {
+		return nil, types.AddressMissingError
+	}
+	var apiErr error
+	if c.useREST() {
+		account, err := c.rest.GetAccount(address)
+		if err == nil {
+			c.served(BackendREST)
+			return account, nil
+		}
+		apiErr = err
+	}
+	if !c.fallback("GetAccount", apiErr) {
+		return nil, orNoBackend(apiErr, "GetAccount")
+	}
+	addr, err := types.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, err
+	}
+	account, err := c.node.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("account %s not found", address)
+	}
+	c.served(BackendRPC)
+	return balanceAccount(address, account), nil
+}
+
+// GetOpenOrders falls back only for queries with a symbol, the node lists open
+// orders per trade pair. Orders served by the node lack the side, type, time in
+// force and times, which its order book does not keep.
+func (c *client) GetOpenOrders(query *types.OpenOrdersQuery) (*types.OpenOrders, error) {
+	if err := query.Check(); err != nil {
+		return nil, err
+	}
+	var apiErr error
+	if c.useREST() {
+		orders, err := c.rest.GetOpenOrders(query)
+		if err == nil {
+			c.served(BackendREST)
+			return orders, nil
+		}
+		apiErr = err
+	}
+	if query.Symbol == "" || !c.fallback("GetOpenOrders", apiErr) {
+		return nil, orNoBackend(apiErr, "GetOpenOrders")
+	}
+	addr, err := types.AccAddressFromBech32(query.SenderAddress)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := c.node.GetOpenOrders(addr, query.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	c.served(BackendRPC)
+	return openOrders(query, orders), nil
+}
+
+func orNoBackend(apiErr error, method string) error {
+	if apiErr != nil {
+		return apiErr
+	}
+	return noBackend(method)
+}
+
+func page(offset, limit *uint32, defaultLimit int) (int, int) {
+	o, l := 0, defaultLimit
+	if offset != nil {
+		o = int(*offset)
+	}
+	if limit != nil {
+		l = int(*limit)
+	}
+	return o, l
+}
+
+// depthFromOrderBook renders the levels of an rpc order book the way the api
+// does, skipping the empty side of each level.
+func depthFromOrderBook(book *types.OrderBook) *types.MarketDepth {
+	depth := &types.MarketDepth{
+		Bids:         make([][]string, 0, len(book.Levels)),
+		Asks:         make([][]string, 0, len(book.Levels)),
+		Height:       book.Height,
+		PendingMatch: book.PendingMatch,
+	}
+	for _, level := range book.Levels {
+		if level.BuyQty > 0 {
+			depth.Bids = append(depth.Bids, []string{level.BuyPrice.String(), level.BuyQty.String()})
+		}
+		if level.SellQty > 0 {
+			depth.Asks = append(depth.Asks, []string{level.SellPrice.String(), level.SellQty.String()})
+		}
+	}
+	return depth
+}
+
+func balanceAccount(address string, account types.Account) *types.BalanceAccount {
+	coins := account.GetCoins()
+	named, _ := account.(types.NamedAccount)
+	balances := make([]types.TokenBalance, 0, len(coins))
+	for _, coin := range coins {
+		balance := types.TokenBalance{Symbol: coin.Denom, Free: types.Fixed8(coin.Amount)}
+		if named != nil {
+			balance.Locked = types.Fixed8(named.GetLockedCoins().AmountOf(coin.Denom))
+			balance.Frozen = types.Fixed8(named.GetFrozenCoins().AmountOf(coin.Denom))
+		}
+		balances = append(balances, balance)
+	}
+	return &types.BalanceAccount{
+		Number:    account.GetAccountNumber(),
+		Address:   address,
+		Balances:  balances,
+		PublicKey: pubKeyBytes(account.GetPubKey()),
+		Sequence:  account.GetSequence(),
+		Flags:     account.GetFlags(),
+	}
+}
+
+func pubKeyBytes(pubKey crypto.PubKey) []byte {
+	switch pk := pubKey.(type) {
+	case nil:
+		return nil
+	case secp256k1.PubKeySecp256k1:
+		return pk[:]
+	}
+	return pubKey.Bytes()
+}
+
+func openOrders(query *types.OpenOrdersQuery, orders []types.OpenOrder) *types.OpenOrders {
+	total := -1
+	if query.Total == 1 {
+		total = len(orders)
+	}
+	offset, limit := page(query.Offset, query.Limit, len(orders))
+	if offset > len(orders) {
+		offset = len(orders)
+	}
+	if offset+limit > len(orders) {
+		limit = len(orders) - offset
+	}
+	result := &types.OpenOrders{Order: make([]types.Order, 0, limit), Total: total}
+	for _, o := range orders[offset : offset+limit] {
+		result.Order = append(result.Order, types.Order{
+			ID:               o.Id,
+			Owner:            query.SenderAddress,
+			Symbol:           o.Symbol,
+			Price:            o.Price.String(),
+			Quantity:         o.Quantity.String(),
+			CumulateQuantity: o.CumQty.String(),
+			Status:           orderStatus(o),
+		})
+	}
+	return result
+}
+
+func orderStatus(o types.OpenOrder) string {
+	if o.CumQty > 0 {
+		return "PartialFill"
+	}
+	return "Ack"
+}
@@ -0,0 +1,106 @@
+package hybrid
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	gtypes "github.com/binance-chain/go-sdk/types"
+)
+
+type fakeREST struct {
+	RESTClient
+	err   error
+	calls int
+}
+
+func (f *fakeREST) GetDepth(query *types.DepthQuery) (*types.MarketDepth, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &types.MarketDepth{Height: 1}, nil
+}
+
+func (f *fakeREST) GetAccount(address string) (*types.BalanceAccount, error) {
+	f.calls++
+	return nil, f.err
+}
+
+type fakeRPC struct {
+	RPCClient
+	account types.Account
+}
+
+func (f *fakeRPC) GetDepth(tradePair string, level int) (*types.OrderBook, error) {
+	return &types.OrderBook{
+		Height: 2,
+		Levels: []types.OrderBookLevel{
+			{BuyPrice: 99e8, BuyQty: 1e8, SellPrice: 101e8, SellQty: 2e8},
+			{SellPrice: 102e8, SellQty: 5e7},
+		},
+	}, nil
+}
+
+func (f *fakeRPC) GetAccount(addr types.AccAddress) (types.Account, error) {
+	return f.account, nil
+}
+
+func TestClientFallsBackWhenAPIUnavailable(t *testing.T) {
+	rest := &fakeREST{err: gtypes.NewError(gtypes.ErrorClassUnavailable, errors.New("bad gateway"))}
+	c := NewClient(rest, &fakeRPC{}).(*client)
+	now := time.Unix(1000, 0)
+	c.now = func() time.Time { return now }
+	var fallbacks []error
+	c.SetOnFallback(func(method string, apiErr error) { fallbacks = append(fallbacks, apiErr) })
+
+	depth, err := c.GetDepth(types.NewDepthQuery("BNB", "BUSD"))
+	assert.NoError(t, err)
+	assert.Equal(t, BackendRPC, c.LastBackend())
+	assert.EqualValues(t, 2, depth.Height)
+	assert.Equal(t, [][]string{{"99.00000000", "1.00000000"}}, depth.Bids)
+	assert.Equal(t, [][]string{{"101.00000000", "2.00000000"}, {"102.00000000", "0.50000000"}}, depth.Asks)
+
+	// the api is skipped while cooling down and tried again afterwards
+	_, err = c.GetDepth(types.NewDepthQuery("BNB", "BUSD"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rest.calls)
+	assert.Len(t, fallbacks, 2)
+	assert.Nil(t, fallbacks[1])
+
+	rest.err = nil
+	now = now.Add(DefaultCooldown)
+	depth, err = c.GetDepth(types.NewDepthQuery("BNB", "BUSD"))
+	assert.NoError(t, err)
+	assert.Equal(t, BackendREST, c.LastBackend())
+	assert.EqualValues(t, 1, depth.Height)
+}
+
+func TestClientKeepsRejections(t *testing.T) {
+	rejected := gtypes.NewError(gtypes.ErrorClassInvalidRequest, errors.New("bad symbol"))
+	c := NewClient(&fakeREST{err: rejected}, &fakeRPC{})
+	_, err := c.GetDepth(types.NewDepthQuery("BNB", "BUSD"))
+	assert.Equal(t, rejected, err)
+}
+
+func TestClientAccountFromNode(t *testing.T) {
+	addr := types.AccAddress(make([]byte, 20))
+	account := &types.AppAccount{
+		BaseAccount: types.BaseAccount{
+			Address:       addr,
+			Coins:         types.Coins{{Denom: "BNB", Amount: 5e8}},
+			AccountNumber: 7,
+			Sequence:      3,
+		},
+		LockedCoins: types.Coins{{Denom: "BNB", Amount: 1e8}},
+	}
+	c := NewClient(nil, &fakeRPC{account: account})
+	balance, err := c.GetAccount(addr.String())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 7, balance.Number)
+	assert.EqualValues(t, 3, balance.Sequence)
+	assert.Equal(t, []types.TokenBalance{{Symbol: "BNB", Free: 5e8, Locked: 1e8}}, balance.Balances)
+}
@@ -0,0 +1,157 @@
+// Package pool runs queries against the node or the api server with bounded
+// concurrency and an optional rate limit, so large batches neither flood the
+// server nor trip its rate limits.
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const DefaultConcurrency = 8
+
+type Config struct {
+	// Concurrency bounds the queries in flight, DefaultConcurrency if not positive.
+	Concurrency int
+	// RatePerSecond bounds how many queries start per second, 0 means no limit.
+	RatePerSecond float64
+	// Burst is how many queries may start at once after an idle period, at least 1.
+	Burst int
+}
+
+// QueryPool executes query closures. It is safe for concurrent use, and the
+// limits hold across all calls sharing the pool.
+type QueryPool struct {
+	slots   chan struct{}
+	limiter *limiter
+}
+
+func NewQueryPool(cfg Config) *QueryPool {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConcurrency
+	}
+	p := &QueryPool{slots: make(chan struct{}, cfg.Concurrency)}
+	if cfg.RatePerSecond > 0 {
+		if cfg.Burst < 1 {
+			cfg.Burst = 1
+		}
+		p.limiter = newLimiter(cfg.RatePerSecond, cfg.Burst)
+	}
+	return p
+}
+
+// Do runs query once a slot and the rate limit allow it. It returns ctx.Err()
+// without running query if ctx is done first.
+func (p *QueryPool) Do(ctx context.Context, query func() error) error {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-p.slots }()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if p.limiter != nil {
+		if err := p.limiter.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return query()
+}
+
+// Run executes all queries and returns the first error. Queries not started yet
+// when a query fails are skipped.
+func (p *QueryPool) Run(ctx context.Context, queries []func() error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	p.run(ctx, queries, func(i int, err error) {
+		if err != nil {
+			once.Do(func() {
+				firstErr = err
+				cancel()
+			})
+		}
+	})
+	return firstErr
+}
+
+// RunAll executes all queries whatever their outcome and returns the error of
+// each query at its index, nil for the queries that succeeded.
+func (p *QueryPool) RunAll(ctx context.Context, queries []func() error) []error {
+	errs := make([]error, len(queries))
+	p.run(ctx, queries, func(i int, err error) {
+		errs[i] = err
+	})
+	return errs
+}
+
+func (p *QueryPool) run(ctx context.Context, queries []func() error, done func(i int, err error)) {
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < cap(p.slots) && w < len(queries); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				done(i, p.Do(ctx, queries[i]))
+			}
+		}()
+	}
+	for i := range queries {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
+// limiter is a token bucket that hands out tokens in advance, callers sleep
+// until their token is due.
+type limiter struct {
+	mtx      sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newLimiter(ratePerSecond float64, burst int) *limiter {
+	return &limiter{
+		interval: time.Duration(float64(time.Second) / ratePerSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	l.mtx.Lock()
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens * float64(l.interval))
+	l.mtx.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mtx.Lock()
+		l.tokens++
+		l.mtx.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,61 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryPoolBoundsConcurrency(t *testing.T) {
+	p := NewQueryPool(Config{Concurrency: 3})
+	var inFlight, peak int32
+	queries := make([]func() error, 20)
+	for i := range queries {
+		queries[i] = func() error {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return nil
+		}
+	}
+	assert.NoError(t, p.Run(context.Background(), queries))
+	assert.True(t, peak <= 3, "peak %d", peak)
+}
+
+func TestQueryPoolRateLimit(t *testing.T) {
+	p := NewQueryPool(Config{Concurrency: 10, RatePerSecond: 100, Burst: 1})
+	queries := make([]func() error, 6)
+	for i := range queries {
+		queries[i] = func() error { return nil }
+	}
+	start := time.Now()
+	assert.NoError(t, p.Run(context.Background(), queries))
+	assert.True(t, time.Since(start) >= 45*time.Millisecond, "took %v", time.Since(start))
+}
+
+func TestQueryPoolErrors(t *testing.T) {
+	p := NewQueryPool(Config{Concurrency: 1})
+	boom := errors.New("boom")
+	var ran int32
+	queries := []func() error{
+		func() error { atomic.AddInt32(&ran, 1); return boom },
+		func() error { atomic.AddInt32(&ran, 1); return nil },
+		func() error { atomic.AddInt32(&ran, 1); return nil },
+	}
+	assert.Equal(t, boom, p.Run(context.Background(), queries))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&ran))
+
+	errs := p.RunAll(context.Background(), queries)
+	assert.Equal(t, []error{boom, nil, nil}, errs)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&ran))
+}
@@ -1,7 +1,6 @@
 package query
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/binance-chain/go-sdk/common/types"
@@ -22,7 +21,7 @@ func (c *client) GetAccount(address string) (*types.BalanceAccount, error) {
 		return nil, err
 	}
 	var account types.BalanceAccount
-	if err := json.Unmarshal(resp, &account); err != nil {
+	if err := types.UnmarshalNodeJSON(resp, &account); err != nil {
 		return nil, err
 	}
 	return &account, nil
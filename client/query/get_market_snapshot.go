@@ -0,0 +1,129 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/binance-chain/go-sdk/client/pool"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+const (
+	snapshotConcurrency = 8
+	snapshotTradesLimit = 50
+	snapshotTokensLimit = 1000
+)
+
+// GetMarketSnapshot fetches the depth, the latest trades and the base and quote
+// token of every pair, given as "BASE_QUOTE", with a bounded number of requests
+// in flight. It fails if any of the requests fails.
+func (c *client) GetMarketSnapshot(pairs []string) (*types.MarketSnapshot, error) {
+	snapshot := &types.MarketSnapshot{
+		Time:  time.Now(),
+		Pairs: make(map[string]*types.PairSnapshot, len(pairs)),
+	}
+	type split struct{ base, quote string }
+	symbols := make(map[string]split, len(pairs))
+	needMini := false
+	for _, pair := range pairs {
+		parts := strings.Split(pair, "_")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("the pair %s should be in format 'symbol1_symbol2'", pair)
+		}
+		symbols[pair] = split{parts[0], parts[1]}
+		snapshot.Pairs[pair] = &types.PairSnapshot{Pair: pair}
+		needMini = needMini || msg.IsValidMiniTokenSymbol(parts[0])
+	}
+
+	var tokens, miniTokens []types.Token
+	jobs := []func() error{
+		func() (err error) {
+			tokens, err = c.getAllTokens()
+			return err
+		},
+	}
+	if needMini {
+		jobs = append(jobs, func() (err error) {
+			miniTokens, err = c.getAllMiniTokens()
+			return err
+		})
+	}
+	for pair, s := range symbols {
+		pairSnapshot, base, quote := snapshot.Pairs[pair], s.base, s.quote
+		jobs = append(jobs, func() error {
+			depth, err := c.GetDepth(types.NewDepthQuery(base, quote))
+			pairSnapshot.Depth = depth
+			return err
+		}, func() error {
+			query := types.NewTradesQuery(false).WithSymbol(base, quote).WithLimit(snapshotTradesLimit)
+			getTrades := c.GetTrades
+			if msg.IsValidMiniTokenSymbol(base) {
+				getTrades = c.GetMiniTrades
+			}
+			trades, err := getTrades(query)
+			if err != nil {
+				return err
+			}
+			pairSnapshot.Trades = trades.Trade
+			return nil
+		})
+	}
+	if err := pool.NewQueryPool(pool.Config{Concurrency: snapshotConcurrency}).Run(context.Background(), jobs); err != nil {
+		return nil, err
+	}
+
+	bySymbol := make(map[string]*types.Token, len(tokens)+len(miniTokens))
+	for i := range tokens {
+		bySymbol[tokens[i].Symbol] = &tokens[i]
+	}
+	for i := range miniTokens {
+		bySymbol[miniTokens[i].Symbol] = &miniTokens[i]
+	}
+	for pair, s := range symbols {
+		snapshot.Pairs[pair].BaseToken = bySymbol[s.base]
+		snapshot.Pairs[pair].QuoteToken = bySymbol[s.quote]
+	}
+	return snapshot, nil
+}
+
+func (c *client) getAllTokens() ([]types.Token, error) {
+	var all []types.Token
+	for offset := uint32(0); ; offset += snapshotTokensLimit {
+		tokens, err := c.GetTokens(types.NewTokensQuery().WithOffset(offset).WithLimit(snapshotTokensLimit))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, tokens...)
+		if len(tokens) < snapshotTokensLimit {
+			return all, nil
+		}
+	}
+}
+
+func (c *client) getAllMiniTokens() ([]types.Token, error) {
+	var all []types.Token
+	for offset := uint32(0); ; offset += snapshotTokensLimit {
+		tokens, err := c.GetMiniTokens(types.NewTokensQuery().WithOffset(offset).WithLimit(snapshotTokensLimit))
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range tokens {
+			all = append(all, types.Token{
+				Name:             token.Name,
+				Symbol:           token.Symbol,
+				OrigSymbol:       token.OrigSymbol,
+				TotalSupply:      token.TotalSupply,
+				Owner:            token.Owner,
+				Mintable:         token.Mintable,
+				ContractAddress:  token.ContractAddress,
+				ContractDecimals: token.ContractDecimals,
+			})
+		}
+		if len(tokens) < snapshotTokensLimit {
+			return all, nil
+		}
+	}
+}
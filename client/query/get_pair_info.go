@@ -0,0 +1,104 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/binance-chain/go-sdk/client/pool"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+const (
+	pairInfoDepthLimit   = 5
+	pairInfoMarketsLimit = 1000
+)
+
+// GetPairInfo fetches the listing, the tokens and the best bid and ask of a
+// pair, given as "BASE_QUOTE", concurrently. It fails if the pair is not listed.
+func (c *client) GetPairInfo(pair string) (*types.PairInfo, error) {
+	parts := strings.Split(pair, "_")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("the pair %s should be in format 'symbol1_symbol2'", pair)
+	}
+	base, quote := parts[0], parts[1]
+	mini := msg.IsValidMiniTokenSymbol(base)
+
+	var (
+		markets            []types.TradingPair
+		tokens, miniTokens []types.Token
+		depth              *types.MarketDepth
+	)
+	jobs := []func() error{
+		func() (err error) {
+			markets, err = c.getAllMarkets(mini)
+			return err
+		},
+		func() (err error) {
+			tokens, err = c.getAllTokens()
+			return err
+		},
+		func() (err error) {
+			depth, err = c.GetDepth(types.NewDepthQuery(base, quote).WithLimit(pairInfoDepthLimit))
+			return err
+		},
+	}
+	if mini {
+		jobs = append(jobs, func() (err error) {
+			miniTokens, err = c.getAllMiniTokens()
+			return err
+		})
+	}
+	if err := pool.NewQueryPool(pool.Config{Concurrency: len(jobs)}).Run(context.Background(), jobs); err != nil {
+		return nil, err
+	}
+
+	info := &types.PairInfo{Pair: pair, Height: depth.Height}
+	listed := false
+	for _, market := range markets {
+		if market.BaseAssetSymbol == base && market.QuoteAssetSymbol == quote {
+			info.ListPrice, info.TickSize, info.LotSize = market.ListPrice, market.TickSize, market.LotSize
+			listed = true
+			break
+		}
+	}
+	if !listed {
+		return nil, fmt.Errorf("the pair %s is not listed", pair)
+	}
+	for _, list := range [][]types.Token{tokens, miniTokens} {
+		for i := range list {
+			switch list[i].Symbol {
+			case base:
+				info.BaseToken = &list[i]
+			case quote:
+				info.QuoteToken = &list[i]
+			}
+		}
+	}
+	if len(depth.Bids) > 0 && len(depth.Bids[0]) == 2 {
+		info.BidPrice, info.BidQuantity = depth.Bids[0][0], depth.Bids[0][1]
+	}
+	if len(depth.Asks) > 0 && len(depth.Asks[0]) == 2 {
+		info.AskPrice, info.AskQuantity = depth.Asks[0][0], depth.Asks[0][1]
+	}
+	return info, nil
+}
+
+func (c *client) getAllMarkets(mini bool) ([]types.TradingPair, error) {
+	getMarkets := c.GetMarkets
+	if mini {
+		getMarkets = c.GetMiniMarkets
+	}
+	var all []types.TradingPair
+	for offset := uint32(0); ; offset += pairInfoMarketsLimit {
+		markets, err := getMarkets(types.NewMarketsQuery().WithOffset(offset).WithLimit(pairInfoMarketsLimit))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, markets...)
+		if len(markets) < pairInfoMarketsLimit {
+			return all, nil
+		}
+	}
+}
@@ -1,8 +1,6 @@
 package query
 
 import (
-	"encoding/json"
-
 	"github.com/binance-chain/go-sdk/common"
 	"github.com/binance-chain/go-sdk/common/types"
 )
@@ -19,7 +17,7 @@ func (c *client) GetTokens(query *types.TokensQuery) ([]types.Token, error) {
 	}
 
 	var tokens []types.Token
-	if err := json.Unmarshal(resp, &tokens); err != nil {
+	if err := types.UnmarshalNodeJSON(resp, &tokens); err != nil {
 		return nil, err
 	}
 
@@ -26,6 +26,8 @@ type QueryClient interface {
 	GetMiniKlines(query *types.KlineQuery) ([]types.Kline, error)
 	GetMiniTicker24h(query *types.Ticker24hQuery) ([]types.Ticker24h, error)
 	GetMiniTrades(query *types.TradesQuery) (*types.Trades, error)
+	GetMarketSnapshot(pairs []string) (*types.MarketSnapshot, error)
+	GetPairInfo(pair string) (*types.PairInfo, error)
 }
 
 type client struct {
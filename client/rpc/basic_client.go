@@ -1,16 +1,17 @@
 package rpc
 
 import (
+	"context"
 	"fmt"
 	"time"
 
-	"github.com/pkg/errors"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	"github.com/tendermint/tendermint/rpc/lib/client"
 	"github.com/tendermint/tendermint/types"
 
+	"github.com/binance-chain/go-sdk/client/screen"
 	ntypes "github.com/binance-chain/go-sdk/common/types"
 	"github.com/binance-chain/go-sdk/keys"
 	"github.com/binance-chain/go-sdk/types/tx"
@@ -54,24 +55,46 @@ type Client interface {
 
 type EventsClient interface {
 	Subscribe(query string, outCapacity ...int) (out chan ctypes.ResultEvent, err error)
+	SubscriptionMetrics() []SubscriptionMetrics
+	SubscribeWithContext(ctx context.Context, query string, outCapacity ...int) (<-chan ctypes.ResultEvent, error)
 	Unsubscribe(query string) error
 	UnsubscribeAll() error
+	StreamBlocks(fromHeight int64, quit chan struct{}, onBlock func(*ctypes.ResultBlock), onError func(error)) error
+	StreamTxs(fromHeight int64, quit chan struct{}, onTx func(*ResultTx), onError func(error)) error
 }
 
 func NewRPCClient(nodeURI string, network ntypes.ChainNetwork) *HTTP {
-	ntypes.Network = network
-	return NewHTTP(nodeURI, "/websocket")
+	return NewClient(nodeURI, WithNetwork(network))
 }
 
 type HTTP struct {
+	// floor is first to keep its int64 fields aligned for atomic access.
+	floor readFloor
 	*WSEvents
 
-	key keys.KeyManager
+	key        keys.KeyManager
+	orderGuard OrderGuard
+	screener   Screener
+	source     int64
+	retry      RetryPolicy
+	headers    headerCache
+	// network is nil to follow types.Network.
+	network *ntypes.ChainNetwork
+	// shared is the client a view made by WithMinHeight keeps its read floor
+	// and headers in, nil for the client itself.
+	shared    *HTTP
+	minHeight int64
 }
 
 // NewHTTP takes a remote endpoint in the form tcp://<host>:<port>
 // and the websocket path (which always seems to be "/websocket")
 func NewHTTP(remote, wsEndpoint string) *HTTP {
+	client := newHTTP(remote, wsEndpoint)
+	client.Start()
+	return client
+}
+
+func newHTTP(remote, wsEndpoint string) *HTTP {
 	rc := rpcclient.NewJSONRPCClient(remote)
 	cdc := rc.Codec()
 	ctypes.RegisterAmino(cdc)
@@ -80,15 +103,32 @@ func NewHTTP(remote, wsEndpoint string) *HTTP {
 
 	rc.SetCodec(cdc)
 	wsEvent := newWSEvents(cdc, remote, wsEndpoint)
-	client := &HTTP{
+	return &HTTP{
 		WSEvents: wsEvent,
 	}
-	client.Start()
-	return client
 }
 
-func (c *HTTP) Status() (*ctypes.ResultStatus, error) {
-	return c.WSEvents.Status()
+// Network returns the network of the client, types.Network unless set with
+// WithNetwork or WithIsolatedNetwork.
+func (c *HTTP) Network() ntypes.ChainNetwork {
+	if c.network != nil {
+		return *c.network
+	}
+	return ntypes.Network
+}
+
+// unmarshalNodeJSON decodes a JSON response of the node, whose addresses are of
+// the network of the client, see types.UnmarshalNodeJSON.
+func (c *HTTP) unmarshalNodeJSON(bz []byte, ptr interface{}) error {
+	return c.cdc.UnmarshalJSON(ntypes.NodeJSON(bz), ptr)
+}
+
+func (c *HTTP) Status() (status *ctypes.ResultStatus, err error) {
+	err = c.withRetry(func() error {
+		status, err = c.WSEvents.Status()
+		return err
+	})
+	return status, err
 }
 
 func (c *HTTP) ABCIInfo() (*ctypes.ResultABCIInfo, error) {
@@ -106,14 +146,32 @@ func (c *HTTP) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts client.
 	if err := ValidateABCIData(data); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.ABCIQueryWithOptions(path, data, opts)
+	var res *ctypes.ResultABCIQuery
+	err := c.withRetry(func() (err error) {
+		res, err = c.WSEvents.ABCIQueryWithOptions(path, data, opts)
+		if err != nil || opts.Height > 0 {
+			return err
+		}
+		return c.checkHeight(res.Response.Height, c.minReadHeight())
+	})
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(res.Response.Value)) > c.maxDecodeSize() {
+		return nil, ExceedResponseSizeError
+	}
+	return res, nil
 }
 
 func (c *HTTP) BroadcastTxCommit(tx types.Tx) (*ResultBroadcastTxCommit, error) {
 	if err := ValidateTx(tx); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.BroadcastTxCommit(tx)
+	res, err := c.WSEvents.BroadcastTxCommit(tx)
+	if err == nil && res.Height > 0 {
+		c.recordCommit(res.Height)
+	}
+	return res, err
 }
 
 func (c *HTTP) BroadcastTxAsync(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
@@ -219,23 +277,18 @@ func (c *HTTP) QueryWithData(path string, data cmn.HexBytes) ([]byte, error) {
 
 	resp := result.Response
 	if !resp.IsOK() {
-		return nil, errors.Errorf(resp.Log)
+		return nil, abciError(resp)
 	}
 
 	return resp.Value, nil
 }
 
 func (c *HTTP) QueryStore(key cmn.HexBytes, storeName string) ([]byte, error) {
-	path := fmt.Sprintf("/store/%s/%s", storeName, "key")
-	result, err := c.ABCIQuery(path, key)
+	res, err := c.QueryStoreWithOptions(key, storeName)
 	if err != nil {
 		return nil, err
 	}
-	resp := result.Response
-	if !resp.IsOK() {
-		return nil, errors.Errorf(resp.Log)
-	}
-	return resp.Value, nil
+	return res.Value, nil
 }
 
 func (c *HTTP) QueryStoreSubspace(key cmn.HexBytes, storeName string) (res []cmn.KVPair, err error) {
@@ -247,14 +300,14 @@ func (c *HTTP) QueryStoreSubspace(key cmn.HexBytes, storeName string) (res []cmn
 
 	resp := result.Response
 	if !resp.IsOK() {
-		return nil, errors.Errorf(resp.Log)
+		return nil, abciError(resp)
 	}
 
 	if len(resp.Value) == 0 {
 		return nil, EmptyResultError
 	}
 
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(resp.Value, &res)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, resp.Value, &res)
 
 	if err != nil {
 		return nil, err
@@ -266,3 +319,32 @@ func (c *HTTP) QueryStoreSubspace(key cmn.HexBytes, storeName string) (res []cmn
 func (c *HTTP) SetKeyManager(k keys.KeyManager) {
 	c.key = k
 }
+
+// SetSource sets the source code of the txs the client signs, see tx.RegisterSource.
+// tx.WithSource still overrides it per tx.
+func (c *HTTP) SetSource(source int64) {
+	c.source = source
+}
+
+// OrderGuard vets new orders before they are signed, see the guard package.
+type OrderGuard interface {
+	Check(symbol string, side int8, price, quantity int64) error
+}
+
+// SetOrderGuard makes CreateOrder run every order through g first. A nil g
+// removes the guard.
+func (c *HTTP) SetOrderGuard(g OrderGuard) {
+	c.orderGuard = g
+}
+
+// Screener vets txs before they are broadcast, see the screen package.
+type Screener interface {
+	Screen(t screen.Tx) error
+}
+
+// SetScreener makes Broadcast, BroadcastIdempotent and the tx helpers run every
+// tx through s first, whether the client signed it or not. The raw
+// BroadcastTx calls are not screened. A nil s removes the screener.
+func (c *HTTP) SetScreener(s Screener) {
+	c.screener = s
+}
@@ -0,0 +1,240 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	ntypes "github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	gtypes "github.com/binance-chain/go-sdk/types"
+)
+
+const (
+	DefaultBreakerThreshold = 3
+	DefaultBreakerCooldown  = 10 * time.Second
+)
+
+type BreakerState int
+
+const (
+	// BreakerClosed lets all calls through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails calls fast until the cooldown is over.
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe through, its outcome closes or reopens the breaker.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Breaker trips after a number of consecutive node failures. Only network errors,
+// timeouts and unavailable responses count, a rejected tx says nothing about the node.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mtx      sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		threshold = DefaultBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+	return &Breaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// Allow returns CircuitOpenError if the call should not be made. Every allowed
+// call must be followed by Record.
+func (b *Breaker) Allow() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return CircuitOpenError
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return CircuitOpenError
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Record reports the outcome of an allowed call.
+func (b *Breaker) Record(err error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.probing = false
+	if !isNodeFailure(err) {
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+func (b *Breaker) State() BreakerState {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+func isNodeFailure(err error) bool {
+	switch gtypes.Classify(err) {
+	case gtypes.ErrorClassNetwork, gtypes.ErrorClassTimeout, gtypes.ErrorClassUnavailable:
+		return true
+	}
+	return false
+}
+
+type poolNode struct {
+	addr    string
+	client  *HTTP
+	breaker *Breaker
+	// quarantined is guarded by the mutex of the pool, see CheckSync.
+	quarantined bool
+}
+
+// NodePool spreads calls over several nodes, each behind its own breaker. Calls go
+// to the first node in order whose breaker allows them and fail over to the next one
+// on node failures, so a dead node costs at most threshold timeouts per cooldown.
+type NodePool struct {
+	nodes  []*poolNode
+	logger log.Logger
+
+	mtx         sync.Mutex
+	consistency Consistency
+	committed   int64
+	writer      *poolNode
+
+	quit chan struct{}
+	once sync.Once
+}
+
+// NewNodePool connects to every node. The nodes are tried in the given order.
+// The options apply to the client of every node.
+func NewNodePool(nodeURIs []string, network ntypes.ChainNetwork, threshold int, cooldown time.Duration, options ...Option) *NodePool {
+	pool := &NodePool{logger: log.NewNopLogger(), quit: make(chan struct{})}
+	for _, uri := range nodeURIs {
+		pool.nodes = append(pool.nodes, &poolNode{
+			addr:    uri,
+			client:  NewClient(uri, append([]Option{WithNetwork(network)}, options...)...),
+			breaker: NewBreaker(threshold, cooldown),
+		})
+	}
+	return pool
+}
+
+func (p *NodePool) SetLogger(logger log.Logger) {
+	p.logger = logger
+}
+
+func (p *NodePool) SetKeyManager(k keys.KeyManager) {
+	for _, node := range p.nodes {
+		node.client.SetKeyManager(k)
+	}
+}
+
+func (p *NodePool) SetSource(source int64) {
+	for _, node := range p.nodes {
+		node.client.SetSource(source)
+	}
+}
+
+// Do runs call against the first available node and fails over on node failures.
+// Quarantined nodes, see CheckSync, are tried after all the others.
+// It returns NoHealthyNodeError without calling anything when all breakers are open.
+func (p *NodePool) Do(call func(c *HTTP) error) error {
+	var lastErr error
+	for _, node := range p.order() {
+		if err := node.breaker.Allow(); err != nil {
+			continue
+		}
+		err := call(node.client)
+		node.breaker.Record(err)
+		p.observe(node)
+		if !isNodeFailure(err) {
+			return err
+		}
+		p.logger.Info("node call failed, trying next node", "node", node.addr, "err", err)
+		lastErr = err
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return NoHealthyNodeError
+}
+
+// States returns the breaker state of every node by address.
+func (p *NodePool) States() map[string]BreakerState {
+	states := make(map[string]BreakerState, len(p.nodes))
+	for _, node := range p.nodes {
+		states[node.addr] = node.breaker.State()
+	}
+	return states
+}
+
+// StartProbing calls Status on nodes with an open breaker every interval, so they
+// rejoin as soon as they recover instead of on the next real call.
+func (p *NodePool) StartProbing(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.quit:
+				return
+			case <-ticker.C:
+				for _, node := range p.nodes {
+					if node.breaker.State() != BreakerHalfOpen || node.breaker.Allow() != nil {
+						continue
+					}
+					_, err := node.client.Status()
+					node.breaker.Record(err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops probing and all node clients.
+func (p *NodePool) Stop() {
+	p.once.Do(func() {
+		close(p.quit)
+		for _, node := range p.nodes {
+			node.client.Stop()
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	// rejected txs do not count as node failures
+	assert.NoError(t, b.Allow())
+	b.Record(errors.New("insufficient funds"))
+	assert.Equal(t, BreakerClosed, b.State())
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, b.Allow())
+		b.Record(context.DeadlineExceeded)
+	}
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.Equal(t, CircuitOpenError, b.Allow())
+
+	// a single probe is let through after the cooldown and a failure reopens the breaker
+	now = now.Add(time.Minute)
+	assert.Equal(t, BreakerHalfOpen, b.State())
+	assert.NoError(t, b.Allow())
+	assert.Equal(t, CircuitOpenError, b.Allow())
+	b.Record(context.DeadlineExceeded)
+	assert.Equal(t, BreakerOpen, b.State())
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, b.Allow())
+	b.Record(nil)
+	assert.Equal(t, BreakerClosed, b.State())
+	assert.NoError(t, b.Allow())
+}
@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+const (
+	bindRequestKeyPrefix = "bindReq:"
+
+	crossBindRelayFeeName        = "crossBindRelayFee"
+	crossUnbindRelayFeeName      = "crossUnbindRelayFee"
+	crossTransferOutRelayFeeName = "crossTransferOutRelayFee"
+
+	boundTokensPageSize = 1000
+)
+
+// GetBoundToken returns the BEP20 contract the token is bound to, or nil if it is not bound.
+func (c *HTTP) GetBoundToken(symbol string) (*types.BoundToken, error) {
+	var contractAddress string
+	var contractDecimals int8
+	if msg.IsValidMiniTokenSymbol(symbol) {
+		token, err := c.GetMiniTokenInfo(symbol)
+		if err != nil {
+			return nil, err
+		}
+		contractAddress, contractDecimals = token.ContractAddress, token.ContractDecimals
+	} else {
+		token, err := c.GetTokenInfo(symbol)
+		if err != nil {
+			return nil, err
+		}
+		contractAddress, contractDecimals = token.ContractAddress, token.ContractDecimals
+	}
+	if contractAddress == "" {
+		return nil, nil
+	}
+	return &types.BoundToken{Symbol: symbol, ContractAddress: contractAddress, ContractDecimals: contractDecimals}, nil
+}
+
+// ListBoundTokens returns every token and mini token bound to a BEP20 contract.
+func (c *HTTP) ListBoundTokens() ([]types.BoundToken, error) {
+	bound := make([]types.BoundToken, 0)
+	for offset := 0; ; offset += boundTokensPageSize {
+		tokens, err := c.ListAllTokens(offset, boundTokensPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range tokens {
+			if token.ContractAddress != "" {
+				bound = append(bound, types.BoundToken{Symbol: token.Symbol, ContractAddress: token.ContractAddress, ContractDecimals: token.ContractDecimals})
+			}
+		}
+		if len(tokens) < boundTokensPageSize {
+			break
+		}
+	}
+	for offset := 0; ; offset += boundTokensPageSize {
+		tokens, err := c.ListAllMiniTokens(offset, boundTokensPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range tokens {
+			if token.ContractAddress != "" {
+				bound = append(bound, types.BoundToken{Symbol: token.Symbol, ContractAddress: token.ContractAddress, ContractDecimals: token.ContractDecimals})
+			}
+		}
+		if len(tokens) < boundTokensPageSize {
+			break
+		}
+	}
+	return bound, nil
+}
+
+// GetBindRequest returns the pending bind request of the token, or nil if there is none.
+func (c *HTTP) GetBindRequest(symbol string) (*types.BindRequest, error) {
+	bz, err := c.QueryStore([]byte(bindRequestKeyPrefix+symbol), BridgeStoreName)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, nil
+	}
+	var req types.BindRequest
+	if err := json.Unmarshal(bz, &req); err != nil {
+		return nil, fmt.Errorf("decode bind request: %v", err)
+	}
+	return &req, nil
+}
+
+// GetBindStatus tells whether the token is bound, waiting for the smart chain to
+// approve a bind, or unbound.
+func (c *HTTP) GetBindStatus(symbol string) (types.BindStatus, error) {
+	bound, err := c.GetBoundToken(symbol)
+	if err != nil {
+		return types.BindStatusUnbound, err
+	}
+	if bound != nil {
+		return types.BindStatusBound, nil
+	}
+	req, err := c.GetBindRequest(symbol)
+	if err != nil {
+		return types.BindStatusUnbound, err
+	}
+	if req != nil {
+		return types.BindStatusPending, nil
+	}
+	return types.BindStatusUnbound, nil
+}
+
+// GetCrossChainFees returns the current fees and relay fees of bridge msgs.
+func (c *HTTP) GetCrossChainFees() (*types.CrossChainFees, error) {
+	params, err := c.GetFee()
+	if err != nil {
+		return nil, err
+	}
+	fees := &types.CrossChainFees{}
+	for _, param := range params {
+		p, ok := param.(*types.FixedFeeParams)
+		if !ok {
+			continue
+		}
+		switch p.MsgType {
+		case msg.BindMsgType:
+			fees.BindFee = p.Fee
+		case crossBindRelayFeeName:
+			fees.BindRelayFee = p.Fee
+		case msg.UnbindMsgType:
+			fees.UnbindFee = p.Fee
+		case crossUnbindRelayFeeName:
+			fees.UnbindRelayFee = p.Fee
+		case msg.TransferOutMsgType:
+			fees.TransferOutFee = p.Fee
+		case crossTransferOutRelayFeeName:
+			fees.TransferOutRelayFee = p.Fee
+		}
+	}
+	return fees, nil
+}
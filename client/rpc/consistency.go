@@ -0,0 +1,184 @@
+package rpc
+
+import (
+	"sync/atomic"
+)
+
+// readFloor is the lowest height reads of the client accept, see
+// SetReadAfterWrite. Fields are accessed atomically.
+type readFloor struct {
+	// committed is the highest height a tx broadcast in commit mode landed at.
+	committed int64
+	minHeight int64
+	// readAfterWrite comes last, the int64 fields must be 64-bit aligned.
+	readAfterWrite int32
+}
+
+// SetReadAfterWrite makes reads served at the latest height fail with
+// StaleNodeError when the node is behind the height of the last tx the client
+// broadcast in commit mode. Behind a load balancer a follow-up query may reach
+// a node that did not see the tx yet, with a RetryPolicy it is retried until
+// the node catches up.
+func (c *HTTP) SetReadAfterWrite(enabled bool) {
+	var flag int32
+	if enabled {
+		flag = 1
+	}
+	atomic.StoreInt32(&c.root().floor.readAfterWrite, flag)
+}
+
+// CommitHeight returns the height of the highest tx the client broadcast in
+// commit mode, 0 if none.
+func (c *HTTP) CommitHeight() int64 {
+	return atomic.LoadInt64(&c.root().floor.committed)
+}
+
+// RequireMinHeight makes reads served at the latest height fail with
+// StaleNodeError while the node is behind height. The floor only rises.
+func (c *HTTP) RequireMinHeight(height int64) {
+	raise(&c.root().floor.minHeight, height)
+}
+
+// WithMinHeight returns a view of the client whose reads served at the latest
+// height fail with StaleNodeError while the node is behind height, e.g. the
+// height of a block the caller already saw. Unlike RequireMinHeight it leaves
+// the client alone. The view shares the connection, read floor and headers of
+// the client, any query made through it is checked.
+func (c *HTTP) WithMinHeight(height int64) *HTTP {
+	if c.minHeight > height {
+		height = c.minHeight
+	}
+	return &HTTP{
+		WSEvents:   c.WSEvents,
+		key:        c.key,
+		orderGuard: c.orderGuard,
+		screener:   c.screener,
+		source:     c.source,
+		retry:      c.retry,
+		network:    c.network,
+		shared:     c.root(),
+		minHeight:  height,
+	}
+}
+
+// root is the client views keep their shared state in.
+func (c *HTTP) root() *HTTP {
+	if c.shared != nil {
+		return c.shared
+	}
+	return c
+}
+
+// minReadHeight is the floor the reads of the client must meet, 0 if none.
+func (c *HTTP) minReadHeight() int64 {
+	floor := &c.root().floor
+	height := atomic.LoadInt64(&floor.minHeight)
+	if atomic.LoadInt32(&floor.readAfterWrite) == 1 {
+		if committed := atomic.LoadInt64(&floor.committed); committed > height {
+			height = committed
+		}
+	}
+	if c.minHeight > height {
+		height = c.minHeight
+	}
+	return height
+}
+
+func (c *HTTP) recordCommit(height int64) {
+	raise(&c.root().floor.committed, height)
+}
+
+// checkHeight returns StaleNodeError if served, the height a query was served
+// at, is below minHeight. Nodes that do not report it are asked their latest
+// height.
+func (c *HTTP) checkHeight(served, minHeight int64) error {
+	if minHeight <= 0 || served >= minHeight {
+		return nil
+	}
+	if served == 0 {
+		status, err := c.WSEvents.Status()
+		if err != nil {
+			return err
+		}
+		if status.SyncInfo.LatestBlockHeight >= minHeight {
+			return nil
+		}
+	}
+	return StaleNodeError
+}
+
+// raise sets *addr to value unless it is higher already.
+func raise(addr *int64, value int64) {
+	for {
+		current := atomic.LoadInt64(addr)
+		if value <= current || atomic.CompareAndSwapInt64(addr, current, value) {
+			return
+		}
+	}
+}
+
+type Consistency int
+
+const (
+	// ConsistencyNone lets any node serve any read.
+	ConsistencyNone Consistency = iota
+	// ConsistencyMinHeight makes nodes behind the last tx the pool broadcast in
+	// commit mode fail reads with StaleNodeError, so the pool fails over.
+	ConsistencyMinHeight
+	// ConsistencySameNode is ConsistencyMinHeight sending calls to the node
+	// that committed the last tx first.
+	ConsistencySameNode
+)
+
+// SetConsistency makes reads through Do see the txs broadcast through it in
+// commit mode.
+func (p *NodePool) SetConsistency(consistency Consistency) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.consistency = consistency
+	if consistency != ConsistencyNone {
+		for _, node := range p.nodes {
+			node.client.RequireMinHeight(p.committed)
+		}
+	}
+}
+
+// order returns the nodes in the order Do tries them: the node that committed
+// last first with ConsistencySameNode, quarantined nodes last.
+func (p *NodePool) order() []*poolNode {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	nodes := make([]*poolNode, 0, len(p.nodes))
+	var first *poolNode
+	if p.consistency == ConsistencySameNode && p.writer != nil && !p.writer.quarantined {
+		first = p.writer
+		nodes = append(nodes, first)
+	}
+	for _, node := range p.nodes {
+		if node != first && !node.quarantined {
+			nodes = append(nodes, node)
+		}
+	}
+	for _, node := range p.nodes {
+		if node.quarantined {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// observe records a commit made on node by a call of Do.
+func (p *NodePool) observe(node *poolNode) {
+	height := node.client.CommitHeight()
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if height <= p.committed {
+		return
+	}
+	p.committed, p.writer = height, node
+	if p.consistency != ConsistencyNone {
+		for _, n := range p.nodes {
+			n.client.RequireMinHeight(height)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package rpc
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	amino "github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcserver "github.com/tendermint/tendermint/rpc/lib/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	gtypes "github.com/binance-chain/go-sdk/types"
+)
+
+func TestReadFloor(t *testing.T) {
+	c := &HTTP{}
+	c.recordCommit(120)
+	assert.Equal(t, int64(120), c.CommitHeight())
+	assert.Zero(t, c.minReadHeight(), "read after write is off")
+
+	c.SetReadAfterWrite(true)
+	assert.Equal(t, int64(120), c.minReadHeight())
+	c.recordCommit(100)
+	assert.Equal(t, int64(120), c.CommitHeight(), "the floor only rises")
+	c.RequireMinHeight(130)
+	assert.Equal(t, int64(130), c.minReadHeight())
+
+	assert.NoError(t, c.checkHeight(130, c.minReadHeight()))
+	assert.Equal(t, StaleNodeError, c.checkHeight(129, c.minReadHeight()))
+	assert.Equal(t, gtypes.ErrorClassUnavailable, gtypes.Classify(StaleNodeError))
+}
+
+func TestNodePoolConsistency(t *testing.T) {
+	a, b := &poolNode{addr: "a", client: &HTTP{}}, &poolNode{addr: "b", client: &HTTP{}}
+	p := &NodePool{nodes: []*poolNode{a, b}}
+
+	b.client.recordCommit(50)
+	p.observe(b)
+	assert.Equal(t, []*poolNode{a, b}, p.order())
+	assert.Zero(t, a.client.minReadHeight())
+
+	p.SetConsistency(ConsistencySameNode)
+	assert.Equal(t, []*poolNode{b, a}, p.order(), "the node that committed goes first")
+	assert.Equal(t, int64(50), a.client.minReadHeight())
+
+	a.client.recordCommit(60)
+	p.observe(a)
+	assert.Equal(t, []*poolNode{a, b}, p.order())
+	assert.Equal(t, int64(60), b.client.minReadHeight())
+}
+
+// laggingNode is a node behind a load balancer: txs commit at the tip, queries
+// are served by a replica that catches up one block per query.
+type laggingNode struct {
+	*httptest.Server
+	account []byte
+	mtx     sync.Mutex
+	tip     int64
+	served  []int64
+}
+
+func newLaggingNode(t *testing.T, replica, tip int64) *laggingNode {
+	cdc := amino.NewCodec()
+	ctypes.RegisterAmino(cdc)
+	node := &laggingNode{tip: tip}
+	acc := types.Account(&types.AppAccount{BaseAccount: types.BaseAccount{Coins: types.Coins{{Denom: "BNB", Amount: 1}}}})
+	var err error
+	node.account, err = gtypes.NewCodec().MarshalBinaryBare(acc)
+	assert.NoError(t, err)
+	next := replica
+	funcs := map[string]*rpcserver.RPCFunc{
+		"abci_query": rpcserver.NewRPCFunc(func(ctx *rpctypes.Context, path string, data cmn.HexBytes, height int64, prove bool) (*ctypes.ResultABCIQuery, error) {
+			node.mtx.Lock()
+			defer node.mtx.Unlock()
+			node.served = append(node.served, next)
+			res := &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: node.account, Height: next}}
+			next++
+			return res, nil
+		}, "path,data,height,prove"),
+		"broadcast_tx_commit": rpcserver.NewRPCFunc(func(ctx *rpctypes.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+			node.mtx.Lock()
+			defer node.mtx.Unlock()
+			return &ctypes.ResultBroadcastTxCommit{Hash: tx.Hash(), Height: node.tip}, nil
+		}, "tx"),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", rpcserver.NewWebsocketManager(funcs, cdc).WebsocketHandler)
+	node.Server = httptest.NewServer(mux)
+	return node
+}
+
+// heights returns the heights queries were served at and forgets them.
+func (node *laggingNode) heights() []int64 {
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	served := node.served
+	node.served = nil
+	return served
+}
+
+func TestReadAfterWrite(t *testing.T) {
+	node := newLaggingNode(t, 10, 13)
+	defer node.Close()
+	c := NewClient("tcp://"+node.Listener.Addr().String(), WithRetry(RetryPolicy{MaxAttempts: 5, Backoff: time.Millisecond}), WithReadAfterWrite())
+	defer c.Stop()
+	addr := types.AccAddress(bytes.Repeat([]byte{1}, types.AddrLen))
+
+	_, err := c.GetAccount(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{10}, node.heights(), "nothing was written yet")
+
+	res, err := c.BroadcastTxCommit(tmtypes.Tx("tx"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(13), res.Height)
+	acc, err := c.GetAccount(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Coins{{Denom: "BNB", Amount: 1}}, acc.GetCoins())
+	assert.Equal(t, []int64{11, 12, 13}, node.heights(), "the read waits for the replica to see the tx")
+
+	// a view checks any query against its height and leaves the client alone
+	balances, err := c.WithMinHeight(16).GetBalances(addr)
+	assert.NoError(t, err)
+	assert.Len(t, balances, 1)
+	assert.Equal(t, []int64{14, 15, 16}, node.heights())
+	_, err = c.WithMinHeight(100).GetAccount(addr)
+	assert.Equal(t, StaleNodeError, err)
+	assert.Len(t, node.heights(), 5, "the view gave up after its attempts")
+	assert.Equal(t, int64(13), c.minReadHeight())
+
+	// commits through a view raise the floor of the client
+	node.mtx.Lock()
+	node.tip = 40
+	node.mtx.Unlock()
+	_, err = c.WithMinHeight(1).BroadcastTxCommit(tmtypes.Tx("tx2"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(40), c.CommitHeight())
+}
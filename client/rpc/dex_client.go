@@ -1,17 +1,19 @@
 package rpc
 
 import (
+	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/binance-chain/go-sdk/client/screen"
 	"github.com/binance-chain/go-sdk/common"
 	"github.com/binance-chain/go-sdk/common/types"
 	sdk "github.com/binance-chain/go-sdk/common/types"
 	"github.com/binance-chain/go-sdk/keys"
-	gtypes "github.com/binance-chain/go-sdk/types"
 	"github.com/binance-chain/go-sdk/types/msg"
 	"github.com/binance-chain/go-sdk/types/tx"
 	core_types "github.com/tendermint/tendermint/rpc/core/types"
@@ -35,19 +37,28 @@ const (
 	AtomicSwapStoreName = "atomic_swap"
 
 	TimeLockrcNotFoundErrorCode = 458760
+
+	swapQueryPageSize = 100
 )
 
-type DexClient interface {
-	Broadcast(m msg.Msg, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+// QueryClient reads chain state. It needs no key manager, hand it to services
+// that only read data.
+type QueryClient interface {
 	TxInfoSearch(query string, prove bool, page, perPage int) ([]Info, error)
 	ListAllTokens(offset int, limit int) ([]types.Token, error)
 	GetTokenInfo(symbol string) (*types.Token, error)
 	GetAccount(addr types.AccAddress) (acc types.Account, err error)
 	GetCommitAccount(addr types.AccAddress) (acc types.Account, err error)
+	GetCommitAccountWithOptions(addr types.AccAddress, opts ...QueryOption) (types.Account, *StoreQueryResult, error)
+	VerifyStoreResult(res *StoreQueryResult) error
+	VerifyBlockSignatures(height *int64) (*BlockSignatures, error)
 
 	GetBalances(addr types.AccAddress) ([]types.TokenBalance, error)
 	GetBalance(addr types.AccAddress, symbol string) (*types.TokenBalance, error)
+	GetBalanceDetails(addr types.AccAddress) ([]types.BalanceDetail, error)
+	GetBalanceDetail(addr types.AccAddress, symbol string) (*types.BalanceDetail, error)
 	GetFee() ([]types.FeeParam, error)
+	GetFeeWithOptions(opts ...QueryOption) ([]types.FeeParam, error)
 	GetOpenOrders(addr types.AccAddress, pair string) ([]types.OpenOrder, error)
 	GetTradingPairs(offset int, limit int) ([]types.TradingPair, error)
 	GetDepth(tradePair string, level int) (*types.OrderBook, error)
@@ -55,18 +66,56 @@ type DexClient interface {
 	GetSideChainProposals(status types.ProposalStatus, numLatest int64, sideChainId string) ([]types.Proposal, error)
 	GetSideChainProposal(proposalId int64, sideChainId string) (types.Proposal, error)
 	GetProposal(proposalId int64) (types.Proposal, error)
+	GetProposalTally(proposalId int64) (types.TallyResult, error)
+	GetSideChainProposalTally(proposalId int64, sideChainId string) (types.TallyResult, error)
+	GetProposalDeposits(proposalId int64) ([]types.Deposit, error)
+	GetSideChainProposalDeposits(proposalId int64, sideChainId string) ([]types.Deposit, error)
 	GetTimelocks(addr types.AccAddress) ([]types.TimeLockRecord, error)
 	GetTimelock(addr types.AccAddress, recordID int64) (*types.TimeLockRecord, error)
 	GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error)
 	GetSwapByCreator(creatorAddr string, offset int64, limit int64) ([]types.SwapBytes, error)
 	GetSwapByRecipient(recipientAddr string, offset int64, limit int64) ([]types.SwapBytes, error)
+	ForEachSwap(addr string, role types.SwapRole, status types.SwapStatus, fn func(swapID types.SwapBytes, swap types.AtomicSwap) error) error
+	WatchSwap(ctx context.Context, swapID types.SwapBytes, interval time.Duration, callbacks SwapCallbacks) (types.AtomicSwap, error)
+	WatchProposal(ctx context.Context, proposalID int64, interval time.Duration, callbacks ProposalCallbacks) (types.Proposal, error)
+	WatchProposals(ctx context.Context, status types.ProposalStatus, interval time.Duration, onProposal func(types.Proposal), onError func(error)) error
 	GetSideChainParams(sideChainId string) ([]msg.SCParam, error)
+	GetParams(module string) (interface{}, error)
+	GetSideChainParamSet(sideChainId string) (*msg.SideChainParams, error)
+	GetSideChainStakeParams(sideChainId string) (*msg.StakeParams, error)
+	GetSideChainSlashParams(sideChainId string) (*msg.SlashParams, error)
+	GetSideChainOracleParams(sideChainId string) (*msg.OracleParams, error)
 
 	ListAllMiniTokens(offset int, limit int) ([]types.MiniToken, error)
 	GetMiniTokenInfo(symbol string) (*types.MiniToken, error)
 	GetMiniTradingPairs(offset int, limit int) ([]types.TradingPair, error)
 
+	GetBoundToken(symbol string) (*types.BoundToken, error)
+	ListBoundTokens() ([]types.BoundToken, error)
+	GetBindRequest(symbol string) (*types.BindRequest, error)
+	GetBindStatus(symbol string) (types.BindStatus, error)
+	GetCrossChainFees() (*types.CrossChainFees, error)
+
+	GetProphecy(chainId sdk.IbcChainID, sequence int64) (*msg.Prophecy, error)
+	GetCurrentOracleSequence(chainId sdk.IbcChainID) (int64, error)
+}
+
+// BroadcastClient submits txs signed elsewhere, e.g. by an offline signer.
+type BroadcastClient interface {
+	BroadcastIdempotent(signedTx []byte, syncType SyncType) (*core_types.ResultBroadcastTx, error)
+	SimulateTx(stdTx tx.StdTx) (*SimulateResult, error)
+}
+
+// SigningClient signs txs with the key manager of the client and broadcasts them.
+type SigningClient interface {
+	Broadcast(m msg.Msg, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+	SignMsg(m msg.Msg, options ...tx.Option) ([]byte, error)
+	Preview(m msg.Msg, options ...tx.Option) (*tx.SignPreview, error)
+	Simulate(m msg.Msg, options ...tx.Option) (*SimulateResult, error)
+
 	SetKeyManager(k keys.KeyManager)
+	SetOrderGuard(g OrderGuard)
+	SetSource(source int64)
 	SendToken(transfers []msg.Transfer, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 	CreateOrder(baseAssetSymbol, quoteAssetSymbol string, op int8, price, quantity int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 	CancelOrder(baseAssetSymbol, quoteAssetSymbol, refId string, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
@@ -77,14 +126,17 @@ type DexClient interface {
 	ClaimHTLT(swapID []byte, randomNumber []byte, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 	RefundHTLT(swapID []byte, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 	TransferTokenOwnership(symbol string, newOwner types.AccAddress, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+	MintToken(symbol string, amount int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+	BurnToken(symbol string, amount int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+	FreezeToken(symbol string, amount int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+	UnfreezeToken(symbol string, amount int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+	SetURI(symbol, tokenURI string, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 
 	Bind(symbol string, amount int64, contractAddress msg.SmartChainAddress, contractDecimals int8, expireTime int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 	Unbind(symbol string, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 	TransferOut(to msg.SmartChainAddress, amount types.Coin, expireTime int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 
 	Claim(chainId sdk.IbcChainID, sequence uint64, payload []byte, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
-	GetProphecy(chainId sdk.IbcChainID, sequence int64) (*msg.Prophecy, error)
-	GetCurrentOracleSequence(chainId sdk.IbcChainID) (int64, error)
 
 	SideChainVote(proposalID int64, option msg.VoteOption, sideChainId string, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 	SideChainDeposit(proposalID int64, amount types.Coins, sideChainId string, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
@@ -98,6 +150,20 @@ type DexClient interface {
 	Vote(proposalID int64, option msg.VoteOption, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 }
 
+// DexClient is the full client. Prefer the narrowest of its parts a service
+// needs, *HTTP satisfies all of them.
+type DexClient interface {
+	QueryClient
+	BroadcastClient
+	SigningClient
+}
+
+var (
+	_ QueryClient     = (*HTTP)(nil)
+	_ BroadcastClient = (*HTTP)(nil)
+	_ SigningClient   = (*HTTP)(nil)
+)
+
 func (c *HTTP) TxInfoSearch(query string, prove bool, page, perPage int) ([]Info, error) {
 	if err := ValidateTxSearchQueryStr(query); err != nil {
 		return nil, err
@@ -118,11 +184,11 @@ func (c *HTTP) ListAllTokens(offset int, limit int) ([]types.Token, error) {
 		return nil, err
 	}
 	if !result.Response.IsOK() {
-		return nil, fmt.Errorf(result.Response.Log)
+		return nil, abciError(result.Response)
 	}
 	bz := result.Response.GetValue()
 	tokens := make([]types.Token, 0)
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(bz, &tokens)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, bz, &tokens)
 	return tokens, err
 }
 
@@ -136,11 +202,11 @@ func (c *HTTP) GetTokenInfo(symbol string) (*types.Token, error) {
 		return nil, err
 	}
 	if !result.Response.IsOK() {
-		return nil, fmt.Errorf(result.Response.Log)
+		return nil, abciError(result.Response)
 	}
 	bz := result.Response.GetValue()
 	token := new(types.Token)
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(bz, token)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, bz, token)
 	return token, err
 }
 
@@ -162,7 +228,7 @@ func (c *HTTP) GetCommitAccount(addr types.AccAddress) (acc types.Account, err e
 	if bz == nil {
 		return nil, nil
 	}
-	err = c.cdc.UnmarshalBinaryBare(bz, &acc)
+	err = unmarshalBinaryBare(c.cdc, bz, &acc)
 	if err != nil {
 		return nil, err
 	}
@@ -176,19 +242,19 @@ func (c *HTTP) GetCommitAccount(addr types.AccAddress) (acc types.Account, err e
 // 2. Node receive Tx(AccountA --> AccountB 2BNB) and check have passed, but not included in block yet.
 // 3. GetAccount will return AccountA(Balance: 8BNB, sequence: 2), AccountB(Balance: 7BNB, sequence: 1)
 func (c *HTTP) GetAccount(addr types.AccAddress) (acc types.Account, err error) {
-	result, err := c.ABCIQuery(fmt.Sprintf("/account/%s", addr.String()), nil)
+	result, err := c.ABCIQuery(fmt.Sprintf("/account/%s", c.Network().FormatAddress(addr)), nil)
 	if err != nil {
 		return nil, err
 	}
 	resp := result.Response
 	if !resp.IsOK() {
-		return nil, errors.New(resp.Log)
+		return nil, abciError(resp)
 	}
 	value := result.Response.GetValue()
 	if len(value) == 0 {
 		return nil, nil
 	}
-	err = c.cdc.UnmarshalBinaryBare(value, &acc)
+	err = unmarshalBinaryBare(c.cdc, value, &acc)
 	if err != nil {
 		return nil, err
 	}
@@ -260,16 +326,124 @@ func (c *HTTP) GetBalance(addr types.AccAddress, symbol string) (*types.TokenBal
 	}, nil
 }
 
+// GetBalanceDetails returns the breakdown of every symbol held by the account, including
+// the amounts in timelocks and in open atomic swaps which are not part of the account coins.
+func (c *HTTP) GetBalanceDetails(addr types.AccAddress) ([]types.BalanceDetail, error) {
+	account, err := c.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+	details := make(map[string]*types.BalanceDetail)
+	symbols := make([]string, 0)
+	detailOf := func(symbol string) *types.BalanceDetail {
+		d, ok := details[symbol]
+		if !ok {
+			d = &types.BalanceDetail{Symbol: symbol}
+			details[symbol] = d
+			symbols = append(symbols, symbol)
+		}
+		return d
+	}
+
+	if account != nil {
+		for _, coin := range account.GetCoins() {
+			detailOf(coin.Denom).Free = types.Fixed8(coin.Amount)
+		}
+		if nacc, ok := account.(types.NamedAccount); ok {
+			for _, coin := range nacc.GetFrozenCoins() {
+				detailOf(coin.Denom).Frozen = types.Fixed8(coin.Amount)
+			}
+			for _, coin := range nacc.GetLockedCoins() {
+				detailOf(coin.Denom).Locked = types.Fixed8(coin.Amount)
+			}
+		}
+	}
+
+	records, err := c.GetTimelocks(addr)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		for _, coin := range record.Amount {
+			d := detailOf(coin.Denom)
+			d.TimeLocked += types.Fixed8(coin.Amount)
+			d.TimeLocks = append(d.TimeLocks, types.TimeLockedAmount{
+				Id:         record.Id,
+				Amount:     types.Fixed8(coin.Amount),
+				UnlockTime: record.LockTime,
+			})
+		}
+	}
+
+	swapping, err := c.getSwappingCoins(addr)
+	if err != nil {
+		return nil, err
+	}
+	for _, coin := range swapping {
+		detailOf(coin.Denom).Swapping += types.Fixed8(coin.Amount)
+	}
+
+	result := make([]types.BalanceDetail, 0, len(symbols))
+	for _, symbol := range symbols {
+		result = append(result, *details[symbol])
+	}
+	return result, nil
+}
+
+// GetBalanceDetail returns the breakdown of a single symbol held by the account.
+func (c *HTTP) GetBalanceDetail(addr types.AccAddress, symbol string) (*types.BalanceDetail, error) {
+	if err := ValidateSymbol(symbol); err != nil {
+		return nil, err
+	}
+	details, err := c.GetBalanceDetails(addr)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range details {
+		if d.Symbol == symbol {
+			return &d, nil
+		}
+	}
+	return &types.BalanceDetail{
+		Symbol:     symbol,
+		Free:       types.Fixed8Zero,
+		Frozen:     types.Fixed8Zero,
+		Locked:     types.Fixed8Zero,
+		TimeLocked: types.Fixed8Zero,
+		Swapping:   types.Fixed8Zero,
+	}, nil
+}
+
+// getSwappingCoins sums the out amount of all the open swaps created by the account.
+func (c *HTTP) getSwappingCoins(addr types.AccAddress) (types.Coins, error) {
+	swapping := types.Coins{}
+	err := c.ForEachSwap(c.Network().FormatAddress(addr), types.SwapRoleCreator, types.Open, func(_ types.SwapBytes, swap types.AtomicSwap) error {
+		swapping = swapping.Plus(swap.OutAmount.Sort())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return swapping, nil
+}
+
 func (c *HTTP) GetFee() ([]types.FeeParam, error) {
-	rawFee, err := c.ABCIQuery(fmt.Sprintf("%s/fees", ParamABCIPrefix), nil)
+	return c.GetFeeWithOptions()
+}
+
+// GetFeeWithOptions is GetFee, WithHeight returns the fee params of the state
+// committed at that height, the fees charged in the next block.
+func (c *HTTP) GetFeeWithOptions(opts ...QueryOption) ([]types.FeeParam, error) {
+	queryOpts := ApplyQueryOptions(opts...)
+	rawFee, err := c.ABCIQueryWithOptions(fmt.Sprintf("%s/fees", ParamABCIPrefix), nil, queryOpts)
 	if err != nil {
 		return nil, err
 	}
 	if !rawFee.Response.IsOK() {
-		return nil, fmt.Errorf(rawFee.Response.Log)
+		return nil, abciError(rawFee.Response)
 	}
 	var fees []types.FeeParam
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(rawFee.Response.GetValue(), &fees)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, rawFee.Response.GetValue(), &fees)
 	return fees, err
 }
 
@@ -282,14 +456,14 @@ func (c *HTTP) GetOpenOrders(addr types.AccAddress, pair string) ([]types.OpenOr
 		return nil, err
 	}
 	if !rawOrders.Response.IsOK() {
-		return nil, fmt.Errorf(rawOrders.Response.Log)
+		return nil, abciError(rawOrders.Response)
 	}
 	bz := rawOrders.Response.GetValue()
 	openOrders := make([]types.OpenOrder, 0)
 	if bz == nil {
 		return openOrders, nil
 	}
-	if err := c.cdc.UnmarshalBinaryLengthPrefixed(bz, &openOrders); err != nil {
+	if err := unmarshalBinaryLengthPrefixed(c.cdc, bz, &openOrders); err != nil {
 		return nil, err
 	} else {
 		return openOrders, nil
@@ -308,13 +482,13 @@ func (c *HTTP) GetTradingPairs(offset int, limit int) ([]types.TradingPair, erro
 		return nil, err
 	}
 	if !rawTradePairs.Response.IsOK() {
-		return nil, fmt.Errorf(rawTradePairs.Response.Log)
+		return nil, abciError(rawTradePairs.Response)
 	}
 	pairs := make([]types.TradingPair, 0)
 	if rawTradePairs.Response.GetValue() == nil {
 		return pairs, nil
 	}
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(rawTradePairs.Response.GetValue(), &pairs)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, rawTradePairs.Response.GetValue(), &pairs)
 	return pairs, err
 }
 
@@ -330,10 +504,10 @@ func (c *HTTP) GetDepth(tradePair string, level int) (*types.OrderBook, error) {
 		return nil, err
 	}
 	if !rawDepth.Response.IsOK() {
-		return nil, fmt.Errorf(rawDepth.Response.Log)
+		return nil, abciError(rawDepth.Response)
 	}
 	var ob types.OrderBook
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(rawDepth.Response.GetValue(), &ob)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, rawDepth.Response.GetValue(), &ob)
 	if err != nil {
 		return nil, err
 	}
@@ -358,14 +532,14 @@ func (c *HTTP) GetTimelocks(addr types.AccAddress) ([]types.TimeLockRecord, erro
 		return nil, err
 	}
 	if rawRecords == nil {
-		return nil, fmt.Errorf("zero records")
+		return nil, ZeroRecordsError
 	}
 	if !rawRecords.Response.IsOK() {
-		return nil, fmt.Errorf(rawRecords.Response.Log)
+		return nil, abciError(rawRecords.Response)
 	}
 	records := make([]types.TimeLockRecord, 0)
 
-	if err = c.cdc.UnmarshalJSON(rawRecords.Response.GetValue(), &records); err != nil {
+	if err = c.unmarshalNodeJSON(rawRecords.Response.GetValue(), &records); err != nil {
 		return nil, err
 	} else {
 		return records, nil
@@ -396,7 +570,7 @@ func (c *HTTP) GetTimelock(addr types.AccAddress, recordID int64) (*types.TimeLo
 	}
 	var record types.TimeLockRecord
 
-	err = c.cdc.UnmarshalJSON(rawRecord.Response.GetValue(), &record)
+	err = c.unmarshalNodeJSON(rawRecord.Response.GetValue(), &record)
 	if err != nil {
 		return nil, err
 	}
@@ -432,11 +606,11 @@ func (c *HTTP) getProposals(status types.ProposalStatus, sideChainId string, num
 		return nil, err
 	}
 	if !rawProposals.Response.IsOK() {
-		return nil, fmt.Errorf(rawProposals.Response.Log)
+		return nil, abciError(rawProposals.Response)
 	}
 	proposals := make([]types.Proposal, 0)
 
-	err = c.cdc.UnmarshalJSON(rawProposals.Response.GetValue(), &proposals)
+	err = c.unmarshalNodeJSON(rawProposals.Response.GetValue(), &proposals)
 	return proposals, err
 }
 
@@ -449,6 +623,59 @@ func (c *HTTP) GetSideChainProposal(proposalId int64, sideChainId string) (types
 }
 
 func (c *HTTP) getProposal(proposalId int64, sideChainId string) (types.Proposal, error) {
+	bz, err := c.queryProposal("custom/gov/proposal", proposalId, sideChainId)
+	if err != nil {
+		return nil, err
+	}
+	var proposal types.Proposal
+
+	err = c.unmarshalNodeJSON(bz, &proposal)
+	return proposal, err
+}
+
+// GetProposalTally returns the votes on a proposal weighted by voting power. While
+// the proposal is in its voting period the tally is computed from the current votes.
+func (c *HTTP) GetProposalTally(proposalId int64) (types.TallyResult, error) {
+	return c.getProposalTally(proposalId, "")
+}
+
+func (c *HTTP) GetSideChainProposalTally(proposalId int64, sideChainId string) (types.TallyResult, error) {
+	return c.getProposalTally(proposalId, sideChainId)
+}
+
+func (c *HTTP) getProposalTally(proposalId int64, sideChainId string) (types.TallyResult, error) {
+	var tally types.TallyResult
+	bz, err := c.queryProposal("custom/gov/tally", proposalId, sideChainId)
+	if err != nil {
+		return tally, err
+	}
+	err = c.unmarshalNodeJSON(bz, &tally)
+	return tally, err
+}
+
+// GetProposalDeposits returns the deposits made on a proposal.
+func (c *HTTP) GetProposalDeposits(proposalId int64) ([]types.Deposit, error) {
+	return c.getProposalDeposits(proposalId, "")
+}
+
+func (c *HTTP) GetSideChainProposalDeposits(proposalId int64, sideChainId string) ([]types.Deposit, error) {
+	return c.getProposalDeposits(proposalId, sideChainId)
+}
+
+func (c *HTTP) getProposalDeposits(proposalId int64, sideChainId string) ([]types.Deposit, error) {
+	bz, err := c.queryProposal("custom/gov/deposits", proposalId, sideChainId)
+	if err != nil {
+		return nil, err
+	}
+	deposits := make([]types.Deposit, 0)
+	if len(bz) == 0 {
+		return deposits, nil
+	}
+	err = c.unmarshalNodeJSON(bz, &deposits)
+	return deposits, err
+}
+
+func (c *HTTP) queryProposal(path string, proposalId int64, sideChainId string) ([]byte, error) {
 	params := types.QueryProposalParams{
 		ProposalID: proposalId,
 	}
@@ -457,17 +684,14 @@ func (c *HTTP) getProposal(proposalId int64, sideChainId string) (types.Proposal
 	if err != nil {
 		return nil, err
 	}
-	rawProposal, err := c.ABCIQuery("custom/gov/proposal", bz)
+	res, err := c.ABCIQuery(path, bz)
 	if err != nil {
 		return nil, err
 	}
-	if !rawProposal.Response.IsOK() {
-		return nil, fmt.Errorf(rawProposal.Response.Log)
+	if !res.Response.IsOK() {
+		return nil, abciError(res.Response)
 	}
-	var proposal types.Proposal
-
-	err = c.cdc.UnmarshalJSON(rawProposal.Response.GetValue(), &proposal)
-	return proposal, err
+	return res.Response.GetValue(), nil
 }
 
 func (c *HTTP) GetSideChainParams(sideChainId string) ([]msg.SCParam, error) {
@@ -480,13 +704,56 @@ func (c *HTTP) GetSideChainParams(sideChainId string) ([]msg.SCParam, error) {
 		return nil, err
 	}
 	if !rawParams.Response.IsOK() {
-		return nil, fmt.Errorf(rawParams.Response.Log)
+		return nil, abciError(rawParams.Response)
 	}
 	var params []msg.SCParam
-	err = c.cdc.UnmarshalJSON(rawParams.Response.GetValue(), &params)
+	err = c.unmarshalNodeJSON(rawParams.Response.GetValue(), &params)
 	return params, err
 }
 
+// GetSideChainParamSet is GetSideChainParams with the param sets sorted by module.
+func (c *HTTP) GetSideChainParamSet(sideChainId string) (*msg.SideChainParams, error) {
+	params, err := c.GetSideChainParams(sideChainId)
+	if err != nil {
+		return nil, err
+	}
+	sc := msg.NewSideChainParams(params)
+	return &sc, nil
+}
+
+func (c *HTTP) GetSideChainStakeParams(sideChainId string) (*msg.StakeParams, error) {
+	sc, err := c.GetSideChainParamSet(sideChainId)
+	if err != nil {
+		return nil, err
+	}
+	if sc.Stake == nil {
+		return nil, fmt.Errorf("no staking params for side chain %s", sideChainId)
+	}
+	return sc.Stake, nil
+}
+
+func (c *HTTP) GetSideChainSlashParams(sideChainId string) (*msg.SlashParams, error) {
+	sc, err := c.GetSideChainParamSet(sideChainId)
+	if err != nil {
+		return nil, err
+	}
+	if sc.Slash == nil {
+		return nil, fmt.Errorf("no slash params for side chain %s", sideChainId)
+	}
+	return sc.Slash, nil
+}
+
+func (c *HTTP) GetSideChainOracleParams(sideChainId string) (*msg.OracleParams, error) {
+	sc, err := c.GetSideChainParamSet(sideChainId)
+	if err != nil {
+		return nil, err
+	}
+	if sc.Oracle == nil {
+		return nil, fmt.Errorf("no oracle params for side chain %s", sideChainId)
+	}
+	return sc.Oracle, nil
+}
+
 func (c *HTTP) existsCC(symbol string) bool {
 	resp, err := c.ABCIQuery(fmt.Sprintf("tokens/info/%s", symbol), nil)
 	if err != nil {
@@ -499,7 +766,7 @@ func (c *HTTP) existsCC(symbol string) bool {
 		return false
 	}
 	var token types.Token
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(resp.Response.GetValue(), &token)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, resp.Response.GetValue(), &token)
 	if err != nil {
 		return false
 	}
@@ -520,13 +787,13 @@ func (c *HTTP) GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error) {
 		return types.AtomicSwap{}, err
 	}
 	if !resp.Response.IsOK() {
-		return types.AtomicSwap{}, fmt.Errorf(resp.Response.Log)
+		return types.AtomicSwap{}, abciError(resp.Response)
 	}
 	if len(resp.Response.GetValue()) == 0 {
-		return types.AtomicSwap{}, fmt.Errorf("zero records")
+		return types.AtomicSwap{}, ZeroRecordsError
 	}
 	var result types.AtomicSwap
-	err = c.cdc.UnmarshalJSON(resp.Response.GetValue(), &result)
+	err = c.unmarshalNodeJSON(resp.Response.GetValue(), &result)
 	if err != nil {
 		return types.AtomicSwap{}, err
 	}
@@ -534,7 +801,7 @@ func (c *HTTP) GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error) {
 }
 
 func (c *HTTP) GetSwapByCreator(creatorAddr string, offset int64, limit int64) ([]types.SwapBytes, error) {
-	addr, err := types.AccAddressFromBech32(creatorAddr)
+	addr, err := c.Network().ParseAddress(creatorAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -555,13 +822,13 @@ func (c *HTTP) GetSwapByCreator(creatorAddr string, offset int64, limit int64) (
 		return nil, err
 	}
 	if !resp.Response.IsOK() {
-		return nil, fmt.Errorf(resp.Response.Log)
+		return nil, abciError(resp.Response)
 	}
 	if len(resp.Response.GetValue()) == 0 {
-		return nil, fmt.Errorf("zero records")
+		return nil, ZeroRecordsError
 	}
 	var swapIDList []types.SwapBytes
-	err = c.cdc.UnmarshalJSON(resp.Response.GetValue(), &swapIDList)
+	err = c.unmarshalNodeJSON(resp.Response.GetValue(), &swapIDList)
 	if err != nil {
 		return nil, err
 	}
@@ -569,7 +836,7 @@ func (c *HTTP) GetSwapByCreator(creatorAddr string, offset int64, limit int64) (
 }
 
 func (c *HTTP) GetSwapByRecipient(recipientAddr string, offset int64, limit int64) ([]types.SwapBytes, error) {
-	recipient, err := types.AccAddressFromBech32(recipientAddr)
+	recipient, err := c.Network().ParseAddress(recipientAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -589,13 +856,13 @@ func (c *HTTP) GetSwapByRecipient(recipientAddr string, offset int64, limit int6
 		return nil, err
 	}
 	if !resp.Response.IsOK() {
-		return nil, fmt.Errorf(resp.Response.Log)
+		return nil, abciError(resp.Response)
 	}
 	if len(resp.Response.GetValue()) == 0 {
-		return nil, fmt.Errorf("zero records")
+		return nil, ZeroRecordsError
 	}
 	var swapIDList []types.SwapBytes
-	err = c.cdc.UnmarshalJSON(resp.Response.GetValue(), &swapIDList)
+	err = c.unmarshalNodeJSON(resp.Response.GetValue(), &swapIDList)
 	if err != nil {
 		return nil, err
 	}
@@ -615,11 +882,11 @@ func (c *HTTP) ListAllMiniTokens(offset int, limit int) ([]types.MiniToken, erro
 		return nil, err
 	}
 	if !result.Response.IsOK() {
-		return nil, fmt.Errorf(result.Response.Log)
+		return nil, abciError(result.Response)
 	}
 	bz := result.Response.GetValue()
 	tokens := make([]types.MiniToken, 0)
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(bz, &tokens)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, bz, &tokens)
 	return tokens, err
 }
 
@@ -633,11 +900,11 @@ func (c *HTTP) GetMiniTokenInfo(symbol string) (*types.MiniToken, error) {
 		return nil, err
 	}
 	if !result.Response.IsOK() {
-		return nil, fmt.Errorf(result.Response.Log)
+		return nil, abciError(result.Response)
 	}
 	bz := result.Response.GetValue()
 	token := new(types.MiniToken)
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(bz, token)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, bz, token)
 	return token, err
 }
 
@@ -653,13 +920,13 @@ func (c *HTTP) GetMiniTradingPairs(offset int, limit int) ([]types.TradingPair,
 		return nil, err
 	}
 	if !rawTradePairs.Response.IsOK() {
-		return nil, fmt.Errorf(rawTradePairs.Response.Log)
+		return nil, abciError(rawTradePairs.Response)
 	}
 	pairs := make([]types.TradingPair, 0)
 	if rawTradePairs.Response.GetValue() == nil {
 		return pairs, nil
 	}
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(rawTradePairs.Response.GetValue(), &pairs)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, rawTradePairs.Response.GetValue(), &pairs)
 	return pairs, err
 }
 
@@ -685,12 +952,18 @@ func (c *HTTP) CreateOrder(baseAssetSymbol, quoteAssetSymbol string, op int8, pr
 	if baseAssetSymbol == "" || quoteAssetSymbol == "" {
 		return nil, fmt.Errorf("BaseAssetSymbol or QuoteAssetSymbol is missing. ")
 	}
+	symbol := common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol)
+	if c.orderGuard != nil {
+		if err := c.orderGuard.Check(symbol, op, price, quantity); err != nil {
+			return nil, err
+		}
+	}
 	fromAddr := c.key.GetAddr()
 	newOrderMsg := msg.NewCreateOrderMsg(
 		fromAddr,
 		"",
 		op,
-		common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol),
+		symbol,
 		price,
 		quantity,
 	)
@@ -762,10 +1035,113 @@ func (c *HTTP) TransferTokenOwnership(symbol string, newOwner types.AccAddress,
 		return nil, KeyMissingError
 	}
 	fromAddr := c.key.GetAddr()
+	if err := c.checkTokenOwner(symbol, fromAddr, false); err != nil {
+		return nil, err
+	}
 	transferOwnershipMsg := msg.NewTransferOwnershipMsg(fromAddr, symbol, newOwner)
 	return c.Broadcast(transferOwnershipMsg, syncType, options...)
 }
 
+func (c *HTTP) MintToken(symbol string, amount int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	if c.key == nil {
+		return nil, KeyMissingError
+	}
+	fromAddr := c.key.GetAddr()
+	if err := c.checkTokenOwner(symbol, fromAddr, true); err != nil {
+		return nil, err
+	}
+	mintMsg := msg.NewMintMsg(fromAddr, symbol, amount)
+	return c.Broadcast(mintMsg, syncType, options...)
+}
+
+func (c *HTTP) BurnToken(symbol string, amount int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	if c.key == nil {
+		return nil, KeyMissingError
+	}
+	fromAddr := c.key.GetAddr()
+	if err := c.checkTokenOwner(symbol, fromAddr, false); err != nil {
+		return nil, err
+	}
+	burnMsg := msg.NewTokenBurnMsg(fromAddr, symbol, amount)
+	return c.Broadcast(burnMsg, syncType, options...)
+}
+
+// FreezeToken and UnfreezeToken may be sent by any holder, so only the
+// existence of the token is checked before signing.
+func (c *HTTP) FreezeToken(symbol string, amount int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	if c.key == nil {
+		return nil, KeyMissingError
+	}
+	if _, err := c.getTokenOwner(symbol); err != nil {
+		return nil, err
+	}
+	freezeMsg := msg.NewFreezeMsg(c.key.GetAddr(), symbol, amount)
+	return c.Broadcast(freezeMsg, syncType, options...)
+}
+
+func (c *HTTP) UnfreezeToken(symbol string, amount int64, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	if c.key == nil {
+		return nil, KeyMissingError
+	}
+	if _, err := c.getTokenOwner(symbol); err != nil {
+		return nil, err
+	}
+	unfreezeMsg := msg.NewUnfreezeMsg(c.key.GetAddr(), symbol, amount)
+	return c.Broadcast(unfreezeMsg, syncType, options...)
+}
+
+// SetURI is only supported by mini tokens.
+func (c *HTTP) SetURI(symbol, tokenURI string, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	if c.key == nil {
+		return nil, KeyMissingError
+	}
+	if !msg.IsValidMiniTokenSymbol(symbol) {
+		return nil, NotMiniTokenError
+	}
+	fromAddr := c.key.GetAddr()
+	if err := c.checkTokenOwner(symbol, fromAddr, false); err != nil {
+		return nil, err
+	}
+	setURIMsg := msg.NewSetUriMsg(fromAddr, symbol, tokenURI)
+	return c.Broadcast(setURIMsg, syncType, options...)
+}
+
+type tokenOwnership struct {
+	owner    types.AccAddress
+	mintable bool
+}
+
+// getTokenOwner looks the symbol up as a mini token or a regular token,
+// depending on its suffix.
+func (c *HTTP) getTokenOwner(symbol string) (*tokenOwnership, error) {
+	if msg.IsValidMiniTokenSymbol(symbol) {
+		token, err := c.GetMiniTokenInfo(symbol)
+		if err != nil {
+			return nil, err
+		}
+		return &tokenOwnership{owner: token.Owner, mintable: token.Mintable}, nil
+	}
+	token, err := c.GetTokenInfo(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenOwnership{owner: token.Owner, mintable: token.Mintable}, nil
+}
+
+func (c *HTTP) checkTokenOwner(symbol string, addr types.AccAddress, mint bool) error {
+	ownership, err := c.getTokenOwner(symbol)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(ownership.owner, addr) {
+		return NotTokenOwnerError
+	}
+	if mint && !ownership.mintable {
+		return TokenNotMintableError
+	}
+	return nil
+}
+
 func (c *HTTP) SideChainVote(proposalID int64, option msg.VoteOption, sideChainId string, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
 	if c.key == nil {
 		return nil, KeyMissingError
@@ -925,6 +1301,13 @@ func (c *HTTP) Broadcast(m msg.Msg, syncType SyncType, options ...tx.Option) (*c
 	if err != nil {
 		return nil, err
 	}
+	return c.broadcastSigned(signBz, syncType)
+}
+
+func (c *HTTP) broadcastSigned(signBz []byte, syncType SyncType) (*core_types.ResultBroadcastTx, error) {
+	if err := c.screen(signBz); err != nil {
+		return nil, err
+	}
 	switch syncType {
 	case Async:
 		return c.BroadcastTxAsync(signBz)
@@ -954,6 +1337,22 @@ func (c *HTTP) Broadcast(m msg.Msg, syncType SyncType, options ...tx.Option) (*c
 	}
 }
 
+// screen runs a signed tx through the screener, if any.
+func (c *HTTP) screen(signBz []byte) error {
+	if c.screener == nil {
+		return nil
+	}
+	parsed, err := ParseTx(c.cdc, signBz)
+	if err != nil {
+		return err
+	}
+	stdTx, ok := parsed.(tx.StdTx)
+	if !ok {
+		return fmt.Errorf("unexpected tx type %T", parsed)
+	}
+	return c.screener.Screen(screen.Tx{Msgs: stdTx.Msgs, Memo: stdTx.Memo, Source: stdTx.Source})
+}
+
 func (c *HTTP) Claim(chainId sdk.IbcChainID, sequence uint64, payload []byte, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
 	if c.key == nil {
 		return nil, KeyMissingError
@@ -977,7 +1376,7 @@ func (c *HTTP) GetProphecy(chainId sdk.IbcChainID, sequence int64) (*msg.Prophec
 	}
 
 	dbProphecy := new(msg.DBProphecy)
-	err = c.cdc.UnmarshalBinaryBare(bz, &dbProphecy)
+	err = unmarshalBinaryBare(c.cdc, bz, &dbProphecy)
 	if err != nil {
 		return nil, err
 	}
@@ -1005,25 +1404,26 @@ func (c *HTTP) GetCurrentOracleSequence(chainId sdk.IbcChainID) (int64, error) {
 }
 
 func (c *HTTP) sign(m msg.Msg, options ...tx.Option) ([]byte, error) {
+	signMsg, err := c.buildSignMsg(m, options...)
+	if err != nil {
+		return nil, err
+	}
+	return c.key.Sign(*signMsg)
+}
+
+func (c *HTTP) buildSignMsg(m msg.Msg, options ...tx.Option) (*tx.StdSignMsg, error) {
 	if c.key == nil {
 		return nil, fmt.Errorf("keymanager is missing, use SetKeyManager to set key")
 	}
 	// prepare message to sign
-	chainID := gtypes.ProdChainID
-	if types.Network == types.TestNetwork {
-		chainID = gtypes.TestnetChainID
-	} else if types.Network == types.TmpTestNetwork {
-		chainID = gtypes.KongoChainId
-	} else if types.Network == types.GangesNetwork {
-		chainID = gtypes.GangesChainId
-	}
 	signMsg := &tx.StdSignMsg{
-		ChainID:       chainID,
+		ChainID:       c.Network().ChainID(),
 		AccountNumber: -1,
 		Sequence:      -1,
 		Memo:          "",
 		Msgs:          []msg.Msg{m},
-		Source:        tx.Source,
+		Source:        c.source,
+		Network:       c.network,
 	}
 
 	for _, op := range options {
@@ -1054,5 +1454,5 @@ func (c *HTTP) sign(m msg.Msg, options ...tx.Option) ([]byte, error) {
 			return nil, err
 		}
 	}
-	return c.key.Sign(*signMsg)
+	return signMsg, nil
 }
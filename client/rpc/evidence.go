@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	// EvidenceDuplicateVote is a validator signing two different blocks at the
+	// same height and round, it is slashed for double signing.
+	EvidenceDuplicateVote = "duplicate_vote"
+	EvidenceUnknown       = "unknown"
+)
+
+// Evidence is proof of validator misbehavior committed in a block.
+type Evidence struct {
+	Type string `json:"type"`
+	// Height is the height of the block that includes the evidence, and
+	// MisbehaviorHeight the height the validator misbehaved at.
+	Height            int64     `json:"height"`
+	Time              time.Time `json:"time"`
+	MisbehaviorHeight int64     `json:"misbehavior_height"`
+	// Validator is the consensus address of the validator.
+	Validator cmn.HexBytes `json:"validator"`
+	Hash      cmn.HexBytes `json:"hash"`
+	// VoteA and VoteB are the conflicting votes of a duplicate vote.
+	VoteA *tmtypes.Vote `json:"vote_a,omitempty"`
+	VoteB *tmtypes.Vote `json:"vote_b,omitempty"`
+}
+
+// Against reports whether the evidence is against the validator with the
+// consensus address addr.
+func (e Evidence) Against(addr []byte) bool {
+	return bytes.Equal(e.Validator, addr)
+}
+
+// GetEvidence returns the evidence committed in the block at height. Blocks
+// without evidence are told apart by their header, only the others are fetched.
+func (c *HTTP) GetEvidence(height int64) ([]Evidence, error) {
+	header, err := c.GetHeader(height)
+	if err != nil {
+		return nil, err
+	}
+	if len(header.EvidenceHash) == 0 {
+		return nil, nil
+	}
+	block, err := c.Block(&height)
+	if err != nil {
+		return nil, err
+	}
+	return BlockEvidence(block.Block), nil
+}
+
+// GetEvidenceRange returns the evidence committed in the blocks in [from, to],
+// in block order.
+func (c *HTTP) GetEvidenceRange(from, to int64) ([]Evidence, error) {
+	if err := ValidateHeightRange(from, to); err != nil {
+		return nil, err
+	}
+	if from == 0 {
+		return nil, fmt.Errorf("heights start at 1")
+	}
+	var evidence []Evidence
+	for height := from; height <= to; height++ {
+		found, err := c.GetEvidence(height)
+		if err != nil {
+			return nil, fmt.Errorf("evidence at height %d: %v", height, err)
+		}
+		evidence = append(evidence, found...)
+	}
+	return evidence, nil
+}
+
+// BlockEvidence lists the evidence committed in block.
+func BlockEvidence(block *tmtypes.Block) []Evidence {
+	if block == nil || len(block.Evidence.Evidence) == 0 {
+		return nil
+	}
+	evidence := make([]Evidence, 0, len(block.Evidence.Evidence))
+	for _, ev := range block.Evidence.Evidence {
+		e := Evidence{
+			Type:              EvidenceUnknown,
+			Height:            block.Height,
+			Time:              block.Time,
+			MisbehaviorHeight: ev.Height(),
+			Validator:         ev.Address(),
+			Hash:              ev.Hash(),
+		}
+		if dv, ok := ev.(*tmtypes.DuplicateVoteEvidence); ok {
+			e.Type, e.VoteA, e.VoteB = EvidenceDuplicateVote, dv.VoteA, dv.VoteB
+		}
+		evidence = append(evidence, e)
+	}
+	return evidence
+}
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestBlockEvidence(t *testing.T) {
+	pub := ed25519.GenPrivKey().PubKey()
+	vote := func(hash byte) *tmtypes.Vote {
+		return &tmtypes.Vote{Type: tmtypes.PrevoteType, Height: 90, ValidatorAddress: pub.Address(),
+			BlockID: tmtypes.BlockID{Hash: []byte{hash}}}
+	}
+	block := &tmtypes.Block{
+		Header:   tmtypes.Header{Height: 100, Time: time.Unix(1600000000, 0)},
+		Evidence: tmtypes.EvidenceData{Evidence: tmtypes.EvidenceList{&tmtypes.DuplicateVoteEvidence{PubKey: pub, VoteA: vote(1), VoteB: vote(2)}}},
+	}
+
+	evidence := BlockEvidence(block)
+	assert.Len(t, evidence, 1)
+	e := evidence[0]
+	assert.Equal(t, EvidenceDuplicateVote, e.Type)
+	assert.Equal(t, int64(100), e.Height)
+	assert.Equal(t, int64(90), e.MisbehaviorHeight)
+	assert.Equal(t, block.Time, e.Time)
+	assert.True(t, e.Against(pub.Address()))
+	assert.False(t, e.Against(ed25519.GenPrivKey().PubKey().Address()))
+	assert.NotEmpty(t, e.Hash)
+	assert.Nil(t, BlockEvidence(&tmtypes.Block{}))
+}
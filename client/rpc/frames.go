@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"regexp"
+	"strings"
+)
+
+type FrameDirection int
+
+const (
+	FrameInbound FrameDirection = iota
+	FrameOutbound
+)
+
+func (d FrameDirection) String() string {
+	if d == FrameOutbound {
+		return "outbound"
+	}
+	return "inbound"
+}
+
+// FrameHook receives every raw websocket frame after redaction. It runs on the
+// read or write loop of the connection, so it must be fast and must not block.
+// Frames are pooled buffers, a hook that keeps one must copy it.
+type FrameHook func(direction FrameDirection, frame []byte)
+
+// Redactor rewrites a frame before it is handed to the FrameHook. It must not
+// modify the frame in place.
+type Redactor func(frame []byte) []byte
+
+const redacted = `"[REDACTED]"`
+
+// RedactJSONFields replaces the string values of the given JSON keys, at any depth.
+func RedactJSONFields(fields ...string) Redactor {
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		quoted[i] = regexp.QuoteMeta(field)
+	}
+	re := regexp.MustCompile(`("(?:` + strings.Join(quoted, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	return func(frame []byte) []byte {
+		return re.ReplaceAll(frame, []byte("${1}"+redacted))
+	}
+}
+
+// DefaultRedactor hides signed txs and signatures.
+var DefaultRedactor = RedactJSONFields("tx", "signature")
+
+type frameObserver struct {
+	hook   FrameHook
+	redact Redactor
+}
+
+// SetFrameHook installs a hook that receives every raw frame sent or received on
+// the websocket, to capture traces when responses fail to decode. Frames pass
+// redact first, a nil redact uses DefaultRedactor. A nil hook removes the hook.
+func (w *WSEvents) SetFrameHook(hook FrameHook, redact Redactor) {
+	if redact == nil {
+		redact = DefaultRedactor
+	}
+	w.frameObserver.Store(&frameObserver{hook: hook, redact: redact})
+}
+
+func (w *WSEvents) observeFrame(direction FrameDirection, frame []byte) {
+	observer, ok := w.frameObserver.Load().(*frameObserver)
+	if !ok || observer.hook == nil {
+		return
+	}
+	observer.hook(direction, observer.redact(frame))
+}
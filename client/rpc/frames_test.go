@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactJSONFields(t *testing.T) {
+	frame := []byte(`{"jsonrpc":"2.0","id":"1","method":"broadcast_tx_sync","params":{"tx":"3gHwYl3uCl\"q=="},"signature": "abc","memo":"tx"}`)
+	redactedFrame := string(DefaultRedactor(frame))
+	assert.Equal(t, `{"jsonrpc":"2.0","id":"1","method":"broadcast_tx_sync","params":{"tx":"[REDACTED]"},"signature": "[REDACTED]","memo":"tx"}`, redactedFrame)
+	assert.Contains(t, string(frame), "3gHwYl3uCl")
+}
+
+func TestFrameHook(t *testing.T) {
+	w := newWSEvents(nil, "tcp://127.0.0.1:27147", "/websocket")
+	w.observeFrame(FrameInbound, []byte(`{}`))
+
+	var got []string
+	w.SetFrameHook(func(direction FrameDirection, frame []byte) {
+		got = append(got, direction.String()+" "+string(frame))
+	}, nil)
+	w.observeFrame(FrameOutbound, []byte(`{"tx":"secret"}`))
+	assert.Equal(t, []string{`outbound {"tx":"[REDACTED]"}`}, got)
+
+	w.SetFrameHook(nil, nil)
+	w.observeFrame(FrameInbound, []byte(`{}`))
+	assert.Len(t, got, 1)
+}
@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// DefaultHeaderCacheSize is how many headers a client keeps by default.
+const DefaultHeaderCacheSize = 1000
+
+// GetHeader returns the header of the committed block at height, its app hash,
+// time and proposer, without fetching the txs of the block. Headers never
+// change once committed, the most recently used are cached, see
+// SetHeaderCacheSize.
+func (c *HTTP) GetHeader(height int64) (*tmtypes.Header, error) {
+	if err := ValidateHeight(&height); err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		return nil, fmt.Errorf("height must be set")
+	}
+	if header, ok := c.root().headers.get(height); ok {
+		return header, nil
+	}
+	var metas []*tmtypes.BlockMeta
+	err := c.withRetry(func() error {
+		res, err := c.WSEvents.BlockchainInfo(height, height)
+		if err == nil {
+			metas = res.BlockMetas
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, meta := range metas {
+		if meta != nil && meta.Header.Height == height {
+			header := meta.Header
+			c.root().headers.add(&header)
+			return &header, nil
+		}
+	}
+	return nil, fmt.Errorf("no header at height %d", height)
+}
+
+// SetHeaderCacheSize bounds the headers kept by GetHeader, 0 restores
+// DefaultHeaderCacheSize and a negative size disables the cache.
+func (c *HTTP) SetHeaderCacheSize(size int) {
+	c.root().headers.resize(size)
+}
+
+// headerCache keeps the most recently used headers by height. The zero value
+// holds up to DefaultHeaderCacheSize headers.
+type headerCache struct {
+	mtx        sync.Mutex
+	maxEntries int
+	entries    map[int64]*list.Element
+	order      *list.List
+}
+
+func (c *headerCache) get(height int64) (*tmtypes.Header, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	e, ok := c.entries[height]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return copyHeader(e.Value.(*tmtypes.Header)), true
+}
+
+func (c *headerCache) add(header *tmtypes.Header) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.maxEntries < 0 {
+		return
+	}
+	if c.entries == nil {
+		c.entries, c.order = make(map[int64]*list.Element), list.New()
+	}
+	if e, ok := c.entries[header.Height]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[header.Height] = c.order.PushFront(copyHeader(header))
+	c.evict()
+}
+
+func (c *headerCache) resize(size int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.maxEntries = size
+	if size < 0 {
+		c.entries, c.order = nil, nil
+		return
+	}
+	c.evict()
+}
+
+// copyHeader copies header with its hashes, so neither the cache nor its
+// callers see what the other does to a header.
+func copyHeader(header *tmtypes.Header) *tmtypes.Header {
+	h := *header
+	h.LastBlockID.Hash = cloneBytes(h.LastBlockID.Hash)
+	h.LastBlockID.PartsHeader.Hash = cloneBytes(h.LastBlockID.PartsHeader.Hash)
+	h.LastCommitHash = cloneBytes(h.LastCommitHash)
+	h.DataHash = cloneBytes(h.DataHash)
+	h.ValidatorsHash = cloneBytes(h.ValidatorsHash)
+	h.NextValidatorsHash = cloneBytes(h.NextValidatorsHash)
+	h.ConsensusHash = cloneBytes(h.ConsensusHash)
+	h.AppHash = cloneBytes(h.AppHash)
+	h.LastResultsHash = cloneBytes(h.LastResultsHash)
+	h.EvidenceHash = cloneBytes(h.EvidenceHash)
+	h.ProposerAddress = tmtypes.Address(cloneBytes(h.ProposerAddress))
+	return &h
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
+
+// evict drops the least recently used headers over the limit, c.mtx must be held.
+func (c *headerCache) evict() {
+	limit := c.maxEntries
+	if limit == 0 {
+		limit = DefaultHeaderCacheSize
+	}
+	for c.order != nil && c.order.Len() > limit {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*tmtypes.Header).Height)
+	}
+}
@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestHeaderCache(t *testing.T) {
+	c := &HTTP{}
+	c.SetHeaderCacheSize(2)
+	for height := int64(1); height <= 3; height++ {
+		c.headers.add(&tmtypes.Header{Height: height, AppHash: []byte{byte(height)}})
+	}
+	_, ok := c.headers.get(1)
+	assert.False(t, ok, "the least recently used header is evicted")
+
+	header, err := c.GetHeader(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2}, []byte(header.AppHash))
+	header.AppHash[0] = 9
+	cached, _ := c.headers.get(2)
+	assert.Equal(t, []byte{2}, []byte(cached.AppHash), "callers get a copy")
+
+	c.headers.add(&tmtypes.Header{Height: 4})
+	_, ok = c.headers.get(3)
+	assert.False(t, ok)
+	_, ok = c.headers.get(2)
+	assert.True(t, ok)
+
+	added := &tmtypes.Header{Height: 6, LastBlockID: tmtypes.BlockID{Hash: []byte{6}}}
+	c.headers.add(added)
+	added.LastBlockID.Hash[0] = 9
+	cached, _ = c.headers.get(6)
+	assert.Equal(t, []byte{6}, []byte(cached.LastBlockID.Hash), "the cache keeps a copy")
+
+	_, err = c.GetHeader(-1)
+	assert.Error(t, err)
+
+	c.SetHeaderCacheSize(-1)
+	c.headers.add(&tmtypes.Header{Height: 5})
+	_, ok = c.headers.get(5)
+	assert.False(t, ok, "the cache is disabled")
+}
@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/rpc/client"
+)
+
+// Hooks are called when the client sees degraded data quality. They run on the
+// goroutine that noticed the problem and must not block. Zero thresholds disable
+// the matching hook.
+type Hooks struct {
+	// SlowQueryThreshold is the latency above which OnSlowQuery is called.
+	SlowQueryThreshold time.Duration
+	// OnSlowQuery gets the rpc method, and the abci path for abci queries.
+	OnSlowQuery func(method, path string, elapsed time.Duration, err error)
+
+	// StaleHeightThreshold is how many blocks the node may lag behind its peers in CheckHeight.
+	StaleHeightThreshold int64
+	OnStaleHeight        func(nodeHeight, peerHeight int64)
+
+	// DecodeErrorThreshold errors within DecodeErrorWindow call OnDecodeErrorSpike.
+	DecodeErrorThreshold int
+	DecodeErrorWindow    time.Duration
+	OnDecodeErrorSpike   func(count int, lastErr error)
+
+	// OnThrottle is called when a gateway refuses the websocket handshake with
+	// 429, or 503 with Retry-After. The client waits retryAfter before dialing again.
+	OnThrottle func(retryAfter time.Duration)
+}
+
+type callInfoKey struct{}
+
+// callInfo is filled by WSClient.Call so SimpleCall can name the slow call.
+type callInfo struct {
+	method string
+	path   string
+}
+
+func withCallInfo(ctx context.Context) (context.Context, *callInfo) {
+	info := &callInfo{}
+	return context.WithValue(ctx, callInfoKey{}, info), info
+}
+
+func recordCallInfo(ctx context.Context, method string, params map[string]interface{}) {
+	if info, ok := ctx.Value(callInfoKey{}).(*callInfo); ok {
+		info.method = method
+		if path, ok := params["path"].(string); ok {
+			info.path = path
+		}
+	}
+}
+
+type monitor struct {
+	mtx          sync.Mutex
+	hooks        Hooks
+	decodeErrors []time.Time
+}
+
+func (m *monitor) getHooks() Hooks {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.hooks
+}
+
+func (m *monitor) observeCall(info *callInfo, elapsed time.Duration, err error) {
+	hooks := m.getHooks()
+	if hooks.OnSlowQuery != nil && hooks.SlowQueryThreshold > 0 && elapsed > hooks.SlowQueryThreshold {
+		hooks.OnSlowQuery(info.method, info.path, elapsed, err)
+	}
+}
+
+func (m *monitor) observeThrottle(retryAfter time.Duration) {
+	if hooks := m.getHooks(); hooks.OnThrottle != nil {
+		hooks.OnThrottle(retryAfter)
+	}
+}
+
+func (m *monitor) observeDecodeError(err error) {
+	m.mtx.Lock()
+	hooks := m.hooks
+	if hooks.OnDecodeErrorSpike == nil || hooks.DecodeErrorThreshold <= 0 {
+		m.mtx.Unlock()
+		return
+	}
+	now := time.Now()
+	recent := m.decodeErrors[:0]
+	for _, t := range m.decodeErrors {
+		if now.Sub(t) < hooks.DecodeErrorWindow {
+			recent = append(recent, t)
+		}
+	}
+	recent = append(recent, now)
+	count := len(recent)
+	if count >= hooks.DecodeErrorThreshold {
+		// start counting anew so a lasting problem is reported once per threshold
+		recent = recent[:0]
+	}
+	m.decodeErrors = recent
+	m.mtx.Unlock()
+	if count >= hooks.DecodeErrorThreshold {
+		hooks.OnDecodeErrorSpike(count, err)
+	}
+}
+
+// SetHooks replaces the data quality hooks of the client.
+func (w *WSEvents) SetHooks(hooks Hooks) {
+	w.monitor.mtx.Lock()
+	defer w.monitor.mtx.Unlock()
+	w.monitor.hooks = hooks
+	w.monitor.decodeErrors = nil
+}
+
+// ReportDecodeError feeds a decode error seen outside the client, like in an
+// indexer, into the decode error spike detection. Errors decoding rpc responses
+// and events are counted by the client itself.
+func (w *WSEvents) ReportDecodeError(err error) {
+	w.monitor.observeDecodeError(err)
+}
+
+// CheckHeight compares the latest height of the node with the highest one of the
+// peers and calls OnStaleHeight if the node lags by more than StaleHeightThreshold.
+// It returns the lag, peers that fail to answer are skipped.
+func (w *WSEvents) CheckHeight(peers ...client.StatusClient) (int64, error) {
+	status, err := w.Status()
+	if err != nil {
+		return 0, err
+	}
+	nodeHeight := status.SyncInfo.LatestBlockHeight
+	var peerHeight int64
+	for _, peer := range peers {
+		peerStatus, err := peer.Status()
+		if err != nil {
+			continue
+		}
+		if h := peerStatus.SyncInfo.LatestBlockHeight; h > peerHeight {
+			peerHeight = h
+		}
+	}
+	lag := peerHeight - nodeHeight
+	if lag < 0 {
+		lag = 0
+	}
+	hooks := w.monitor.getHooks()
+	if hooks.OnStaleHeight != nil && hooks.StaleHeightThreshold > 0 && lag > hooks.StaleHeightThreshold {
+		hooks.OnStaleHeight(nodeHeight, peerHeight)
+	}
+	return lag, nil
+}
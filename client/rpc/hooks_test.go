@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeErrorSpike(t *testing.T) {
+	m := &monitor{}
+	var spikes []int
+	m.hooks = Hooks{
+		DecodeErrorThreshold: 3,
+		DecodeErrorWindow:    time.Minute,
+		OnDecodeErrorSpike:   func(count int, lastErr error) { spikes = append(spikes, count) },
+	}
+	for i := 0; i < 7; i++ {
+		m.observeDecodeError(errors.New("bad json"))
+	}
+	assert.Equal(t, []int{3, 3}, spikes)
+}
+
+func TestSlowQuery(t *testing.T) {
+	m := &monitor{}
+	var slow []string
+	m.hooks = Hooks{
+		SlowQueryThreshold: time.Second,
+		OnSlowQuery: func(method, path string, elapsed time.Duration, err error) {
+			slow = append(slow, method+" "+path)
+		},
+	}
+	m.observeCall(&callInfo{method: "status"}, time.Millisecond, nil)
+	m.observeCall(&callInfo{method: "abci_query", path: "/account/bnb1"}, 2*time.Second, nil)
+	assert.Equal(t, []string{"abci_query /account/bnb1"}, slow)
+}
@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"bytes"
+	"strings"
+
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// SignMsg signs m into a tx ready for BroadcastIdempotent. Unless the options
+// set them, the account number and sequence are queried.
+func (c *HTTP) SignMsg(m msg.Msg, options ...tx.Option) ([]byte, error) {
+	return c.sign(m, options...)
+}
+
+// BroadcastIdempotent broadcasts a signed tx unless the node already knows it,
+// so it is safe to call again with the same bytes after an ambiguous network
+// error. A committed tx returns its deliver result. A tx waiting in the mempool
+// returns code 0 and no data whatever the syncType, it passed CheckTx already.
+func (c *HTTP) BroadcastIdempotent(signedTx []byte, syncType SyncType) (*core_types.ResultBroadcastTx, error) {
+	if res, err := c.knownTx(signedTx); err != nil || res != nil {
+		return res, err
+	}
+	res, err := c.broadcastSigned(signedTx, syncType)
+	if err != nil && strings.Contains(err.Error(), "already exists in cache") {
+		// a previous attempt reached the node after all
+		return &core_types.ResultBroadcastTx{Hash: tx.Hash(signedTx)}, nil
+	}
+	return res, err
+}
+
+// knownTx looks the tx up in the committed txs and the mempool of the node. It
+// returns nil if the node does not know the tx.
+func (c *HTTP) knownTx(signedTx []byte) (*core_types.ResultBroadcastTx, error) {
+	hash := tx.Hash(signedTx)
+	committed, err := c.Tx(hash, false)
+	if err == nil {
+		return &core_types.ResultBroadcastTx{
+			Code: committed.TxResult.Code,
+			Data: committed.TxResult.Data,
+			Log:  committed.TxResult.Log,
+			Hash: hash,
+		}, nil
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		return nil, err
+	}
+	pending, err := c.UnconfirmedTxs(maxUnConfirmedTxs)
+	if err != nil {
+		return nil, err
+	}
+	// only the head of a long mempool is listed, a tx further back is caught
+	// by the cache error of the node on broadcast
+	for _, pendingTx := range pending.Txs {
+		if bytes.Equal(pendingTx, signedTx) {
+			return &core_types.ResultBroadcastTx{Hash: hash}, nil
+		}
+	}
+	return nil, nil
+}
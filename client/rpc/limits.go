@@ -0,0 +1,204 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/tendermint/go-amino"
+
+	gtypes "github.com/binance-chain/go-sdk/types"
+)
+
+// DefaultMaxDecodeSize caps the abci query values handed to the codec. It is
+// lower than the frame limit because decoding can take many times the memory
+// of the encoded value.
+const DefaultMaxDecodeSize = 4 * 1024 * 1024
+
+// SetMaxResponseSize caps the size of a single websocket frame read from the
+// node. Larger frames are dropped, so the call waiting for one fails with a
+// timeout instead of exhausting memory. n <= 0 restores the default.
+func (w *WSEvents) SetMaxResponseSize(n int64) {
+	atomic.StoreInt64(&w.responseSizeLimit, n)
+}
+
+func (w *WSEvents) maxResponseSize() int64 {
+	if n := atomic.LoadInt64(&w.responseSizeLimit); n > 0 {
+		return n
+	}
+	return gtypes.DefaultMaxResponseSize
+}
+
+// SetMaxDecodeSize caps the size of abci query values, queries returning a
+// larger value fail with ExceedResponseSizeError. n <= 0 restores the default.
+func (w *WSEvents) SetMaxDecodeSize(n int64) {
+	atomic.StoreInt64(&w.decodeSizeLimit, n)
+}
+
+func (w *WSEvents) maxDecodeSize() int64 {
+	if n := atomic.LoadInt64(&w.decodeSizeLimit); n > 0 {
+		return n
+	}
+	return DefaultMaxDecodeSize
+}
+
+func (c *WSClient) maxFrameSize() int64 {
+	if c.readLimit == nil {
+		return gtypes.DefaultMaxResponseSize
+	}
+	return c.readLimit()
+}
+
+// readLimited reads r to the end into buf, failing once more than limit bytes
+// are read.
+func readLimited(buf *bytes.Buffer, r io.Reader, limit int64) ([]byte, error) {
+	if _, err := buf.ReadFrom(io.LimitReader(r, limit+1)); err != nil {
+		return nil, err
+	}
+	if int64(buf.Len()) > limit {
+		return nil, ExceedResponseSizeError
+	}
+	return buf.Bytes(), nil
+}
+
+const (
+	// maxDecodeElements caps the elements of a repeated field of a decoded
+	// value. An empty element takes two bytes on the wire but a whole zero
+	// value once decoded, a value of many makes the codec allocate many times
+	// its size.
+	maxDecodeElements = 100000
+	// maxAminoDepth bounds the nesting checkAminoBounds follows.
+	maxAminoDepth = 32
+	// prefixLength is the length of the prefix of registered concrete types.
+	prefixLength = 4
+)
+
+// aminoFieldsError is a value that does not parse as amino fields, the codec
+// reports it when it decodes the value.
+type aminoFieldsError struct{}
+
+func (aminoFieldsError) Error() string { return "not amino fields" }
+
+// checkAminoBounds walks the amino fields of bz before they are decoded: every
+// length prefix must fit in the bytes left and no repeated field may have more
+// than maxDecodeElements elements. Length prefixed fields that parse as fields
+// themselves are followed, the others are bytes or strings. Values that are
+// no amino fields are left to the codec to reject.
+func checkAminoBounds(bz []byte) error {
+	return boundsError(scanAminoFields(bz, 0, true))
+}
+
+// boundsError drops err unless it is a broken bound.
+func boundsError(err error) error {
+	if _, ok := err.(aminoFieldsError); ok {
+		return nil
+	}
+	return err
+}
+
+// aminoPrefixLength returns the length of the prefix in front of the fields of
+// a value decoded into ptr: the one of any registered concrete type for an
+// interface, the one of the type if it is registered.
+func aminoPrefixLength(cdc *amino.Codec, ptr interface{}) int {
+	t := reflect.TypeOf(ptr).Elem()
+	if t.Kind() == reflect.Interface {
+		return prefixLength
+	}
+	// a registered type encodes its zero value as its prefix alone
+	if zero, err := cdc.MarshalBinaryBare(reflect.Zero(t).Interface()); err == nil && len(zero) == prefixLength {
+		return prefixLength
+	}
+	return 0
+}
+
+// scanAminoFields checks the fields of bz. A length prefix beyond the bytes
+// left breaks a bound at the top level, where bz is known to be amino, and
+// makes nested bytes opaque.
+func scanAminoFields(bz []byte, depth int, top bool) error {
+	counts := map[uint64]int{}
+	var nested [][]byte
+	for len(bz) > 0 {
+		key, n := binary.Uvarint(bz)
+		if n <= 0 {
+			return aminoFieldsError{}
+		}
+		bz = bz[n:]
+		switch amino.Typ3(key & 0x07) {
+		case amino.Typ3_Varint:
+			if _, n = binary.Uvarint(bz); n <= 0 {
+				return aminoFieldsError{}
+			}
+			bz = bz[n:]
+		case amino.Typ3_8Byte:
+			if len(bz) < 8 {
+				return aminoFieldsError{}
+			}
+			bz = bz[8:]
+		case amino.Typ3_4Byte:
+			if len(bz) < 4 {
+				return aminoFieldsError{}
+			}
+			bz = bz[4:]
+		case amino.Typ3_ByteLength:
+			length, n := binary.Uvarint(bz)
+			if n <= 0 {
+				return aminoFieldsError{}
+			}
+			bz = bz[n:]
+			if length > uint64(len(bz)) {
+				if top {
+					return ExceedFieldLengthError
+				}
+				return aminoFieldsError{}
+			}
+			counts[key>>3]++
+			if counts[key>>3] > maxDecodeElements {
+				return ExceedElementCountError
+			}
+			nested = append(nested, bz[:length])
+			bz = bz[length:]
+		default:
+			return aminoFieldsError{}
+		}
+	}
+	if depth >= maxAminoDepth {
+		return nil
+	}
+	for _, field := range nested {
+		// interface values start with a prefix, only one of the forms parses
+		// past its own fields so a field is followed once
+		err := scanAminoFields(field, depth+1, false)
+		if _, opaque := err.(aminoFieldsError); opaque && len(field) >= prefixLength {
+			err = scanAminoFields(field[prefixLength:], depth+1, false)
+		}
+		if boundsError(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalBinaryBare is cdc.UnmarshalBinaryBare of a value that passed
+// checkAminoBounds.
+func unmarshalBinaryBare(cdc *amino.Codec, bz []byte, ptr interface{}) error {
+	if n := aminoPrefixLength(cdc, ptr); len(bz) >= n {
+		if err := checkAminoBounds(bz[n:]); err != nil {
+			return err
+		}
+	}
+	return cdc.UnmarshalBinaryBare(bz, ptr)
+}
+
+// unmarshalBinaryLengthPrefixed is cdc.UnmarshalBinaryLengthPrefixed of a
+// value that passed checkAminoBounds.
+func unmarshalBinaryLengthPrefixed(cdc *amino.Codec, bz []byte, ptr interface{}) error {
+	length, n := binary.Uvarint(bz)
+	if prefix := aminoPrefixLength(cdc, ptr); n > 0 && length <= uint64(len(bz)-n) && length >= uint64(prefix) {
+		if err := checkAminoBounds(bz[n+prefix : n+int(length)]); err != nil {
+			return err
+		}
+	}
+	return cdc.UnmarshalBinaryLengthPrefixed(bz, ptr)
+}
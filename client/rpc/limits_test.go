@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	gtypes "github.com/binance-chain/go-sdk/types"
+)
+
+func TestReadLimited(t *testing.T) {
+	var buf bytes.Buffer
+	data, err := readLimited(&buf, bytes.NewReader([]byte("0123456789")), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789", string(data))
+
+	buf.Reset()
+	_, err = readLimited(&buf, bytes.NewReader([]byte("0123456789a")), 10)
+	assert.Equal(t, ExceedResponseSizeError, err)
+}
+
+func TestResponseSizeLimits(t *testing.T) {
+	w := newWSEvents(nil, "tcp://127.0.0.1:27147", "/websocket")
+	assert.Equal(t, int64(gtypes.DefaultMaxResponseSize), w.maxResponseSize())
+	assert.Equal(t, int64(DefaultMaxDecodeSize), w.maxDecodeSize())
+
+	w.SetMaxResponseSize(1024)
+	w.SetMaxDecodeSize(512)
+	c := NewWSClient(w.remote, w.endpoint, w.responsesCh, setReadLimit(w.maxResponseSize))
+	assert.Equal(t, int64(1024), c.maxFrameSize())
+	assert.Equal(t, int64(512), w.maxDecodeSize())
+
+	w.SetMaxResponseSize(0)
+	assert.Equal(t, int64(gtypes.DefaultMaxResponseSize), c.maxFrameSize())
+}
+
+func TestAminoBounds(t *testing.T) {
+	cdc := gtypes.NewCodec()
+	owner := types.AccAddress(bytes.Repeat([]byte{1}, types.AddrLen))
+	tokens := []types.Token{{Name: "Binance Chain Native Token", Symbol: "BNB", TotalSupply: 1e16, Owner: owner}, {Symbol: "XYZ-000", Owner: owner}}
+	bz, err := cdc.MarshalBinaryLengthPrefixed(tokens)
+	assert.NoError(t, err)
+	var decoded []types.Token
+	assert.NoError(t, unmarshalBinaryLengthPrefixed(cdc, bz, &decoded))
+	assert.Equal(t, tokens, decoded)
+
+	// registered concrete types start with their prefix
+	var acc types.Account = &types.AppAccount{BaseAccount: types.BaseAccount{
+		Address: owner, Coins: types.Coins{{Denom: "BNB", Amount: 1}}, PubKey: secp256k1.GenPrivKey().PubKey(), Sequence: 3}}
+	bz, err = cdc.MarshalBinaryBare(acc)
+	assert.NoError(t, err)
+	var decodedAcc types.Account
+	assert.NoError(t, unmarshalBinaryBare(cdc, bz, &decodedAcc))
+	assert.Equal(t, acc, decodedAcc)
+
+	// a crafted length prefix is rejected before anything is allocated
+	crafted := append([]byte{0x0a, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, "BNB"...)
+	assert.Equal(t, ExceedFieldLengthError, unmarshalBinaryBare(cdc, crafted, &types.TradingPair{}))
+	tokenPrefix, err := cdc.MarshalBinaryBare(types.Token{})
+	assert.NoError(t, err)
+	crafted = append(tokenPrefix, crafted...)
+	assert.Equal(t, ExceedFieldLengthError, unmarshalBinaryBare(cdc, crafted, &types.Token{}))
+	assert.Equal(t, ExceedFieldLengthError, unmarshalBinaryBare(cdc, crafted, &decodedAcc))
+	prefixed := append([]byte{byte(len(crafted))}, crafted...)
+	assert.Equal(t, ExceedFieldLengthError, unmarshalBinaryLengthPrefixed(cdc, prefixed, &types.Token{}))
+
+	// so are more empty elements than a list may have, nested or not
+	flood := bytes.Repeat([]byte{0x0a, 0x00}, maxDecodeElements+1)
+	assert.Equal(t, ExceedElementCountError, unmarshalBinaryBare(cdc, flood, &decoded))
+	length := make([]byte, binary.MaxVarintLen64)
+	nested := append([]byte{0x12}, length[:binary.PutUvarint(length, uint64(len(flood)))]...)
+	assert.Equal(t, ExceedElementCountError, checkAminoBounds(append(nested, flood...)))
+	assert.NoError(t, checkAminoBounds(flood[:2*maxDecodeElements]))
+
+	// values that are no amino fields are left to the codec
+	assert.NoError(t, checkAminoBounds([]byte{0x07}))
+}
+
+func TestParseTxRejectsOversizedTx(t *testing.T) {
+	_, err := ParseTx(nil, make([]byte, maxTxLength+1))
+	assert.Equal(t, ExceedTxLengthError, err)
+}
+
+func TestDialHonorsRetryAfter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	var retryAfter time.Duration
+	c := NewWSClient("tcp://"+srv.Listener.Addr().String(), "/websocket", nil, setOnThrottle(func(d time.Duration) { retryAfter = d }))
+	err := c.dial()
+	assert.IsType(t, &gtypes.ThrottledError{}, err)
+	assert.Equal(t, 30*time.Second, retryAfter)
+	assert.True(t, c.retryAt.After(time.Now().Add(29*time.Second)))
+}
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// SubscriptionMetrics describes how well a subscription keeps up with the chain.
+// Lag is the time between a block and the delivery of its event, it is only known
+// for block and block header events.
+type SubscriptionMetrics struct {
+	Query        string        `json:"query"`
+	Delivered    uint64        `json:"delivered"`
+	Dropped      uint64        `json:"dropped"`
+	DecodeErrors uint64        `json:"decode_errors"`
+	LastLag      time.Duration `json:"last_lag"`
+	MaxLag       time.Duration `json:"max_lag"`
+	LastDelivery time.Time     `json:"last_delivery"`
+}
+
+type subscriptionStats struct {
+	mtx     sync.Mutex
+	metrics SubscriptionMetrics
+}
+
+func newSubscriptionStats(query string) *subscriptionStats {
+	return &subscriptionStats{metrics: SubscriptionMetrics{Query: query}}
+}
+
+func (s *subscriptionStats) delivered(event ctypes.ResultEvent) {
+	now := time.Now()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.metrics.Delivered++
+	s.metrics.LastDelivery = now
+	if blockTime, ok := eventBlockTime(event); ok {
+		lag := now.Sub(blockTime)
+		s.metrics.LastLag = lag
+		if lag > s.metrics.MaxLag {
+			s.metrics.MaxLag = lag
+		}
+	}
+}
+
+func (s *subscriptionStats) dropped() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.metrics.Dropped++
+}
+
+func (s *subscriptionStats) decodeError() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.metrics.DecodeErrors++
+}
+
+func (s *subscriptionStats) snapshot() SubscriptionMetrics {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.metrics
+}
+
+func eventBlockTime(event ctypes.ResultEvent) (time.Time, bool) {
+	switch data := event.Data.(type) {
+	case types.EventDataNewBlock:
+		if data.Block != nil {
+			return data.Block.Header.Time, true
+		}
+	case types.EventDataNewBlockHeader:
+		return data.Header.Time, true
+	}
+	return time.Time{}, false
+}
+
+func (w *WSEvents) subscriptionStats(id interface{}) *subscriptionStats {
+	if stats, ok := w.subscriptionStatsMap.Load(id); ok {
+		return stats.(*subscriptionStats)
+	}
+	return nil
+}
+
+// SubscriptionMetrics returns the metrics of the active subscriptions, sorted by
+// query. Metrics are reset when a query is unsubscribed.
+func (w *WSEvents) SubscriptionMetrics() []SubscriptionMetrics {
+	var metrics []SubscriptionMetrics
+	w.subscriptionStatsMap.Range(func(_, stats interface{}) bool {
+		metrics = append(metrics, stats.(*subscriptionStats).snapshot())
+		return true
+	})
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Query < metrics[j].Query })
+	return metrics
+}
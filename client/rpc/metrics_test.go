@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSubscriptionStats(t *testing.T) {
+	stats := newSubscriptionStats(newBlockHeaderQuery)
+	header := types.EventDataNewBlockHeader{}
+	header.Header.Time = time.Now().Add(-2 * time.Second)
+	stats.delivered(ctypes.ResultEvent{Data: header})
+	header.Header.Time = time.Now().Add(-time.Second)
+	stats.delivered(ctypes.ResultEvent{Data: header})
+	stats.delivered(ctypes.ResultEvent{Data: types.EventDataTx{}})
+	stats.dropped()
+	stats.decodeError()
+
+	m := stats.snapshot()
+	assert.Equal(t, newBlockHeaderQuery, m.Query)
+	assert.Equal(t, uint64(3), m.Delivered)
+	assert.Equal(t, uint64(1), m.Dropped)
+	assert.Equal(t, uint64(1), m.DecodeErrors)
+	assert.True(t, m.MaxLag >= 2*time.Second)
+	assert.True(t, m.LastLag >= time.Second && m.LastLag < m.MaxLag)
+}
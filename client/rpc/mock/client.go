@@ -2,6 +2,7 @@ package mock
 
 import (
 	"github.com/binance-chain/go-sdk/client/rpc"
+	"fmt"
 	"reflect"
 
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -127,6 +128,17 @@ func (c Client) Commit(height *int64) (*ctypes.ResultCommit, error) {
 	return core.Commit(&rpctypes.Context{}, height)
 }
 
+func (c Client) GetHeader(height int64) (*types.Header, error) {
+	res, err := core.BlockchainInfo(&rpctypes.Context{}, height, height)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.BlockMetas) == 0 {
+		return nil, fmt.Errorf("no header at height %d", height)
+	}
+	return &res.BlockMetas[0].Header, nil
+}
+
 func (c Client) Validators(height *int64) (*ctypes.ResultValidators, error) {
 	return core.Validators(&rpctypes.Context{}, height)
 }
@@ -18,7 +18,7 @@ func (c *HTTP) GetStakeValidators() ([]types.Validator, error) {
 		return nil, err
 	}
 	var validators []types.Validator
-	err = c.cdc.UnmarshalJSON(rawVal.Response.GetValue(), &validators)
+	err = c.unmarshalNodeJSON(rawVal.Response.GetValue(), &validators)
 	return validators, err
 
 }
@@ -37,7 +37,7 @@ func (c *HTTP) GetDelegatorUnbondingDelegations(delegatorAddr types.AccAddress)
 		return nil, err
 	}
 	var unbondingDelegations []types.UnbondingDelegation
-	err = c.cdc.UnmarshalJSON(rawDel.Response.GetValue(), &unbondingDelegations)
+	err = c.unmarshalNodeJSON(rawDel.Response.GetValue(), &unbondingDelegations)
 	return unbondingDelegations, err
 
 }
@@ -0,0 +1,199 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	ntypes "github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	gtypes "github.com/binance-chain/go-sdk/types"
+)
+
+// Option configures a client built by NewClient.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	network         *ntypes.ChainNetwork
+	wsEndpoint      string
+	timeout         time.Duration
+	logger          log.Logger
+	retry           RetryPolicy
+	keyManager      keys.KeyManager
+	hooks           *Hooks
+	maxResponseSize int64
+	maxDecodeSize   int64
+	readAfterWrite  bool
+	screener        Screener
+	isolated        bool
+}
+
+// WithNetwork selects the network of the client. Like NewRPCClient it sets the
+// process wide types.Network used to format addresses.
+func WithNetwork(network ntypes.ChainNetwork) Option {
+	return func(o *clientOptions) {
+		o.network = &network
+		o.isolated = false
+	}
+}
+
+// WithIsolatedNetwork selects the network of the client without touching
+// types.Network, so clients of different networks can share a process. The
+// client signs for and queries addresses of its own network.
+func WithIsolatedNetwork(network ntypes.ChainNetwork) Option {
+	return func(o *clientOptions) {
+		o.network = &network
+		o.isolated = true
+	}
+}
+
+// WithWSEndpoint overrides the websocket path of the node, "/websocket" by default.
+func WithWSEndpoint(endpoint string) Option {
+	return func(o *clientOptions) {
+		o.wsEndpoint = endpoint
+	}
+}
+
+// WithTimeout bounds every call, DefaultTimeout by default.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+func WithLogger(logger log.Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithRetry retries failed reads, see RetryPolicy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retry = policy
+	}
+}
+
+func WithKeyManager(k keys.KeyManager) Option {
+	return func(o *clientOptions) {
+		o.keyManager = k
+	}
+}
+
+func WithHooks(hooks Hooks) Option {
+	return func(o *clientOptions) {
+		o.hooks = &hooks
+	}
+}
+
+// WithMaxResponseSize caps websocket frames, see SetMaxResponseSize.
+func WithMaxResponseSize(n int64) Option {
+	return func(o *clientOptions) {
+		o.maxResponseSize = n
+	}
+}
+
+// WithMaxDecodeSize caps abci query values, see SetMaxDecodeSize.
+func WithMaxDecodeSize(n int64) Option {
+	return func(o *clientOptions) {
+		o.maxDecodeSize = n
+	}
+}
+
+// WithReadAfterWrite makes reads see the txs broadcast in commit mode, see
+// SetReadAfterWrite.
+func WithReadAfterWrite() Option {
+	return func(o *clientOptions) {
+		o.readAfterWrite = true
+	}
+}
+
+// WithScreener runs the txs the client broadcasts through s, see SetScreener.
+func WithScreener(s Screener) Option {
+	return func(o *clientOptions) {
+		o.screener = s
+	}
+}
+
+// NewClient connects to the node at nodeURI, in the form tcp://<host>:<port>.
+// Options not given keep the defaults of NewRPCClient, so new ones can be added
+// without breaking callers.
+func NewClient(nodeURI string, options ...Option) *HTTP {
+	c := newClient(nodeURI, options...)
+	c.Start()
+	return c
+}
+
+func newClient(nodeURI string, options ...Option) *HTTP {
+	o := clientOptions{wsEndpoint: "/websocket", timeout: DefaultTimeout}
+	for _, option := range options {
+		option(&o)
+	}
+	if o.network != nil && !o.isolated {
+		ntypes.Network = *o.network
+	}
+	c := newHTTP(nodeURI, o.wsEndpoint)
+	c.network = o.network
+	c.SetTimeOut(o.timeout)
+	c.SetRetry(o.retry)
+	c.SetMaxResponseSize(o.maxResponseSize)
+	c.SetMaxDecodeSize(o.maxDecodeSize)
+	c.SetReadAfterWrite(o.readAfterWrite)
+	if o.logger != nil {
+		c.SetLogger(o.logger)
+	}
+	if o.keyManager != nil {
+		c.SetKeyManager(o.keyManager)
+	}
+	if o.hooks != nil {
+		c.SetHooks(*o.hooks)
+	}
+	c.SetScreener(o.screener)
+	return c
+}
+
+// RetryPolicy retries reads, abci queries and status, that failed on the way
+// to the node: network errors, timeouts and unavailable or throttled gateways.
+// Broadcasts are never retried. The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts counts the first call too.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, doubled for each further one.
+	// A throttled call waits at least as long as the gateway asked.
+	Backoff time.Duration
+	// MaxBackoff caps the wait, 0 means no cap.
+	MaxBackoff time.Duration
+}
+
+// SetRetry replaces the retry policy of the client.
+func (c *HTTP) SetRetry(policy RetryPolicy) {
+	c.retry = policy
+}
+
+func (c *HTTP) withRetry(call func() error) error {
+	policy := c.retry
+	backoff := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(err) {
+			return err
+		}
+		wait := backoff
+		if retryAfter, ok := gtypes.RetryAfter(err); ok && retryAfter > wait {
+			wait = retryAfter
+		}
+		if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+			wait = policy.MaxBackoff
+		}
+		time.Sleep(wait)
+		backoff *= 2
+	}
+}
+
+func retryable(err error) bool {
+	switch gtypes.Classify(err) {
+	case gtypes.ErrorClassNetwork, gtypes.ErrorClassTimeout, gtypes.ErrorClassUnavailable:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/client/screen"
+	ntypes "github.com/binance-chain/go-sdk/common/types"
+	gtypes "github.com/binance-chain/go-sdk/types"
+)
+
+func TestNewClientAppliesOptions(t *testing.T) {
+	network := ntypes.Network
+	defer func() { ntypes.Network = network }()
+
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+	screener := screen.NewChain()
+	c := newClient("tcp://127.0.0.1:1", WithNetwork(ntypes.TestNetwork), WithTimeout(time.Second), WithRetry(policy), WithMaxDecodeSize(512), WithScreener(screener))
+	assert.Equal(t, ntypes.TestNetwork, ntypes.Network)
+	assert.Equal(t, time.Second, c.timeout)
+	assert.Equal(t, policy, c.retry)
+	assert.Equal(t, int64(512), c.maxDecodeSize())
+	assert.Equal(t, "/websocket", c.endpoint)
+	assert.Equal(t, ntypes.TestNetwork, c.Network())
+	assert.Equal(t, screener, c.screener)
+
+	c = newClient("tcp://127.0.0.1:1", WithIsolatedNetwork(ntypes.ProdNetwork))
+	assert.Equal(t, ntypes.TestNetwork, ntypes.Network, "the process wide network is left alone")
+	assert.Equal(t, ntypes.ProdNetwork, c.Network())
+}
+
+func TestWithRetry(t *testing.T) {
+	c := &HTTP{retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}}
+	unavailable := gtypes.NewError(gtypes.ErrorClassUnavailable, errors.New("bad gateway"))
+
+	calls := 0
+	err := c.withRetry(func() error {
+		calls++
+		if calls < 3 {
+			return unavailable
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = c.withRetry(func() error {
+		calls++
+		return unavailable
+	})
+	assert.Equal(t, unavailable, err)
+	assert.Equal(t, 3, calls)
+
+	// errors from the chain are final
+	calls = 0
+	rejected := errors.New("invalid path")
+	assert.Equal(t, rejected, c.withRetry(func() error {
+		calls++
+		return rejected
+	}))
+	assert.Equal(t, 1, calls)
+}
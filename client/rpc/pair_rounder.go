@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+const defaultPairCacheTTL = time.Minute
+
+// PairRounder rounds prices and quantities to the tick and lot sizes of trading
+// pairs. The sizes change on chain, so the pair list is cached for ttl only.
+type PairRounder struct {
+	client QueryClient
+	ttl    time.Duration
+
+	mtx       sync.Mutex
+	pairs     map[string]types.TradingPair
+	updatedAt time.Time
+}
+
+// NewPairRounder creates a rounder backed by the given client. A non-positive ttl
+// falls back to one minute.
+func NewPairRounder(client QueryClient, ttl time.Duration) *PairRounder {
+	if ttl <= 0 {
+		ttl = defaultPairCacheTTL
+	}
+	return &PairRounder{client: client, ttl: ttl}
+}
+
+// RoundToTick rounds price to the current tick size of pair, e.g. "BNB_BUSD-BD1".
+func (r *PairRounder) RoundToTick(price int64, pair string, mode types.RoundingMode) (int64, error) {
+	p, err := r.Pair(pair)
+	if err != nil {
+		return 0, err
+	}
+	return p.RoundPrice(price, mode)
+}
+
+// RoundToLot rounds quantity to the current lot size of pair.
+func (r *PairRounder) RoundToLot(quantity int64, pair string, mode types.RoundingMode) (int64, error) {
+	p, err := r.Pair(pair)
+	if err != nil {
+		return 0, err
+	}
+	return p.RoundQuantity(quantity, mode)
+}
+
+// Pair returns the cached trading pair, reloading the pair list if it is stale
+// or does not have the pair yet.
+func (r *PairRounder) Pair(pair string) (types.TradingPair, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if p, ok := r.pairs[pair]; ok && time.Since(r.updatedAt) <= r.ttl {
+		return p, nil
+	}
+	if err := r.refresh(); err != nil {
+		return types.TradingPair{}, err
+	}
+	p, ok := r.pairs[pair]
+	if !ok {
+		return types.TradingPair{}, PairNotFoundError
+	}
+	return p, nil
+}
+
+func (r *PairRounder) refresh() error {
+	pairs := make(map[string]types.TradingPair)
+	for _, list := range []func(offset, limit int) ([]types.TradingPair, error){r.client.GetTradingPairs, r.client.GetMiniTradingPairs} {
+		for offset := 0; ; offset += symbolListPageSize {
+			page, err := list(offset, symbolListPageSize)
+			if err != nil {
+				return err
+			}
+			for _, p := range page {
+				pairs[common.CombineSymbol(p.BaseAssetSymbol, p.QuoteAssetSymbol)] = p
+			}
+			if len(page) < symbolListPageSize {
+				break
+			}
+		}
+	}
+	r.pairs = pairs
+	r.updatedAt = time.Now()
+	return nil
+}
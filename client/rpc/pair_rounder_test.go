@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+type pairsClient struct {
+	DexClient
+	pairs, miniPairs []types.TradingPair
+	calls            int
+}
+
+func (c *pairsClient) GetTradingPairs(offset int, limit int) ([]types.TradingPair, error) {
+	c.calls++
+	return c.pairs, nil
+}
+
+func (c *pairsClient) GetMiniTradingPairs(offset int, limit int) ([]types.TradingPair, error) {
+	return c.miniPairs, nil
+}
+
+func TestPairRounder(t *testing.T) {
+	c := &pairsClient{
+		pairs:     []types.TradingPair{{BaseAssetSymbol: "BNB", QuoteAssetSymbol: "BUSD-BD1", TickSize: 1e5, LotSize: 1e6}},
+		miniPairs: []types.TradingPair{{BaseAssetSymbol: "XYZ-000M", QuoteAssetSymbol: "BNB", TickSize: 10, LotSize: 1e8}},
+	}
+	r := NewPairRounder(c, 0)
+
+	price, err := r.RoundToTick(1234567, "BNB_BUSD-BD1", types.RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1200000), price)
+	price, err = r.RoundToTick(1234567, "BNB_BUSD-BD1", types.RoundUp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1300000), price)
+	price, err = r.RoundToTick(1250000, "BNB_BUSD-BD1", types.RoundNearest)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1300000), price)
+
+	qty, err := r.RoundToLot(149999999, "XYZ-000M_BNB", types.RoundNearest)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1e8), qty)
+	assert.Equal(t, 1, c.calls)
+
+	_, err = r.RoundToLot(1, "ABC_BNB", types.RoundDown)
+	assert.Equal(t, PairNotFoundError, err)
+	_, err = r.RoundToTick(-1, "BNB_BUSD-BD1", types.RoundDown)
+	assert.Error(t, err)
+}
@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The params GetParams returns. The node serves no params query for the other
+// modules, like dex, tokens or gov.
+const (
+	// FeeParamsModule are the fees of param/fees, as []types.FeeParam.
+	FeeParamsModule = "fees"
+	// SideChainParamsModule, followed by "/<side chain id>", are the params of
+	// a side chain from param/sideParams, as *msg.SideChainParams.
+	SideChainParamsModule = "sideParams"
+)
+
+var UnsupportedParamsModuleError = fmt.Errorf("the node serves no params of the module, only %s and %s/<side chain id>", FeeParamsModule, SideChainParamsModule)
+
+// GetParams returns the live params of a module, see FeeParamsModule and
+// SideChainParamsModule. Other modules fail with UnsupportedParamsModuleError.
+func (c *HTTP) GetParams(module string) (interface{}, error) {
+	if module == FeeParamsModule {
+		return c.GetFee()
+	}
+	if sideChainId := strings.TrimPrefix(module, SideChainParamsModule+"/"); sideChainId != module && sideChainId != "" {
+		return c.GetSideChainParamSet(sideChainId)
+	}
+	return nil, UnsupportedParamsModuleError
+}
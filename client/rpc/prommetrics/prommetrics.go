@@ -0,0 +1,58 @@
+// Package prommetrics exports the subscription metrics of the rpc client to Prometheus.
+package prommetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+)
+
+// MetricsSource is satisfied by *rpc.HTTP.
+type MetricsSource interface {
+	SubscriptionMetrics() []rpc.SubscriptionMetrics
+}
+
+// SubscriptionCollector reports the metrics of every active subscription with
+// the query as label. Register it with prometheus.MustRegister.
+type SubscriptionCollector struct {
+	source MetricsSource
+
+	delivered    *prometheus.Desc
+	dropped      *prometheus.Desc
+	decodeErrors *prometheus.Desc
+	lastLag      *prometheus.Desc
+	maxLag       *prometheus.Desc
+}
+
+func NewSubscriptionCollector(namespace string, source MetricsSource) *SubscriptionCollector {
+	labels := []string{"query"}
+	name := func(metric string) string {
+		return prometheus.BuildFQName(namespace, "subscription", metric)
+	}
+	return &SubscriptionCollector{
+		source:       source,
+		delivered:    prometheus.NewDesc(name("delivered_total"), "Events delivered to the subscriber.", labels, nil),
+		dropped:      prometheus.NewDesc(name("dropped_total"), "Events dropped because the subscriber fell behind.", labels, nil),
+		decodeErrors: prometheus.NewDesc(name("decode_errors_total"), "Events that could not be decoded.", labels, nil),
+		lastLag:      prometheus.NewDesc(name("lag_seconds"), "Time between the last block and the delivery of its event.", labels, nil),
+		maxLag:       prometheus.NewDesc(name("max_lag_seconds"), "Largest time between a block and the delivery of its event.", labels, nil),
+	}
+}
+
+func (c *SubscriptionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.delivered
+	ch <- c.dropped
+	ch <- c.decodeErrors
+	ch <- c.lastLag
+	ch <- c.maxLag
+}
+
+func (c *SubscriptionCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.source.SubscriptionMetrics() {
+		ch <- prometheus.MustNewConstMetric(c.delivered, prometheus.CounterValue, float64(m.Delivered), m.Query)
+		ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(m.Dropped), m.Query)
+		ch <- prometheus.MustNewConstMetric(c.decodeErrors, prometheus.CounterValue, float64(m.DecodeErrors), m.Query)
+		ch <- prometheus.MustNewConstMetric(c.lastLag, prometheus.GaugeValue, m.LastLag.Seconds(), m.Query)
+		ch <- prometheus.MustNewConstMetric(c.maxLag, prometheus.GaugeValue, m.MaxLag.Seconds(), m.Query)
+	}
+}
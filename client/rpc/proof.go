@@ -0,0 +1,108 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/rpc/client"
+
+	"github.com/binance-chain/go-sdk/common/proof"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+type QueryOption func(*client.ABCIQueryOptions)
+
+// WithProof asks the node for a merkle proof of the result.
+func WithProof() QueryOption {
+	return func(opts *client.ABCIQueryOptions) {
+		opts.Prove = true
+	}
+}
+
+// WithHeight queries the state at a past height instead of the latest one.
+func WithHeight(height int64) QueryOption {
+	return func(opts *client.ABCIQueryOptions) {
+		opts.Height = height
+	}
+}
+
+// ApplyQueryOptions returns the options set by opts over the defaults, for
+// implementations of the client interfaces taking a QueryOption.
+func ApplyQueryOptions(opts ...QueryOption) client.ABCIQueryOptions {
+	queryOpts := client.DefaultABCIQueryOptions
+	for _, opt := range opts {
+		opt(&queryOpts)
+	}
+	return queryOpts
+}
+
+// StoreQueryResult is the raw result of a store query. Proof is only set when
+// the query was made WithProof, an empty Value with a proof proves absence.
+type StoreQueryResult struct {
+	StoreName string        `json:"store_name"`
+	Key       cmn.HexBytes  `json:"key"`
+	Value     []byte        `json:"value"`
+	Height    int64         `json:"height"`
+	Proof     *merkle.Proof `json:"proof,omitempty"`
+}
+
+// Verify checks the proof of the result against the app hash of the header at Height+1.
+func (r *StoreQueryResult) Verify(appHash []byte) error {
+	if r.Proof == nil {
+		return fmt.Errorf("the result has no proof, query with WithProof")
+	}
+	if len(r.Value) == 0 {
+		return proof.VerifyAbsence(r.Proof, appHash, r.StoreName, r.Key)
+	}
+	return proof.VerifyValue(r.Proof, appHash, r.StoreName, r.Key, r.Value)
+}
+
+func (c *HTTP) QueryStoreWithOptions(key cmn.HexBytes, storeName string, opts ...QueryOption) (*StoreQueryResult, error) {
+	queryOpts := ApplyQueryOptions(opts...)
+	path := fmt.Sprintf("/store/%s/%s", storeName, "key")
+	result, err := c.ABCIQueryWithOptions(path, key, queryOpts)
+	if err != nil {
+		return nil, err
+	}
+	resp := result.Response
+	if !resp.IsOK() {
+		return nil, abciError(resp)
+	}
+	return &StoreQueryResult{
+		StoreName: storeName,
+		Key:       key,
+		Value:     resp.Value,
+		Height:    resp.Height,
+		Proof:     resp.Proof,
+	}, nil
+}
+
+// VerifyStoreResult verifies the proof of the result against the app hash of the
+// next block. It fails if that block is not committed yet, the header itself is
+// trusted as returned by the node.
+func (c *HTTP) VerifyStoreResult(res *StoreQueryResult) error {
+	header, err := c.GetHeader(res.Height + 1)
+	if err != nil {
+		return err
+	}
+	return res.Verify(header.AppHash)
+}
+
+// GetCommitAccountWithOptions is GetCommitAccount returning the raw store result,
+// including the proof when queried WithProof.
+func (c *HTTP) GetCommitAccountWithOptions(addr types.AccAddress, opts ...QueryOption) (types.Account, *StoreQueryResult, error) {
+	key := append([]byte("account:"), addr.Bytes()...)
+	res, err := c.QueryStoreWithOptions(key, AccountStoreName, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(res.Value) == 0 {
+		return nil, res, nil
+	}
+	var acc types.Account
+	if err := unmarshalBinaryBare(c.cdc, res.Value, &acc); err != nil {
+		return nil, nil, err
+	}
+	return acc, res, nil
+}
@@ -0,0 +1,204 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+const (
+	DefaultProposalWatchInterval = 5 * time.Second
+	// proposalWatchLatest is how many of the latest proposals in a status
+	// WatchProposals looks at on every poll.
+	proposalWatchLatest = 100
+)
+
+// ProposalCallbacks are called by WatchProposal. Any of them may be nil.
+type ProposalCallbacks struct {
+	// OnStatusChange is called on the first poll and then each time the status
+	// of the proposal changes, from is StatusNil on the first call.
+	OnStatusChange func(proposal types.Proposal, from types.ProposalStatus)
+	// OnError gets query errors, the watch goes on after them.
+	OnError func(err error)
+}
+
+// WatchProposal polls the proposal every interval until voting on it is over,
+// and returns it passed, rejected or executed, or until ctx is done.
+func (c *HTTP) WatchProposal(ctx context.Context, proposalID int64, interval time.Duration, callbacks ProposalCallbacks) (types.Proposal, error) {
+	return watchProposal(ctx, func() (types.Proposal, error) {
+		return c.GetProposal(proposalID)
+	}, interval, callbacks)
+}
+
+func watchProposal(ctx context.Context, get func() (types.Proposal, error), interval time.Duration, callbacks ProposalCallbacks) (types.Proposal, error) {
+	if interval <= 0 {
+		interval = DefaultProposalWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := types.StatusNil
+	var proposal types.Proposal
+	for {
+		p, err := get()
+		if err != nil {
+			if callbacks.OnError != nil {
+				callbacks.OnError(err)
+			}
+		} else {
+			proposal = p
+			if status := p.GetStatus(); status != last {
+				if callbacks.OnStatusChange != nil {
+					callbacks.OnStatusChange(p, last)
+				}
+				last = status
+			}
+			switch last {
+			case types.StatusPassed, types.StatusRejected, types.StatusExecuted:
+				return proposal, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return proposal, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WatchProposals polls the latest proposals in status every interval and calls
+// onProposal for each one that enters the status after the watch started, until
+// ctx is done. onError, if not nil, gets query errors.
+func (c *HTTP) WatchProposals(ctx context.Context, status types.ProposalStatus, interval time.Duration, onProposal func(types.Proposal), onError func(error)) error {
+	return watchProposals(ctx, func() ([]types.Proposal, error) {
+		return c.GetProposals(status, proposalWatchLatest)
+	}, interval, onProposal, onError)
+}
+
+func watchProposals(ctx context.Context, list func() ([]types.Proposal, error), interval time.Duration, onProposal func(types.Proposal), onError func(error)) error {
+	if interval <= 0 {
+		interval = DefaultProposalWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	// seen is nil until the first successful poll, whose proposals were in the
+	// status before the watch started.
+	var seen map[int64]bool
+	for {
+		proposals, err := list()
+		if err != nil {
+			if onError != nil {
+				onError(err)
+			}
+		} else {
+			current := make(map[int64]bool, len(proposals))
+			for _, p := range proposals {
+				current[p.GetProposalID()] = true
+				if seen != nil && !seen[p.GetProposalID()] {
+					onProposal(p)
+				}
+			}
+			seen = current
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProposalSubmission is the outcome of SubmitProposalWithDeposit. Deposit is
+// nil when no deposit was needed.
+type ProposalSubmission struct {
+	ProposalID int64
+	Submit     *core_types.ResultBroadcastTx
+	Deposit    *core_types.ResultBroadcastTx
+}
+
+// SubmitProposalWithDeposit submits a proposal with initialDeposit and, once
+// it is committed, deposits what the proposal lacks to reach minDeposit, the
+// min deposit of the gov params of the chain, so it goes to voting in one
+// call. Both txs are committed. A rejected deposit is returned with the
+// submission, the proposal stays in its deposit period.
+func (c *HTTP) SubmitProposalWithDeposit(title string, description string, proposalType msg.ProposalKind, initialDeposit, minDeposit types.Coins, votingPeriod time.Duration, options ...tx.Option) (*ProposalSubmission, error) {
+	res, err := c.SubmitProposal(title, description, proposalType, initialDeposit, votingPeriod, Commit, options...)
+	if err != nil {
+		return nil, err
+	}
+	if err := BroadcastError(res); err != nil {
+		return nil, err
+	}
+	id, err := strconv.ParseInt(string(res.Data), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("read the id of proposal %q: %v", title, err)
+	}
+	submission := &ProposalSubmission{ProposalID: id, Submit: res}
+	lacking := depositShortfall(minDeposit, initialDeposit)
+	if len(lacking) == 0 {
+		return submission, nil
+	}
+	submission.Deposit, err = c.Deposit(id, lacking, Commit, options...)
+	if err != nil {
+		return submission, err
+	}
+	return submission, BroadcastError(submission.Deposit)
+}
+
+// depositShortfall returns the coins of min that deposited does not cover.
+func depositShortfall(min, deposited types.Coins) types.Coins {
+	var lacking types.Coins
+	for _, coin := range min {
+		if missing := coin.Amount - deposited.AmountOf(coin.Denom); missing > 0 {
+			lacking = append(lacking, types.Coin{Denom: coin.Denom, Amount: missing})
+		}
+	}
+	return lacking
+}
+
+// VoteWhenActive polls the proposal every interval until it enters its voting
+// period and votes option on it, with the given sync type. It fails without
+// voting if the proposal is decided first, and returns when ctx is done.
+func (c *HTTP) VoteWhenActive(ctx context.Context, proposalID int64, option msg.VoteOption, interval time.Duration, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	if c.key == nil {
+		return nil, KeyMissingError
+	}
+	err := waitForVoting(ctx, func() (types.Proposal, error) {
+		return c.GetProposal(proposalID)
+	}, interval)
+	if err != nil {
+		return nil, err
+	}
+	return c.Vote(proposalID, option, syncType, options...)
+}
+
+// waitForVoting returns once the proposal is in its voting period. Query errors
+// are retried on the next poll.
+func waitForVoting(ctx context.Context, get func() (types.Proposal, error), interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultProposalWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if p, err := get(); err == nil {
+			switch status := p.GetStatus(); status {
+			case types.StatusVotingPeriod:
+				return nil
+			case types.StatusPassed, types.StatusRejected, types.StatusExecuted:
+				return fmt.Errorf("proposal %d is %s, voting is over", p.GetProposalID(), status)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+func TestWatchProposal(t *testing.T) {
+	statuses := []types.ProposalStatus{types.StatusDepositPeriod, types.StatusDepositPeriod, types.StatusVotingPeriod, types.StatusPassed}
+	polls := 0
+	get := func() (types.Proposal, error) {
+		polls++
+		if polls == 2 {
+			return nil, fmt.Errorf("node down")
+		}
+		status := statuses[0]
+		statuses = statuses[1:]
+		return &types.TextProposal{ProposalID: 7, Status: status}, nil
+	}
+	var transitions []string
+	var errs int
+	proposal, err := watchProposal(context.Background(), get, time.Millisecond, ProposalCallbacks{
+		OnStatusChange: func(p types.Proposal, from types.ProposalStatus) {
+			transitions = append(transitions, fmt.Sprintf("%s->%s", from, p.GetStatus()))
+		},
+		OnError: func(error) { errs++ },
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, types.StatusPassed, proposal.GetStatus())
+	assert.Equal(t, []string{"->DepositPeriod", "DepositPeriod->VotingPeriod", "VotingPeriod->Passed"}, transitions)
+	assert.Equal(t, 1, errs)
+}
+
+func TestWatchProposals(t *testing.T) {
+	pages := [][]int64{{1, 2}, {1, 2, 3}, {2, 3, 4}}
+	list := func() ([]types.Proposal, error) {
+		ids := pages[0]
+		if len(pages) > 1 {
+			pages = pages[1:]
+		}
+		proposals := make([]types.Proposal, 0, len(ids))
+		for _, id := range ids {
+			proposals = append(proposals, &types.TextProposal{ProposalID: id, Status: types.StatusVotingPeriod})
+		}
+		return proposals, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var entered []int64
+	err := watchProposals(ctx, list, time.Millisecond, func(p types.Proposal) {
+		entered = append(entered, p.GetProposalID())
+		if len(entered) == 2 {
+			cancel()
+		}
+	}, nil)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []int64{3, 4}, entered)
+}
+
+func TestWaitForVoting(t *testing.T) {
+	statuses := []types.ProposalStatus{types.StatusDepositPeriod, types.StatusVotingPeriod}
+	polls := 0
+	get := func() (types.Proposal, error) {
+		polls++
+		if polls == 1 {
+			return nil, fmt.Errorf("node down")
+		}
+		status := statuses[0]
+		statuses = statuses[1:]
+		return &types.TextProposal{ProposalID: 7, Status: status}, nil
+	}
+	assert.NoError(t, waitForVoting(context.Background(), get, time.Millisecond))
+	assert.Equal(t, 3, polls)
+
+	err := waitForVoting(context.Background(), func() (types.Proposal, error) {
+		return &types.TextProposal{ProposalID: 7, Status: types.StatusRejected}, nil
+	}, time.Millisecond)
+	assert.EqualError(t, err, "proposal 7 is Rejected, voting is over")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = waitForVoting(ctx, func() (types.Proposal, error) {
+		return &types.TextProposal{ProposalID: 7, Status: types.StatusDepositPeriod}, nil
+	}, time.Millisecond)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestDepositShortfall(t *testing.T) {
+	min := types.Coins{{Denom: "BNB", Amount: 1000}, {Denom: "XYZ", Amount: 5}}
+	assert.Equal(t, types.Coins{{Denom: "BNB", Amount: 400}, {Denom: "XYZ", Amount: 5}}, depositShortfall(min, types.Coins{{Denom: "BNB", Amount: 600}}))
+	assert.Empty(t, depositShortfall(min, types.Coins{{Denom: "BNB", Amount: 2000}, {Denom: "XYZ", Amount: 5}}))
+}
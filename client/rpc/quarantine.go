@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"time"
+)
+
+// DefaultMaxLag is how many blocks a node may be behind the highest node of a
+// pool before it is quarantined.
+const DefaultMaxLag = 5
+
+type syncStatus struct {
+	node       *poolNode
+	height     int64
+	catchingUp bool
+	err        error
+}
+
+// CheckSync asks every node for its status and quarantines the nodes catching
+// up or more than maxLag blocks behind the highest one, DefaultMaxLag if not
+// positive. Quarantined nodes are tried by Do only after all the others, and
+// rejoin on the first check that finds them in sync. Nodes that fail to answer
+// are left to their breaker.
+func (p *NodePool) CheckSync(maxLag int64) {
+	statuses := make([]syncStatus, len(p.nodes))
+	for i, node := range p.nodes {
+		statuses[i].node = node
+		status, err := node.client.Status()
+		if err != nil {
+			statuses[i].err = err
+			continue
+		}
+		statuses[i].height = status.SyncInfo.LatestBlockHeight
+		statuses[i].catchingUp = status.SyncInfo.CatchingUp
+	}
+	p.quarantine(statuses, maxLag)
+}
+
+// StartSyncChecks runs CheckSync every interval until Stop.
+func (p *NodePool) StartSyncChecks(interval time.Duration, maxLag int64) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.quit:
+				return
+			case <-ticker.C:
+				p.CheckSync(maxLag)
+			}
+		}
+	}()
+}
+
+// Quarantined returns the addresses of the quarantined nodes.
+func (p *NodePool) Quarantined() []string {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	var addrs []string
+	for _, node := range p.nodes {
+		if node.quarantined {
+			addrs = append(addrs, node.addr)
+		}
+	}
+	return addrs
+}
+
+func (p *NodePool) quarantine(statuses []syncStatus, maxLag int64) {
+	if maxLag <= 0 {
+		maxLag = DefaultMaxLag
+	}
+	var highest int64
+	for _, s := range statuses {
+		if s.err == nil && s.height > highest {
+			highest = s.height
+		}
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, s := range statuses {
+		if s.err != nil {
+			continue
+		}
+		lag := highest - s.height
+		quarantined := s.catchingUp || lag > maxLag
+		if quarantined == s.node.quarantined {
+			continue
+		}
+		s.node.quarantined = quarantined
+		if quarantined {
+			p.logger.Info("node quarantined", "node", s.node.addr, "height", s.height, "lag", lag, "catching_up", s.catchingUp)
+		} else {
+			p.logger.Info("node back in sync", "node", s.node.addr, "height", s.height)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestNodePoolQuarantine(t *testing.T) {
+	a, b, c := &poolNode{addr: "a", client: &HTTP{}}, &poolNode{addr: "b", client: &HTTP{}}, &poolNode{addr: "c", client: &HTTP{}}
+	p := &NodePool{nodes: []*poolNode{a, b, c}, logger: log.NewNopLogger()}
+
+	p.quarantine([]syncStatus{{node: a, height: 100, catchingUp: true}, {node: b, height: 110}, {node: c, height: 104}}, 5)
+	assert.Equal(t, []string{"a", "c"}, p.Quarantined())
+	assert.Equal(t, []*poolNode{b, a, c}, p.order())
+
+	// nodes that do not answer keep their state
+	p.quarantine([]syncStatus{{node: a, height: 111}, {node: b, height: 112}, {node: c, err: errors.New("timeout")}}, 5)
+	assert.Equal(t, []string{"c"}, p.Quarantined())
+	assert.Equal(t, []*poolNode{a, b, c}, p.order())
+
+	// the node that committed last goes first, unless it is quarantined
+	p.SetConsistency(ConsistencySameNode)
+	c.client.recordCommit(100)
+	p.observe(c)
+	assert.Equal(t, []*poolNode{a, b, c}, p.order())
+	p.quarantine([]syncStatus{{node: c, height: 112}}, 5)
+	assert.Equal(t, []*poolNode{c, a, b}, p.order())
+}
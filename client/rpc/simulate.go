@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	gtypes "github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+const simulateABCIPath = "/app/simulate"
+
+// SimulateResult is the would-be outcome of a tx. Fee is the fixed fee the tx
+// would be charged; trading fees of new orders depend on fills and are not included.
+type SimulateResult struct {
+	Code uint32       `json:"code"`
+	Log  string       `json:"log"`
+	Data []byte       `json:"data"`
+	Tags []cmn.KVPair `json:"tags"`
+	Fee  types.Coins  `json:"fee"`
+}
+
+func (r *SimulateResult) IsOK() bool {
+	return r.Code == 0
+}
+
+// simulateResponse mirrors the result the app encodes for simulate queries.
+type simulateResponse struct {
+	Code uint32
+	Data []byte
+	Log  string
+	Tags []cmn.KVPair
+}
+
+// SimulateTx runs the tx through the ante handler and msg handlers of the node without
+// committing it or adding it to the mempool. The tx must carry a signature, use
+// Simulate to sign a msg with the key manager of the client.
+func (c *HTTP) SimulateTx(stdTx tx.StdTx) (*SimulateResult, error) {
+	bz, err := tx.Cdc.MarshalBinaryLengthPrefixed(stdTx)
+	if err != nil {
+		return nil, err
+	}
+	return c.simulate(bz, stdTx.Msgs)
+}
+
+// Simulate signs the msg like Broadcast does and simulates it instead of broadcasting.
+// The sequence is not consumed, so the same options can be passed to Broadcast afterwards.
+func (c *HTTP) Simulate(m msg.Msg, options ...tx.Option) (*SimulateResult, error) {
+	bz, err := c.sign(m, options...)
+	if err != nil {
+		return nil, err
+	}
+	return c.simulate(bz, []msg.Msg{m})
+}
+
+// Preview builds the sign doc Broadcast would sign for the msg, without signing it,
+// and renders it for the user to confirm together with the fixed fee.
+func (c *HTTP) Preview(m msg.Msg, options ...tx.Option) (*tx.SignPreview, error) {
+	signMsg, err := c.buildSignMsg(m, options...)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := c.GetFee()
+	if err != nil {
+		return nil, err
+	}
+	return tx.Preview(*signMsg, CalculateFixedFee(fees, signMsg.Msgs)), nil
+}
+
+func (c *HTTP) simulate(txBytes []byte, msgs []msg.Msg) (*SimulateResult, error) {
+	if err := ValidateTx(txBytes); err != nil {
+		return nil, err
+	}
+	raw, err := c.ABCIQuery(simulateABCIPath, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	result := &SimulateResult{}
+	if !raw.Response.IsOK() {
+		result.Code = raw.Response.Code
+		result.Log = raw.Response.Log
+		return result, nil
+	}
+	var res simulateResponse
+	if err := unmarshalBinaryLengthPrefixed(c.cdc, raw.Response.GetValue(), &res); err != nil {
+		return nil, fmt.Errorf("decode simulate result: %v", err)
+	}
+	result.Code = res.Code
+	result.Log = res.Log
+	result.Data = res.Data
+	result.Tags = res.Tags
+	if result.IsOK() {
+		fees, err := c.GetFee()
+		if err != nil {
+			return nil, err
+		}
+		result.Fee = CalculateFixedFee(fees, msgs)
+	}
+	return result, nil
+}
+
+// CalculateFixedFee returns the fixed fee charged in BNB for msgs under the given
+// fee params. Transfers with at least LowerLimitAsMulti output coins pay the
+// multi transfer fee per coin.
+func CalculateFixedFee(fees []types.FeeParam, msgs []msg.Msg) types.Coins {
+	var total int64
+	for _, m := range msgs {
+		for _, param := range fees {
+			switch p := param.(type) {
+			case *types.TransferFeeParam:
+				send, ok := m.(msg.SendMsg)
+				if !ok || p.MsgType != m.Type() {
+					continue
+				}
+				var num int64
+				for _, out := range send.Outputs {
+					num += int64(len(out.Coins))
+				}
+				if num >= p.LowerLimitAsMulti {
+					total += p.MultiTransferFee * num
+				} else {
+					total += p.Fee
+				}
+			case *types.FixedFeeParams:
+				if p.MsgType == m.Type() && p.FeeFor != types.FeeFree {
+					total += p.Fee
+				}
+			}
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	return types.Coins{{Denom: gtypes.NativeSymbol, Amount: total}}
+}
@@ -46,6 +46,7 @@ type StakingClient interface {
 	GetSideChainUnBondingDelegationsByValidator(sideChainId string, valAddr types.ValAddress) ([]types.UnbondingDelegation, error)
 	GetSideChainRedelegationsByValidator(sideChainId string, valAddr types.ValAddress) ([]types.Redelegation, error)
 	GetSideChainPool(sideChainId string) (*types.Pool, error)
+	GetStakingPool() (*types.Pool, error)
 	GetSideChainAllValidatorsCount(sideChainId string, jailInvolved bool) (int, error)
 }
 
@@ -175,7 +176,7 @@ func (c *HTTP) QuerySideChainValidator(sideChainId string, valAddr types.ValAddr
 
 	var validator types.Validator
 
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(bz, &validator)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, bz, &validator)
 
 	if err != nil {
 		return nil, err
@@ -212,7 +213,7 @@ func (c *HTTP) QuerySideChainTopValidators(sideChainId string, top int) ([]types
 	}
 
 	var bvs []bechValidator
-	if err = c.cdc.UnmarshalJSON(res, &bvs); err != nil {
+	if err = c.unmarshalNodeJSON(res, &bvs); err != nil {
 		return nil, err
 	}
 
@@ -275,7 +276,7 @@ func (c *HTTP) QuerySideChainDelegation(sideChainId string, delAddr types.AccAdd
 	}
 
 	var delResponse types.DelegationResponse
-	if err := c.cdc.UnmarshalJSON(response, &delResponse); err != nil {
+	if err := c.unmarshalNodeJSON(response, &delResponse); err != nil {
 		return nil, err
 	}
 
@@ -304,7 +305,7 @@ func (c *HTTP) QuerySideChainDelegations(sideChainId string, delAddr types.AccAd
 		return delegationResponses, fmt.Errorf("No delegation found with delegator-addr %s ", delAddr)
 	}
 
-	if err := c.cdc.UnmarshalJSON(response, &delegationResponses); err != nil {
+	if err := c.unmarshalNodeJSON(response, &delegationResponses); err != nil {
 		return delegationResponses, err
 	}
 
@@ -450,7 +451,7 @@ func (c *HTTP) GetSideChainUnBondingDelegationsByValidator(sideChainId string, v
 		return ubds, nil
 	}
 
-	if err = c.cdc.UnmarshalJSON(response, &ubds); err != nil {
+	if err = c.unmarshalNodeJSON(response, &ubds); err != nil {
 		return nil, err
 	}
 
@@ -478,7 +479,7 @@ func (c *HTTP) GetSideChainRedelegationsByValidator(sideChainId string, valAddr
 		return reds, nil
 	}
 
-	if err = c.cdc.UnmarshalJSON(response, &reds); err != nil {
+	if err = c.unmarshalNodeJSON(response, &reds); err != nil {
 		return nil, err
 	}
 
@@ -507,7 +508,7 @@ func (c *HTTP) GetSideChainPool(sideChainId string) (*types.Pool, error) {
 	}
 
 	var pool types.Pool
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(res, &pool)
+	err = unmarshalBinaryLengthPrefixed(c.cdc, res, &pool)
 	if err != nil {
 		return nil, err
 	}
@@ -515,6 +516,23 @@ func (c *HTTP) GetSideChainPool(sideChainId string) (*types.Pool, error) {
 	return &pool, nil
 }
 
+// GetStakingPool returns the bonded and not bonded token totals of the Binance
+// Chain staking pool, see Pool.BondedRatio for the share that is bonded.
+func (c *HTTP) GetStakingPool() (*types.Pool, error) {
+	response, err := c.QueryWithData("custom/stake/pool", nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(response) == 0 {
+		return &types.Pool{LooseTokens: types.ZeroDec(), BondedTokens: types.ZeroDec()}, nil
+	}
+	var pool types.Pool
+	if err := c.unmarshalNodeJSON(response, &pool); err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
 func (c *HTTP) GetSideChainAllValidatorsCount(sideChainId string, jailInvolved bool) (int, error) {
 	params := types.NewBaseParams(sideChainId)
 
@@ -601,7 +619,7 @@ type ubdValue struct {
 // unmarshal a unbonding delegation from a store key and value
 func unmarshalUBD(cdc *amino.Codec, key, value []byte) (ubd types.UnbondingDelegation, err error) {
 	var storeValue ubdValue
-	err = cdc.UnmarshalBinaryLengthPrefixed(value, &storeValue)
+	err = unmarshalBinaryLengthPrefixed(cdc, value, &storeValue)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,163 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/tendermint/go-amino"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+const (
+	newBlockHeaderQuery = "tm.event = 'NewBlockHeader'"
+	txEventQuery        = "tm.event = 'Tx'"
+	// txEventCapacity buffers the tx events of a few busy blocks.
+	txEventCapacity = 1000
+)
+
+// TxObserver is fed the txs committed to the chain by FollowTxs. The account
+// cache of a transaction client satisfies it.
+type TxObserver interface {
+	ObserveTx(t tx.StdTx)
+	InvalidateAccountCache()
+}
+
+// FollowTxs passes the txs committed to the chain to observer through the tx
+// events of the node, until ctx is done. When the subscription ends the observer
+// is invalidated, as txs may be missed from then on.
+func (w *WSEvents) FollowTxs(ctx context.Context, observer TxObserver) error {
+	events, err := w.SubscribeWithContext(ctx, txEventQuery, txEventCapacity)
+	if err != nil {
+		return err
+	}
+	go followTxs(w.cdc, events, observer)
+	return nil
+}
+
+func followTxs(cdc *amino.Codec, events <-chan ctypes.ResultEvent, observer TxObserver) {
+	defer observer.InvalidateAccountCache()
+	for event := range events {
+		var raw types.Tx
+		switch data := event.Data.(type) {
+		case types.EventDataTx:
+			raw = data.Tx
+		case *types.EventDataTx:
+			raw = data.Tx
+		default:
+			continue
+		}
+		parsed, err := ParseTx(cdc, raw)
+		if err != nil {
+			continue
+		}
+		if t, ok := parsed.(tx.StdTx); ok {
+			observer.ObserveTx(t)
+		}
+	}
+}
+
+// StreamBlocks delivers every block from fromHeight on, exactly once and in height
+// order. New block headers from the websocket subscription only signal the latest
+// height; every height that was not delivered yet, including the ones missed while
+// the connection was down, is fetched through Block. With fromHeight <= 0 the
+// stream starts at the first header received.
+//
+// Only one stream can run per client since both share the NewBlockHeader subscription.
+// The stream stops and unsubscribes when quit is closed. If the subscription is
+// closed under it, it stops with SubscriptionClosedError, a new stream from the
+// next undelivered height picks up where it left.
+func (w *WSEvents) StreamBlocks(fromHeight int64, quit chan struct{}, onBlock func(*ctypes.ResultBlock), onError func(error)) error {
+	return w.streamHeights(fromHeight, quit, func(height int64) error {
+		block, err := w.Block(&height)
+		if err != nil {
+			return err
+		}
+		onBlock(block)
+		return nil
+	}, onError)
+}
+
+// StreamTxs is like StreamBlocks but delivers the txs of every block, together with
+// their DeliverTx results, in (height, index) order.
+func (w *WSEvents) StreamTxs(fromHeight int64, quit chan struct{}, onTx func(*ResultTx), onError func(error)) error {
+	return w.streamHeights(fromHeight, quit, func(height int64) error {
+		block, err := w.Block(&height)
+		if err != nil {
+			return err
+		}
+		if len(block.Block.Data.Txs) == 0 {
+			return nil
+		}
+		results, err := w.BlockResults(&height)
+		if err != nil {
+			return err
+		}
+		if results.Results == nil || len(results.Results.DeliverTx) != len(block.Block.Data.Txs) {
+			return EmptyResultError
+		}
+		for i, tx := range block.Block.Data.Txs {
+			onTx(&ResultTx{
+				Hash:     tx.Hash(),
+				Height:   height,
+				Index:    uint32(i),
+				TxResult: *results.Results.DeliverTx[i],
+				Tx:       tx,
+			})
+		}
+		return nil
+	}, onError)
+}
+
+// streamHeights calls deliver for every height up to the latest announced one. A
+// height whose delivery fails is retried on the next header, so nothing is skipped.
+func (w *WSEvents) streamHeights(fromHeight int64, quit chan struct{}, deliver func(height int64) error, onError func(error)) error {
+	events, err := w.Subscribe(newBlockHeaderQuery, 16)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer w.Unsubscribe(newBlockHeaderQuery)
+		deliverHeights(events, fromHeight, quit, deliver, onError)
+	}()
+	return nil
+}
+
+// deliverHeights is the loop of streamHeights over the new block header events.
+func deliverHeights(events <-chan ctypes.ResultEvent, next int64, quit chan struct{}, deliver func(height int64) error, onError func(error)) {
+	for {
+		select {
+		case <-quit:
+			return
+		case event, ok := <-events:
+			if !ok {
+				if onError != nil {
+					onError(SubscriptionClosedError)
+				}
+				return
+			}
+			header, ok := event.Data.(types.EventDataNewBlockHeader)
+			if !ok {
+				continue
+			}
+			latest := header.Header.Height
+			if next <= 0 {
+				next = latest
+			}
+			for ; next <= latest; next++ {
+				select {
+				case <-quit:
+					return
+				default:
+				}
+				if err := deliver(next); err != nil {
+					if onError != nil {
+						onError(err)
+					}
+					break
+				}
+			}
+		}
+	}
+}
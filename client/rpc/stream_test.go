@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type recordingObserver struct {
+	txs         []tx.StdTx
+	invalidated bool
+}
+
+func (o *recordingObserver) ObserveTx(t tx.StdTx) {
+	o.txs = append(o.txs, t)
+}
+
+func (o *recordingObserver) InvalidateAccountCache() {
+	o.invalidated = true
+}
+
+func TestFollowTxs(t *testing.T) {
+	km, err := keys.NewPrivateKeyManager("9579fff0cab07a4379e845a890105004ba4c8276f8ad9d22082b2acbf02d884b")
+	assert.NoError(t, err)
+	signed := tx.StdTx{Signatures: []tx.StdSignature{{PubKey: km.GetPrivKey().PubKey(), Sequence: 5}}}
+	bz, err := tx.Cdc.MarshalBinaryLengthPrefixed(signed)
+	assert.NoError(t, err)
+
+	events := make(chan ctypes.ResultEvent, 4)
+	events <- ctypes.ResultEvent{Data: types.EventDataTx{TxResult: types.TxResult{Tx: bz}}}
+	events <- ctypes.ResultEvent{Data: types.EventDataNewBlockHeader{}}
+	events <- ctypes.ResultEvent{Data: types.EventDataTx{TxResult: types.TxResult{Tx: []byte{0x01}}}}
+	close(events)
+
+	observer := &recordingObserver{}
+	followTxs(tx.Cdc, events, observer)
+	if assert.Len(t, observer.txs, 1, "other events and undecodable txs are skipped") {
+		assert.Equal(t, int64(5), observer.txs[0].Signatures[0].Sequence)
+	}
+	assert.True(t, observer.invalidated, "txs are missed once the subscription ends")
+}
+
+func headerEvent(height int64) ctypes.ResultEvent {
+	header := types.EventDataNewBlockHeader{}
+	header.Header.Height = height
+	return ctypes.ResultEvent{Data: header}
+}
+
+func TestDeliverHeights(t *testing.T) {
+	events := make(chan ctypes.ResultEvent, 8)
+	events <- headerEvent(3)
+	events <- headerEvent(6) // skips heights 4 and 5
+	events <- ctypes.ResultEvent{Data: types.EventDataTx{}}
+	events <- headerEvent(7)
+	events <- headerEvent(5) // announced late
+	close(events)
+
+	failure := errors.New("fetch block 4 failed")
+	var delivered []int64
+	attempts := map[int64]int{}
+	deliver := func(height int64) error {
+		attempts[height]++
+		if height == 4 && attempts[height] == 1 {
+			return failure
+		}
+		delivered = append(delivered, height)
+		return nil
+	}
+	var errs []error
+	deliverHeights(events, 2, make(chan struct{}), deliver, func(err error) { errs = append(errs, err) })
+
+	assert.Equal(t, []int64{2, 3, 4, 5, 6, 7}, delivered, "every height once and in order")
+	assert.Equal(t, 2, attempts[4], "a failed height is retried on the next header")
+	assert.Equal(t, []error{failure, SubscriptionClosedError}, errs)
+}
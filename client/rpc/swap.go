@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// ForEachSwap calls fn with every swap of the account in the given role and
+// status, types.NULL matching any status. It pages through the creator and
+// recipient queries and reports a swap the account is on both sides of once.
+// The iteration stops at the first error returned by fn, which is returned.
+func (c *HTTP) ForEachSwap(addr string, role types.SwapRole, status types.SwapStatus, fn func(swapID types.SwapBytes, swap types.AtomicSwap) error) error {
+	seen := make(map[string]bool)
+	visit := func(swapIDs []types.SwapBytes) error {
+		for _, swapID := range swapIDs {
+			key := hex.EncodeToString(swapID)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			swap, err := c.GetSwapByID(swapID)
+			if err != nil {
+				return err
+			}
+			if status != types.NULL && swap.Status != status {
+				continue
+			}
+			if err := fn(swapID, swap); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if role == types.SwapRoleAny || role == types.SwapRoleCreator {
+		if err := pageSwaps(addr, c.GetSwapByCreator, visit); err != nil {
+			return err
+		}
+	}
+	if role == types.SwapRoleAny || role == types.SwapRoleRecipient {
+		if err := pageSwaps(addr, c.GetSwapByRecipient, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pageSwaps(addr string, query func(addr string, offset int64, limit int64) ([]types.SwapBytes, error), visit func([]types.SwapBytes) error) error {
+	for offset := int64(0); ; offset += swapQueryPageSize {
+		swapIDs, err := query(addr, offset, swapQueryPageSize)
+		if err == ZeroRecordsError {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := visit(swapIDs); err != nil {
+			return err
+		}
+		if int64(len(swapIDs)) < swapQueryPageSize {
+			return nil
+		}
+	}
+}
+
+const DefaultSwapWatchInterval = 3 * time.Second
+
+// SwapCallbacks are called by WatchSwap as the swap changes state. Any of them may be nil.
+type SwapCallbacks struct {
+	OnClaimed  func(swap types.AtomicSwap)
+	OnRefunded func(swap types.AtomicSwap)
+	// OnExpired is called once when the chain passes the expire height of a swap
+	// that is still open, from then on it can only be refunded.
+	OnExpired func(swap types.AtomicSwap, height int64)
+	// OnError gets query errors, the watch goes on after them.
+	OnError func(err error)
+}
+
+// WatchSwap polls the swap every interval until it is claimed or refunded and
+// returns it in its final state, or until ctx is done. The swap ID is the one
+// msg.CalculateSwapID derives from the random number hash.
+func (c *HTTP) WatchSwap(ctx context.Context, swapID types.SwapBytes, interval time.Duration, callbacks SwapCallbacks) (types.AtomicSwap, error) {
+	if interval <= 0 {
+		interval = DefaultSwapWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	expired := false
+	for {
+		swap, done, err := c.checkSwap(swapID, &expired, callbacks)
+		if done {
+			return swap, nil
+		}
+		if err != nil && callbacks.OnError != nil {
+			callbacks.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return swap, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *HTTP) checkSwap(swapID types.SwapBytes, expired *bool, callbacks SwapCallbacks) (types.AtomicSwap, bool, error) {
+	swap, err := c.GetSwapByID(swapID)
+	if err != nil {
+		return swap, false, err
+	}
+	switch swap.Status {
+	case types.Completed:
+		if callbacks.OnClaimed != nil {
+			callbacks.OnClaimed(swap)
+		}
+		return swap, true, nil
+	case types.Expired:
+		// the chain only sets a swap to expired when it is refunded
+		if callbacks.OnRefunded != nil {
+			callbacks.OnRefunded(swap)
+		}
+		return swap, true, nil
+	}
+	if *expired {
+		return swap, false, nil
+	}
+	status, err := c.Status()
+	if err != nil {
+		return swap, false, err
+	}
+	if height := status.SyncInfo.LatestBlockHeight; height >= swap.ExpireHeight {
+		*expired = true
+		if callbacks.OnExpired != nil {
+			callbacks.OnExpired(swap, height)
+		}
+	}
+	return swap, false, nil
+}
@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+func TestPageSwaps(t *testing.T) {
+	total := int64(swapQueryPageSize + 20)
+	var offsets []int64
+	query := func(addr string, offset int64, limit int64) ([]types.SwapBytes, error) {
+		offsets = append(offsets, offset)
+		if offset >= total {
+			return nil, ZeroRecordsError
+		}
+		n := total - offset
+		if n > limit {
+			n = limit
+		}
+		return make([]types.SwapBytes, n), nil
+	}
+	var visited int
+	err := pageSwaps("addr", query, func(swapIDs []types.SwapBytes) error {
+		visited += len(swapIDs)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int(total), visited)
+	assert.Equal(t, []int64{0, swapQueryPageSize}, offsets)
+
+	total = swapQueryPageSize
+	offsets, visited = nil, 0
+	err = pageSwaps("addr", query, func(swapIDs []types.SwapBytes) error {
+		visited += len(swapIDs)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int(total), visited)
+	assert.Equal(t, []int64{0, swapQueryPageSize}, offsets)
+}
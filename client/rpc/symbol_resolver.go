@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+const (
+	defaultSymbolCacheTTL = 10 * time.Minute
+	symbolListPageSize    = 1000
+)
+
+// SymbolResolver resolves human names like "BUSD" to full on-chain symbols
+// like "BUSD-BD1". The token list is fetched lazily and cached for ttl.
+type SymbolResolver struct {
+	client QueryClient
+	ttl    time.Duration
+
+	mtx       sync.Mutex
+	symbols   map[string][]string // base name -> full symbols
+	updatedAt time.Time
+}
+
+// NewSymbolResolver creates a resolver backed by the given client. A non-positive
+// ttl falls back to ten minutes.
+func NewSymbolResolver(client QueryClient, ttl time.Duration) *SymbolResolver {
+	if ttl <= 0 {
+		ttl = defaultSymbolCacheTTL
+	}
+	return &SymbolResolver{client: client, ttl: ttl}
+}
+
+// Resolve returns the full symbol for name. Full symbols are validated and returned
+// as is; base names must match exactly one listed token or mini token.
+func (r *SymbolResolver) Resolve(name string) (string, error) {
+	normalized := msg.NormalizeSymbol(name)
+	if symbol, err := msg.ValidateUserSymbol(normalized); err == nil {
+		return symbol, nil
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.symbols == nil || time.Since(r.updatedAt) > r.ttl {
+		if err := r.refresh(); err != nil {
+			return "", err
+		}
+	}
+	candidates := r.symbols[normalized]
+	switch len(candidates) {
+	case 0:
+		return "", SymbolNotFoundError
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", fmt.Errorf("symbol %s is ambiguous, candidates: %v", normalized, candidates)
+	}
+}
+
+// Refresh forces the cached token list to be reloaded.
+func (r *SymbolResolver) Refresh() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.refresh()
+}
+
+func (r *SymbolResolver) refresh() error {
+	symbols := make(map[string][]string)
+	add := func(symbol string) {
+		base := msg.BaseSymbol(symbol)
+		symbols[base] = append(symbols[base], symbol)
+	}
+	for offset := 0; ; offset += symbolListPageSize {
+		tokens, err := r.client.ListAllTokens(offset, symbolListPageSize)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			add(token.Symbol)
+		}
+		if len(tokens) < symbolListPageSize {
+			break
+		}
+	}
+	for offset := 0; ; offset += symbolListPageSize {
+		tokens, err := r.client.ListAllMiniTokens(offset, symbolListPageSize)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			add(token.Symbol)
+		}
+		if len(tokens) < symbolListPageSize {
+			break
+		}
+	}
+	r.symbols = symbols
+	r.updatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// fakeTokenLister lists the given tokens and counts how often the token list is fetched.
+type fakeTokenLister struct {
+	QueryClient
+	tokens     []types.Token
+	miniTokens []types.MiniToken
+	lists      int
+}
+
+func (f *fakeTokenLister) ListAllTokens(offset int, limit int) ([]types.Token, error) {
+	f.lists++
+	if offset >= len(f.tokens) {
+		return nil, nil
+	}
+	return f.tokens[offset:], nil
+}
+
+func (f *fakeTokenLister) ListAllMiniTokens(offset int, limit int) ([]types.MiniToken, error) {
+	if offset >= len(f.miniTokens) {
+		return nil, nil
+	}
+	return f.miniTokens[offset:], nil
+}
+
+func TestSymbolResolverAmbiguousBaseName(t *testing.T) {
+	lister := &fakeTokenLister{
+		tokens:     []types.Token{{Symbol: "BNB"}, {Symbol: "BUSD-BD1"}, {Symbol: "BUSD-AAA"}, {Symbol: "ABC-123"}},
+		miniTokens: []types.MiniToken{{Symbol: "XYZ-000M"}},
+	}
+	resolver := NewSymbolResolver(lister, time.Minute)
+
+	_, err := resolver.Resolve("busd")
+	if assert.Error(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "BUSD-BD1") && strings.Contains(err.Error(), "BUSD-AAA"), err.Error())
+	}
+	symbol, err := resolver.Resolve(" abc ")
+	assert.NoError(t, err)
+	assert.Equal(t, "ABC-123", symbol)
+	symbol, err = resolver.Resolve("xyz")
+	assert.NoError(t, err)
+	assert.Equal(t, "XYZ-000M", symbol)
+	_, err = resolver.Resolve("NOPE")
+	assert.Equal(t, SymbolNotFoundError, err)
+	assert.Equal(t, 1, lister.lists)
+
+	// a full symbol is not looked up
+	symbol, err = resolver.Resolve("busd-bd1")
+	assert.NoError(t, err)
+	assert.Equal(t, "BUSD-BD1", symbol)
+	assert.Equal(t, 1, lister.lists)
+}
+
+func TestSymbolResolverCacheExpiry(t *testing.T) {
+	lister := &fakeTokenLister{}
+	resolver := NewSymbolResolver(lister, 50*time.Millisecond)
+
+	_, err := resolver.Resolve("ABC")
+	assert.Equal(t, SymbolNotFoundError, err)
+	lister.tokens = []types.Token{{Symbol: "ABC-123"}}
+
+	// the token issued since is not seen until the cache expires
+	_, err = resolver.Resolve("ABC")
+	assert.Equal(t, SymbolNotFoundError, err)
+	assert.Equal(t, 1, lister.lists)
+
+	time.Sleep(100 * time.Millisecond)
+	symbol, err := resolver.Resolve("ABC")
+	assert.NoError(t, err)
+	assert.Equal(t, "ABC-123", symbol)
+	assert.Equal(t, 2, lister.lists)
+}
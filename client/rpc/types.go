@@ -150,6 +150,12 @@ type Info struct {
 	Height int64             `json:"height"`
 	Tx     tx.Tx             `json:"tx"`
 	Result ResponseDeliverTx `json:"result"`
+	// Source is the source code of the tx and SourceName the application it is
+	// registered to.
+	Source     int64  `json:"source"`
+	SourceName string `json:"source_name"`
+	// Data is the data field of the tx, if it has one.
+	Data []byte `json:"data,omitempty"`
 }
 
 func (r *Info) complement() {
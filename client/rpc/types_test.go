@@ -0,0 +1,25 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+func TestFormatTxResultSurfacesSourceAndData(t *testing.T) {
+	from := types.AccAddress([]byte("format-tx-sender----"))
+	vote := msg.NewMsgVote(from, 1, msg.OptionYes)
+	stdTx := tx.NewStdTx([]msg.Msg{vote}, nil, "", tx.SourceTrustWallet, []byte(`{"invoice":42}`))
+	bz, err := tx.Cdc.MarshalBinaryLengthPrefixed(stdTx)
+	assert.NoError(t, err)
+
+	info, err := formatTxResult(tx.Cdc, &ResultTx{Height: 10, Tx: bz})
+	assert.NoError(t, err)
+	assert.Equal(t, tx.SourceTrustWallet, info.Source)
+	assert.Equal(t, "Trust Wallet", info.SourceName)
+	assert.Equal(t, []byte(`{"invoice":42}`), info.Data)
+}
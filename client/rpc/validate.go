@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"strings"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/common"
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
 	"github.com/tendermint/tendermint/types"
+
+	gtypes "github.com/binance-chain/go-sdk/types"
 )
 
 const (
@@ -38,9 +42,59 @@ var (
 	PairFormatError                   = fmt.Errorf("the pair should in format 'symbol1_symbol2'")
 	DepthLevelExceedRangeError        = fmt.Errorf("the level is out of range [%d, %d]", 0, maxDepthLevel)
 	KeyMissingError                   = fmt.Errorf("keymanager is missing, use SetKeyManager to set key")
-	EmptyResultError				  = fmt.Errorf("Empty result ")
+	EmptyResultError                  = fmt.Errorf("Empty result ")
+	ZeroRecordsError                  = fmt.Errorf("zero records")
+	NotTokenOwnerError                = fmt.Errorf("the key is not the owner of the token")
+	TokenNotMintableError             = fmt.Errorf("the token is not mintable")
+	NotMiniTokenError                 = fmt.Errorf("the token is not a mini token")
+	SymbolNotFoundError               = fmt.Errorf("no token matches the symbol")
+	PairNotFoundError                 = fmt.Errorf("no trading pair matches the symbol")
+	CircuitOpenError                  = fmt.Errorf("the circuit breaker of the node is open")
+	NoHealthyNodeError                = fmt.Errorf("the circuit breakers of all nodes are open")
+	StaleNodeError                    = fmt.Errorf("the node is behind the height the read requires")
+	ExceedResponseSizeError           = fmt.Errorf("the response exceed the max response size")
+	ExceedFieldLengthError            = fmt.Errorf("a field of the response is longer than the bytes left")
+	ExceedElementCountError           = fmt.Errorf("a list of the response exceed max length %d ", maxDecodeElements)
+	SubscriptionClosedError           = fmt.Errorf("the event subscription was closed")
 )
 
+func init() {
+	gtypes.RegisterErrorClass(gtypes.ErrorClassInvalidRequest,
+		ExceedABCIPathLengthError, ExceedABCIDataLengthError, ExceedTxLengthError, LimitNegativeError,
+		ExceedMaxUnConfirmedTxsNumError, HeightNegativeError, MaxMinHeightConflictError, HashLengthError,
+		ExceedABCIQueryStrLengthError, ExceedTxSearchQueryStrLengthError, OffsetNegativeError,
+		DepthLevelExceedRangeError, KeyMissingError, ExceedResponseSizeError, ExceedFieldLengthError,
+		ExceedElementCountError)
+	gtypes.RegisterErrorClass(gtypes.ErrorClassInvalidSymbol,
+		SymbolLengthExceedRangeError, PairFormatError, NotMiniTokenError, SymbolNotFoundError, PairNotFoundError)
+	gtypes.RegisterErrorClass(gtypes.ErrorClassUnauthorized, NotTokenOwnerError, TokenNotMintableError)
+	gtypes.RegisterErrorClass(gtypes.ErrorClassUnavailable, CircuitOpenError, NoHealthyNodeError, StaleNodeError,
+		SubscriptionClosedError)
+}
+
+// abciError turns a failed query response into a classified error.
+func abciError(resp abci.ResponseQuery) error {
+	return gtypes.NewABCIError(resp.Code, resp.Log)
+}
+
+// orderGoneLog is part of the log the chain rejects a cancel with when the order
+// was filled or cancelled already.
+const orderGoneLog = "Failed to find order"
+
+// IsOrderGone reports whether res rejects a cancel because the order is no longer open.
+func IsOrderGone(res *core_types.ResultBroadcastTx) bool {
+	return res != nil && res.Code != abci.CodeTypeOK && strings.Contains(res.Log, orderGoneLog)
+}
+
+// BroadcastError returns the classified error of a rejected tx, with the parsed
+// log as *gtypes.ABCILog when the log is structured, or nil if res succeeded.
+func BroadcastError(res *core_types.ResultBroadcastTx) error {
+	if res == nil || res.Code == abci.CodeTypeOK {
+		return nil
+	}
+	return gtypes.NewABCIError(res.Code, res.Log)
+}
+
 func ValidateABCIPath(path string) error {
 	if len(path) > maxABCIPathLength {
 		return ExceedABCIPathLengthError
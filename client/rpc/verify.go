@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/tendermint/go-amino"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// TxSignatures is the outcome of verifying the signatures of one tx of a block.
+// Err is nil when the tx decoded and all its signatures are valid.
+type TxSignatures struct {
+	Hash cmn.HexBytes
+	Err  error
+}
+
+// BlockSignatures is the outcome of verifying all txs of a block.
+type BlockSignatures struct {
+	Height  int64
+	ChainID string
+	Txs     []TxSignatures
+}
+
+// Valid reports whether every tx of the block verified.
+func (b *BlockSignatures) Valid() bool {
+	for _, t := range b.Txs {
+		if t.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyBlockSignatures fetches the block at height, the latest when nil, and
+// verifies the signatures of all its txs concurrently against the chain id of
+// the block, see keys.BatchVerifier. Only the signatures are checked: whether
+// the signers could afford the tx or used the right sequence is up to the chain.
+func (c *HTTP) VerifyBlockSignatures(height *int64) (*BlockSignatures, error) {
+	block, err := c.Block(height)
+	if err != nil {
+		return nil, err
+	}
+	return VerifyBlock(c.cdc, block.Block, keys.NewBatchVerifier(0))
+}
+
+// VerifyBlock verifies the signatures of all txs of block with verifier, which
+// may be shared across blocks to keep its public key cache.
+func VerifyBlock(cdc *amino.Codec, block *tmtypes.Block, verifier *keys.BatchVerifier) (*BlockSignatures, error) {
+	if block == nil {
+		return nil, fmt.Errorf("missing block")
+	}
+	res := &BlockSignatures{
+		Height:  block.Height,
+		ChainID: block.ChainID,
+		Txs:     make([]TxSignatures, len(block.Txs)),
+	}
+	stdTxs := make([]tx.StdTx, 0, len(block.Txs))
+	decoded := make([]int, 0, len(block.Txs))
+	for i, raw := range block.Txs {
+		res.Txs[i].Hash = raw.Hash()
+		parsed, err := ParseTx(cdc, raw)
+		if err != nil {
+			res.Txs[i].Err = fmt.Errorf("decode tx: %v", err)
+			continue
+		}
+		stdTxs = append(stdTxs, parsed.(tx.StdTx))
+		decoded = append(decoded, i)
+	}
+	for j, err := range verifier.VerifyStdTxs(block.ChainID, stdTxs) {
+		res.Txs[decoded[j]].Err = err
+	}
+	return res, nil
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+func TestVerifyBlock(t *testing.T) {
+	km, err := keys.NewPrivateKeyManager("9579fff0cab07a4379e845a890105004ba4c8276f8ad9d22082b2acbf02d884b")
+	assert.NoError(t, err)
+	signed, err := km.Sign(tx.StdSignMsg{
+		ChainID: "test-chain",
+		Msgs:    []msg.Msg{msg.NewMsgVote(km.GetAddr(), 1, msg.OptionYes)},
+	})
+	assert.NoError(t, err)
+
+	block := &tmtypes.Block{}
+	block.Height = 10
+	block.ChainID = "test-chain"
+	block.Txs = tmtypes.Txs{signed, []byte("garbage"), signed}
+	res, err := VerifyBlock(tx.Cdc, block, keys.NewBatchVerifier(0))
+	assert.NoError(t, err)
+	assert.False(t, res.Valid())
+	assert.Len(t, res.Txs, 3)
+	assert.NoError(t, res.Txs[0].Err)
+	assert.Error(t, res.Txs[1].Err)
+	assert.NoError(t, res.Txs[2].Err)
+	assert.Equal(t, tmtypes.Tx(signed).Hash(), []byte(res.Txs[0].Hash))
+
+	// the same txs are not valid on another chain
+	block.ChainID = "other-chain"
+	block.Txs = tmtypes.Txs{signed}
+	res, err = VerifyBlock(tx.Cdc, block, keys.NewBatchVerifier(0))
+	assert.NoError(t, err)
+	assert.False(t, res.Valid())
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net"
@@ -23,7 +24,9 @@ import (
 	"github.com/tendermint/tendermint/rpc/lib/types"
 	"github.com/tendermint/tendermint/types"
 
+	"github.com/binance-chain/go-sdk/common/bufpool"
 	"github.com/binance-chain/go-sdk/common/uuid"
+	gtypes "github.com/binance-chain/go-sdk/types"
 	"github.com/binance-chain/go-sdk/types/tx"
 )
 
@@ -60,9 +63,14 @@ type WSEvents struct {
 
 	responsesCh chan rpctypes.RPCResponse
 
-	responseChanMap sync.Map
+	responseChanMap      sync.Map
+	subscriptionStatsMap sync.Map
+	frameObserver        atomic.Value
+	responseSizeLimit    int64
+	decodeSizeLimit      int64
 
 	timeout time.Duration
+	monitor *monitor
 }
 
 func newWSEvents(cdc *amino.Codec, remote, endpoint string) *WSEvents {
@@ -76,6 +84,7 @@ func newWSEvents(cdc *amino.Codec, remote, endpoint string) *WSEvents {
 		timeout:              DefaultTimeout,
 		responsesCh:          make(chan rpctypes.RPCResponse),
 		reconnect:            make(chan *WSClient),
+		monitor:              &monitor{},
 	}
 
 	wsEvents.BaseService = *cmn.NewBaseService(nil, "WSEvents", wsEvents)
@@ -84,7 +93,7 @@ func newWSEvents(cdc *amino.Codec, remote, endpoint string) *WSEvents {
 
 // OnStart implements cmn.Service by starting WSClient and event loop.
 func (w *WSEvents) OnStart() error {
-	wsClient := NewWSClient(w.remote, w.endpoint, w.responsesCh, setOnDialSuccess(w.redoSubscriptionsAfter))
+	wsClient := NewWSClient(w.remote, w.endpoint, w.responsesCh, setOnDialSuccess(w.redoSubscriptionsAfter), setOnFrame(w.observeFrame), setReadLimit(w.maxResponseSize), setOnThrottle(w.monitor.observeThrottle))
 	wsClient.SetCodec(w.cdc)
 	err := wsClient.Start()
 	if err != nil {
@@ -159,11 +168,51 @@ func (w *WSEvents) Subscribe(query string,
 	w.subscriptionsIdMap[query] = id
 	w.subscriptionSet[id] = true
 	w.mtx.Unlock()
+	w.subscriptionStatsMap.Store(id, newSubscriptionStats(query))
 	go w.WaitForEventResponse(id, outResp, outEvent, quit)
 
 	return outEvent, nil
 }
 
+// SubscribeWithContext is like Subscribe, but the subscription lives as long as ctx.
+// Once ctx is done the query is unsubscribed on the node and the returned channel
+// is closed. It is closed as well if the subscription ends before.
+func (w *WSEvents) SubscribeWithContext(ctx context.Context, query string,
+	outCapacity ...int) (<-chan ctypes.ResultEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	events, err := w.Subscribe(query, outCapacity...)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan ctypes.ResultEvent, cap(events))
+	go func() {
+		defer close(out)
+		defer func() {
+			if err := w.Unsubscribe(query); err != nil {
+				w.Logger.Error("failed to unsubscribe canceled subscription", "query", query, "err", err)
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
 // Unsubscribe implements EventsClient by using WSClient to unsubscribe given
 // subscriber from query.
 func (w *WSEvents) Unsubscribe(query string) error {
@@ -177,6 +226,7 @@ func (w *WSEvents) Unsubscribe(query string) error {
 	if id, ok := w.subscriptionsIdMap[query]; ok {
 		delete(w.subscriptionSet, id)
 		w.responseChanMap.Delete(id)
+		w.subscriptionStatsMap.Delete(id)
 	}
 	if quit, ok := w.subscriptionsQuitMap[query]; ok {
 		close(quit)
@@ -200,6 +250,7 @@ func (w *WSEvents) UnsubscribeAll() error {
 	w.mtx.Lock()
 	for _, id := range w.subscriptionsIdMap {
 		w.responseChanMap.Delete(id)
+		w.subscriptionStatsMap.Delete(id)
 	}
 	for _, quit := range w.subscriptionsQuitMap {
 		close(quit)
@@ -225,6 +276,7 @@ func (w *WSEvents) getWsClient() *WSClient {
 }
 
 func (w *WSEvents) WaitForEventResponse(requestId interface{}, in chan rpctypes.RPCResponse, eventOut chan ctypes.ResultEvent, quit chan struct{}) {
+	stats := w.subscriptionStats(requestId)
 
 	for {
 		select {
@@ -243,9 +295,20 @@ func (w *WSEvents) WaitForEventResponse(requestId interface{}, in chan rpctypes.
 			err := w.cdc.UnmarshalJSON(resp.Result, res)
 			if err != nil {
 				w.Logger.Debug("receive unexpected data from event stream", "result", resp.Result)
+				w.monitor.observeDecodeError(err)
+				if stats != nil {
+					stats.decodeError()
+				}
 				continue
 			}
-			eventOut <- *res
+			select {
+			case eventOut <- *res:
+				if stats != nil {
+					stats.delivered(*res)
+				}
+			case <-quit:
+				return
+			}
 		}
 	}
 }
@@ -259,13 +322,19 @@ func (w *WSEvents) WaitForResponse(ctx context.Context, outChan chan rpctypes.RP
 		if resp.Error != nil {
 			return resp.Error
 		}
-		return w.cdc.UnmarshalJSON(resp.Result, result)
+		if err := w.cdc.UnmarshalJSON(resp.Result, result); err != nil {
+			w.monitor.observeDecodeError(err)
+			return err
+		}
+		return nil
 	case <-ctx.Done():
 		w.reconnect <- ws
 		return ctx.Err()
 	}
 }
 
+// SimpleCall sends a request and waits for its response. Errors carry the
+// json-rpc id of the request, see types.RequestID.
 func (w *WSEvents) SimpleCall(doRpc func(ctx context.Context, id rpctypes.JSONRPCStringID) error, ws *WSClient, proto interface{}) error {
 	id, err := ws.GenRequestId()
 	if err != nil {
@@ -277,10 +346,18 @@ func (w *WSEvents) SimpleCall(doRpc func(ctx context.Context, id rpctypes.JSONRP
 	defer w.responseChanMap.Delete(id)
 	ctx, cancel := w.NewContext()
 	defer cancel()
-	if err = doRpc(ctx, id); err != nil {
-		return err
+	ctx, info := withCallInfo(ctx)
+	start := time.Now()
+	defer func() {
+		w.monitor.observeCall(info, time.Since(start), err)
+	}()
+	if err = doRpc(ctx, id); err == nil {
+		err = w.WaitForResponse(ctx, outChan, proto, ws)
+	}
+	if err != nil {
+		w.Logger.Debug("rpc call failed", "method", info.method, "request id", id, "err", err)
 	}
-	return w.WaitForResponse(ctx, outChan, proto, ws)
+	return gtypes.WithRequestID(err, string(id))
 }
 
 func (w *WSEvents) Status() (*ctypes.ResultStatus, error) {
@@ -479,8 +556,14 @@ func (w *WSEvents) NewContext() (context.Context, context.CancelFunc) {
 // After being reconnected, it is necessary to redo subscription to server
 // otherwise no data will be automatically received.
 func (w *WSEvents) redoSubscriptionsAfter() {
-
+	// the dial succeeds in its own goroutine, while subscriptions may be added
+	w.mtx.RLock()
+	subscriptions := make(map[string]rpctypes.JSONRPCStringID, len(w.subscriptionsIdMap))
 	for q, id := range w.subscriptionsIdMap {
+		subscriptions[q] = id
+	}
+	w.mtx.RUnlock()
+	for q, id := range subscriptions {
 		ctx, _ := context.WithTimeout(context.Background(), w.timeout)
 		err := w.getWsClient().Subscribe(ctx, id, q)
 		if err != nil {
@@ -505,7 +588,7 @@ func (w *WSEvents) reconnectRoutine() {
 		case <-checkTicker.C:
 			if !w.getWsClient().IsRunning() {
 				w.Logger.Info("ws client have been stopped, try start new one", "server", w.getWsClient())
-				wsClient := NewWSClient(w.remote, w.endpoint, w.responsesCh, setOnDialSuccess(w.redoSubscriptionsAfter))
+				wsClient := NewWSClient(w.remote, w.endpoint, w.responsesCh, setOnDialSuccess(w.redoSubscriptionsAfter), setOnFrame(w.observeFrame), setReadLimit(w.maxResponseSize), setOnThrottle(w.monitor.observeThrottle))
 				wsClient.SetCodec(w.cdc)
 				err := wsClient.Start()
 				// should not happen
@@ -548,6 +631,9 @@ func (w *WSEvents) eventListener() {
 				case outChan <- resp:
 				default:
 					w.Logger.Error("wanted to publish response, but out channel is full", "result", resp.Result)
+					if stats := w.subscriptionStats(realId); stats != nil {
+						stats.dropped()
+					}
 				}
 			}
 		case <-w.Quit():
@@ -592,6 +678,12 @@ type WSClient struct {
 	protocol string
 
 	onDialSuccess func()
+	onFrame       func(FrameDirection, []byte)
+	readLimit     func() int64
+	onThrottle    func(retryAfter time.Duration)
+
+	// retryAt is when a gateway that throttled the handshake lets us dial again.
+	retryAt time.Time
 }
 
 // NewWSClient returns a new client. See the commentary on the func(*WSClient)
@@ -682,6 +774,7 @@ func (c *WSClient) Call(ctx context.Context, method string, id rpctypes.JSONRPCS
 	if !c.IsActive() {
 		return errors.New("websocket client is dialing or stopped, can't send any request")
 	}
+	recordCallInfo(ctx, method, params)
 	request, err := rpctypes.MapToRequest(c.cdc, id, method, params)
 	if err != nil {
 		return err
@@ -714,9 +807,20 @@ func (c *WSClient) dial() error {
 		Proxy:   http.ProxyFromEnvironment,
 	}
 	rHeader := http.Header{}
-	conn, _, err := dialer.Dial(c.protocol+"://"+c.Address+c.Endpoint, rHeader)
+	conn, resp, err := dialer.Dial(c.protocol+"://"+c.Address+c.Endpoint, rHeader)
 	if err != nil {
-		return err
+		if resp == nil {
+			return err
+		}
+		throttled := gtypes.NewThrottledError(resp.StatusCode, resp.Header, err, time.Now())
+		if throttled == nil {
+			return err
+		}
+		c.retryAt = time.Now().Add(throttled.RetryAfter)
+		if c.onThrottle != nil {
+			c.onThrottle(throttled.RetryAfter)
+		}
+		return throttled
 	}
 	// only do once during the lifecycle of WSClient
 	c.conn = conn
@@ -743,7 +847,10 @@ func (c *WSClient) dialRoutine() {
 		select {
 		case <-c.Quit():
 			return
-		case <-dialTicker.C:
+		case now := <-dialTicker.C:
+			if now.Before(c.retryAt) {
+				continue
+			}
 			err := c.dial()
 			if err == nil {
 				return
@@ -774,7 +881,20 @@ func (c *WSClient) writeRoutine() {
 					c.Logger.Error("failed to set write deadline", "err", err)
 				}
 			}
-			if err := c.conn.WriteJSON(request); err != nil {
+			buf := bufpool.Default.Get()
+			if err := json.NewEncoder(buf).Encode(request); err != nil {
+				bufpool.Default.Put(buf)
+				c.Logger.Error("failed to encode request", "err", err)
+				continue
+			}
+			// drop the newline Encode appends
+			frame := bytes.TrimSuffix(buf.Bytes(), []byte{'\n'})
+			if c.onFrame != nil {
+				c.onFrame(FrameOutbound, frame)
+			}
+			err := c.conn.WriteMessage(websocket.TextMessage, frame)
+			bufpool.Default.Put(buf)
+			if err != nil {
 				c.Logger.Error("failed to send request", "err", err)
 				c.Stop()
 				return
@@ -816,19 +936,39 @@ func (c *WSClient) readRoutine() {
 				c.Logger.Error("failed to set read deadline", "err", err)
 			}
 		}
-		_, data, err := c.conn.ReadMessage()
+		_, r, err := c.conn.NextReader()
 		if err != nil {
 			c.Logger.Error("failed to read response", "err", err)
 			c.Stop()
 			return
 		}
+		// the rest of an oversized frame is discarded by the next NextReader
+		buf := bufpool.Default.Get()
+		data, err := readLimited(buf, r, c.maxFrameSize())
+		if err == ExceedResponseSizeError {
+			bufpool.Default.Put(buf)
+			c.Logger.Error("dropped oversized response", "limit", c.maxFrameSize())
+			continue
+		} else if err != nil {
+			bufpool.Default.Put(buf)
+			c.Logger.Error("failed to read response", "err", err)
+			c.Stop()
+			return
+		}
+		if c.onFrame != nil {
+			c.onFrame(FrameInbound, data)
+		}
 
+		// the result is copied out of the frame, so the buffer can go back
+		// before the response is handed on
 		var response rpctypes.RPCResponse
 		err = json.Unmarshal(data, &response)
 		if err != nil {
 			c.Logger.Error("failed to parse response", "err", err, "data", string(data))
+			bufpool.Default.Put(buf)
 			continue
 		}
+		bufpool.Default.Put(buf)
 		// Combine a non-blocking read on BaseService.Quit with a non-blocking write on responsesCh to avoid blocking
 		// c.wg.Wait() in c.Stop(). Note we rely on Quit being closed so that it sends unlimited Quit signals to stop
 		// both readRoutine and writeRoutine
@@ -957,6 +1097,24 @@ func setOnDialSuccess(onDial func()) func(c *WSClient) {
 	}
 }
 
+func setOnFrame(onFrame func(FrameDirection, []byte)) func(c *WSClient) {
+	return func(c *WSClient) {
+		c.onFrame = onFrame
+	}
+}
+
+func setOnThrottle(onThrottle func(retryAfter time.Duration)) func(c *WSClient) {
+	return func(c *WSClient) {
+		c.onThrottle = onThrottle
+	}
+}
+
+func setReadLimit(readLimit func() int64) func(c *WSClient) {
+	return func(c *WSClient) {
+		c.readLimit = readLimit
+	}
+}
+
 func makeHTTPDialer(remoteAddr string) (string, string, func(string, string) (net.Conn, error)) {
 	// protocol to use for http operations, to support both http and https
 	clientProtocol := protoHTTP
@@ -1010,17 +1168,26 @@ func formatTxResult(cdc *amino.Codec, res *ResultTx) (Info, error) {
 	if err != nil {
 		return Info{}, err
 	}
-	return Info{
+	info := Info{
 		Hash:   res.Hash,
 		Height: res.Height,
 		Tx:     parsedTx,
 		Result: res.TxResult,
-	}, nil
+	}
+	if stdTx, ok := parsedTx.(tx.StdTx); ok {
+		info.Source = stdTx.Source
+		info.Data = stdTx.Data
+	}
+	info.SourceName = tx.SourceName(info.Source)
+	return info, nil
 }
 
 func ParseTx(cdc *amino.Codec, txBytes []byte) (tx.Tx, error) {
+	if len(txBytes) > maxTxLength {
+		return nil, ExceedTxLengthError
+	}
 	var parsedTx tx.StdTx
-	err := cdc.UnmarshalBinaryLengthPrefixed(txBytes, &parsedTx)
+	err := unmarshalBinaryLengthPrefixed(cdc, txBytes, &parsedTx)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,156 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// fakeNode is the websocket endpoint of a node. It records the subscribe and
+// unsubscribe requests it gets and publishes events to the subscribed ids.
+type fakeNode struct {
+	*httptest.Server
+	mtx      sync.Mutex
+	requests []string
+	ids      map[string]string
+	conn     *websocket.Conn
+}
+
+func newFakeNode(t *testing.T) *fakeNode {
+	node := &fakeNode{ids: map[string]string{}}
+	upgrader := websocket.Upgrader{}
+	node.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		node.mtx.Lock()
+		node.conn = conn
+		node.mtx.Unlock()
+		for {
+			var request struct {
+				ID     string `json:"id"`
+				Method string `json:"method"`
+				Params struct {
+					Query string `json:"query"`
+				} `json:"params"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			node.mtx.Lock()
+			node.requests = append(node.requests, request.Method+" "+request.Params.Query)
+			if request.Method == "subscribe" {
+				node.ids[request.Params.Query] = request.ID
+			}
+			node.mtx.Unlock()
+		}
+	}))
+	return node
+}
+
+func (node *fakeNode) received() []string {
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	return append([]string(nil), node.requests...)
+}
+
+func (node *fakeNode) publish(t *testing.T, w *WSEvents, query string, event ctypes.ResultEvent) {
+	result, err := w.cdc.MarshalJSON(event)
+	assert.NoError(t, err)
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	response := map[string]interface{}{"jsonrpc": "2.0", "id": node.ids[query] + "#event", "result": json.RawMessage(result)}
+	assert.NoError(t, node.conn.WriteJSON(response))
+}
+
+func startWSEvents(t *testing.T, node *fakeNode) *WSEvents {
+	w := newHTTP("tcp://"+node.Listener.Addr().String(), "/websocket").WSEvents
+	assert.NoError(t, w.Start())
+	return w
+}
+
+func waitClosed(t *testing.T, events <-chan ctypes.ResultEvent) {
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("the subscription channel is not closed")
+		}
+	}
+}
+
+func TestSubscribeWithContextCanceledBeforeFirstEvent(t *testing.T) {
+	node := newFakeNode(t)
+	defer node.Close()
+	w := startWSEvents(t, node)
+	defer w.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := w.SubscribeWithContext(ctx, newBlockHeaderQuery)
+	assert.Equal(t, context.Canceled, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	events, err := w.SubscribeWithContext(ctx, newBlockHeaderQuery)
+	assert.NoError(t, err)
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "no event is delivered after the cancel")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the subscription channel is not closed")
+	}
+	assert.Eventually(t, func() bool { return len(node.received()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"subscribe " + newBlockHeaderQuery, "unsubscribe " + newBlockHeaderQuery}, node.received())
+
+	// the query can be subscribed again
+	events, err = w.SubscribeWithContext(context.Background(), newBlockHeaderQuery)
+	assert.NoError(t, err)
+	assert.NotNil(t, events)
+}
+
+func TestSubscribeWithContextDoubleUnsubscribe(t *testing.T) {
+	node := newFakeNode(t)
+	defer node.Close()
+	w := startWSEvents(t, node)
+	defer w.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := w.SubscribeWithContext(ctx, newBlockHeaderQuery)
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return len(node.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	header := types.EventDataNewBlockHeader{}
+	header.Header.Height = 7
+	node.publish(t, w, newBlockHeaderQuery, ctypes.ResultEvent{Query: newBlockHeaderQuery, Data: header})
+	select {
+	case event := <-events:
+		assert.Equal(t, int64(7), event.Data.(types.EventDataNewBlockHeader).Header.Height)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the event is not delivered")
+	}
+
+	// unsubscribing by hand and then canceling unsubscribes twice, which must not
+	// close the quit channel of the subscription twice
+	assert.NoError(t, w.Unsubscribe(newBlockHeaderQuery))
+	cancel()
+	waitClosed(t, events)
+	assert.NoError(t, w.Unsubscribe(newBlockHeaderQuery))
+
+	_, err = w.Subscribe(newBlockHeaderQuery)
+	assert.NoError(t, err, "the query is forgotten once unsubscribed")
+}
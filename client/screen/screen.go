@@ -0,0 +1,177 @@
+// Package screen vets txs before they are broadcast, e.g. against sanctions
+// lists. Screening functions are registered on a Chain, which the rpc and
+// transaction clients run on every tx they broadcast. The first function that
+// returns an error vetoes the tx.
+package screen
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+type Reason string
+
+const (
+	// ReasonSanctioned is a tx involving an address on a sanctions list.
+	ReasonSanctioned Reason = "sanctioned"
+	// ReasonDenied is a tx involving an address denied by the user.
+	ReasonDenied Reason = "denied"
+	// ReasonPolicy is a tx refused for any other rule of the user.
+	ReasonPolicy Reason = "policy"
+)
+
+// Rejection is the error returned for a vetoed tx.
+type Rejection struct {
+	Reason Reason
+	// Screener is the name the vetoing function was registered with.
+	Screener string
+	// Address is the address that caused the veto, if any.
+	Address types.AccAddress
+	Message string
+}
+
+func (r *Rejection) Error() string {
+	if len(r.Address) > 0 {
+		return fmt.Sprintf("tx rejected by screener %s (%s) for %s: %s", r.Screener, r.Reason, r.Address, r.Message)
+	}
+	return fmt.Sprintf("tx rejected by screener %s (%s): %s", r.Screener, r.Reason, r.Message)
+}
+
+// Tx is a tx about to be broadcast.
+type Tx struct {
+	Msgs   []msg.Msg
+	Memo   string
+	Source int64
+}
+
+// Func screens a tx. It returns a *Rejection to veto it, any other error, e.g.
+// an unavailable list, stops the broadcast too.
+type Func func(t Tx) error
+
+// Chain runs screening functions in the order they were registered. It is
+// safe for concurrent use.
+type Chain struct {
+	mtx   sync.RWMutex
+	funcs []named
+}
+
+type named struct {
+	name string
+	f    Func
+}
+
+func NewChain() *Chain {
+	return &Chain{}
+}
+
+// Register adds f under name, replacing the function registered under the
+// same name.
+func (c *Chain) Register(name string, f Func) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	// Screen iterates the slice unlocked, it is replaced rather than modified
+	funcs := make([]named, 0, len(c.funcs)+1)
+	replaced := false
+	for _, n := range c.funcs {
+		if n.name == name {
+			n.f, replaced = f, true
+		}
+		funcs = append(funcs, n)
+	}
+	if !replaced {
+		funcs = append(funcs, named{name: name, f: f})
+	}
+	c.funcs = funcs
+}
+
+func (c *Chain) Unregister(name string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	funcs := make([]named, 0, len(c.funcs))
+	for _, n := range c.funcs {
+		if n.name != name {
+			funcs = append(funcs, n)
+		}
+	}
+	c.funcs = funcs
+}
+
+// Screen runs t through the functions until one returns an error. Rejections
+// get the name of their function.
+func (c *Chain) Screen(t Tx) error {
+	c.mtx.RLock()
+	funcs := c.funcs
+	c.mtx.RUnlock()
+	for _, n := range funcs {
+		err := n.f(t)
+		if err == nil {
+			continue
+		}
+		if r, ok := err.(*Rejection); ok {
+			if r.Screener == "" {
+				r.Screener = n.name
+			}
+			return r
+		}
+		return fmt.Errorf("screener %s: %v", n.name, err)
+	}
+	return nil
+}
+
+// Addresses returns every address a msg involves, recipients included, once.
+func Addresses(m msg.Msg) []types.AccAddress {
+	addrs := m.GetInvolvedAddresses()
+	if htlt, ok := m.(msg.HTLTMsg); ok {
+		// the recipient of a swap is not among the involved addresses
+		addrs = append(addrs, htlt.To)
+	}
+	unique := addrs[:0:0]
+	for _, addr := range addrs {
+		seen := false
+		for _, u := range unique {
+			seen = seen || bytes.Equal(u, addr)
+		}
+		if !seen && len(addr) > 0 {
+			unique = append(unique, addr)
+		}
+	}
+	return unique
+}
+
+// DenyAddresses returns a Func that vetoes, with reason, the txs involving an
+// address for which listed returns true.
+func DenyAddresses(reason Reason, listed func(addr types.AccAddress) (bool, error)) Func {
+	return func(t Tx) error {
+		for _, m := range t.Msgs {
+			for _, addr := range Addresses(m) {
+				ok, err := listed(addr)
+				if err != nil {
+					return err
+				}
+				if ok {
+					return &Rejection{Reason: reason, Address: addr, Message: fmt.Sprintf("%s msg involves a listed address", m.Type())}
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// AddressSet is a set of addresses, for DenyAddresses.
+type AddressSet map[string]bool
+
+func NewAddressSet(addrs ...types.AccAddress) AddressSet {
+	s := AddressSet{}
+	for _, addr := range addrs {
+		s[string(addr)] = true
+	}
+	return s
+}
+
+func (s AddressSet) Contains(addr types.AccAddress) (bool, error) {
+	return s[string(addr)], nil
+}
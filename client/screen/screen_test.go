@@ -0,0 +1,62 @@
+package screen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func addr(s string) types.AccAddress {
+	return types.AccAddress([]byte(s + "-address-bytes-padding")[:types.AddrLen])
+}
+
+func send(from, to types.AccAddress) msg.Msg {
+	coins := types.Coins{{Denom: "BNB", Amount: 1}}
+	return msg.CreateSendMsg(from, coins, []msg.Transfer{{ToAddr: to, Coins: coins}})
+}
+
+func TestChain(t *testing.T) {
+	alice, bob, mallory := addr("alice"), addr("bob"), addr("mallory")
+	chain := NewChain()
+	chain.Register("sanctions", DenyAddresses(ReasonSanctioned, NewAddressSet(mallory).Contains))
+	chain.Register("memo", func(t Tx) error {
+		if t.Memo == "" {
+			return &Rejection{Reason: ReasonPolicy, Message: "memo required"}
+		}
+		return nil
+	})
+
+	assert.NoError(t, chain.Screen(Tx{Msgs: []msg.Msg{send(alice, bob)}, Memo: "1"}))
+
+	err := chain.Screen(Tx{Msgs: []msg.Msg{send(alice, bob), send(alice, mallory)}, Memo: "1"})
+	r, ok := err.(*Rejection)
+	assert.True(t, ok)
+	assert.Equal(t, ReasonSanctioned, r.Reason)
+	assert.Equal(t, "sanctions", r.Screener)
+	assert.Equal(t, mallory, r.Address)
+
+	err = chain.Screen(Tx{Msgs: []msg.Msg{send(alice, bob)}})
+	assert.Equal(t, ReasonPolicy, err.(*Rejection).Reason)
+	assert.Equal(t, "memo", err.(*Rejection).Screener)
+
+	chain.Unregister("memo")
+	assert.NoError(t, chain.Screen(Tx{Msgs: []msg.Msg{send(alice, bob)}}))
+
+	// errors other than rejections stop the broadcast too
+	chain.Register("sanctions", DenyAddresses(ReasonSanctioned, func(types.AccAddress) (bool, error) {
+		return false, errors.New("list unavailable")
+	}))
+	err = chain.Screen(Tx{Msgs: []msg.Msg{send(alice, bob)}})
+	assert.EqualError(t, err, "screener sanctions: list unavailable")
+}
+
+func TestAddressesOfSwap(t *testing.T) {
+	alice, bob := addr("alice"), addr("bob")
+	htlt := msg.NewHTLTMsg(alice, bob, "", "", []byte{1}, 1, types.Coins{{Denom: "BNB", Amount: 1}}, "1:BNB", 360, false)
+	assert.Contains(t, Addresses(htlt), bob)
+	assert.Len(t, Addresses(send(alice, alice)), 1)
+}
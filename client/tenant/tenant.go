@@ -0,0 +1,235 @@
+// Package tenant signs and broadcasts txs for many accounts over one node
+// connection, for platforms offering chain access to their own customers.
+// Every tenant has its key manager, its sequence and its rate limit, and the
+// Manager routes each request to the tenant named by its id.
+package tenant
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/pool"
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	gtypes "github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// Client is the part of the rpc client the tenants share. *rpc.HTTP satisfies
+// it, its own key manager is not used.
+type Client interface {
+	GetAccount(addr types.AccAddress) (types.Account, error)
+	BroadcastIdempotent(signedTx []byte, syncType rpc.SyncType) (*core_types.ResultBroadcastTx, error)
+}
+
+type Tenant struct {
+	ID         string
+	KeyManager keys.KeyManager
+	// Limits bounds the requests of the tenant in flight and per second, the
+	// zero value allows DefaultConcurrency requests and no rate limit.
+	Limits pool.Config
+	// Source is the source code of the txs of the tenant, see tx.RegisterSource.
+	Source int64
+}
+
+// UnknownTenantError is returned for requests naming no tenant of the manager.
+type UnknownTenantError struct {
+	ID string
+}
+
+func (e *UnknownTenantError) Error() string {
+	return fmt.Sprintf("unknown tenant %q", e.ID)
+}
+
+// Manager holds the tenants. It is safe for concurrent use.
+type Manager struct {
+	client  Client
+	chainID string
+
+	mtx     sync.RWMutex
+	tenants map[string]*tenant
+}
+
+type tenant struct {
+	Tenant
+	addr types.AccAddress
+	pool *pool.QueryPool
+
+	// mtx serializes the txs of the tenant, so they reach the node in the
+	// order of their sequences.
+	mtx           sync.Mutex
+	synced        bool
+	accountNumber int64
+	sequence      int64
+}
+
+func NewManager(client Client, chainID string) *Manager {
+	return &Manager{client: client, chainID: chainID, tenants: map[string]*tenant{}}
+}
+
+// Add adds a tenant, its account is queried on its first tx.
+func (m *Manager) Add(t Tenant) error {
+	if t.ID == "" || t.KeyManager == nil {
+		return fmt.Errorf("a tenant needs an id and a key manager")
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.tenants[t.ID]; ok {
+		return fmt.Errorf("tenant %q exists already", t.ID)
+	}
+	m.tenants[t.ID] = &tenant{Tenant: t, addr: t.KeyManager.GetAddr(), pool: pool.NewQueryPool(t.Limits)}
+	return nil
+}
+
+// Remove removes a tenant, requests in flight complete.
+func (m *Manager) Remove(id string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.tenants, id)
+}
+
+// IDs returns the ids of the tenants, sorted.
+func (m *Manager) IDs() []string {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	ids := make([]string, 0, len(m.tenants))
+	for id := range m.tenants {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Address returns the address of a tenant, the signer of its msgs.
+func (m *Manager) Address(id string) (types.AccAddress, error) {
+	t, err := m.tenant(id)
+	if err != nil {
+		return nil, err
+	}
+	return t.addr, nil
+}
+
+// Sign signs msgs for a tenant with its next sequence, for txs broadcast
+// elsewhere. Every msg must be signed by the tenant alone.
+func (m *Manager) Sign(ctx context.Context, id string, msgs []msg.Msg, options ...tx.Option) ([]byte, error) {
+	t, err := m.tenant(id)
+	if err != nil {
+		return nil, err
+	}
+	var signedTx []byte
+	err = t.pool.Do(ctx, func() error {
+		t.mtx.Lock()
+		defer t.mtx.Unlock()
+		signedTx, err = m.sign(t, msgs, options...)
+		return err
+	})
+	return signedTx, err
+}
+
+// Broadcast signs and broadcasts msgs for a tenant. A tx the node rejects
+// makes the next tx of the tenant query its sequence again.
+func (m *Manager) Broadcast(ctx context.Context, id string, msgs []msg.Msg, syncType rpc.SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	t, err := m.tenant(id)
+	if err != nil {
+		return nil, err
+	}
+	var res *core_types.ResultBroadcastTx
+	err = t.pool.Do(ctx, func() error {
+		t.mtx.Lock()
+		defer t.mtx.Unlock()
+		signedTx, err := m.sign(t, msgs, options...)
+		if err != nil {
+			return err
+		}
+		res, err = m.client.BroadcastIdempotent(signedTx, syncType)
+		switch {
+		case err != nil && gtypes.Classify(err) == gtypes.ErrorClassSequence:
+			t.synced = false
+		case err == nil && res.Code != 0 && syncType != rpc.Commit:
+			// rejected by CheckTx, the sequence was not used
+			t.synced = false
+		}
+		// other errors may hide a tx that reached the node, the sequence
+		// stays used and a wrong guess is caught by the next tx
+		return err
+	})
+	return res, err
+}
+
+// Resync makes the next tx of a tenant query its sequence again.
+func (m *Manager) Resync(id string) error {
+	t, err := m.tenant(id)
+	if err != nil {
+		return err
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.synced = false
+	return nil
+}
+
+func (m *Manager) tenant(id string) (*tenant, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	t, ok := m.tenants[id]
+	if !ok {
+		return nil, &UnknownTenantError{ID: id}
+	}
+	return t, nil
+}
+
+// sign signs msgs with the next sequence of t, t.mtx must be held.
+func (m *Manager) sign(t *tenant, msgs []msg.Msg, options ...tx.Option) ([]byte, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no msgs to sign")
+	}
+	for _, mg := range msgs {
+		for _, signer := range mg.GetSigners() {
+			if !bytes.Equal(signer, t.addr) {
+				return nil, fmt.Errorf("%s msg is signed by %s, not by tenant %q", mg.Type(), signer, t.ID)
+			}
+		}
+		if err := mg.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	signMsg := &tx.StdSignMsg{
+		ChainID:       m.chainID,
+		AccountNumber: -1,
+		Sequence:      -1,
+		Msgs:          msgs,
+		Source:        t.Source,
+	}
+	for _, option := range options {
+		signMsg = option(signMsg)
+	}
+	if signMsg.AccountNumber != -1 && signMsg.Sequence != -1 {
+		// the caller manages the sequence, the local one can't be trusted
+		t.synced = false
+		return t.KeyManager.Sign(*signMsg)
+	}
+	if !t.synced {
+		acc, err := m.client.GetAccount(t.addr)
+		if err != nil {
+			return nil, err
+		}
+		if acc == nil {
+			return nil, fmt.Errorf("account of tenant %q not found", t.ID)
+		}
+		t.accountNumber, t.sequence, t.synced = acc.GetAccountNumber(), acc.GetSequence(), true
+	}
+	signMsg.AccountNumber, signMsg.Sequence = t.accountNumber, t.sequence
+	signedTx, err := t.KeyManager.Sign(*signMsg)
+	if err != nil {
+		return nil, err
+	}
+	t.sequence++
+	return signedTx, nil
+}
@@ -0,0 +1,99 @@
+package tenant
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/pool"
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type fakeClient struct {
+	mtx       sync.Mutex
+	sequences map[string]int64
+	queries   int
+	reject    bool
+	sent      []tx.StdTx
+}
+
+func (c *fakeClient) GetAccount(addr types.AccAddress) (types.Account, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.queries++
+	return &types.AppAccount{BaseAccount: types.BaseAccount{Address: addr, AccountNumber: 1, Sequence: c.sequences[addr.String()]}}, nil
+}
+
+func (c *fakeClient) BroadcastIdempotent(signedTx []byte, syncType rpc.SyncType) (*core_types.ResultBroadcastTx, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	parsed, err := rpc.ParseTx(tx.Cdc, signedTx)
+	if err != nil {
+		return nil, err
+	}
+	c.sent = append(c.sent, parsed.(tx.StdTx))
+	if c.reject {
+		return &core_types.ResultBroadcastTx{Code: 65540, Log: "insufficient funds"}, nil
+	}
+	return &core_types.ResultBroadcastTx{Hash: tx.Hash(signedTx)}, nil
+}
+
+func send(from types.AccAddress) []msg.Msg {
+	coins := types.Coins{{Denom: "BNB", Amount: 1}}
+	return []msg.Msg{msg.CreateSendMsg(from, coins, []msg.Transfer{{ToAddr: from, Coins: coins}})}
+}
+
+func TestManager(t *testing.T) {
+	alice, err := keys.NewKeyManager()
+	assert.NoError(t, err)
+	bob, err := keys.NewKeyManager()
+	assert.NoError(t, err)
+	client := &fakeClient{sequences: map[string]int64{alice.GetAddr().String(): 10, bob.GetAddr().String(): 3}}
+	m := NewManager(client, "test-chain")
+	assert.NoError(t, m.Add(Tenant{ID: "alice", KeyManager: alice}))
+	assert.NoError(t, m.Add(Tenant{ID: "bob", KeyManager: bob, Limits: pool.Config{Concurrency: 1, RatePerSecond: 1000, Burst: 10}}))
+	assert.Error(t, m.Add(Tenant{ID: "bob", KeyManager: bob}))
+	assert.Equal(t, []string{"alice", "bob"}, m.IDs())
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := m.Broadcast(ctx, "alice", send(alice.GetAddr()), rpc.Sync)
+		assert.NoError(t, err)
+	}
+	_, err = m.Broadcast(ctx, "bob", send(bob.GetAddr()), rpc.Sync)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, client.queries, "one account query per tenant")
+	assert.Equal(t, int64(10), client.sent[0].Signatures[0].Sequence)
+	assert.Equal(t, int64(11), client.sent[1].Signatures[0].Sequence)
+	assert.Equal(t, int64(3), client.sent[2].Signatures[0].Sequence)
+	assert.Equal(t, bob.GetAddr().Bytes(), client.sent[2].Signatures[0].PubKey.Address().Bytes())
+
+	// msgs of another tenant are refused
+	_, err = m.Broadcast(ctx, "alice", send(bob.GetAddr()), rpc.Sync)
+	assert.Error(t, err)
+	_, err = m.Sign(ctx, "carol", send(bob.GetAddr()))
+	assert.IsType(t, &UnknownTenantError{}, err)
+
+	// a rejected tx did not use its sequence
+	client.reject = true
+	res, err := m.Broadcast(ctx, "bob", send(bob.GetAddr()), rpc.Sync)
+	assert.NoError(t, err)
+	assert.NotZero(t, res.Code)
+	client.reject = false
+	client.sequences[bob.GetAddr().String()] = 4
+	_, err = m.Broadcast(ctx, "bob", send(bob.GetAddr()), rpc.Sync)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, client.queries)
+	assert.Equal(t, int64(4), client.sent[len(client.sent)-1].Signatures[0].Sequence)
+
+	m.Remove("bob")
+	_, err = m.Address("bob")
+	assert.Error(t, err)
+}
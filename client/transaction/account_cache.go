@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// accountCache remembers the account number and next sequence of the signer so
+// a broadcast does not have to query the account first. A sequence is handed out
+// as soon as it is reserved so concurrent broadcasts get different ones.
+type accountCache struct {
+	mtx      sync.Mutex
+	enabled  bool
+	valid    bool
+	number   int64
+	sequence int64
+}
+
+func (a *accountCache) setEnabled(enabled bool) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.enabled = enabled
+	a.valid = false
+}
+
+// reserve returns the cached account number and the next sequence, and moves
+// the cache to the sequence after it.
+func (a *accountCache) reserve() (number, sequence int64, ok bool) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if !a.enabled || !a.valid {
+		return 0, 0, false
+	}
+	number, sequence = a.number, a.sequence
+	a.sequence++
+	return number, sequence, true
+}
+
+// store caches an account fetched from the chain whose next tx uses sequence,
+// which the caller is about to use.
+func (a *accountCache) store(number, sequence int64) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if !a.enabled {
+		return
+	}
+	if !a.valid || sequence+1 > a.sequence {
+		a.number, a.sequence, a.valid = number, sequence+1, true
+	}
+}
+
+func (a *accountCache) invalidate() {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.valid = false
+}
+
+// observe invalidates the cache when a tx signed by addr uses a sequence the
+// cache has not handed out, meaning another process signs for the account too.
+func (a *accountCache) observe(addr []byte, t tx.StdTx) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if !a.valid {
+		return
+	}
+	for _, sig := range t.Signatures {
+		if sig.PubKey != nil && bytes.Equal(sig.PubKey.Address(), addr) && sig.Sequence >= a.sequence {
+			a.valid = false
+			return
+		}
+	}
+}
+
+// SetAccountCache turns on or off caching the account number and sequence of
+// the signer between broadcasts. The cache is dropped whenever a broadcast fails,
+// as the chain may not have used the sequence.
+func (c *client) SetAccountCache(enabled bool) {
+	c.accounts.setEnabled(enabled)
+}
+
+// InvalidateAccountCache makes the next broadcast query the account again.
+func (c *client) InvalidateAccountCache() {
+	c.accounts.invalidate()
+}
+
+// ObserveTx lets the account cache see a tx included in a block, for example
+// fed by the FollowTxs of an rpc client. A tx of the signer with a sequence the
+// cache did not hand out invalidates it.
+func (c *client) ObserveTx(t tx.StdTx) {
+	c.accounts.observe(c.keyManager.GetAddr(), t)
+}
@@ -0,0 +1,50 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+func TestAccountCache(t *testing.T) {
+	var a accountCache
+	a.store(7, 3)
+	_, _, ok := a.reserve()
+	assert.False(t, ok, "a disabled cache stores nothing")
+
+	a.setEnabled(true)
+	a.store(7, 3)
+	number, sequence, ok := a.reserve()
+	assert.True(t, ok)
+	assert.Equal(t, int64(7), number)
+	assert.Equal(t, int64(4), sequence)
+	_, sequence, _ = a.reserve()
+	assert.Equal(t, int64(5), sequence)
+
+	a.invalidate()
+	_, _, ok = a.reserve()
+	assert.False(t, ok)
+}
+
+func TestAccountCacheObserve(t *testing.T) {
+	key := secp256k1.GenPrivKey().PubKey()
+	other := secp256k1.GenPrivKey().PubKey()
+	var a accountCache
+	a.setEnabled(true)
+	a.store(7, 3)
+	a.reserve()
+
+	// txs with sequences the cache handed out and txs of other signers are fine
+	a.observe(key.Address(), tx.StdTx{Signatures: []tx.StdSignature{{PubKey: key, Sequence: 4}}})
+	a.observe(key.Address(), tx.StdTx{Signatures: []tx.StdSignature{{PubKey: other, Sequence: 9}}})
+	_, sequence, ok := a.reserve()
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), sequence)
+
+	a.observe(key.Address(), tx.StdTx{Signatures: []tx.StdSignature{{PubKey: key, Sequence: 6}}})
+	_, _, ok = a.reserve()
+	assert.False(t, ok)
+}
@@ -15,6 +15,9 @@ func (c *client) BurnToken(symbol string, amount int64, sync bool, options ...Op
 	if symbol == "" {
 		return nil, fmt.Errorf("Burn token symbol can't be empty ")
 	}
+	if err := c.checkTokenOwner(symbol, false); err != nil {
+		return nil, err
+	}
 	fromAddr := c.keyManager.GetAddr()
 
 	burnMsg := msg.NewTokenBurnMsg(
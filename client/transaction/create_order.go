@@ -18,12 +18,18 @@ func (c *client) CreateOrder(baseAssetSymbol, quoteAssetSymbol string, op int8,
 	if baseAssetSymbol == "" || quoteAssetSymbol == "" {
 		return nil, fmt.Errorf("BaseAssetSymbol or QuoteAssetSymbol is missing. ")
 	}
+	symbol := common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol)
+	if c.orderGuard != nil {
+		if err := c.orderGuard.Check(symbol, op, price, quantity); err != nil {
+			return nil, err
+		}
+	}
 	fromAddr := c.keyManager.GetAddr()
 	newOrderMsg := msg.NewCreateOrderMsg(
 		fromAddr,
 		"",
 		op,
-		common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol),
+		symbol,
 		price,
 		quantity,
 	)
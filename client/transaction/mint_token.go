@@ -15,6 +15,9 @@ func (c *client) MintToken(symbol string, amount int64, sync bool, options ...Op
 	if symbol == "" {
 		return nil, fmt.Errorf("Mint token symbol can't be empty ")
 	}
+	if err := c.checkTokenOwner(symbol, true); err != nil {
+		return nil, err
+	}
 	fromAddr := c.keyManager.GetAddr()
 
 	mintMsg := msg.NewMintMsg(
@@ -0,0 +1,28 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/client/screen"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func TestScreenerVetoesBeforeSigning(t *testing.T) {
+	km, err := keys.NewPrivateKeyManager("9579fff0cab07a4379e845a890105004ba4c8276f8ad9d22082b2acbf02d884b")
+	assert.NoError(t, err)
+	denied := types.AccAddress([]byte("denied-address-bytes-padding")[:types.AddrLen])
+	chain := screen.NewChain()
+	chain.Register("deny", screen.DenyAddresses(screen.ReasonDenied, screen.NewAddressSet(denied).Contains))
+
+	// no query or basic client: a vetoed tx must not reach them
+	c := NewClient("test", km, nil, nil)
+	c.(ScreenerSetter).SetScreener(chain)
+	_, err = c.SendToken([]msg.Transfer{{ToAddr: denied, Coins: types.Coins{{Denom: "BNB", Amount: 1}}}}, true)
+	r, ok := err.(*screen.Rejection)
+	assert.True(t, ok)
+	assert.Equal(t, screen.ReasonDenied, r.Reason)
+}
@@ -13,7 +13,7 @@ type SetAccountFlagsResult struct {
 
 func (c *client) AddAccountFlags(flagOptions []types.FlagOption, sync bool, options ...Option) (*SetAccountFlagsResult, error) {
 	fromAddr := c.keyManager.GetAddr()
-	acc, err := c.queryClient.GetAccount(fromAddr.String())
+	acc, err := c.queryClient.GetAccount(c.address())
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,9 @@ type SetUriResult struct {
 }
 
 func (c *client) SetURI(symbol, tokenURI string, sync bool, options ...Option) (*SetUriResult, error) {
+	if err := c.checkTokenOwner(symbol, false); err != nil {
+		return nil, err
+	}
 	fromAddr := c.keyManager.GetAddr()
 
 	setURIMsg := msg.NewSetUriMsg(fromAddr, symbol, tokenURI)
@@ -0,0 +1,84 @@
+package transaction
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+// tokenPageSize is the number of tokens asked for per page while looking a
+// symbol up, the most the api returns.
+const tokenPageSize = 1000
+
+var (
+	NotTokenOwnerError    = errors.New("the key is not the owner of the token")
+	TokenNotMintableError = errors.New("the token is not mintable")
+	TokenNotFoundError    = errors.New("no token matches the symbol")
+)
+
+type tokenOwnership struct {
+	symbol   string
+	owner    types.AccAddress
+	mintable bool
+}
+
+// getTokenOwner looks the symbol up as a mini token or a regular token,
+// depending on its suffix, paging through the tokens of the api.
+func (c *client) getTokenOwner(symbol string) (*tokenOwnership, error) {
+	mini := msg.IsValidMiniTokenSymbol(symbol)
+	for offset := uint32(0); ; offset += tokenPageSize {
+		tokens, err := c.tokenPage(mini, types.NewTokensQuery().WithOffset(offset).WithLimit(tokenPageSize))
+		if err != nil {
+			return nil, err
+		}
+		for i := range tokens {
+			if tokens[i].symbol == symbol {
+				return &tokens[i], nil
+			}
+		}
+		if len(tokens) < tokenPageSize {
+			return nil, TokenNotFoundError
+		}
+	}
+}
+
+func (c *client) tokenPage(mini bool, query *types.TokensQuery) ([]tokenOwnership, error) {
+	var page []tokenOwnership
+	if mini {
+		tokens, err := c.queryClient.GetMiniTokens(query)
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range tokens {
+			page = append(page, tokenOwnership{symbol: token.Symbol, owner: token.Owner, mintable: token.Mintable})
+		}
+		return page, nil
+	}
+	tokens, err := c.queryClient.GetTokens(query)
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		page = append(page, tokenOwnership{symbol: token.Symbol, owner: token.Owner, mintable: token.Mintable})
+	}
+	return page, nil
+}
+
+// checkTokenOwner fails before anything is signed when the key does not own
+// the token, or when mint is set and the token is not mintable, rather than
+// leaving it to the chain to reject the tx.
+func (c *client) checkTokenOwner(symbol string, mint bool) error {
+	ownership, err := c.getTokenOwner(symbol)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(ownership.owner, c.keyManager.GetAddr()) {
+		return NotTokenOwnerError
+	}
+	if mint && !ownership.mintable {
+		return TokenNotMintableError
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/client/query"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+)
+
+// fakeTokens serves the token lists of the api, the other queries are not
+// expected.
+type fakeTokens struct {
+	query.QueryClient
+	tokens     []types.Token
+	miniTokens []types.MiniToken
+}
+
+func (f *fakeTokens) GetTokens(q *types.TokensQuery) ([]types.Token, error) {
+	start, end := page(q, len(f.tokens))
+	return f.tokens[start:end], nil
+}
+
+func (f *fakeTokens) GetMiniTokens(q *types.TokensQuery) ([]types.MiniToken, error) {
+	start, end := page(q, len(f.miniTokens))
+	return f.miniTokens[start:end], nil
+}
+
+func page(q *types.TokensQuery, n int) (int, int) {
+	start, end := int(*q.Offset), int(*q.Offset)+int(*q.Limit)
+	if start > n {
+		start = n
+	}
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+func TestTokenOwnerCheck(t *testing.T) {
+	km, err := keys.NewPrivateKeyManager("9579fff0cab07a4379e845a890105004ba4c8276f8ad9d22082b2acbf02d884b")
+	assert.NoError(t, err)
+	other := types.AccAddress([]byte("another-token-owner-"))
+	tokens := &fakeTokens{miniTokens: []types.MiniToken{{Symbol: "MINE-000M", Owner: other}}}
+	for i := 0; i < tokenPageSize; i++ {
+		tokens.tokens = append(tokens.tokens, types.Token{Symbol: "FILL-000", Owner: other})
+	}
+	tokens.tokens = append(tokens.tokens,
+		types.Token{Symbol: "OTHER-000", Owner: other, Mintable: true},
+		types.Token{Symbol: "FIXED-000", Owner: km.GetAddr()})
+
+	// no basic client: a rejected tx must not be broadcast
+	c := NewClient("test", km, tokens, nil)
+	_, err = c.MintToken("OTHER-000", 1, true)
+	assert.Equal(t, NotTokenOwnerError, err, "the token is on the second page")
+	_, err = c.BurnToken("OTHER-000", 1, true)
+	assert.Equal(t, NotTokenOwnerError, err)
+	_, err = c.TransferTokenOwnership("OTHER-000", km.GetAddr(), true)
+	assert.Equal(t, NotTokenOwnerError, err)
+	_, err = c.SetURI("MINE-000M", "https://example.com", true)
+	assert.Equal(t, NotTokenOwnerError, err)
+
+	_, err = c.MintToken("FIXED-000", 1, true)
+	assert.Equal(t, TokenNotMintableError, err)
+	_, err = c.BurnToken("NONE-000", 1, true)
+	assert.Equal(t, TokenNotFoundError, err)
+}
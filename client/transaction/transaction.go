@@ -7,8 +7,11 @@ import (
 
 	"github.com/binance-chain/go-sdk/client/basic"
 	"github.com/binance-chain/go-sdk/client/query"
+	"github.com/binance-chain/go-sdk/client/screen"
+	"github.com/binance-chain/go-sdk/common/bufpool"
 	"github.com/binance-chain/go-sdk/common/types"
 	"github.com/binance-chain/go-sdk/keys"
+	gtypes "github.com/binance-chain/go-sdk/types"
 	"github.com/binance-chain/go-sdk/types/msg"
 	"github.com/binance-chain/go-sdk/types/tx"
 )
@@ -19,6 +22,7 @@ var (
 	WithSource           = tx.WithSource
 	WithMemo             = tx.WithMemo
 	WithAcNumAndSequence = tx.WithAcNumAndSequence
+	WithData             = tx.WithData
 )
 
 type TransactionClient interface {
@@ -53,6 +57,26 @@ type TransactionClient interface {
 	SetURI(symbol, tokenURI string, sync bool, options ...Option) (*SetUriResult, error)
 
 	GetKeyManager() keys.KeyManager
+	SetOrderGuard(g OrderGuard)
+	SetSource(source int64)
+	SetAccountCache(enabled bool)
+	InvalidateAccountCache()
+	ObserveTx(t tx.StdTx)
+}
+
+// OrderGuard vets new orders before they are signed, see the guard package.
+type OrderGuard interface {
+	Check(symbol string, side int8, price, quantity int64) error
+}
+
+// Screener vets txs before they are signed, see the screen package.
+type Screener interface {
+	Screen(t screen.Tx) error
+}
+
+// ScreenerSetter is implemented by the clients of NewClient, see SetScreener.
+type ScreenerSetter interface {
+	SetScreener(s Screener)
 }
 
 type client struct {
@@ -60,10 +84,49 @@ type client struct {
 	queryClient query.QueryClient
 	keyManager  keys.KeyManager
 	chainId     string
+	orderGuard  OrderGuard
+	screener    Screener
+	accounts    accountCache
+	source      int64
+	// network is nil to follow types.Network.
+	network *types.ChainNetwork
+}
+
+// address renders the address of the key with the prefix of the network of
+// the client.
+func (c *client) address() string {
+	addr := c.keyManager.GetAddr()
+	if c.network != nil {
+		return c.network.FormatAddress(addr)
+	}
+	return addr.String()
 }
 
 func NewClient(chainId string, keyManager keys.KeyManager, queryClient query.QueryClient, basicClient basic.BasicClient) TransactionClient {
-	return &client{basicClient, queryClient, keyManager, chainId}
+	return &client{basicClient: basicClient, queryClient: queryClient, keyManager: keyManager, chainId: chainId}
+}
+
+// NewNetworkClient is NewClient for a chain of network, which may be another
+// one than types.Network. The txs are signed with the addresses of network.
+func NewNetworkClient(chainId string, network types.ChainNetwork, keyManager keys.KeyManager, queryClient query.QueryClient, basicClient basic.BasicClient) TransactionClient {
+	return &client{basicClient: basicClient, queryClient: queryClient, keyManager: keyManager, chainId: chainId, network: &network}
+}
+
+// SetOrderGuard makes CreateOrder run every order through g first. A nil g
+// removes the guard.
+func (c *client) SetOrderGuard(g OrderGuard) {
+	c.orderGuard = g
+}
+
+// SetScreener makes every tx run through s before it is signed. A nil s
+// removes the screener.
+func (c *client) SetScreener(s Screener) {
+	c.screener = s
+}
+
+// SetSource sets the source code of the txs the client signs, see tx.RegisterSource.
+func (c *client) SetSource(source int64) {
+	c.source = source
 }
 
 func (c *client) GetKeyManager() keys.KeyManager {
@@ -78,21 +141,35 @@ func (c *client) broadcastMsg(m msg.Msg, sync bool, options ...Option) (*tx.TxCo
 		Sequence:      -1,
 		Memo:          "",
 		Msgs:          []msg.Msg{m},
-		Source:        tx.Source,
+		Source:        c.source,
+		Network:       c.network,
 	}
 
 	for _, op := range options {
 		signMsg = op(signMsg)
 	}
 
-	if signMsg.Sequence == -1 || signMsg.AccountNumber == -1 {
-		fromAddr := c.keyManager.GetAddr()
-		acc, err := c.queryClient.GetAccount(fromAddr.String())
-		if err != nil {
+	if c.screener != nil {
+		if err := c.screener.Screen(screen.Tx{Msgs: signMsg.Msgs, Memo: signMsg.Memo, Source: signMsg.Source}); err != nil {
 			return nil, err
 		}
-		signMsg.Sequence = acc.Sequence
-		signMsg.AccountNumber = acc.Number
+	}
+
+	if signMsg.Sequence == -1 || signMsg.AccountNumber == -1 {
+		if number, sequence, ok := c.accounts.reserve(); ok {
+			signMsg.AccountNumber, signMsg.Sequence = number, sequence
+		} else {
+			acc, err := c.queryClient.GetAccount(c.address())
+			if err != nil {
+				return nil, err
+			}
+			signMsg.Sequence = acc.Sequence
+			signMsg.AccountNumber = acc.Number
+			c.accounts.store(acc.Number, acc.Sequence)
+		}
+	} else {
+		// the caller manages the sequence, the cache can't tell what is used
+		defer c.accounts.invalidate()
 	}
 
 	// special logic for createOrder, to save account query
@@ -103,26 +180,44 @@ func (c *client) broadcastMsg(m msg.Msg, sync bool, options ...Option) (*tx.TxCo
 
 	for _, m := range signMsg.Msgs {
 		if err := m.ValidateBasic(); err != nil {
+			c.accounts.invalidate()
 			return nil, err
 		}
 	}
 
 	rawBz, err := c.keyManager.Sign(*signMsg)
 	if err != nil {
+		c.accounts.invalidate()
 		return nil, err
 	}
-	// Hex encoded signed transaction, ready to be posted to BncChain API
-	hexTx := []byte(hex.EncodeToString(rawBz))
+	// Hex encoded signed transaction, ready to be posted to BncChain API. It
+	// is not needed once posted, so it is encoded into a pooled buffer.
+	hexTx := bufpool.Default.Get()
+	defer bufpool.Default.Put(hexTx)
+	hex.NewEncoder(hexTx).Write(rawBz)
 	param := map[string]string{}
 	if sync {
 		param["sync"] = "true"
 	}
-	commits, err := c.basicClient.PostTx(hexTx, param)
+	commits, err := c.basicClient.PostTx(hexTx.Bytes(), param)
 	if err != nil {
+		c.accounts.invalidate()
 		return nil, err
 	}
 	if len(commits) < 1 {
+		c.accounts.invalidate()
 		return nil, fmt.Errorf("Len of tx Commit result is less than 1 ")
 	}
+	if !commits[0].Ok || commits[0].Code != 0 {
+		c.accounts.invalidate()
+	}
 	return &commits[0], nil
 }
+
+// CommitError returns the classified error of a rejected tx, or nil if res succeeded.
+func CommitError(res *tx.TxCommitResult) error {
+	if res == nil || (res.Ok && res.Code == tx.CodeOk) {
+		return nil
+	}
+	return gtypes.NewABCIError(uint32(res.Code), res.Log)
+}
@@ -11,6 +11,9 @@ type TransferTokenOwnershipResult struct {
 }
 
 func (c *client) TransferTokenOwnership(symbol string, newOwner types.AccAddress, sync bool, options ...Option) (*TransferTokenOwnershipResult, error) {
+	if err := c.checkTokenOwner(symbol, false); err != nil {
+		return nil, err
+	}
 	fromAddr := c.keyManager.GetAddr()
 	transferOwnershipMsg := msg.NewTransferOwnershipMsg(fromAddr, symbol, newOwner)
 	commit, err := c.broadcastMsg(transferOwnershipMsg, sync, options...)
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/binance-chain/go-sdk/client"
+	"github.com/binance-chain/go-sdk/client/transaction"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+type adapter struct {
+	c v1.DexClient
+}
+
+// FromV1 wraps a v1 client, the two can be used side by side.
+func FromV1(c v1.DexClient) Client {
+	return &adapter{c: c}
+}
+
+func (a *adapter) V1() v1.DexClient {
+	return a.c
+}
+
+func (a *adapter) Account(ctx context.Context, addr string) (*types.BalanceAccount, error) {
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetAccount(addr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*types.BalanceAccount), nil
+}
+
+func (a *adapter) Time(ctx context.Context) (*types.Time, error) {
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetTime()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*types.Time), nil
+}
+
+func (a *adapter) NodeInfo(ctx context.Context) (*types.ResultStatus, error) {
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetNodeInfo()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*types.ResultStatus), nil
+}
+
+func (a *adapter) Tokens(ctx context.Context, opts ...QueryOption) ([]types.Token, error) {
+	o := applyQueryOptions(opts)
+	query := &types.TokensQuery{Offset: o.offset, Limit: o.limit}
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetTokens(query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]types.Token), nil
+}
+
+func (a *adapter) Markets(ctx context.Context, opts ...QueryOption) ([]types.TradingPair, error) {
+	o := applyQueryOptions(opts)
+	query := &types.MarketsQuery{Offset: o.offset, Limit: o.limit}
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetMarkets(query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]types.TradingPair), nil
+}
+
+func (a *adapter) PairInfo(ctx context.Context, pair string) (*types.PairInfo, error) {
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetPairInfo(pair)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*types.PairInfo), nil
+}
+
+func (a *adapter) Depth(ctx context.Context, pair string, opts ...QueryOption) (*types.MarketDepth, error) {
+	base, quote, err := splitPair(pair)
+	if err != nil {
+		return nil, err
+	}
+	query := types.NewDepthQuery(base, quote)
+	query.Limit = applyQueryOptions(opts).limit
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetDepth(query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*types.MarketDepth), nil
+}
+
+func (a *adapter) Ticker24h(ctx context.Context, pair string) (*types.Ticker24h, error) {
+	base, quote, err := splitPair(pair)
+	if err != nil {
+		return nil, err
+	}
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetTicker24h(types.NewTicker24hQuery().WithSymbol(base, quote))
+	})
+	if err != nil {
+		return nil, err
+	}
+	tickers := res.([]types.Ticker24h)
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("no ticker for %s", pair)
+	}
+	return &tickers[0], nil
+}
+
+func (a *adapter) Trades(ctx context.Context, pair string, opts ...QueryOption) (*types.Trades, error) {
+	base, quote, err := splitPair(pair)
+	if err != nil {
+		return nil, err
+	}
+	o := applyQueryOptions(opts)
+	query := types.NewTradesQuery(false).WithSymbol(base, quote)
+	query.Offset, query.Limit = o.offset, o.limit
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetTrades(query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*types.Trades), nil
+}
+
+func (a *adapter) Order(ctx context.Context, id string) (*types.Order, error) {
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetOrder(id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*types.Order), nil
+}
+
+func (a *adapter) OpenOrders(ctx context.Context, addr string, opts ...QueryOption) (*types.OpenOrders, error) {
+	o := applyQueryOptions(opts)
+	query := types.NewOpenOrdersQuery(addr, false)
+	query.Offset, query.Limit = o.offset, o.limit
+	res, err := do(ctx, func() (interface{}, error) {
+		return a.c.GetOpenOrders(query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*types.OpenOrders), nil
+}
+
+func (a *adapter) CreateOrder(ctx context.Context, order Order, opts ...TxOption) (*transaction.CreateOrderResult, error) {
+	base, quote, err := splitPair(order.Pair)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	o := applyTxOptions(opts)
+	return a.c.CreateOrder(base, quote, order.Side, order.Price, order.Quantity, !o.async, o.options...)
+}
+
+func (a *adapter) CancelOrder(ctx context.Context, pair, refID string, opts ...TxOption) (*transaction.CancelOrderResult, error) {
+	base, quote, err := splitPair(pair)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	o := applyTxOptions(opts)
+	return a.c.CancelOrder(base, quote, refID, !o.async, o.options...)
+}
+
+func (a *adapter) Send(ctx context.Context, transfers []msg.Transfer, opts ...TxOption) (*transaction.SendTokenResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	o := applyTxOptions(opts)
+	return a.c.SendToken(transfers, !o.async, o.options...)
+}
@@ -0,0 +1,201 @@
+// Package client is the stable, context-first api of the dex client. It wraps
+// the v1 client in github.com/binance-chain/go-sdk/client, whose signatures stay
+// as they are, so code can move call by call: FromV1 wraps an existing v1
+// client and V1 hands it back for calls v2 does not cover yet.
+//
+// Import it under a name of its own:
+//
+//	clientv2 "github.com/binance-chain/go-sdk/client/v2"
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "github.com/binance-chain/go-sdk/client"
+	"github.com/binance-chain/go-sdk/client/transaction"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+// Client is the v2 dex client. Pairs are given as "BASE_QUOTE".
+//
+// Reads return ctx.Err() as soon as ctx is done, the request is abandoned.
+// Txs only check ctx before they are signed: once broadcast is under way they
+// run to completion, as an abandoned tx may still be committed.
+type Client interface {
+	Account(ctx context.Context, addr string) (*types.BalanceAccount, error)
+	Time(ctx context.Context) (*types.Time, error)
+	NodeInfo(ctx context.Context) (*types.ResultStatus, error)
+	Tokens(ctx context.Context, opts ...QueryOption) ([]types.Token, error)
+	Markets(ctx context.Context, opts ...QueryOption) ([]types.TradingPair, error)
+	PairInfo(ctx context.Context, pair string) (*types.PairInfo, error)
+	Depth(ctx context.Context, pair string, opts ...QueryOption) (*types.MarketDepth, error)
+	Ticker24h(ctx context.Context, pair string) (*types.Ticker24h, error)
+	Trades(ctx context.Context, pair string, opts ...QueryOption) (*types.Trades, error)
+	Order(ctx context.Context, id string) (*types.Order, error)
+	OpenOrders(ctx context.Context, addr string, opts ...QueryOption) (*types.OpenOrders, error)
+
+	CreateOrder(ctx context.Context, order Order, opts ...TxOption) (*transaction.CreateOrderResult, error)
+	CancelOrder(ctx context.Context, pair, refID string, opts ...TxOption) (*transaction.CancelOrderResult, error)
+	Send(ctx context.Context, transfers []msg.Transfer, opts ...TxOption) (*transaction.SendTokenResult, error)
+
+	// V1 returns the wrapped v1 client.
+	V1() v1.DexClient
+}
+
+// Order is a new limit order. Price and Quantity are Fixed8 values.
+type Order struct {
+	Pair string
+	// Side is msg.OrderSide.BUY or msg.OrderSide.SELL.
+	Side     int8
+	Price    int64
+	Quantity int64
+}
+
+// Option configures a client built by New.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	keyManager keys.KeyManager
+	apiKey     string
+	isolated   bool
+}
+
+// WithKeyManager sets the key txs are signed with, reads need none.
+func WithKeyManager(km keys.KeyManager) Option {
+	return func(o *clientOptions) {
+		o.keyManager = km
+	}
+}
+
+// WithAPIKey sends requests to the internal api with the key.
+func WithAPIKey(key string) Option {
+	return func(o *clientOptions) {
+		o.apiKey = key
+	}
+}
+
+// WithIsolatedNetwork leaves types.Network alone, so clients of different
+// networks can share a process. Txs are signed for the chain of the node, with
+// the addresses of the network passed to New.
+func WithIsolatedNetwork() Option {
+	return func(o *clientOptions) {
+		o.isolated = true
+	}
+}
+
+// New connects to the api at baseURL, a host like "testnet-dex.binance.org".
+// Like v1 it sets the process wide types.Network, unless WithIsolatedNetwork.
+func New(ctx context.Context, baseURL string, network types.ChainNetwork, opts ...Option) (Client, error) {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	res, err := do(ctx, func() (interface{}, error) {
+		if o.isolated {
+			return v1.NewIsolatedDexClient(baseURL, network, o.keyManager, o.apiKey)
+		} else if o.apiKey != "" {
+			return v1.NewDexClientWithApiKey(baseURL, network, o.keyManager, o.apiKey)
+		}
+		return v1.NewDexClient(baseURL, network, o.keyManager)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return FromV1(res.(v1.DexClient)), nil
+}
+
+// QueryOption narrows a read.
+type QueryOption func(*queryOptions)
+
+type queryOptions struct {
+	offset, limit *uint32
+}
+
+// WithOffset skips the first offset results of a list.
+func WithOffset(offset uint32) QueryOption {
+	return func(o *queryOptions) {
+		o.offset = &offset
+	}
+}
+
+// WithLimit bounds the results of a list, or the levels of a depth.
+func WithLimit(limit uint32) QueryOption {
+	return func(o *queryOptions) {
+		o.limit = &limit
+	}
+}
+
+func applyQueryOptions(opts []QueryOption) queryOptions {
+	var o queryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// TxOption configures a tx.
+type TxOption func(*txOptions)
+
+type txOptions struct {
+	async   bool
+	options []transaction.Option
+}
+
+// Async returns once the tx passed CheckTx, by default txs wait to be committed.
+func Async() TxOption {
+	return func(o *txOptions) {
+		o.async = true
+	}
+}
+
+// WithTxOptions passes v1 tx options, memo, source, sequence and the like.
+func WithTxOptions(options ...transaction.Option) TxOption {
+	return func(o *txOptions) {
+		o.options = append(o.options, options...)
+	}
+}
+
+func applyTxOptions(opts []TxOption) txOptions {
+	var o txOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func splitPair(pair string) (string, string, error) {
+	parts := strings.Split(pair, "_")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("the pair %s should be in format 'symbol1_symbol2'", pair)
+	}
+	return parts[0], parts[1], nil
+}
+
+type result struct {
+	value interface{}
+	err   error
+}
+
+// do runs call until it returns or ctx is done, whichever comes first. The
+// result comes back over a channel, a call that outlives ctx writes nothing
+// the caller still reads.
+func do(ctx context.Context, call func() (interface{}, error)) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := call()
+		done <- result{value: value, err: err}
+	}()
+	select {
+	case res := <-done:
+		return res.value, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/binance-chain/go-sdk/client"
+	"github.com/binance-chain/go-sdk/client/transaction"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+type fakeV1 struct {
+	v1.DexClient
+	depth    *types.DepthQuery
+	block    chan struct{}
+	started  chan struct{}
+	returned chan struct{}
+	orders   int
+	lastTx   bool
+	options  int
+}
+
+func (f *fakeV1) GetDepth(query *types.DepthQuery) (*types.MarketDepth, error) {
+	f.depth = query
+	return &types.MarketDepth{Height: 7}, nil
+}
+
+func (f *fakeV1) GetTime() (*types.Time, error) {
+	<-f.block
+	return &types.Time{}, nil
+}
+
+func (f *fakeV1) GetAccount(addr string) (*types.BalanceAccount, error) {
+	close(f.started)
+	<-f.block
+	defer close(f.returned)
+	return &types.BalanceAccount{Number: 1}, nil
+}
+
+func (f *fakeV1) CreateOrder(base, quote string, op int8, price, quantity int64, sync bool, options ...transaction.Option) (*transaction.CreateOrderResult, error) {
+	f.orders++
+	f.lastTx, f.options = sync, len(options)
+	return &transaction.CreateOrderResult{OrderId: base + "-" + quote}, nil
+}
+
+func TestAdapter(t *testing.T) {
+	fake := &fakeV1{block: make(chan struct{})}
+	c := FromV1(fake)
+	assert.Equal(t, v1.DexClient(fake), c.V1())
+	ctx := context.Background()
+
+	depth, err := c.Depth(ctx, "XYZ-000_BNB", WithLimit(5))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), depth.Height)
+	assert.Equal(t, "XYZ-000_BNB", fake.depth.Symbol)
+	assert.Equal(t, uint32(5), *fake.depth.Limit)
+	_, err = c.Depth(ctx, "XYZ-000")
+	assert.Error(t, err)
+
+	// a read is abandoned when its context is done
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = c.Time(timeout)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	close(fake.block)
+
+	order := Order{Pair: "XYZ-000_BNB", Side: msg.OrderSide.BUY, Price: 1e8, Quantity: 1e8}
+	res, err := c.CreateOrder(ctx, order, Async(), WithTxOptions(transaction.WithMemo("v2")))
+	assert.NoError(t, err)
+	assert.Equal(t, "XYZ-000-BNB", res.OrderId)
+	assert.False(t, fake.lastTx, "async")
+	assert.Equal(t, 1, fake.options)
+	_, err = c.CreateOrder(ctx, order)
+	assert.NoError(t, err)
+	assert.True(t, fake.lastTx, "sync by default")
+
+	canceled, cancelNow := context.WithCancel(ctx)
+	cancelNow()
+	_, err = c.CreateOrder(canceled, order)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 2, fake.orders, "a canceled tx is not sent")
+}
+
+func TestAdapterCanceled(t *testing.T) {
+	// the read finishes while the caller gives up, run with -race
+	for i := 0; i < 100; i++ {
+		fake := &fakeV1{block: make(chan struct{}), started: make(chan struct{}), returned: make(chan struct{})}
+		c := FromV1(fake)
+		ctx, cancel := context.WithCancel(context.Background())
+		type got struct {
+			acc *types.BalanceAccount
+			err error
+		}
+		results := make(chan got)
+		go func() {
+			acc, err := c.Account(ctx, "bnb1")
+			results <- got{acc, err}
+		}()
+		<-fake.started
+		cancel()
+		close(fake.block)
+		res := <-results
+		<-fake.returned
+		if res.err != nil {
+			assert.Equal(t, context.Canceled, res.err)
+			assert.Nil(t, res.acc, "no result with the error")
+		} else {
+			assert.Equal(t, int64(1), res.acc.Number)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package websocket
+
+import (
+	"strconv"
+	"sync"
+	"unsafe"
+
+	"github.com/binance-chain/go-sdk/common/jsonscan"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// MarketDecoder decodes depth and trade messages in place, without the
+// reflection and intermediate values of encoding/json. Decoding into an event
+// that is reused, as the subscriptions do when events are released, does not
+// allocate for depth messages once the book levels have grown to size; the
+// symbol and event type are only copied when they change.
+//
+// A MarketDecoder is not safe for concurrent use, give each goroutine one.
+type MarketDecoder struct {
+	// ZeroCopy makes decoded strings, like trade and order ids, point into
+	// the message instead of copying them. They then keep the whole message
+	// alive and change with it, so only set it when the message buffer is
+	// never reused.
+	ZeroCopy bool
+
+	s jsonscan.Scanner
+}
+
+// DecodeMarketDelta decodes a depthUpdate message into ev, reusing its book
+// levels.
+func (d *MarketDecoder) DecodeMarketDelta(data []byte, ev *MarketDeltaEvent) error {
+	prev := *ev
+	*ev = MarketDeltaEvent{Bids: prev.Bids[:0], Asks: prev.Asks[:0]}
+	d.s.Reset(data)
+	if err := d.s.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		key, ok, err := d.s.NextKey()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch string(key) {
+		case "e":
+			ev.EventType, err = d.str(prev.EventType)
+		case "E":
+			ev.EventTime, err = d.int()
+		case "s":
+			ev.Symbol, err = d.str(prev.Symbol)
+		case "b":
+			ev.Bids, err = d.levels(ev.Bids)
+		case "a":
+			ev.Asks, err = d.levels(ev.Asks)
+		default:
+			_, err = d.s.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return d.s.End()
+}
+
+// DecodeMarketDepth decodes a depth snapshot message into ev, reusing its
+// book levels.
+func (d *MarketDecoder) DecodeMarketDepth(data []byte, ev *MarketDepthEvent) error {
+	prev := *ev
+	*ev = MarketDepthEvent{Bids: prev.Bids[:0], Asks: prev.Asks[:0]}
+	d.s.Reset(data)
+	if err := d.s.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		key, ok, err := d.s.NextKey()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		switch string(key) {
+		case "lastUpdateId":
+			ev.LastUpdateID, err = d.int()
+		case "symbol":
+			ev.Symbol, err = d.str(prev.Symbol)
+		case "bids":
+			ev.Bids, err = d.levels(ev.Bids)
+		case "asks":
+			ev.Asks, err = d.levels(ev.Asks)
+		default:
+			_, err = d.s.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return d.s.End()
+}
+
+// DecodeTrades decodes a trades message, appending to events[:0] and reusing
+// the events already in its capacity.
+func (d *MarketDecoder) DecodeTrades(data []byte, events []*TradeEvent) ([]*TradeEvent, error) {
+	events = events[:0]
+	d.s.Reset(data)
+	if err := d.s.BeginArray(); err != nil {
+		return events, err
+	}
+	for {
+		ok, err := d.s.NextElement()
+		if err != nil {
+			return events, err
+		}
+		if !ok {
+			break
+		}
+		var ev *TradeEvent
+		if len(events) < cap(events) {
+			ev = events[:len(events)+1][len(events)]
+		}
+		if ev == nil {
+			ev = tradePool.Get().(*TradeEvent)
+		}
+		events = append(events, ev)
+		if err := d.trade(ev); err != nil {
+			return events, err
+		}
+	}
+	return events, d.s.End()
+}
+
+func (d *MarketDecoder) trade(ev *TradeEvent) error {
+	prev := *ev
+	*ev = TradeEvent{}
+	if err := d.s.BeginObject(); err != nil {
+		return err
+	}
+	for {
+		key, ok, err := d.s.NextKey()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		switch string(key) {
+		case "e":
+			ev.EventType, err = d.str(prev.EventType)
+		case "E":
+			ev.EventTime, err = d.int()
+		case "s":
+			ev.Symbol, err = d.str(prev.Symbol)
+		case "t":
+			ev.TradeID, err = d.str("")
+		case "p":
+			ev.Price, err = d.fixed8()
+		case "q":
+			ev.Qty, err = d.fixed8()
+		case "b":
+			ev.BuyerOrderID, err = d.str("")
+		case "a":
+			ev.SellerOrderID, err = d.str("")
+		case "T":
+			ev.TradeTime, err = d.int()
+		case "sa":
+			ev.SellerAddress, err = d.str(prev.SellerAddress)
+		case "ba":
+			ev.BuyerAddress, err = d.str(prev.BuyerAddress)
+		default:
+			_, err = d.s.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// levels decodes [["price","qty"],...] into levels[:0], reusing the inner
+// slices within its capacity.
+func (d *MarketDecoder) levels(levels [][]types.Fixed8) ([][]types.Fixed8, error) {
+	levels = levels[:0]
+	if d.s.Null() {
+		return levels, nil
+	}
+	if err := d.s.BeginArray(); err != nil {
+		return levels, err
+	}
+	if levels == nil {
+		// like encoding/json, an empty array is not nil
+		levels = [][]types.Fixed8{}
+	}
+	for {
+		ok, err := d.s.NextElement()
+		if err != nil {
+			return levels, err
+		}
+		if !ok {
+			return levels, nil
+		}
+		var level []types.Fixed8
+		if len(levels) < cap(levels) {
+			level = levels[:len(levels)+1][len(levels)][:0]
+		}
+		if err := d.s.BeginArray(); err != nil {
+			return levels, err
+		}
+		for {
+			ok, err := d.s.NextElement()
+			if err != nil {
+				return levels, err
+			}
+			if !ok {
+				break
+			}
+			f, err := d.fixed8()
+			if err != nil {
+				return levels, err
+			}
+			level = append(level, f)
+		}
+		levels = append(levels, level)
+	}
+}
+
+// str reads a string, or a number as written. prev is returned when equal to
+// avoid copying values that repeat from message to message.
+func (d *MarketDecoder) str(prev string) (string, error) {
+	if d.s.Null() {
+		return "", nil
+	}
+	var raw []byte
+	var escaped bool
+	var err error
+	if d.s.Kind() == jsonscan.Number {
+		raw, err = d.s.Number()
+	} else {
+		raw, escaped, err = d.s.String()
+	}
+	switch {
+	case err != nil:
+		return "", err
+	case escaped:
+		return jsonscan.Unescape(raw)
+	case string(raw) == prev:
+		return prev, nil
+	case d.ZeroCopy && len(raw) > 0:
+		return *(*string)(unsafe.Pointer(&raw)), nil
+	}
+	return string(raw), nil
+}
+
+// int reads an integer, quoted or not.
+func (d *MarketDecoder) int() (int64, error) {
+	if d.s.Kind() != jsonscan.String {
+		return d.s.Int()
+	}
+	raw, _, err := d.s.String()
+	if err != nil {
+		return 0, err
+	}
+	n, ok := jsonscan.ParseInt(raw)
+	if !ok {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// fixed8 reads an amount, a decimal string or, like Fixed8.UnmarshalJSON
+// accepts, a number.
+func (d *MarketDecoder) fixed8() (types.Fixed8, error) {
+	switch d.s.Kind() {
+	case jsonscan.String:
+		raw, escaped, err := d.s.String()
+		if err != nil {
+			return 0, err
+		}
+		if escaped {
+			s, err := jsonscan.Unescape(raw)
+			if err != nil {
+				return 0, err
+			}
+			return types.Fixed8DecodeString(s)
+		}
+		return types.ParseFixed8(raw)
+	case jsonscan.Null:
+		d.s.Null()
+		return 0, nil
+	}
+	raw, err := d.s.Number()
+	if err != nil {
+		return 0, err
+	}
+	if f, err := types.ParseFixed8(raw); err == nil {
+		return f, nil
+	}
+	fl, err := strconv.ParseFloat(string(raw), 64)
+	if err != nil {
+		return 0, err
+	}
+	return types.Fixed8(float64(types.Fixed8Decimals) * fl), nil
+}
+
+var (
+	marketDeltaPool = sync.Pool{New: func() interface{} { return new(MarketDeltaEvent) }}
+	marketDepthPool = sync.Pool{New: func() interface{} { return new(MarketDepthEvent) }}
+	tradePool       = sync.Pool{New: func() interface{} { return new(TradeEvent) }}
+)
+
+// ReleaseMarketDeltaEvent hands an event received from SubscribeMarketDiffEvent
+// back to be decoded into again, sparing the allocations of the next message.
+// Neither the event nor its Bids and Asks may be used afterwards.
+func ReleaseMarketDeltaEvent(ev *MarketDeltaEvent) {
+	marketDeltaPool.Put(ev)
+}
+
+// ReleaseMarketDepthEvent is ReleaseMarketDeltaEvent for SubscribeMarketDepthEvent.
+func ReleaseMarketDepthEvent(ev *MarketDepthEvent) {
+	marketDepthPool.Put(ev)
+}
+
+// ReleaseTradeEvents is ReleaseMarketDeltaEvent for SubscribeTradeEvent.
+func ReleaseTradeEvents(events []*TradeEvent) {
+	for _, ev := range events {
+		tradePool.Put(ev)
+	}
+}
@@ -0,0 +1,110 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const deltaMessage = `{"e":"depthUpdate","E":1568362462591,"s":"BNB_BTC","b":[["0.00210000","10.00000000"],["0.0020","0"]],"a":[["0.0024",5]],"u":7}`
+
+const tradesMessage = `[{"e":"trade","E":1568362462591,"s":"BNB_BTC","t":"1568-0","p":"0.0021","q":"1.5","b":"B1-1","a":"S1-2","T":1568362462590,"sa":"bnb1s","ba":"bnb1b"},
+{"e":"trade","E":1568362462592,"s":"BNB_BTC","t":"1569-0","p":"0.00211","q":"2","b":"B1-3","a":"S1-4","T":1568362462591,"sa":"bnb1s","ba":"bnb1b"}]`
+
+func TestDecoderMatchesEncodingJSON(t *testing.T) {
+	var d MarketDecoder
+
+	var want, got MarketDeltaEvent
+	assert.NoError(t, json.Unmarshal([]byte(deltaMessage), &want))
+	assert.NoError(t, d.DecodeMarketDelta([]byte(deltaMessage), &got))
+	assert.Equal(t, want, got)
+
+	depth := `{"lastUpdateId":160,"symbol":"BNB_BTC","bids":[["0.0024","10"]],"asks":[]}`
+	var wantDepth, gotDepth MarketDepthEvent
+	assert.NoError(t, json.Unmarshal([]byte(depth), &wantDepth))
+	assert.NoError(t, d.DecodeMarketDepth([]byte(depth), &gotDepth))
+	assert.Equal(t, wantDepth, gotDepth)
+
+	var wantTrades []*TradeEvent
+	assert.NoError(t, json.Unmarshal([]byte(tradesMessage), &wantTrades))
+	gotTrades, err := d.DecodeTrades([]byte(tradesMessage), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, wantTrades, gotTrades)
+
+	d.ZeroCopy = true
+	gotTrades, err = d.DecodeTrades([]byte(tradesMessage), gotTrades)
+	assert.NoError(t, err)
+	assert.Equal(t, wantTrades, gotTrades)
+}
+
+func TestDecoderReusesEvents(t *testing.T) {
+	var d MarketDecoder
+	var ev MarketDeltaEvent
+	assert.NoError(t, d.DecodeMarketDelta([]byte(deltaMessage), &ev))
+	// fields missing from the next message are cleared, not left over
+	assert.NoError(t, d.DecodeMarketDelta([]byte(`{"e":"depthUpdate","s":"BNB_BTC","b":[["1","2"]]}`), &ev))
+	assert.Equal(t, int64(0), ev.EventTime)
+	assert.Len(t, ev.Bids, 1)
+	assert.Empty(t, ev.Asks)
+
+	msg := []byte(deltaMessage)
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := d.DecodeMarketDelta(msg, &ev); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestDecoderRejectsMalformed(t *testing.T) {
+	var d MarketDecoder
+	for _, bad := range []string{
+		`{"e":"depthUpdate","b":[["0.1.2","1"]]}`,
+		`{"e":"depthUpdate","b":[["0.000000001","1"]]}`,
+		`{"e":"depthUpdate","E":"soon"}`,
+		`{"e":"depthUpdate"`,
+		`[]`,
+	} {
+		var ev MarketDeltaEvent
+		assert.Error(t, d.DecodeMarketDelta([]byte(bad), &ev), bad)
+	}
+}
+
+func benchmarkDepth(levels int) []byte {
+	var b strings.Builder
+	b.WriteString(`{"e":"depthUpdate","E":1568362462591,"s":"BNB_BTC","b":[`)
+	for i := 0; i < levels; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `["0.00%d","%d.5"]`, 2100+i, i+1)
+	}
+	b.WriteString(`],"a":[]}`)
+	return []byte(b.String())
+}
+
+func BenchmarkDecodeMarketDeltaJSON(b *testing.B) {
+	msg := benchmarkDepth(20)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var ev MarketDeltaEvent
+		if err := json.Unmarshal(msg, &ev); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeMarketDelta(b *testing.B) {
+	msg := benchmarkDepth(20)
+	var d MarketDecoder
+	var ev MarketDeltaEvent
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := d.DecodeMarketDelta(msg, &ev); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
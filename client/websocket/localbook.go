@@ -0,0 +1,334 @@
+package websocket
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/binance-chain/go-sdk/client/query"
+	"github.com/binance-chain/go-sdk/common"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// DepthSnapshotFunc fetches a snapshot of the book, with the height it was
+// taken at.
+type DepthSnapshotFunc func() (*types.MarketDepth, error)
+
+// QueryDepthSnapshot fetches snapshots of up to limit levels from the api
+// server, limit 0 uses the default of the server.
+func QueryDepthSnapshot(q query.QueryClient, baseAssetSymbol, quoteAssetSymbol string, limit uint32) DepthSnapshotFunc {
+	return func() (*types.MarketDepth, error) {
+		param := types.NewDepthQuery(baseAssetSymbol, quoteAssetSymbol)
+		if limit > 0 {
+			param.WithLimit(limit)
+		}
+		return q.GetDepth(param)
+	}
+}
+
+// BookLevel is a price level of a LocalBook.
+type BookLevel struct {
+	Price types.Fixed8
+	Qty   types.Fixed8
+}
+
+// Resync reports a LocalBook that threw its state away and started over from
+// a new snapshot.
+type Resync struct {
+	Symbol string
+	// Height is the height of the snapshot the inconsistent book was synced
+	// from.
+	Height int64
+	// Reason is the inconsistency that was detected.
+	Reason error
+	// Count is the number of resyncs of the book so far, including this one.
+	Count int
+}
+
+// InconsistentBookError is a delta that cannot apply to the book.
+type InconsistentBookError struct {
+	Symbol string
+	// EventTime is the event time of the delta, E.
+	EventTime int64
+	Reason    string
+}
+
+func (e *InconsistentBookError) Error() string {
+	return fmt.Sprintf("book of %s inconsistent at delta of time %d: %s", e.Symbol, e.EventTime, e.Reason)
+}
+
+// maxBufferedDeltas caps the deltas kept while no snapshot could be fetched.
+// The oldest go first, they are the most likely to be in the next snapshot.
+const maxBufferedDeltas = 1000
+
+// LocalBook maintains a book from a snapshot and the marketDiff stream.
+// Deltas carry no height or update id, only the time of the event, E, so they
+// cannot be matched with the height of a snapshot. They set levels to their
+// new quantity though, they do not add to it: the book replays the deltas
+// received since it was last out of sync onto the snapshot, in stream order,
+// and a delta the snapshot already has is overwritten by the ones after it.
+// A delta older than the one before it, or one that leaves the book crossed,
+// means one was lost or reordered, the book then fetches a new snapshot,
+// replays the deltas buffered since and reports a Resync. Replayed deltas may
+// be older than the snapshot, the book is not checked for crossing until the
+// stream goes on.
+//
+// A LocalBook is safe for concurrent use.
+type LocalBook struct {
+	base, quote string
+	symbol      string
+	snapshot    DepthSnapshotFunc
+
+	// OnResync, if set, is called after every resync, on the goroutine that
+	// applied the delta. The book is not locked.
+	OnResync func(Resync)
+
+	mtx     sync.RWMutex
+	bids    map[types.Fixed8]types.Fixed8
+	asks    map[types.Fixed8]types.Fixed8
+	height  int64
+	time    int64
+	synced  bool
+	pending []*MarketDeltaEvent
+	resyncs int
+}
+
+// NewLocalBook returns a book of the pair that fetches snapshots with
+// snapshot. It syncs on the first delta applied.
+func NewLocalBook(baseAssetSymbol, quoteAssetSymbol string, snapshot DepthSnapshotFunc) *LocalBook {
+	return &LocalBook{
+		base:     baseAssetSymbol,
+		quote:    quoteAssetSymbol,
+		symbol:   common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol),
+		snapshot: snapshot,
+		bids:     map[types.Fixed8]types.Fixed8{},
+		asks:     map[types.Fixed8]types.Fixed8{},
+	}
+}
+
+// Subscribe subscribes to the deltas of the pair and applies them,
+// onUpdate is called after each. Snapshots that fail to be fetched go to
+// onError, the book retries with the next delta.
+func (b *LocalBook) Subscribe(c WSClient, quit chan struct{}, onUpdate func(*LocalBook), onError func(err error), onClose func()) error {
+	return c.SubscribeMarketDiffEvent(b.base, b.quote, quit, func(event *MarketDeltaEvent) {
+		err := b.Apply(event)
+		ReleaseMarketDeltaEvent(event)
+		if err != nil {
+			if onError != nil {
+				onError(err)
+			}
+			return
+		}
+		if onUpdate != nil {
+			onUpdate(b)
+		}
+	}, onError, onClose)
+}
+
+// Apply applies a delta of the pair. The event is not retained. It only
+// fails when the book is out of sync and could not be synced again, the
+// next delta then tries once more.
+func (b *LocalBook) Apply(ev *MarketDeltaEvent) error {
+	b.mtx.Lock()
+	var resync *Resync
+	if b.synced {
+		if reason := b.apply(ev, true); reason != nil {
+			b.resyncs++
+			resync = &Resync{Symbol: b.symbol, Height: b.height, Reason: reason, Count: b.resyncs}
+			b.synced = false
+			// the delta may be fine, the book before it was not
+			b.buffer(ev)
+		}
+	} else {
+		b.buffer(ev)
+	}
+	var err error
+	if !b.synced {
+		err = b.sync()
+	}
+	b.mtx.Unlock()
+	if resync != nil && b.OnResync != nil {
+		b.OnResync(*resync)
+	}
+	return err
+}
+
+// apply applies ev, returning why it does not fit the book. A crossed book is
+// only reported with checkCrossed.
+func (b *LocalBook) apply(ev *MarketDeltaEvent, checkCrossed bool) error {
+	inconsistent := func(reason string) error {
+		return &InconsistentBookError{Symbol: b.symbol, EventTime: ev.EventTime, Reason: reason}
+	}
+	if ev.EventTime < b.time {
+		return inconsistent(fmt.Sprintf("delta is older than the one of time %d", b.time))
+	}
+	b.time = ev.EventTime
+	if err := applyLevels(b.bids, ev.Bids); err != nil {
+		return inconsistent(err.Error())
+	}
+	if err := applyLevels(b.asks, ev.Asks); err != nil {
+		return inconsistent(err.Error())
+	}
+	if bid, ask, ok := b.top(); checkCrossed && ok && bid >= ask {
+		return inconsistent(fmt.Sprintf("book crossed, bid %s >= ask %s", bid, ask))
+	}
+	return nil
+}
+
+func applyLevels(book map[types.Fixed8]types.Fixed8, levels [][]types.Fixed8) error {
+	for _, level := range levels {
+		if len(level) < 2 {
+			return fmt.Errorf("malformed level %v", level)
+		}
+		if level[1] == 0 {
+			delete(book, level[0])
+		} else {
+			book[level[0]] = level[1]
+		}
+	}
+	return nil
+}
+
+func (b *LocalBook) buffer(ev *MarketDeltaEvent) {
+	if len(b.pending) == maxBufferedDeltas {
+		b.pending = append(b.pending[:0], b.pending[1:]...)
+	}
+	copied := &MarketDeltaEvent{EventType: ev.EventType, EventTime: ev.EventTime, Symbol: ev.Symbol}
+	copied.Bids = copyLevels(ev.Bids)
+	copied.Asks = copyLevels(ev.Asks)
+	b.pending = append(b.pending, copied)
+}
+
+func copyLevels(levels [][]types.Fixed8) [][]types.Fixed8 {
+	copied := make([][]types.Fixed8, len(levels))
+	for i, level := range levels {
+		copied[i] = append([]types.Fixed8(nil), level...)
+	}
+	return copied
+}
+
+// sync loads a snapshot and replays the buffered deltas onto it. A replayed
+// delta that does not fit is dropped with the rest of the buffer, the next
+// delta syncs again.
+func (b *LocalBook) sync() error {
+	depth, err := b.snapshot()
+	if err != nil {
+		return err
+	}
+	bids, err := snapshotLevels(depth.Bids)
+	if err != nil {
+		return err
+	}
+	asks, err := snapshotLevels(depth.Asks)
+	if err != nil {
+		return err
+	}
+	b.bids, b.asks, b.height, b.time = bids, asks, depth.Height, 0
+	pending := b.pending
+	b.pending = nil
+	for _, ev := range pending {
+		if err := b.apply(ev, false); err != nil {
+			return err
+		}
+	}
+	b.synced = true
+	return nil
+}
+
+func snapshotLevels(levels [][]string) (map[types.Fixed8]types.Fixed8, error) {
+	book := make(map[types.Fixed8]types.Fixed8, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			return nil, fmt.Errorf("malformed level %v", level)
+		}
+		price, err := types.Fixed8DecodeString(level[0])
+		if err != nil {
+			return nil, err
+		}
+		qty, err := types.Fixed8DecodeString(level[1])
+		if err != nil {
+			return nil, err
+		}
+		if qty != 0 {
+			book[price] = qty
+		}
+	}
+	return book, nil
+}
+
+func (b *LocalBook) top() (bid, ask types.Fixed8, ok bool) {
+	if len(b.bids) == 0 || len(b.asks) == 0 {
+		return 0, 0, false
+	}
+	first := true
+	for price := range b.bids {
+		if first || price > bid {
+			bid, first = price, false
+		}
+	}
+	first = true
+	for price := range b.asks {
+		if first || price < ask {
+			ask, first = price, false
+		}
+	}
+	return bid, ask, true
+}
+
+// Symbol returns the pair of the book.
+func (b *LocalBook) Symbol() string {
+	return b.symbol
+}
+
+// Synced reports whether the book is up to date with the stream.
+func (b *LocalBook) Synced() bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.synced
+}
+
+// Height returns the height of the snapshot the book was last synced from,
+// deltas do not move it.
+func (b *LocalBook) Height() int64 {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.height
+}
+
+// EventTime returns the event time, E, of the last delta applied.
+func (b *LocalBook) EventTime() int64 {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.time
+}
+
+// Resyncs returns the number of times the book was found inconsistent.
+func (b *LocalBook) Resyncs() int {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.resyncs
+}
+
+// Depth returns up to n levels of each side, best first, n <= 0 returns all.
+func (b *LocalBook) Depth(n int) (bids, asks []BookLevel) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return sortedLevels(b.bids, n, true), sortedLevels(b.asks, n, false)
+}
+
+func sortedLevels(book map[types.Fixed8]types.Fixed8, n int, desc bool) []BookLevel {
+	levels := make([]BookLevel, 0, len(book))
+	for price, qty := range book {
+		levels = append(levels, BookLevel{Price: price, Qty: qty})
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if desc {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+	if n > 0 && len(levels) > n {
+		levels = levels[:n]
+	}
+	return levels
+}
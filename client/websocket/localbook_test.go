@@ -0,0 +1,86 @@
+package websocket
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+func delta(eventTime int64, bids, asks [][]types.Fixed8) *MarketDeltaEvent {
+	return &MarketDeltaEvent{EventType: "depthUpdate", EventTime: eventTime, Symbol: "BNB_BTCB-1DE", Bids: bids, Asks: asks}
+}
+
+func level(price, qty int64) []types.Fixed8 {
+	return []types.Fixed8{types.Fixed8(price), types.Fixed8(qty)}
+}
+
+func TestLocalBook(t *testing.T) {
+	snapshots := []*types.MarketDepth{
+		{Height: 10, Bids: [][]string{{"0.00000100", "5"}}, Asks: [][]string{{"0.00000200", "5"}}},
+		{Height: 14, Bids: [][]string{{"0.00000101", "1"}}, Asks: [][]string{{"0.00000150", "2"}}},
+		{Height: 15, Bids: [][]string{{"0.00000101", "1"}}, Asks: [][]string{{"0.00000190", "2"}}},
+	}
+	var fetchErr error
+	book := NewLocalBook("BNB", "BTCB-1DE", func() (*types.MarketDepth, error) {
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		s := snapshots[0]
+		snapshots = snapshots[1:]
+		return s, nil
+	})
+	var resyncs []Resync
+	book.OnResync = func(r Resync) { resyncs = append(resyncs, r) }
+
+	// the delta the book synced on is replayed onto the snapshot
+	assert.NoError(t, book.Apply(delta(1000, [][]types.Fixed8{level(90, 1)}, nil)))
+	assert.True(t, book.Synced())
+	assert.Equal(t, int64(10), book.Height())
+	assert.NoError(t, book.Apply(delta(1001, [][]types.Fixed8{level(100, 0), level(110, 3)}, nil)))
+	bids, asks := book.Depth(0)
+	assert.Equal(t, []BookLevel{{Price: 110, Qty: 3}, {Price: 90, Qty: 1}}, bids)
+	assert.Equal(t, []BookLevel{{Price: 200, Qty: 500000000}}, asks)
+	assert.Equal(t, int64(10), book.Height(), "deltas have no height")
+	assert.Equal(t, int64(1001), book.EventTime())
+
+	// deltas of the same event time are fine, an older one resyncs
+	assert.NoError(t, book.Apply(delta(1001, [][]types.Fixed8{level(90, 0)}, nil)))
+	assert.NoError(t, book.Apply(delta(1000, nil, [][]types.Fixed8{level(120, 1)})))
+	assert.Len(t, resyncs, 1)
+	assert.Equal(t, Resync{Symbol: "BNB_BTCB-1DE", Height: 10, Reason: resyncs[0].Reason, Count: 1}, resyncs[0])
+	assert.Equal(t, &InconsistentBookError{Symbol: "BNB_BTCB-1DE", EventTime: 1000, Reason: "delta is older than the one of time 1001"}, resyncs[0].Reason)
+	assert.Equal(t, int64(14), book.Height())
+	bids, asks = book.Depth(0)
+	assert.Equal(t, []BookLevel{{Price: 101, Qty: 100000000}}, bids)
+	assert.Equal(t, []BookLevel{{Price: 120, Qty: 1}, {Price: 150, Qty: 200000000}}, asks)
+
+	// so does a crossed book, the delta is replayed onto the new snapshot
+	fetchErr = errors.New("api down")
+	err := book.Apply(delta(1002, [][]types.Fixed8{level(160, 1)}, nil))
+	assert.Equal(t, fetchErr, err)
+	assert.False(t, book.Synced())
+	assert.Len(t, resyncs, 2)
+	assert.Equal(t, 2, book.Resyncs())
+
+	fetchErr = nil
+	assert.NoError(t, book.Apply(delta(1003, nil, [][]types.Fixed8{level(180, 4)})))
+	assert.True(t, book.Synced())
+	assert.Equal(t, int64(15), book.Height())
+	bids, asks = book.Depth(1)
+	assert.Equal(t, []BookLevel{{Price: 160, Qty: 1}}, bids)
+	assert.Equal(t, []BookLevel{{Price: 180, Qty: 4}}, asks)
+
+	// replayed deltas may be older than the snapshot, they are not checked
+	// for crossing
+	book = NewLocalBook("BNB", "BTCB-1DE", func() (*types.MarketDepth, error) {
+		return &types.MarketDepth{Height: 20, Bids: [][]string{{"0.00000100", "1"}}, Asks: [][]string{{"0.00000110", "1"}}}, nil
+	})
+	assert.NoError(t, book.Apply(delta(2000, [][]types.Fixed8{level(120, 1)}, nil)))
+	assert.NoError(t, book.Apply(delta(2001, [][]types.Fixed8{level(120, 0)}, nil)))
+	assert.Equal(t, 0, book.Resyncs())
+	bids, _ = book.Depth(0)
+	assert.Equal(t, []BookLevel{{Price: 100, Qty: 100000000}}, bids)
+}
@@ -1,7 +1,6 @@
 package websocket
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/binance-chain/go-sdk/common"
@@ -16,11 +15,14 @@ type MarketDeltaEvent struct {
 	Asks      [][]types.Fixed8 `json:"a"` // "a": [ [ "0.0024", "10" ] ]
 }
 
+// SubscribeMarketDiffEvent streams book updates of a pair. Release events with
+// ReleaseMarketDeltaEvent once handled to avoid allocating for each message.
 func (c *client) SubscribeMarketDiffEvent(baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onReceive func(event *MarketDeltaEvent), onError func(err error), onClose func()) error {
+	var decoder MarketDecoder
 	msgs, err := c.baseClient.WsGet(fmt.Sprintf("%s@%s", common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol), "marketDiff"), func(bz []byte) (interface{}, error) {
-		var event MarketDeltaEvent
-		err := json.Unmarshal(bz, &event)
-		return &event, err
+		event := marketDeltaPool.Get().(*MarketDeltaEvent)
+		err := decoder.DecodeMarketDelta(bz, event)
+		return event, err
 	}, quit)
 	if err != nil {
 		return err
@@ -40,11 +42,14 @@ type MarketDepthEvent struct {
 	Asks         [][]types.Fixed8 `json:"asks"`         // "asks": [ [ "0.0024", "10" ] ]
 }
 
+// SubscribeMarketDepthEvent streams book snapshots of a pair. Release events
+// with ReleaseMarketDepthEvent once handled to avoid allocating for each message.
 func (c *client) SubscribeMarketDepthEvent(baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onReceive func(event *MarketDepthEvent), onError func(err error), onClose func()) error {
+	var decoder MarketDecoder
 	msgs, err := c.baseClient.WsGet(fmt.Sprintf("%s@%s", common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol), "marketDepth"), func(bz []byte) (interface{}, error) {
-		var event MarketDepthEvent
-		err := json.Unmarshal(bz, &event)
-		return &event, err
+		event := marketDepthPool.Get().(*MarketDepthEvent)
+		err := decoder.DecodeMarketDepth(bz, event)
+		return event, err
 	}, quit)
 	if err != nil {
 		return err
@@ -16,7 +16,7 @@ type OrderEvent struct {
 	OrderQty             types.Fixed8 `json:"q"` //"q": "1.00000000", `will be published`
 	OrderPrice           types.Fixed8 `json:"p"` //"p": "0.10264410", `will be published`
 	CurrentExecutionType string       `json:"x"` //"x": "NEW", always `NEW` for now `will be published`
-	CurrentOrderStatus   string       `json:"X"` //"X": "Ack", "Canceled", "Expired", "IocNoFill", "PartialFill", "FullyFill", "FailedBlocking", "FailedMatching", "Unknown"
+	CurrentOrderStatus   string       `json:"X"` //"X": "Ack", "Canceled", "Expired", "IocNoFill", "IocExpire", "PartialFill", "FullyFill", "FailedBlocking", "FailedMatching", "Unknown"
 	OrderID              string       `json:"i"` //"i": "917E1846D6B3C40B97465CCF52818471E2C1027C-466",
 	LastExecutedQty      types.Fixed8 `json:"l"` // "l": "0.00000000",
 	LastExecutedPrice    types.Fixed8 `json:"L"` // "L": "0.00000000",
@@ -27,6 +27,38 @@ type OrderEvent struct {
 	OrderCreationTime    int64        `json:"O"` //"O": 1499405658657, `will be published`
 }
 
+// OrderExpiry is an order the match engine removed at the end of a block,
+// see types.ExpiryReason.
+type OrderExpiry struct {
+	Reason       types.ExpiryReason
+	Height       int64
+	Symbol       string
+	OrderID      string
+	Side         int8
+	Price        types.Fixed8
+	Quantity     types.Fixed8
+	CumFilledQty types.Fixed8
+}
+
+// Expiry returns the expiry the event reports, ok is false for any other update
+// of the order.
+func (e *OrderEvent) Expiry() (expiry OrderExpiry, ok bool) {
+	reason, ok := types.ParseExpiryReason(e.CurrentOrderStatus)
+	if !ok {
+		return OrderExpiry{}, false
+	}
+	return OrderExpiry{
+		Reason:       reason,
+		Height:       e.TransactionTime,
+		Symbol:       e.Symbol,
+		OrderID:      e.OrderID,
+		Side:         e.Side,
+		Price:        e.OrderPrice,
+		Quantity:     e.OrderQty,
+		CumFilledQty: e.CommulativeFilledQty,
+	}, true
+}
+
 func (c *client) SubscribeOrderEvent(userAddr string, quit chan struct{}, onReceive func(event []*OrderEvent), onError func(err error), onClose func()) error {
 	msgs, err := c.baseClient.WsGet(userAddr, func(bz []byte) (interface{}, error) {
 		events := make([]*OrderEvent, 0)
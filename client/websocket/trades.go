@@ -1,7 +1,6 @@
 package websocket
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/binance-chain/go-sdk/common"
@@ -22,11 +21,12 @@ type TradeEvent struct {
 	BuyerAddress  string       `json:"ba"` // "ba": 0x4092778e4e78230f46a1534c0fbc8fa39780892c
 }
 
+// SubscribeTradeEvent streams the trades of a pair. Release events with
+// ReleaseTradeEvents once handled to reuse them for the next messages.
 func (c *client) SubscribeTradeEvent(baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onReceive func(events []*TradeEvent), onError func(err error), onClose func()) error {
+	var decoder MarketDecoder
 	msgs, err := c.baseClient.WsGet(fmt.Sprintf("%s@%s", common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol), "trades"), func(bz []byte) (interface{}, error) {
-		events := make([]*TradeEvent, 0)
-		err := json.Unmarshal(bz, &events)
-		return events, err
+		return decoder.DecodeTrades(bz, nil)
 	}, quit)
 	if err != nil {
 		return err
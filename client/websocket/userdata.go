@@ -0,0 +1,241 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+const (
+	userDataStreamPath = "/userDataStream"
+	// ListenKeyKeepAlive is how often a listen key is kept alive, well within the
+	// hour after which the accelerated node expires it.
+	ListenKeyKeepAlive = 30 * time.Minute
+	// userDataRetryDelay is the pause before a dropped user data stream is reopened.
+	userDataRetryDelay = time.Second
+)
+
+// TransferEvent is a transfer from or to the account of a user data stream.
+type TransferEvent struct {
+	EventType string           `json:"e"` // "e": "outboundTransferInfo"
+	EventTime int64            `json:"E"` // "E": 12893, the block height
+	TxHash    string           `json:"H"`
+	From      string           `json:"f"`
+	To        []TransferOutput `json:"t"`
+}
+
+type TransferOutput struct {
+	Address string         `json:"o"`
+	Coins   []TransferCoin `json:"c"`
+}
+
+type TransferCoin struct {
+	Asset  string       `json:"a"`
+	Amount types.Fixed8 `json:"A"`
+}
+
+// UserDataHandlers receive the events of a user data stream. Handlers left nil
+// drop their events.
+type UserDataHandlers struct {
+	OnOrders func(events []*OrderEvent)
+	// OnOrderExpiry gets the orders of OnOrders that expired or were IOC
+	// cancelled, after OnOrders.
+	OnOrderExpiry func(expiry OrderExpiry)
+	OnAccount     func(event *AccountEvent)
+	OnTransfer    func(event *TransferEvent)
+	// OnError gets the failures of the stream and of the listen key management,
+	// the stream recovers from them by itself.
+	OnError func(err error)
+	OnClose func()
+}
+
+type listenKeyResponse struct {
+	ListenKey string `json:"listenKey"`
+}
+
+// CreateListenKey opens a user data stream for the address on the accelerated node.
+func (c *client) CreateListenKey(userAddr string) (string, error) {
+	bz, err := c.baseClient.Post(userDataStreamPath, nil, map[string]string{"address": userAddr})
+	if err != nil {
+		return "", err
+	}
+	var res listenKeyResponse
+	if err := json.Unmarshal(bz, &res); err != nil {
+		return "", err
+	}
+	if res.ListenKey == "" {
+		return "", fmt.Errorf("no listen key in response: %s", string(bz))
+	}
+	return res.ListenKey, nil
+}
+
+// KeepAliveListenKey extends the life of a listen key, see ListenKeyKeepAlive.
+func (c *client) KeepAliveListenKey(listenKey string) error {
+	_, err := c.baseClient.Put(userDataStreamPath, map[string]string{"listenKey": listenKey})
+	return err
+}
+
+// CloseListenKey ends the user data stream of the listen key.
+func (c *client) CloseListenKey(listenKey string) error {
+	_, err := c.baseClient.Delete(userDataStreamPath, map[string]string{"listenKey": listenKey})
+	return err
+}
+
+// SubscribeUserDataEvent streams the orders, balances and transfers of the address
+// through one listen key. The key is kept alive and replaced when it expires, and
+// a dropped connection is reopened, until quit is closed.
+func (c *client) SubscribeUserDataEvent(userAddr string, quit chan struct{}, handlers UserDataHandlers) error {
+	stream := &userDataStream{client: c, userAddr: userAddr, quit: quit, handlers: handlers}
+	if err := stream.open(); err != nil {
+		return err
+	}
+	go stream.run()
+	return nil
+}
+
+type userDataStream struct {
+	client   *client
+	userAddr string
+	quit     chan struct{}
+	handlers UserDataHandlers
+
+	listenKey string
+	connQuit  chan struct{}
+	msgs      <-chan interface{}
+}
+
+// open creates a listen key and connects to its stream.
+func (s *userDataStream) open() error {
+	listenKey, err := s.client.CreateListenKey(s.userAddr)
+	if err != nil {
+		return err
+	}
+	connQuit := make(chan struct{})
+	msgs, err := s.client.baseClient.WsGet(listenKey, decodeUserData, connQuit)
+	if err != nil {
+		s.client.CloseListenKey(listenKey)
+		return err
+	}
+	s.listenKey, s.connQuit, s.msgs = listenKey, connQuit, msgs
+	return nil
+}
+
+func (s *userDataStream) closeConn() {
+	close(s.connQuit)
+	s.client.CloseListenKey(s.listenKey)
+	s.msgs = nil
+}
+
+func (s *userDataStream) run() {
+	keepAlive := time.NewTicker(ListenKeyKeepAlive)
+	defer keepAlive.Stop()
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-s.quit:
+			if s.msgs != nil {
+				s.closeConn()
+			}
+			if s.handlers.OnClose != nil {
+				s.handlers.OnClose()
+			}
+			return
+		case <-keepAlive.C:
+			if s.msgs == nil {
+				continue
+			}
+			if err := s.client.KeepAliveListenKey(s.listenKey); err != nil {
+				s.onError(fmt.Errorf("keep listen key alive, renewing it: %v", err))
+				s.closeConn()
+				retry = s.reopen()
+			}
+		case <-retry:
+			retry = s.reopen()
+		case m, ok := <-s.msgs:
+			if err, isErr := m.(error); !ok || isErr {
+				if isErr {
+					s.onError(err)
+				}
+				s.closeConn()
+				retry = time.After(userDataRetryDelay)
+				continue
+			}
+			s.dispatch(m)
+		}
+	}
+}
+
+// reopen connects with a new listen key and returns when to try again if it fails.
+func (s *userDataStream) reopen() <-chan time.Time {
+	if err := s.open(); err != nil {
+		s.onError(err)
+		return time.After(userDataRetryDelay)
+	}
+	return nil
+}
+
+func (s *userDataStream) dispatch(m interface{}) {
+	switch event := m.(type) {
+	case []*OrderEvent:
+		if s.handlers.OnOrders != nil {
+			s.handlers.OnOrders(event)
+		}
+		if s.handlers.OnOrderExpiry != nil {
+			for _, order := range event {
+				if expiry, ok := order.Expiry(); ok {
+					s.handlers.OnOrderExpiry(expiry)
+				}
+			}
+		}
+	case *AccountEvent:
+		if s.handlers.OnAccount != nil {
+			s.handlers.OnAccount(event)
+		}
+	case *TransferEvent:
+		if s.handlers.OnTransfer != nil {
+			s.handlers.OnTransfer(event)
+		}
+	}
+}
+
+func (s *userDataStream) onError(err error) {
+	if s.handlers.OnError != nil {
+		s.handlers.OnError(err)
+	}
+}
+
+// decodeUserData tells the events of a user data stream apart: orders come as a
+// list, balances and transfers by their event type. Other events are dropped.
+func decodeUserData(bz []byte) (interface{}, error) {
+	if len(bz) > 0 && bz[0] == '[' {
+		events := make([]*OrderEvent, 0)
+		if err := json.Unmarshal(bz, &events); err != nil {
+			return nil, err
+		}
+		return events, nil
+	}
+	// a struct would match "E" too, json field names are case insensitive
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &fields); err != nil {
+		return nil, err
+	}
+	var eventType string
+	json.Unmarshal(fields["e"], &eventType)
+	switch eventType {
+	case "outboundAccountInfo":
+		var event AccountEvent
+		if err := json.Unmarshal(bz, &event); err != nil {
+			return nil, err
+		}
+		return &event, nil
+	case "outboundTransferInfo":
+		var event TransferEvent
+		if err := json.Unmarshal(bz, &event); err != nil {
+			return nil, err
+		}
+		return &event, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,126 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/client/basic"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// fakeBasic hands out numbered listen keys and a message channel per stream.
+type fakeBasic struct {
+	mtx     sync.Mutex
+	keys    int
+	closed  []string
+	streams map[string]chan interface{}
+}
+
+func (f *fakeBasic) Get(path string, qp map[string]string) ([]byte, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+func (f *fakeBasic) Post(path string, body interface{}, param map[string]string) ([]byte, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.keys++
+	return []byte(fmt.Sprintf(`{"listenKey":"key-%d"}`, f.keys)), nil
+}
+
+func (f *fakeBasic) Put(path string, param map[string]string) ([]byte, error) {
+	return nil, nil
+}
+
+func (f *fakeBasic) Delete(path string, param map[string]string) ([]byte, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.closed = append(f.closed, param["listenKey"])
+	return nil, nil
+}
+
+func (f *fakeBasic) GetTx(txHash string) (*tx.TxResult, error) { return nil, nil }
+
+func (f *fakeBasic) PostTx(hexTx []byte, param map[string]string) ([]tx.TxCommitResult, error) {
+	return nil, nil
+}
+
+func (f *fakeBasic) WsGet(path string, constructMsg func([]byte) (interface{}, error), closeCh <-chan struct{}) (<-chan interface{}, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	msgs := make(chan interface{}, 1)
+	f.streams[path] = msgs
+	return msgs, nil
+}
+
+func (f *fakeBasic) SetMaxResponseSize(n int64) {}
+
+func (f *fakeBasic) SetCache(cache basic.ResponseCache) {}
+
+func (f *fakeBasic) SetOnThrottle(fn func(path string, retryAfter time.Duration)) {}
+
+func (f *fakeBasic) SetRequestIDFunc(fn func() string) {}
+
+func (f *fakeBasic) stream(key string) chan interface{} {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.streams[key]
+}
+
+func TestUserDataStreamReconnectsWithNewKey(t *testing.T) {
+	basic := &fakeBasic{streams: map[string]chan interface{}{}}
+	c := &client{baseClient: basic}
+	quit := make(chan struct{})
+	accounts := make(chan *AccountEvent, 1)
+	closed := make(chan struct{})
+	err := c.SubscribeUserDataEvent("bnb1user", quit, UserDataHandlers{
+		OnAccount: func(event *AccountEvent) { accounts <- event },
+		OnClose:   func() { close(closed) },
+	})
+	assert.NoError(t, err)
+
+	basic.stream("key-1") <- errors.New("connection reset")
+	assert.Eventually(t, func() bool { return basic.stream("key-2") != nil }, 3*time.Second, 10*time.Millisecond)
+
+	event, err := decodeUserData([]byte(`{"e":"outboundAccountInfo","E":7,"B":[{"a":"BNB","f":"1.00000000","r":"0.00000000","l":"0.00000000"}]}`))
+	assert.NoError(t, err)
+	basic.stream("key-2") <- event
+	assert.Equal(t, int64(7), (<-accounts).EventTime)
+
+	close(quit)
+	<-closed
+	basic.mtx.Lock()
+	assert.Equal(t, []string{"key-1", "key-2"}, basic.closed)
+	basic.mtx.Unlock()
+}
+
+func TestDecodeUserData(t *testing.T) {
+	orders, err := decodeUserData([]byte(`[{"e":"executionReport","s":"BTC-86A_BNB","i":"order-1"}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, "order-1", orders.([]*OrderEvent)[0].OrderID)
+
+	transfer, err := decodeUserData([]byte(`{"e":"outboundTransferInfo","E":12893,"H":"0434","f":"bnb1from","t":[{"o":"bnb1to","c":[{"a":"BNB","A":"100.00000000"}]}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "bnb1to", transfer.(*TransferEvent).To[0].Address)
+
+	other, err := decodeUserData([]byte(`{"e":"somethingElse"}`))
+	assert.NoError(t, err)
+	assert.Nil(t, other)
+}
+
+func TestUserDataOrderExpiry(t *testing.T) {
+	orders, err := decodeUserData([]byte(`[
+		{"e":"executionReport","s":"BTC-86A_BNB","i":"order-1","X":"Ack","T":7},
+		{"e":"executionReport","s":"BTC-86A_BNB","i":"order-2","X":"IocNoFill","T":7,"q":"2.00000000","p":"0.50000000"}]`))
+	assert.NoError(t, err)
+	var expiries []OrderExpiry
+	stream := &userDataStream{handlers: UserDataHandlers{OnOrderExpiry: func(e OrderExpiry) { expiries = append(expiries, e) }}}
+	stream.dispatch(orders)
+	assert.Equal(t, []OrderExpiry{{Reason: types.ExpiryIocNoFill, Height: 7, Symbol: "BTC-86A_BNB", OrderID: "order-2",
+		Price: types.NewFixed8(1) / 2, Quantity: types.NewFixed8(2)}}, expiries)
+}
@@ -16,6 +16,11 @@ type WSClient interface {
 	SubscribeMiniTickerEvent(baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onReceive func(event *MiniTickerEvent), onError func(err error), onClose func()) error
 	SubscribeAllMiniTickersEvent(quit chan struct{}, onReceive func(events []*MiniTickerEvent), onError func(err error), onClose func()) error
 	SubscribeTradeEvent(baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onReceive func(events []*TradeEvent), onError func(err error), onClose func()) error
+	SubscribeUserDataEvent(userAddr string, quit chan struct{}, handlers UserDataHandlers) error
+
+	CreateListenKey(userAddr string) (string, error)
+	KeepAliveListenKey(listenKey string) error
+	CloseListenKey(listenKey string) error
 }
 
 type client struct {
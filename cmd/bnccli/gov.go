@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func init() {
+	register("proposals", "[status] [limit]: list proposals, status is e.g. VotingPeriod", func(e *env, args []string) (interface{}, error) {
+		status := types.StatusNil
+		var limit int64 = 10
+		var err error
+		if len(args) > 0 {
+			if status, err = types.ProposalStatusFromString(args[0]); err != nil {
+				return nil, err
+			}
+		}
+		if len(args) > 1 {
+			if limit, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+				return nil, err
+			}
+		}
+		return e.rpcClient().GetProposals(status, limit)
+	})
+	register("proposal", "<id>: show a proposal", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 1, "<id>"); err != nil {
+			return nil, err
+		}
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return e.rpcClient().GetProposal(id)
+	})
+	register("vote", "<id> <Yes|No|Abstain|NoWithVeto>: vote on a proposal", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 2, "<id> <option>"); err != nil {
+			return nil, err
+		}
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		option, err := msg.VoteOptionFromString(args[1])
+		if err != nil {
+			return nil, err
+		}
+		c, err := e.signer()
+		if err != nil {
+			return nil, err
+		}
+		return c.Vote(id, option, e.sync)
+	})
+	register("deposit", "<id> <coins>: deposit on a proposal", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 2, "<id> <coins>"); err != nil {
+			return nil, err
+		}
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		coins, err := parseCoins(args[1])
+		if err != nil {
+			return nil, err
+		}
+		c, err := e.signer()
+		if err != nil {
+			return nil, err
+		}
+		return c.Deposit(id, coins, e.sync)
+	})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/binance-chain/go-sdk/config"
+	"github.com/binance-chain/go-sdk/keys"
+)
+
+func init() {
+	register("keys-new", "create a random key and print its mnemonic and address", func(e *env, args []string) (interface{}, error) {
+		km, err := keys.NewKeyManager()
+		if err != nil {
+			return nil, err
+		}
+		mnemonic, err := km.ExportAsMnemonic()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"address": km.GetAddr().String(), "mnemonic": mnemonic}, nil
+	})
+	register("keys-show", "print the address of the configured key", func(e *env, args []string) (interface{}, error) {
+		km, err := config.KeyFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"address": km.GetAddr().String()}, nil
+	})
+	register("keys-export", "<password> <file>: write the configured key to an encrypted keystore file", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 2, "<password> <file>"); err != nil {
+			return nil, err
+		}
+		km, err := config.KeyFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		keystore, err := km.ExportAsKeyStore(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if err := writeJSON(args[1], keystore); err != nil {
+			return nil, fmt.Errorf("write keystore: %v", err)
+		}
+		return map[string]string{"address": km.GetAddr().String(), "file": args[1]}, nil
+	})
+	register("keys-migrate", "<file> <out> <sdk|v3> <password> [new password]: re-encrypt a keystore in another format, checking the address is unchanged", func(e *env, args []string) (interface{}, error) {
+		var newPassword string
+		if len(args) == 5 {
+			newPassword, args = args[4], args[:4]
+		}
+		if err := expectArgs(args, 4, "<file> <out> <sdk|v3> <password> [new password]"); err != nil {
+			return nil, err
+		}
+		format, err := keys.ParseKeystoreFormat(args[2])
+		if err != nil {
+			return nil, err
+		}
+		keyJSON, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return nil, err
+		}
+		migrated, err := keys.MigrateKeyStore(keyJSON, args[3], newPassword, keys.KeystoreOptions{Format: format})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeJSON(args[1], migrated); err != nil {
+			return nil, fmt.Errorf("write keystore: %v", err)
+		}
+		return map[string]string{"address": migrated.Address, "file": args[1], "format": format.String()}, nil
+	})
+}
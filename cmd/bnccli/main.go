@@ -0,0 +1,136 @@
+// Command bnccli exposes the SDK from the command line and prints JSON results.
+// Each command is also meant as a small, correct example of SDK usage.
+//
+// Keys are read from the environment so they never end up in the shell history:
+// BNC_MNEMONIC, BNC_PRIVATE_KEY, BNC_KEYSTORE together with BNC_KEYSTORE_PASSWORD,
+// or BNC_ENCRYPTED_KEY together with BNC_KEY_PASSPHRASE.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/config"
+)
+
+type command struct {
+	usage string
+	run   func(env *env, args []string) (interface{}, error)
+}
+
+var commands = map[string]command{}
+
+func register(name, usage string, run func(env *env, args []string) (interface{}, error)) {
+	commands[name] = command{usage: usage, run: run}
+}
+
+type env struct {
+	node    string
+	network types.ChainNetwork
+	sync    rpc.SyncType
+	client  *rpc.HTTP
+}
+
+// rpcClient connects lazily so commands like "keys new" work offline.
+func (e *env) rpcClient() *rpc.HTTP {
+	if e.client == nil {
+		e.client = rpc.NewRPCClient(e.node, e.network)
+	}
+	return e.client
+}
+
+// signer returns a client with the key manager from the environment.
+func (e *env) signer() (*rpc.HTTP, error) {
+	km, err := config.KeyFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	c := e.rpcClient()
+	c.SetKeyManager(km)
+	return c, nil
+}
+
+func parseSync(name string) (rpc.SyncType, error) {
+	switch strings.ToLower(name) {
+	case "async":
+		return rpc.Async, nil
+	case "sync":
+		return rpc.Sync, nil
+	case "commit":
+		return rpc.Commit, nil
+	}
+	return 0, fmt.Errorf("unknown sync mode %q", name)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: bnccli [flags] <command> [args]\n\nflags:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-14s %s\n", name, commands[name].usage)
+	}
+}
+
+func main() {
+	node := flag.String("node", "tcp://dataseed1.binance.org:80", "rpc address of the node")
+	network := flag.String("network", "mainnet", "mainnet, testnet, ganges or kongo")
+	sync := flag.String("sync", "commit", "broadcast mode for txs: async, sync or commit")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	e := &env{node: *node}
+	var err error
+	if e.network, err = config.ParseNetwork(*network); err != nil {
+		fail(err)
+	}
+	types.Network = e.network
+	if e.sync, err = parseSync(*sync); err != nil {
+		fail(err)
+	}
+
+	result, err := cmd.run(e, flag.Args()[1:])
+	if e.client != nil {
+		e.client.Stop()
+	}
+	if err != nil {
+		fail(err)
+	}
+	out := json.NewEncoder(os.Stdout)
+	out.SetIndent("", "  ")
+	if err := out.Encode(result); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	json.NewEncoder(os.Stderr).Encode(map[string]string{"error": err.Error()})
+	os.Exit(1)
+}
+
+func expectArgs(args []string, n int, usage string) error {
+	if len(args) != n {
+		return fmt.Errorf("expected %d arguments: %s", n, usage)
+	}
+	return nil
+}
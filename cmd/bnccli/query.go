@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/hex"
+	"strconv"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+func init() {
+	register("status", "show the node status", func(e *env, args []string) (interface{}, error) {
+		return e.rpcClient().Status()
+	})
+	register("account", "<address>: show an account", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 1, "<address>"); err != nil {
+			return nil, err
+		}
+		addr, err := types.AccAddressFromBech32(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return e.rpcClient().GetAccount(addr)
+	})
+	register("balances", "<address>: show free, frozen, locked, timelocked and swapping balances", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 1, "<address>"); err != nil {
+			return nil, err
+		}
+		addr, err := types.AccAddressFromBech32(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return e.rpcClient().GetBalanceDetails(addr)
+	})
+	register("tokens", "[offset] [limit]: list tokens", func(e *env, args []string) (interface{}, error) {
+		offset, limit, err := parsePage(args)
+		if err != nil {
+			return nil, err
+		}
+		return e.rpcClient().ListAllTokens(offset, limit)
+	})
+	register("token", "<symbol>: show a token", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 1, "<symbol>"); err != nil {
+			return nil, err
+		}
+		return e.rpcClient().GetTokenInfo(args[0])
+	})
+	register("pairs", "[offset] [limit]: list trading pairs", func(e *env, args []string) (interface{}, error) {
+		offset, limit, err := parsePage(args)
+		if err != nil {
+			return nil, err
+		}
+		return e.rpcClient().GetTradingPairs(offset, limit)
+	})
+	register("depth", "<pair> [level]: show the order book of a pair", func(e *env, args []string) (interface{}, error) {
+		if len(args) < 1 {
+			return nil, expectArgs(args, 1, "<pair> [level]")
+		}
+		level := 20
+		if len(args) > 1 {
+			var err error
+			if level, err = strconv.Atoi(args[1]); err != nil {
+				return nil, err
+			}
+		}
+		return e.rpcClient().GetDepth(args[0], level)
+	})
+	register("open-orders", "<address> <pair>: list open orders", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 2, "<address> <pair>"); err != nil {
+			return nil, err
+		}
+		addr, err := types.AccAddressFromBech32(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return e.rpcClient().GetOpenOrders(addr, args[1])
+	})
+	register("fees", "show the fee schedule", func(e *env, args []string) (interface{}, error) {
+		return e.rpcClient().GetFee()
+	})
+	register("tx", "<hash>: show a tx", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 1, "<hash>"); err != nil {
+			return nil, err
+		}
+		hash, err := hex.DecodeString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return e.rpcClient().Tx(hash, false)
+	})
+}
+
+func parsePage(args []string) (offset, limit int, err error) {
+	limit = 100
+	if len(args) > 0 {
+		if offset, err = strconv.Atoi(args[0]); err != nil {
+			return
+		}
+	}
+	if len(args) > 1 {
+		limit, err = strconv.Atoi(args[1])
+	}
+	return
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/config"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func init() {
+	register("swap", "<swap-id>: show an atomic swap", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 1, "<swap-id>"); err != nil {
+			return nil, err
+		}
+		swapID, err := hex.DecodeString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return e.rpcClient().GetSwapByID(swapID)
+	})
+	register("htlt", "<recipient> <coins> <expected-income> <height-span>: create a same-chain swap with a fresh secret", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 4, "<recipient> <coins> <expected-income> <height-span>"); err != nil {
+			return nil, err
+		}
+		recipient, err := types.AccAddressFromBech32(args[0])
+		if err != nil {
+			return nil, err
+		}
+		coins, err := parseCoins(args[1])
+		if err != nil {
+			return nil, err
+		}
+		heightSpan, err := strconv.ParseInt(args[3], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		km, err := config.KeyFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		c := e.rpcClient()
+		c.SetKeyManager(km)
+		timestamp := time.Now().Unix()
+		randomNumber, randomNumberHash, err := msg.GenerateRandomNumberHash(timestamp)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.HTLT(recipient, "", "", randomNumberHash, timestamp, coins, args[2], heightSpan, false, e.sync)
+		if err != nil {
+			return nil, err
+		}
+		// the random number is only printed here, keep it to claim the swap
+		return map[string]interface{}{
+			"result":        res,
+			"swap_id":       hex.EncodeToString(msg.CalculateSwapID(randomNumberHash, km.GetAddr(), "")),
+			"random_number": hex.EncodeToString(randomNumber),
+			"timestamp":     timestamp,
+		}, nil
+	})
+	register("claim", "<swap-id> <random-number>: claim a swap", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 2, "<swap-id> <random-number>"); err != nil {
+			return nil, err
+		}
+		swapID, err := hex.DecodeString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		randomNumber, err := hex.DecodeString(args[1])
+		if err != nil {
+			return nil, err
+		}
+		c, err := e.signer()
+		if err != nil {
+			return nil, err
+		}
+		swap, err := c.GetSwapByID(swapID)
+		if err != nil {
+			return nil, err
+		}
+		if !msg.VerifyRandomNumber(randomNumber, swap.RandomNumberHash, swap.Timestamp) {
+			return nil, fmt.Errorf("the random number does not match the random number hash of the swap")
+		}
+		return c.ClaimHTLT(swapID, randomNumber, e.sync)
+	})
+	register("refund", "<swap-id>: refund an expired swap", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 1, "<swap-id>"); err != nil {
+			return nil, err
+		}
+		swapID, err := hex.DecodeString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		c, err := e.signer()
+		if err != nil {
+			return nil, err
+		}
+		return c.RefundHTLT(swapID, e.sync)
+	})
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func init() {
+	register("send", "<to> <coins>: transfer coins, e.g. send bnb1... 1.5:BNB", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 2, "<to> <coins>"); err != nil {
+			return nil, err
+		}
+		to, err := types.AccAddressFromBech32(args[0])
+		if err != nil {
+			return nil, err
+		}
+		coins, err := parseCoins(args[1])
+		if err != nil {
+			return nil, err
+		}
+		c, err := e.signer()
+		if err != nil {
+			return nil, err
+		}
+		return c.SendToken([]msg.Transfer{{ToAddr: to, Coins: coins}}, e.sync)
+	})
+	register("order", "<buy|sell> <base> <quote> <price> <quantity>: place a limit order", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 5, "<buy|sell> <base> <quote> <price> <quantity>"); err != nil {
+			return nil, err
+		}
+		side, err := msg.SideStringToSideCode(args[0])
+		if err != nil {
+			return nil, err
+		}
+		price, err := types.Fixed8DecodeString(args[3])
+		if err != nil {
+			return nil, err
+		}
+		quantity, err := types.Fixed8DecodeString(args[4])
+		if err != nil {
+			return nil, err
+		}
+		c, err := e.signer()
+		if err != nil {
+			return nil, err
+		}
+		return c.CreateOrder(args[1], args[2], side, price.ToInt64(), quantity.ToInt64(), e.sync)
+	})
+	register("cancel", "<base> <quote> <order-id>: cancel an order", func(e *env, args []string) (interface{}, error) {
+		if err := expectArgs(args, 3, "<base> <quote> <order-id>"); err != nil {
+			return nil, err
+		}
+		c, err := e.signer()
+		if err != nil {
+			return nil, err
+		}
+		return c.CancelOrder(args[0], args[1], args[2], e.sync)
+	})
+}
+
+// parseCoins parses "1.5:BNB,10:BUSD-BD1" into sorted coins.
+func parseCoins(s string) (types.Coins, error) {
+	var coins types.Coins
+	for _, part := range strings.Split(s, ",") {
+		fields := strings.SplitN(part, ":", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid coin %q, expected <amount>:<denom>", part)
+		}
+		amount, err := types.Fixed8DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount %q: %v", fields[0], err)
+		}
+		coins = append(coins, types.Coin{Denom: fields[1], Amount: amount.ToInt64()})
+	}
+	return coins.Sort(), nil
+}
+
+func writeJSON(file string, v interface{}) error {
+	bz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, bz, 0600)
+}
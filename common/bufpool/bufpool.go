@@ -0,0 +1,98 @@
+// Package bufpool reuses the byte buffers that frames are read into and
+// requests are encoded into around the codec. Services issuing thousands of
+// queries a second otherwise allocate, and grow, a fresh buffer for every
+// frame.
+//
+// The amino codec itself is not pooled: Marshal returns a fresh slice the
+// caller keeps and Unmarshal decodes the caller's bytes, it takes no buffer.
+// Nor are api response bodies, they are handed to the caller. What is pooled
+// are the websocket frames read and written by the rpc client and the hex of
+// the txs the api client broadcasts, none of which outlive the call.
+package bufpool
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultMaxSize is the largest buffer Default keeps for reuse. Larger ones,
+// grown by the odd big response, are left to the garbage collector so the
+// pool does not pin their memory.
+const DefaultMaxSize = 1024 * 1024
+
+// Default is the pool the clients share.
+var Default = New(DefaultMaxSize)
+
+// Pool is a sync.Pool of *bytes.Buffer that counts its use.
+type Pool struct {
+	// 64-bit words first, for atomic access on 32-bit platforms
+	maxSize  int64
+	gets     uint64
+	misses   uint64
+	puts     uint64
+	discards uint64
+
+	pool sync.Pool
+}
+
+// New returns a pool keeping buffers of at most maxSize bytes, maxSize <= 0
+// keeps all of them.
+func New(maxSize int) *Pool {
+	p := &Pool{maxSize: int64(maxSize)}
+	p.pool.New = func() interface{} {
+		atomic.AddUint64(&p.misses, 1)
+		return new(bytes.Buffer)
+	}
+	return p
+}
+
+// Get returns an empty buffer.
+func (p *Pool) Get() *bytes.Buffer {
+	atomic.AddUint64(&p.gets, 1)
+	return p.pool.Get().(*bytes.Buffer)
+}
+
+// Put hands buf back. Neither buf nor any slice of its contents may be used
+// afterwards.
+func (p *Pool) Put(buf *bytes.Buffer) {
+	if buf == nil {
+		return
+	}
+	if max := atomic.LoadInt64(&p.maxSize); max > 0 && int64(buf.Cap()) > max {
+		atomic.AddUint64(&p.discards, 1)
+		return
+	}
+	atomic.AddUint64(&p.puts, 1)
+	buf.Reset()
+	p.pool.Put(buf)
+}
+
+// SetMaxSize changes the largest buffer kept for reuse, n <= 0 keeps all.
+func (p *Pool) SetMaxSize(n int) {
+	atomic.StoreInt64(&p.maxSize, int64(n))
+}
+
+// Stats are the counters of a pool since it was created.
+type Stats struct {
+	// Gets counts buffers taken from the pool.
+	Gets uint64
+	// Misses counts the Gets that had to allocate a new buffer, a hit rate
+	// of 1 - Misses/Gets close to one means the pool is doing its job.
+	Misses uint64
+	// Puts counts buffers handed back and kept.
+	Puts uint64
+	// Discards counts buffers handed back but dropped for exceeding the max
+	// size. Many of them suggest raising it.
+	Discards uint64
+}
+
+// Stats returns the current counters.
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Gets:     atomic.LoadUint64(&p.gets),
+		Misses:   atomic.LoadUint64(&p.misses),
+		Puts:     atomic.LoadUint64(&p.puts),
+		Discards: atomic.LoadUint64(&p.discards),
+	}
+}
@@ -0,0 +1,52 @@
+package bufpool
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolStats(t *testing.T) {
+	p := New(128)
+	buf := p.Get()
+	assert.Equal(t, 0, buf.Len())
+	buf.WriteString("hello")
+	p.Put(buf)
+
+	buf = p.Get()
+	assert.Equal(t, 0, buf.Len(), "buffers come back empty")
+	buf.Write(make([]byte, 256))
+	p.Put(buf)
+	p.Put(nil)
+
+	stats := p.Stats()
+	assert.Equal(t, uint64(2), stats.Gets)
+	assert.Equal(t, uint64(1), stats.Puts)
+	assert.Equal(t, uint64(1), stats.Discards, "buffers over the max size are dropped")
+	assert.True(t, stats.Misses >= 1 && stats.Misses <= 2)
+
+	p.SetMaxSize(0)
+	big := bytes.NewBuffer(make([]byte, 0, 1024))
+	p.Put(big)
+	assert.Equal(t, uint64(2), p.Stats().Puts)
+}
+
+func BenchmarkPool(b *testing.B) {
+	frame := bytes.Repeat([]byte("x"), 8*1024)
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := Default.Get()
+			buf.ReadFrom(bytes.NewReader(frame))
+			Default.Put(buf)
+		}
+	})
+	b.Run("fresh", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var buf bytes.Buffer
+			buf.ReadFrom(bytes.NewReader(frame))
+		}
+	})
+}
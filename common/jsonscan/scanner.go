@@ -0,0 +1,350 @@
+// Package jsonscan reads JSON in place, token by token, for hot paths that
+// decode messages of a known shape. Unlike encoding/json it builds no values
+// and does not allocate: keys, strings and numbers are returned as slices of
+// the input, valid as long as the input is.
+package jsonscan
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Kind is the kind of the next value.
+type Kind int
+
+const (
+	Invalid Kind = iota
+	Object
+	Array
+	String
+	Number
+	True
+	False
+	Null
+)
+
+// Scanner reads one JSON document. The zero value is empty, Reset it with
+// the input. A Scanner may be reused for any number of documents.
+type Scanner struct {
+	data []byte
+	pos  int
+	// first is set right after '{' or '[' until the first member or element
+	// is read. Nested values always end after a member of the enclosing one,
+	// so a single flag is enough.
+	first bool
+}
+
+// Reset starts scanning data.
+func (s *Scanner) Reset(data []byte) {
+	s.data = data
+	s.pos = 0
+	s.first = false
+}
+
+// SyntaxError is malformed input at Offset.
+type SyntaxError struct {
+	Offset int
+	msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("json: %s at offset %d", e.msg, e.Offset)
+}
+
+func (s *Scanner) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Offset: s.pos, msg: fmt.Sprintf(format, args...)}
+}
+
+func (s *Scanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *Scanner) peek() byte {
+	s.skipSpace()
+	if s.pos >= len(s.data) {
+		return 0
+	}
+	return s.data[s.pos]
+}
+
+// Kind returns the kind of the next value without consuming it.
+func (s *Scanner) Kind() Kind {
+	switch c := s.peek(); {
+	case c == '{':
+		return Object
+	case c == '[':
+		return Array
+	case c == '"':
+		return String
+	case c == '-' || c >= '0' && c <= '9':
+		return Number
+	case c == 't':
+		return True
+	case c == 'f':
+		return False
+	case c == 'n':
+		return Null
+	}
+	return Invalid
+}
+
+// BeginObject consumes the '{' of the next value, read its members with NextKey.
+func (s *Scanner) BeginObject() error {
+	if s.peek() != '{' {
+		return s.errorf("expected object")
+	}
+	s.pos++
+	s.first = true
+	return nil
+}
+
+// NextKey returns the key of the next member of the current object, ok is
+// false once the object is closed. The value must be read or skipped before
+// the next call. Keys with escapes are returned raw.
+func (s *Scanner) NextKey() (key []byte, ok bool, err error) {
+	c := s.peek()
+	if c == '}' {
+		s.pos++
+		s.first = false
+		return nil, false, nil
+	}
+	if !s.first {
+		if c != ',' {
+			return nil, false, s.errorf("expected ',' or '}'")
+		}
+		s.pos++
+	}
+	s.first = false
+	key, _, err = s.String()
+	if err != nil {
+		return nil, false, err
+	}
+	if s.peek() != ':' {
+		return nil, false, s.errorf("expected ':'")
+	}
+	s.pos++
+	return key, true, nil
+}
+
+// BeginArray consumes the '[' of the next value, read its elements with NextElement.
+func (s *Scanner) BeginArray() error {
+	if s.peek() != '[' {
+		return s.errorf("expected array")
+	}
+	s.pos++
+	s.first = true
+	return nil
+}
+
+// NextElement reports whether the current array has another element, which
+// must be read or skipped before the next call.
+func (s *Scanner) NextElement() (bool, error) {
+	c := s.peek()
+	if c == ']' {
+		s.pos++
+		s.first = false
+		return false, nil
+	}
+	if !s.first {
+		if c != ',' {
+			return false, s.errorf("expected ',' or ']'")
+		}
+		s.pos++
+	}
+	s.first = false
+	return true, nil
+}
+
+// String reads a string and returns its content without the quotes. escaped
+// reports backslash escapes in it, which Unescape resolves.
+func (s *Scanner) String() (raw []byte, escaped bool, err error) {
+	if s.peek() != '"' {
+		return nil, false, s.errorf("expected string")
+	}
+	start := s.pos + 1
+	for i := start; i < len(s.data); i++ {
+		switch c := s.data[i]; {
+		case c == '"':
+			s.pos = i + 1
+			return s.data[start:i], escaped, nil
+		case c == '\\':
+			escaped = true
+			i++
+		case c < 0x20:
+			s.pos = i
+			return nil, false, s.errorf("control character in string")
+		}
+	}
+	s.pos = len(s.data)
+	return nil, false, s.errorf("unterminated string")
+}
+
+// Unescape resolves the escapes of a string returned by String. It allocates
+// and is meant for the rare strings that have escapes.
+func Unescape(raw []byte) (string, error) {
+	quoted := make([]byte, 0, len(raw)+2)
+	quoted = append(append(append(quoted, '"'), raw...), '"')
+	var str string
+	err := json.Unmarshal(quoted, &str)
+	return str, err
+}
+
+// Number reads a number and returns it as written.
+func (s *Scanner) Number() ([]byte, error) {
+	if s.Kind() != Number {
+		return nil, s.errorf("expected number")
+	}
+	start := s.pos
+	for s.pos < len(s.data) {
+		switch c := s.data[s.pos]; {
+		case c >= '0' && c <= '9', c == '-', c == '+', c == '.', c == 'e', c == 'E':
+			s.pos++
+		default:
+			return s.data[start:s.pos], nil
+		}
+	}
+	return s.data[start:s.pos], nil
+}
+
+// Int reads an integer number.
+func (s *Scanner) Int() (int64, error) {
+	start := s.pos
+	raw, err := s.Number()
+	if err != nil {
+		return 0, err
+	}
+	n, ok := ParseInt(raw)
+	if !ok {
+		s.pos = start
+		return 0, s.errorf("invalid integer %q", raw)
+	}
+	return n, nil
+}
+
+// ParseInt parses a decimal integer without allocating, ok is false for
+// anything else, including values that overflow int64.
+func ParseInt(b []byte) (n int64, ok bool) {
+	neg := len(b) > 0 && b[0] == '-'
+	if neg {
+		b = b[1:]
+	}
+	if len(b) == 0 {
+		return 0, false
+	}
+	var u uint64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		if u > (1<<63)/10 {
+			return 0, false
+		}
+		u = u*10 + uint64(c-'0')
+	}
+	if neg {
+		if u > 1<<63 {
+			return 0, false
+		}
+		return -int64(u), true
+	}
+	if u > 1<<63-1 {
+		return 0, false
+	}
+	return int64(u), true
+}
+
+// Null consumes a null if it is next and reports whether it was.
+func (s *Scanner) Null() bool {
+	if s.peek() == 'n' && s.hasPrefix("null") {
+		s.pos += 4
+		return true
+	}
+	return false
+}
+
+func (s *Scanner) hasPrefix(lit string) bool {
+	if len(s.data)-s.pos < len(lit) {
+		return false
+	}
+	return string(s.data[s.pos:s.pos+len(lit)]) == lit
+}
+
+// Skip reads the next value, whatever it is, and returns it as written.
+func (s *Scanner) Skip() ([]byte, error) {
+	s.skipSpace()
+	start := s.pos
+	switch s.Kind() {
+	case String:
+		if _, _, err := s.String(); err != nil {
+			return nil, err
+		}
+	case Number:
+		if _, err := s.Number(); err != nil {
+			return nil, err
+		}
+	case True, False, Null:
+		lit := "null"
+		if c := s.data[s.pos]; c == 't' {
+			lit = "true"
+		} else if c == 'f' {
+			lit = "false"
+		}
+		if !s.hasPrefix(lit) {
+			return nil, s.errorf("invalid literal")
+		}
+		s.pos += len(lit)
+	case Object:
+		if err := s.BeginObject(); err != nil {
+			return nil, err
+		}
+		for {
+			_, ok, err := s.NextKey()
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			if _, err := s.Skip(); err != nil {
+				return nil, err
+			}
+		}
+	case Array:
+		if err := s.BeginArray(); err != nil {
+			return nil, err
+		}
+		for {
+			ok, err := s.NextElement()
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			if _, err := s.Skip(); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if s.pos >= len(s.data) {
+			return nil, s.errorf("unexpected end of input")
+		}
+		return nil, s.errorf("unexpected character %q", s.data[s.pos])
+	}
+	return s.data[start:s.pos], nil
+}
+
+// End checks that nothing but white space follows the document.
+func (s *Scanner) End() error {
+	if s.peek() != 0 || s.pos < len(s.data) {
+		return s.errorf("unexpected data after the document")
+	}
+	return nil
+}
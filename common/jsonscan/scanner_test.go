@@ -0,0 +1,82 @@
+package jsonscan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanner(t *testing.T) {
+	var s Scanner
+	s.Reset([]byte(` {"a": [1, "x\"y", {"b": null}], "c": -12, "d": true, "e": {}, "f": []} `))
+	assert.NoError(t, s.BeginObject())
+
+	key, ok, err := s.NextKey()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "a", string(key))
+	assert.NoError(t, s.BeginArray())
+	ok, _ = s.NextElement()
+	assert.True(t, ok)
+	n, err := s.Int()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	ok, _ = s.NextElement()
+	assert.True(t, ok)
+	raw, escaped, err := s.String()
+	assert.NoError(t, err)
+	assert.True(t, escaped)
+	str, err := Unescape(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, `x"y`, str)
+	ok, _ = s.NextElement()
+	assert.True(t, ok)
+	skipped, err := s.Skip()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b": null}`, string(skipped))
+	ok, err = s.NextElement()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	key, _, _ = s.NextKey()
+	assert.Equal(t, "c", string(key))
+	n, err = s.Int()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-12), n)
+	for _, want := range []string{"d", "e", "f"} {
+		key, ok, err = s.NextKey()
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, want, string(key))
+		_, err = s.Skip()
+		assert.NoError(t, err)
+	}
+	_, ok, err = s.NextKey()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, s.End())
+}
+
+func TestScannerErrors(t *testing.T) {
+	for _, bad := range []string{``, `{`, `{"a" 1}`, `{"a":1 "b":2}`, `[1,]`, `{"a":tru}`, `"abc`, `[1] 2`} {
+		var s Scanner
+		s.Reset([]byte(bad))
+		_, err := s.Skip()
+		if err == nil {
+			err = s.End()
+		}
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	for in, want := range map[string]int64{"0": 0, "-7": -7, "9223372036854775807": 1<<63 - 1, "-9223372036854775808": -1 << 63} {
+		n, ok := ParseInt([]byte(in))
+		assert.True(t, ok, in)
+		assert.Equal(t, want, n, in)
+	}
+	for _, bad := range []string{"", "-", "1.5", "9223372036854775808", "-9223372036854775809", "1e3"} {
+		_, ok := ParseInt([]byte(bad))
+		assert.False(t, ok, bad)
+	}
+}
@@ -71,7 +71,13 @@ func (pkl PrivKeyLedgerSecp256k1) ShowSignAddr() error {
 }
 
 func (pkl PrivKeyLedgerSecp256k1) Sign(msg []byte) ([]byte, error) {
-	err := pkl.ShowSignAddr()
+	return pkl.NetworkSign(msg, types.Network)
+}
+
+// NetworkSign is Sign showing the signing address on the device with the prefix
+// of network, the one the sign bytes are for.
+func (pkl PrivKeyLedgerSecp256k1) NetworkSign(msg []byte, network types.ChainNetwork) ([]byte, error) {
+	err := pkl.ledger.ShowAddressSECP256K1(pkl.path, network.Bech32Prefixes())
 	if err != nil {
 		return nil, err
 	}
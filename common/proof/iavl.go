@@ -0,0 +1,302 @@
+package proof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+const (
+	ProofOpIAVLValue   = "iavl:v"
+	ProofOpIAVLAbsence = "iavl:a"
+)
+
+var ErrInvalidProof = errors.New("invalid proof")
+
+// RangeProof is the IAVL range proof carried by iavl:v and iavl:a proof ops.
+type RangeProof struct {
+	LeftPath   PathToLeaf      `json:"left_path"`
+	InnerNodes []PathToLeaf    `json:"inner_nodes"`
+	Leaves     []ProofLeafNode `json:"leaves"`
+}
+
+// PathToLeaf lists the inner nodes from the root down to a leaf.
+type PathToLeaf []ProofInnerNode
+
+type ProofInnerNode struct {
+	Height  int8   `json:"height"`
+	Size    int64  `json:"size"`
+	Version int64  `json:"version"`
+	Left    []byte `json:"left"`
+	Right   []byte `json:"right"`
+}
+
+type ProofLeafNode struct {
+	Key       cmn.HexBytes `json:"key"`
+	ValueHash cmn.HexBytes `json:"value"`
+	Version   int64        `json:"version"`
+}
+
+func (pin ProofInnerNode) Hash(childHash []byte) []byte {
+	buf := new(bytes.Buffer)
+	amino.EncodeInt8(buf, pin.Height)
+	amino.EncodeVarint(buf, pin.Size)
+	amino.EncodeVarint(buf, pin.Version)
+	if len(pin.Left) == 0 {
+		amino.EncodeByteSlice(buf, childHash)
+		amino.EncodeByteSlice(buf, pin.Right)
+	} else {
+		amino.EncodeByteSlice(buf, pin.Left)
+		amino.EncodeByteSlice(buf, childHash)
+	}
+	hash := sha256.Sum256(buf.Bytes())
+	return hash[:]
+}
+
+func (pln ProofLeafNode) Hash() []byte {
+	buf := new(bytes.Buffer)
+	amino.EncodeInt8(buf, 0)
+	amino.EncodeVarint(buf, 1)
+	amino.EncodeVarint(buf, pln.Version)
+	amino.EncodeByteSlice(buf, pln.Key)
+	amino.EncodeByteSlice(buf, pln.ValueHash)
+	hash := sha256.Sum256(buf.Bytes())
+	return hash[:]
+}
+
+func (pl PathToLeaf) isLeftmost() bool {
+	for _, node := range pl {
+		if len(node.Left) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pl PathToLeaf) isRightmost() bool {
+	for _, node := range pl {
+		if len(node.Right) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pl PathToLeaf) rootHash(leaf ProofLeafNode) []byte {
+	hash := leaf.Hash()
+	for i := len(pl) - 1; i >= 0; i-- {
+		hash = pl[i].Hash(hash)
+	}
+	return hash
+}
+
+// ComputeRootHash returns the root hash the proof commits to and whether its
+// last leaf is the last item of the tree.
+func (proof *RangeProof) ComputeRootHash() (rootHash []byte, treeEnd bool, err error) {
+	if len(proof.Leaves) == 0 {
+		return nil, false, errors.Wrap(ErrInvalidProof, "no leaves")
+	}
+	if len(proof.InnerNodes)+1 != len(proof.Leaves) {
+		return nil, false, errors.Wrap(ErrInvalidProof, "there should be one more leaf than inner node paths")
+	}
+	leaves := proof.Leaves
+	inners := proof.InnerNodes
+
+	// compute walks from a leaf to the root of its subtree, verifying the
+	// right siblings on the way against the remaining leaves.
+	var compute func(path PathToLeaf, rightmost bool) (hash []byte, treeEnd bool, done bool, err error)
+	compute = func(path PathToLeaf, rightmost bool) ([]byte, bool, bool, error) {
+		leaf := leaves[0]
+		leaves = leaves[1:]
+		hash := path.rootHash(leaf)
+		if len(leaves) == 0 {
+			return hash, rightmost && path.isRightmost(), true, nil
+		}
+		for len(path) > 0 {
+			last := path[len(path)-1]
+			path = path[:len(path)-1]
+			if len(last.Right) == 0 {
+				continue
+			}
+			if len(inners) == 0 {
+				return nil, false, false, errors.Wrap(ErrInvalidProof, "missing inner nodes")
+			}
+			next := inners[0]
+			inners = inners[1:]
+			derived, treeEnd, done, err := compute(next, rightmost && path.isRightmost())
+			if err != nil {
+				return nil, treeEnd, false, err
+			}
+			if !bytes.Equal(derived, last.Right) {
+				return nil, treeEnd, false, errors.Wrapf(ErrInvalidProof, "intermediate hash %X does not match %X", derived, last.Right)
+			}
+			if done {
+				return hash, treeEnd, true, nil
+			}
+		}
+		return hash, false, false, nil
+	}
+
+	rootHash, treeEnd, done, err := compute(proof.LeftPath, true)
+	if err != nil {
+		return nil, treeEnd, err
+	}
+	if !done {
+		return nil, treeEnd, errors.Wrap(ErrInvalidProof, "left over leaves")
+	}
+	return rootHash, treeEnd, nil
+}
+
+// VerifyItem checks that the proof contains key with the given value. The
+// root hash must be checked separately.
+func (proof *RangeProof) VerifyItem(key, value []byte) error {
+	valueHash := sha256.Sum256(value)
+	for _, leaf := range proof.Leaves {
+		if bytes.Equal(leaf.Key, key) {
+			if !bytes.Equal(leaf.ValueHash, valueHash[:]) {
+				return errors.Wrap(ErrInvalidProof, "leaf value hash does not match")
+			}
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrInvalidProof, "key %X is not in the proof", key)
+}
+
+// VerifyAbsence checks that the neighbouring leaves in the proof leave no room
+// for key. The root hash must be checked separately.
+func (proof *RangeProof) VerifyAbsence(key []byte, treeEnd bool) error {
+	cmp := bytes.Compare(key, proof.Leaves[0].Key)
+	if cmp < 0 {
+		if proof.LeftPath.isLeftmost() {
+			return nil
+		}
+		return errors.Wrap(ErrInvalidProof, "absence not proved by left path")
+	} else if cmp == 0 {
+		return errors.Wrap(ErrInvalidProof, "absence disproved by the first leaf")
+	}
+	if len(proof.LeftPath) == 0 || proof.LeftPath.isRightmost() {
+		return nil
+	}
+	for i := 1; i < len(proof.Leaves); i++ {
+		cmp := bytes.Compare(key, proof.Leaves[i].Key)
+		if cmp < 0 {
+			return nil
+		} else if cmp == 0 {
+			return errors.Wrapf(ErrInvalidProof, "absence disproved by leaf %d", i)
+		}
+	}
+	if treeEnd {
+		return nil
+	}
+	return errors.Wrap(ErrInvalidProof, "absence not proved by right leaf")
+}
+
+// iavlOp is the encoded form of both IAVL proof ops.
+type iavlOp struct {
+	Proof *RangeProof `json:"proof"`
+}
+
+// IAVLValueOp proves that a key is set to a value in an IAVL tree.
+type IAVLValueOp struct {
+	key   []byte
+	Proof *RangeProof
+}
+
+func NewIAVLValueOp(key []byte, proof *RangeProof) IAVLValueOp {
+	return IAVLValueOp{key: key, Proof: proof}
+}
+
+func IAVLValueOpDecoder(pop merkle.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpIAVLValue {
+		return nil, fmt.Errorf("unexpected proof op type %s, want %s", pop.Type, ProofOpIAVLValue)
+	}
+	var op iavlOp
+	if err := cdc.UnmarshalBinaryLengthPrefixed(pop.Data, &op); err != nil {
+		return nil, errors.Wrap(err, "decode iavl value op")
+	}
+	if op.Proof == nil {
+		return nil, errors.Wrap(ErrInvalidProof, "empty iavl value op")
+	}
+	return NewIAVLValueOp(pop.Key, op.Proof), nil
+}
+
+func (op IAVLValueOp) GetKey() []byte {
+	return op.key
+}
+
+func (op IAVLValueOp) ProofOp() merkle.ProofOp {
+	return merkle.ProofOp{
+		Type: ProofOpIAVLValue,
+		Key:  op.key,
+		Data: cdc.MustMarshalBinaryLengthPrefixed(iavlOp{Proof: op.Proof}),
+	}
+}
+
+func (op IAVLValueOp) Run(args [][]byte) ([][]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("expected 1 value, got %d", len(args))
+	}
+	root, _, err := op.Proof.ComputeRootHash()
+	if err != nil {
+		return nil, err
+	}
+	if err := op.Proof.VerifyItem(op.key, args[0]); err != nil {
+		return nil, err
+	}
+	return [][]byte{root}, nil
+}
+
+// IAVLAbsenceOp proves that a key is not set in an IAVL tree.
+type IAVLAbsenceOp struct {
+	key   []byte
+	Proof *RangeProof
+}
+
+func NewIAVLAbsenceOp(key []byte, proof *RangeProof) IAVLAbsenceOp {
+	return IAVLAbsenceOp{key: key, Proof: proof}
+}
+
+func IAVLAbsenceOpDecoder(pop merkle.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpIAVLAbsence {
+		return nil, fmt.Errorf("unexpected proof op type %s, want %s", pop.Type, ProofOpIAVLAbsence)
+	}
+	var op iavlOp
+	if err := cdc.UnmarshalBinaryLengthPrefixed(pop.Data, &op); err != nil {
+		return nil, errors.Wrap(err, "decode iavl absence op")
+	}
+	if op.Proof == nil {
+		return nil, errors.Wrap(ErrInvalidProof, "empty iavl absence op")
+	}
+	return NewIAVLAbsenceOp(pop.Key, op.Proof), nil
+}
+
+func (op IAVLAbsenceOp) GetKey() []byte {
+	return op.key
+}
+
+func (op IAVLAbsenceOp) ProofOp() merkle.ProofOp {
+	return merkle.ProofOp{
+		Type: ProofOpIAVLAbsence,
+		Key:  op.key,
+		Data: cdc.MustMarshalBinaryLengthPrefixed(iavlOp{Proof: op.Proof}),
+	}
+}
+
+func (op IAVLAbsenceOp) Run(args [][]byte) ([][]byte, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("expected no value, got %d", len(args))
+	}
+	root, treeEnd, err := op.Proof.ComputeRootHash()
+	if err != nil {
+		return nil, err
+	}
+	if err := op.Proof.VerifyAbsence(op.key, treeEnd); err != nil {
+		return nil, err
+	}
+	return [][]byte{root}, nil
+}
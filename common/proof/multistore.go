@@ -0,0 +1,101 @@
+package proof
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+const ProofOpMultiStore = "multistore"
+
+// MultiStoreProof lists the commit of every substore, the app hash is their
+// simple merkle map root.
+type MultiStoreProof struct {
+	StoreInfos []StoreInfo `json:"store_infos"`
+}
+
+type StoreInfo struct {
+	Name string    `json:"name"`
+	Core StoreCore `json:"core"`
+}
+
+type StoreCore struct {
+	CommitID CommitID `json:"commit_id"`
+}
+
+type CommitID struct {
+	Version int64  `json:"version"`
+	Hash    []byte `json:"hash"`
+}
+
+func (si StoreInfo) Hash() []byte {
+	// the name is not hashed here, the simple map includes it as the key
+	return tmhash.Sum(cdc.MustMarshalBinaryLengthPrefixed(si.Core))
+}
+
+func (proof *MultiStoreProof) ComputeRootHash() []byte {
+	m := make(map[string][]byte, len(proof.StoreInfos))
+	for _, si := range proof.StoreInfos {
+		m[si.Name] = si.Hash()
+	}
+	return merkle.SimpleHashFromMap(m)
+}
+
+type multiStoreOp struct {
+	Proof *MultiStoreProof `json:"proof"`
+}
+
+// MultiStoreProofOp proves that a substore root is part of the app hash.
+type MultiStoreProofOp struct {
+	key   []byte
+	Proof *MultiStoreProof
+}
+
+func NewMultiStoreProofOp(storeName []byte, proof *MultiStoreProof) MultiStoreProofOp {
+	return MultiStoreProofOp{key: storeName, Proof: proof}
+}
+
+func MultiStoreProofOpDecoder(pop merkle.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpMultiStore {
+		return nil, fmt.Errorf("unexpected proof op type %s, want %s", pop.Type, ProofOpMultiStore)
+	}
+	var op multiStoreOp
+	if err := cdc.UnmarshalBinaryLengthPrefixed(pop.Data, &op); err != nil {
+		return nil, errors.Wrap(err, "decode multistore op")
+	}
+	if op.Proof == nil {
+		return nil, errors.Wrap(ErrInvalidProof, "empty multistore op")
+	}
+	return NewMultiStoreProofOp(pop.Key, op.Proof), nil
+}
+
+func (op MultiStoreProofOp) GetKey() []byte {
+	return op.key
+}
+
+func (op MultiStoreProofOp) ProofOp() merkle.ProofOp {
+	return merkle.ProofOp{
+		Type: ProofOpMultiStore,
+		Key:  op.key,
+		Data: cdc.MustMarshalBinaryLengthPrefixed(multiStoreOp{Proof: op.Proof}),
+	}
+}
+
+func (op MultiStoreProofOp) Run(args [][]byte) ([][]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("expected 1 store root, got %d", len(args))
+	}
+	for _, si := range op.Proof.StoreInfos {
+		if si.Name != string(op.key) {
+			continue
+		}
+		if !bytes.Equal(si.Core.CommitID.Hash, args[0]) {
+			return nil, errors.Wrapf(ErrInvalidProof, "hash mismatch for store %s: %X vs %X", si.Name, si.Core.CommitID.Hash, args[0])
+		}
+		return [][]byte{op.Proof.ComputeRootHash()}, nil
+	}
+	return nil, errors.Wrapf(ErrInvalidProof, "store %s is not in the multistore proof", op.key)
+}
@@ -0,0 +1,39 @@
+// Package proof verifies the merkle proofs returned by store queries with prove
+// enabled. A store proof chains an IAVL op, proving the key inside its substore,
+// with a multistore op, proving the substore root inside the app hash.
+package proof
+
+import (
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+var cdc = amino.NewCodec()
+
+// DefaultProofRuntime decodes the proof ops used by the node stores.
+func DefaultProofRuntime() *merkle.ProofRuntime {
+	prt := merkle.DefaultProofRuntime()
+	prt.RegisterOpDecoder(ProofOpIAVLValue, IAVLValueOpDecoder)
+	prt.RegisterOpDecoder(ProofOpIAVLAbsence, IAVLAbsenceOpDecoder)
+	prt.RegisterOpDecoder(ProofOpMultiStore, MultiStoreProofOpDecoder)
+	return prt
+}
+
+// KeyPath returns the key path of key in the named store.
+func KeyPath(storeName string, key []byte) string {
+	kp := merkle.KeyPath{}
+	kp = kp.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
+	kp = kp.AppendKey(key, merkle.KeyEncodingHex)
+	return kp.String()
+}
+
+// VerifyValue checks that key is set to value in the named store under appHash.
+// The state at height h is committed by the app hash in the header of block h+1.
+func VerifyValue(proof *merkle.Proof, appHash []byte, storeName string, key, value []byte) error {
+	return DefaultProofRuntime().VerifyValue(proof, appHash, KeyPath(storeName, key), value)
+}
+
+// VerifyAbsence checks that key is not set in the named store under appHash.
+func VerifyAbsence(proof *merkle.Proof, appHash []byte, storeName string, key []byte) error {
+	return DefaultProofRuntime().VerifyAbsence(proof, appHash, KeyPath(storeName, key))
+}
@@ -0,0 +1,73 @@
+package proof
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+// tree is a three leaf IAVL tree: root(inner(a, b), c).
+type tree struct {
+	a, b, c ProofLeafNode
+	inner   ProofInnerNode
+	root    ProofInnerNode
+}
+
+func leaf(key, value string) ProofLeafNode {
+	hash := sha256.Sum256([]byte(value))
+	return ProofLeafNode{Key: []byte(key), ValueHash: hash[:], Version: 1}
+}
+
+func newTree() tree {
+	t := tree{a: leaf("a", "1"), b: leaf("c", "2"), c: leaf("e", "3")}
+	t.inner = ProofInnerNode{Height: 1, Size: 2, Version: 1}
+	t.root = ProofInnerNode{Height: 2, Size: 3, Version: 1, Right: t.c.Hash()}
+	return t
+}
+
+func (t tree) rootHash() []byte {
+	inner := t.inner
+	inner.Left = t.a.Hash()
+	return t.root.Hash(inner.Hash(t.b.Hash()))
+}
+
+func storeProof(op merkle.ProofOperator, storeRoot []byte) (*merkle.Proof, []byte) {
+	other := StoreInfo{Name: "main", Core: StoreCore{CommitID{Version: 1, Hash: []byte{1}}}}
+	acc := StoreInfo{Name: "acc", Core: StoreCore{CommitID{Version: 1, Hash: storeRoot}}}
+	ms := &MultiStoreProof{StoreInfos: []StoreInfo{other, acc}}
+	proof := &merkle.Proof{Ops: []merkle.ProofOp{op.ProofOp(), NewMultiStoreProofOp([]byte("acc"), ms).ProofOp()}}
+	return proof, ms.ComputeRootHash()
+}
+
+func TestVerifyValue(t *testing.T) {
+	tr := newTree()
+	inner := tr.inner
+	inner.Left = tr.a.Hash()
+	rp := &RangeProof{LeftPath: PathToLeaf{tr.root, inner}, Leaves: []ProofLeafNode{tr.b}}
+	proof, appHash := storeProof(NewIAVLValueOp([]byte("c"), rp), tr.rootHash())
+
+	assert.NoError(t, VerifyValue(proof, appHash, "acc", []byte("c"), []byte("2")))
+	assert.Error(t, VerifyValue(proof, appHash, "acc", []byte("c"), []byte("3")))
+	assert.Error(t, VerifyValue(proof, appHash, "main", []byte("c"), []byte("2")))
+	assert.Error(t, VerifyValue(proof, []byte("bad"), "acc", []byte("c"), []byte("2")))
+}
+
+func TestVerifyAbsence(t *testing.T) {
+	tr := newTree()
+	inner := tr.inner
+	inner.Right = tr.b.Hash()
+	rp := &RangeProof{
+		LeftPath:   PathToLeaf{tr.root, inner},
+		InnerNodes: []PathToLeaf{{}},
+		Leaves:     []ProofLeafNode{tr.a, tr.b},
+	}
+	proof, appHash := storeProof(NewIAVLAbsenceOp([]byte("b"), rp), tr.rootHash())
+	assert.NoError(t, VerifyAbsence(proof, appHash, "acc", []byte("b")))
+
+	proof, _ = storeProof(NewIAVLAbsenceOp([]byte("c"), rp), tr.rootHash())
+	assert.Error(t, VerifyAbsence(proof, appHash, "acc", []byte("c")))
+	proof, _ = storeProof(NewIAVLAbsenceOp([]byte("d"), rp), tr.rootHash())
+	assert.Error(t, VerifyAbsence(proof, appHash, "acc", []byte("d")))
+}
@@ -68,7 +68,8 @@ func (bz AccAddress) MarshalJSON() ([]byte, error) {
 	return json.Marshal(bz.String())
 }
 
-// UnmarshalJSON to Unmarshal from JSON assuming Bech32 encoding
+// UnmarshalJSON to Unmarshal from JSON assuming Bech32 encoding, see
+// UnmarshalNodeJSON for responses of another network
 func (bz *AccAddress) UnmarshalJSON(data []byte) error {
 	var s string
 	err := json.Unmarshal(data, &s)
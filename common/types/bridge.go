@@ -0,0 +1,60 @@
+package types
+
+// BindStatus is how far a BEP2 token is in binding to a BEP20 contract.
+type BindStatus int8
+
+const (
+	BindStatusUnbound BindStatus = iota
+	// BindStatusPending means a bind request waits for the token hub on the smart chain.
+	BindStatusPending
+	BindStatusBound
+)
+
+func (s BindStatus) String() string {
+	switch s {
+	case BindStatusPending:
+		return "pending"
+	case BindStatusBound:
+		return "bound"
+	}
+	return "unbound"
+}
+
+// BoundToken maps a BEP2 symbol to its BEP20 contract.
+type BoundToken struct {
+	Symbol           string `json:"symbol"`
+	ContractAddress  string `json:"contract_address"`
+	ContractDecimals int8   `json:"contract_decimals"`
+}
+
+// BindRequest is a bind waiting to be approved on the smart chain.
+type BindRequest struct {
+	From             AccAddress `json:"from"`
+	Symbol           string     `json:"symbol"`
+	Amount           int64      `json:"amount"`
+	DeductedAmount   int64      `json:"deducted_amount"`
+	ContractAddress  string     `json:"contract_address"`
+	ContractDecimals int8       `json:"contract_decimals"`
+	ExpireTime       int64      `json:"expire_time"`
+}
+
+// CrossChainFees are the BNB fees of bridge msgs. The relay fee of a msg is paid
+// on top of its fee and goes to the relayer delivering the package.
+type CrossChainFees struct {
+	BindFee             int64 `json:"bind_fee"`
+	BindRelayFee        int64 `json:"bind_relay_fee"`
+	UnbindFee           int64 `json:"unbind_fee"`
+	UnbindRelayFee      int64 `json:"unbind_relay_fee"`
+	TransferOutFee      int64 `json:"transfer_out_fee"`
+	TransferOutRelayFee int64 `json:"transfer_out_relay_fee"`
+}
+
+// TransferOutCost is the BNB a TransferOut costs besides the transferred amount.
+func (f CrossChainFees) TransferOutCost() int64 {
+	return f.TransferOutFee + f.TransferOutRelayFee
+}
+
+// BindCost is the BNB a Bind costs besides the bound amount.
+func (f CrossChainFees) BindCost() int64 {
+	return f.BindFee + f.BindRelayFee
+}
@@ -108,6 +108,15 @@ func (d Dec) Abs() Dec {
 	return d
 }
 
+// addition
+func (d Dec) Add(d2 Dec) Dec {
+	c := d.int64 + d2.int64
+	if (c > d.int64) != (d2.int64 > 0) {
+		panic("Int overflow")
+	}
+	return Dec{c}
+}
+
 // subtraction
 func (d Dec) Sub(d2 Dec) Dec {
 	c := d.int64 - d2.int64
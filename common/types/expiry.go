@@ -0,0 +1,26 @@
+package types
+
+// ExpiryReason is why the match engine dropped an order from the book at the
+// end of a block. These orders show neither in tx logs nor in block results,
+// only in the order stream, where the reason is the status of the order.
+type ExpiryReason string
+
+const (
+	// ExpiryIocNoFill is an IOC order that matched nothing.
+	ExpiryIocNoFill ExpiryReason = "IocNoFill"
+	// ExpiryIocExpire is the unfilled rest of a partially filled IOC order.
+	ExpiryIocExpire ExpiryReason = "IocExpire"
+	// ExpiryExpired is a GTC order that outlived its time in force, they are
+	// removed on breathe blocks.
+	ExpiryExpired ExpiryReason = "Expired"
+)
+
+// ParseExpiryReason returns the reason for an order status, ok is false for
+// statuses that are not an expiry.
+func ParseExpiryReason(status string) (ExpiryReason, bool) {
+	switch r := ExpiryReason(status); r {
+	case ExpiryIocNoFill, ExpiryIocExpire, ExpiryExpired:
+		return r, true
+	}
+	return "", false
+}
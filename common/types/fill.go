@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+// Fill is an execution of an order of an account, the unit inventory tracking
+// and pnl accounting work with. Side is msg.OrderSide.BUY or SELL, Price and
+// Quantity are Fixed8 values and Fees what the execution was charged.
+type Fill struct {
+	Symbol   string    `json:"symbol"`
+	OrderID  string    `json:"order_id"`
+	TradeID  string    `json:"trade_id"`
+	Side     int8      `json:"side"`
+	Price    int64     `json:"price"`
+	Quantity int64     `json:"quantity"`
+	Fees     Coins     `json:"fees,omitempty"`
+	Time     time.Time `json:"time"`
+}
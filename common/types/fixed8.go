@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 const (
@@ -69,8 +71,12 @@ func Fixed8DecodeString(s string) (Fixed8, error) {
 	ip, err := strconv.Atoi(parts[0])
 	if err != nil {
 		return 0, errInvalidString
+	}
+	v := int64(ip) * int64(Fixed8Decimals)
+	if v/int64(Fixed8Decimals) != int64(ip) {
+		return 0, errInvalidString
 	} else if len(parts) == 1 {
-		return NewFixed8(int64(ip)), nil
+		return Fixed8(v), nil
 	}
 
 	fp, err := strconv.Atoi(parts[1])
@@ -80,11 +86,105 @@ func Fixed8DecodeString(s string) (Fixed8, error) {
 	for i := len(parts[1]); i < precision; i++ {
 		fp *= 10
 	}
-	return Fixed8(ip*Fixed8Decimals + fp), nil
+	if v > math.MaxInt64-int64(fp) {
+		return 0, errInvalidString
+	}
+	return Fixed8(v + int64(fp)), nil
+}
+
+// ParseFixed8 parses b like Fixed8DecodeString, without allocating, for
+// decoding market data. It is stricter: the fraction must be 1 to 8 digits
+// and only the integer part may carry a sign.
+func ParseFixed8(b []byte) (Fixed8, error) {
+	neg := len(b) > 0 && (b[0] == '-' || b[0] == '+')
+	if neg {
+		neg = b[0] == '-'
+		b = b[1:]
+	}
+	var v int64
+	digits, fraction := 0, -1
+	for _, c := range b {
+		switch {
+		case c == '.' && fraction < 0 && digits > 0:
+			fraction = 0
+			continue
+		case c < '0' || c > '9':
+			return 0, errInvalidString
+		}
+		if fraction >= 0 {
+			fraction++
+			if fraction > precision {
+				return 0, errInvalidString
+			}
+		} else {
+			digits++
+		}
+		if v > (math.MaxInt64-9)/10 {
+			return 0, errInvalidString
+		}
+		v = v*10 + int64(c-'0')
+	}
+	if digits == 0 || fraction == 0 {
+		return 0, errInvalidString
+	}
+	scale := precision
+	if fraction > 0 {
+		scale -= fraction
+	}
+	for ; scale > 0; scale-- {
+		if v > math.MaxInt64/10 {
+			return 0, errInvalidString
+		}
+		v *= 10
+	}
+	if neg {
+		v = -v
+	}
+	return Fixed8(v), nil
+}
+
+// NumberDecoding selects how Fixed8 decodes amounts from JSON.
+type NumberDecoding int32
+
+const (
+	// DecodeNumbersLenient accepts amounts as strings or as JSON numbers,
+	// converting numbers through float64, which may lose precision. Amounts
+	// out of the range of Fixed8 are still rejected.
+	DecodeNumbersLenient NumberDecoding = iota
+	// DecodeNumbersExact parses amounts, strings or numbers, as decimals and
+	// fails with *PrecisionLossError on any amount that Fixed8 can't hold
+	// exactly, more than 8 decimals or out of range.
+	DecodeNumbersExact
+)
+
+var numberDecoding int32
+
+// SetNumberDecoding sets how Fixed8 decodes amounts from JSON, for the whole
+// process like Network. DecodeNumbersLenient by default.
+func SetNumberDecoding(mode NumberDecoding) {
+	atomic.StoreInt32(&numberDecoding, int32(mode))
+}
+
+// GetNumberDecoding returns the mode set by SetNumberDecoding.
+func GetNumberDecoding() NumberDecoding {
+	return NumberDecoding(atomic.LoadInt32(&numberDecoding))
+}
+
+// PrecisionLossError is returned in DecodeNumbersExact mode for an amount Fixed8
+// can't hold exactly.
+type PrecisionLossError struct {
+	Value string
+}
+
+func (e *PrecisionLossError) Error() string {
+	return fmt.Sprintf("amount %s does not fit a Fixed8 exactly", e.Value)
 }
 
 // UnmarshalJSON implements the json unmarshaller interface
 func (f *Fixed8) UnmarshalJSON(data []byte) error {
+	if GetNumberDecoding() == DecodeNumbersExact {
+		return f.unmarshalExact(data)
+	}
 	var s string
 	if err := json.Unmarshal(data, &s); err == nil {
 		p, err := Fixed8DecodeString(s)
@@ -100,7 +200,31 @@ func (f *Fixed8) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	*f = Fixed8(float64(Fixed8Decimals) * fl)
+	v := float64(Fixed8Decimals) * fl
+	// float64(math.MaxInt64) is 2^63, which no longer fits
+	if v >= math.MaxInt64 || v < math.MinInt64 {
+		return fmt.Errorf("amount %s overflows Fixed8", data)
+	}
+	*f = Fixed8(v)
+	return nil
+}
+
+// unmarshalExact decodes a string or a number without going through float64.
+func (f *Fixed8) unmarshalExact(data []byte) error {
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	value := number.String()
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return errInvalidString
+	}
+	r.Mul(r, new(big.Rat).SetInt64(int64(Fixed8Decimals)))
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return &PrecisionLossError{Value: value}
+	}
+	*f = Fixed8(r.Num().Int64())
 	return nil
 }
 
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeFixed8(data string) (Fixed8, error) {
+	var f Fixed8
+	err := json.Unmarshal([]byte(data), &f)
+	return f, err
+}
+
+func TestFixed8DecodeLenient(t *testing.T) {
+	defer SetNumberDecoding(GetNumberDecoding())
+	SetNumberDecoding(DecodeNumbersLenient)
+
+	for data, want := range map[string]Fixed8{
+		`"1.5"`:                  15e7,
+		`1.5`:                    15e7,
+		`"92233720368.54775807"`: math.MaxInt64,
+		`0`:                      0,
+		// more than 8 decimals are cut, as float64 gives them
+		`0.123456789`: 12345678,
+	} {
+		got, err := decodeFixed8(data)
+		assert.NoError(t, err, data)
+		assert.Equal(t, want, got, data)
+	}
+
+	for _, data := range []string{
+		`92233720368.54775808`,
+		`1e12`,
+		`-1e12`,
+		`"92233720368.54775808"`,
+		`"92233720369"`,
+		`"0.123456789"`,
+		`"abc"`,
+		`true`,
+	} {
+		_, err := decodeFixed8(data)
+		assert.Error(t, err, data)
+	}
+}
+
+func TestFixed8DecodeExact(t *testing.T) {
+	defer SetNumberDecoding(GetNumberDecoding())
+	SetNumberDecoding(DecodeNumbersExact)
+
+	for data, want := range map[string]Fixed8{
+		`"1.5"`:                  15e7,
+		`1.5`:                    15e7,
+		`1e2`:                    1e10,
+		`0.00000001`:             1,
+		`92233720368.54775807`:   math.MaxInt64,
+		`-92233720368.54775808`:  math.MinInt64,
+		`"92233720368.54775807"`: math.MaxInt64,
+	} {
+		got, err := decodeFixed8(data)
+		assert.NoError(t, err, data)
+		assert.Equal(t, want, got, data)
+	}
+
+	for _, data := range []string{
+		`0.123456789`,
+		`"0.123456789"`,
+		`1e-9`,
+		`92233720368.54775808`,
+		`-92233720368.54775809`,
+		`1e12`,
+	} {
+		_, err := decodeFixed8(data)
+		assert.IsType(t, &PrecisionLossError{}, err, data)
+	}
+
+	_, err := decodeFixed8(`"abc"`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/binance-chain/go-sdk/common/bech32"
+)
+
+// The public chain ids, see also types.ProdChainID and the like.
+const (
+	prodChainID   = "Binance-Chain-Tigris"
+	gangesChainID = "Binance-Chain-Ganges"
+	kongoChainID  = "Binance-Chain-Kongo"
+)
+
+// FormatAddress renders addr with the bech32 prefix of the network, unlike
+// AccAddress.String which uses the process wide Network. Clients serving
+// several networks at once format and parse addresses with their own.
+func (this ChainNetwork) FormatAddress(addr AccAddress) string {
+	bech32Addr, err := bech32.ConvertAndEncode(this.Bech32Prefixes(), addr.Bytes())
+	if err != nil {
+		panic(err)
+	}
+	return bech32Addr
+}
+
+// ParseAddress decodes an address of the network.
+func (this ChainNetwork) ParseAddress(address string) (AccAddress, error) {
+	bz, err := GetFromBech32(address, this.Bech32Prefixes())
+	if err != nil {
+		return nil, err
+	}
+	return AccAddress(bz), nil
+}
+
+// ChainID returns the chain id of the network.
+func (this ChainNetwork) ChainID() string {
+	switch this {
+	case ProdNetwork:
+		return prodChainID
+	case TmpTestNetwork:
+		return kongoChainID
+	default:
+		return gangesChainID
+	}
+}
+
+// knownNetworks are the networks of the account prefixes node responses are
+// decoded from, testnets share theirs.
+var knownNetworks = []ChainNetwork{ProdNetwork, TestNetwork}
+
+// AccAddressFromAnyBech32 decodes an account address of any known network,
+// unlike AccAddressFromBech32 which takes those of the process wide Network
+// only. Addresses from a node or an api server are of its own network.
+func AccAddressFromAnyBech32(address string) (AccAddress, error) {
+	hrp, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range knownNetworks {
+		if hrp == network.Bech32Prefixes() {
+			return AccAddress(bz), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid bech32 prefix %s", hrp)
+}
+
+// UnmarshalNodeJSON is json.Unmarshal for the responses of a node or an api
+// server, which may be of another network than Network: the account addresses
+// of any known network decode into AccAddress fields, which otherwise only take
+// those of Network.
+func UnmarshalNodeJSON(data []byte, v interface{}) error {
+	return json.Unmarshal(NodeJSON(data), v)
+}
+
+// NodeJSON returns the JSON of a node response with the account addresses of
+// any known network in the prefix of Network, see UnmarshalNodeJSON.
+func NodeJSON(data []byte) []byte {
+	prefix := Network.Bech32Prefixes()
+	return replaceAddresses(data, func(hrp string) bool {
+		return hrp != prefix
+	}, prefix)
+}
+
+// AddressesJSON returns data, JSON rendered by the encoders of AccAddress with
+// the prefix of Network, with the account addresses in the prefix of the
+// network. It lets a client encode for its own network, like the sign bytes of
+// its msgs, while Network is another one.
+func (this ChainNetwork) AddressesJSON(data []byte) []byte {
+	from, to := Network.Bech32Prefixes(), this.Bech32Prefixes()
+	if from == to {
+		return data
+	}
+	return replaceAddresses(data, func(hrp string) bool {
+		return hrp == from
+	}, to)
+}
+
+// replaceAddresses renders the JSON strings of data that are account addresses
+// with a prefix matched by from with prefix instead. The rest of data, like its
+// formatting, is kept as is.
+func replaceAddresses(data []byte, from func(hrp string) bool, prefix string) []byte {
+	var out []byte
+	last := 0
+	for i := 0; i < len(data); i++ {
+		if data[i] != '"' {
+			continue
+		}
+		start, escaped := i+1, false
+		for i++; i < len(data) && data[i] != '"'; i++ {
+			if data[i] == '\\' {
+				escaped = true
+				i++
+			}
+		}
+		if escaped || i >= len(data) {
+			continue
+		}
+		hrp, bz, err := bech32.DecodeAndConvert(string(data[start:i]))
+		if err != nil || hrp == prefix || !from(hrp) || !isAccountPrefix(hrp) {
+			continue
+		}
+		address, err := bech32.ConvertAndEncode(prefix, bz)
+		if err != nil {
+			continue
+		}
+		out = append(append(out, data[last:start]...), address...)
+		last = i
+	}
+	if out == nil {
+		return data
+	}
+	return append(out, data[last:]...)
+}
+
+func isAccountPrefix(hrp string) bool {
+	for _, network := range knownNetworks {
+		if hrp == network.Bech32Prefixes() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalNodeJSON(t *testing.T) {
+	defer func(network ChainNetwork) { Network = network }(Network)
+	Network = ProdNetwork
+	addr := AccAddress([]byte("node-response-owner-"))
+	response := []byte(`{"owner":"` + TestNetwork.FormatAddress(addr) + `","name":"a \"quoted\" name"}`)
+
+	var token Token
+	assert.Error(t, json.Unmarshal(response, &token), "AccAddress takes the addresses of Network only")
+	assert.NoError(t, UnmarshalNodeJSON(response, &token))
+	assert.Equal(t, addr, token.Owner)
+	assert.Equal(t, `a "quoted" name`, token.Name)
+}
+
+func TestAddressesJSON(t *testing.T) {
+	defer func(network ChainNetwork) { Network = network }(Network)
+	Network = ProdNetwork
+	addr := AccAddress([]byte("sign-bytes-sender---"))
+	bz, err := json.Marshal(map[string]interface{}{"from": addr, "memo": "x", "amount": 1})
+	assert.NoError(t, err)
+
+	assert.Equal(t, string(bz), string(ProdNetwork.AddressesJSON(bz)))
+	assert.Equal(t, `{"amount":1,"from":"`+TestNetwork.FormatAddress(addr)+`","memo":"x"}`, string(TestNetwork.AddressesJSON(bz)))
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+)
+
+var fixed8Decimals = big.NewInt(int64(Fixed8Decimals))
+
+// QuoteAmount is the quote asset amount of quantity base asset at price, all in
+// Fixed8, rounded with mode.
+func QuoteAmount(quantity, price int64, mode RoundingMode) (int64, error) {
+	if quantity < 0 || price < 0 {
+		return 0, fmt.Errorf("quantity and price must not be negative")
+	}
+	num := new(big.Int).Mul(big.NewInt(quantity), big.NewInt(price))
+	return divRound(num, fixed8Decimals, mode)
+}
+
+// BaseQuantity is the base asset quantity that costs quoteAmount at price, all in
+// Fixed8, rounded with mode.
+func BaseQuantity(quoteAmount, price int64, mode RoundingMode) (int64, error) {
+	if quoteAmount < 0 || price <= 0 {
+		return 0, fmt.Errorf("quote amount must not be negative and price must be positive")
+	}
+	num := new(big.Int).Mul(big.NewInt(quoteAmount), fixed8Decimals)
+	return divRound(num, big.NewInt(price), mode)
+}
+
+// QuantityForQuoteAmount is BaseQuantity rounded to the lot size of the pair. The
+// exact quantity is rounded once, straight to a whole number of lots, so with
+// RoundDown the order never costs more than quoteAmount.
+func (p TradingPair) QuantityForQuoteAmount(quoteAmount, price int64, mode RoundingMode) (int64, error) {
+	lot := p.LotSize.ToInt64()
+	if lot <= 0 {
+		return 0, fmt.Errorf("lot size must be positive, got %d", lot)
+	}
+	if quoteAmount < 0 || price <= 0 {
+		return 0, fmt.Errorf("quote amount must not be negative and price must be positive")
+	}
+	num := new(big.Int).Mul(big.NewInt(quoteAmount), fixed8Decimals)
+	den := new(big.Int).Mul(big.NewInt(price), big.NewInt(lot))
+	lots, err := divRound(num, den, mode)
+	if err != nil {
+		return 0, err
+	}
+	quantity := new(big.Int).Mul(big.NewInt(lots), big.NewInt(lot))
+	if !quantity.IsInt64() {
+		return 0, fmt.Errorf("quantity %s overflows int64", quantity)
+	}
+	return quantity.Int64(), nil
+}
+
+// divRound divides two non-negative numbers, rounding with mode.
+func divRound(num, den *big.Int, mode RoundingMode) (int64, error) {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 {
+		switch mode {
+		case RoundDown:
+		case RoundUp:
+			quo.Add(quo, big.NewInt(1))
+		case RoundNearest:
+			if new(big.Int).Lsh(rem, 1).Cmp(den) >= 0 {
+				quo.Add(quo, big.NewInt(1))
+			}
+		default:
+			return 0, fmt.Errorf("unknown rounding mode %d", mode)
+		}
+	}
+	if !quo.IsInt64() {
+		return 0, fmt.Errorf("%s overflows int64", quo)
+	}
+	return quo.Int64(), nil
+}
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteAmount(t *testing.T) {
+	tests := []struct {
+		quantity, price   int64
+		down, up, nearest int64
+	}{
+		{3e8, 5e7, 15e7, 15e7, 15e7},
+		{1, 5e7, 0, 1, 1},
+		{1, 4e7, 0, 1, 0},
+		{1, 6e7, 0, 1, 1},
+		{5, 0, 0, 0, 0},
+		// the product is beyond int64, the result is not
+		{math.MaxInt64, 1e8, math.MaxInt64, math.MaxInt64, math.MaxInt64},
+	}
+	for _, test := range tests {
+		for mode, want := range map[RoundingMode]int64{RoundDown: test.down, RoundUp: test.up, RoundNearest: test.nearest} {
+			got, err := QuoteAmount(test.quantity, test.price, mode)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got, "%d at %d, %s", test.quantity, test.price, mode)
+		}
+	}
+
+	_, err := QuoteAmount(math.MaxInt64, 2e8, RoundDown)
+	assert.Error(t, err, "overflow")
+	_, err = QuoteAmount(-1, 1e8, RoundDown)
+	assert.Error(t, err)
+	_, err = QuoteAmount(1, -1e8, RoundDown)
+	assert.Error(t, err)
+	_, err = QuoteAmount(1, 5e7, RoundingMode(7))
+	assert.Error(t, err)
+}
+
+func TestBaseQuantity(t *testing.T) {
+	tests := []struct {
+		quoteAmount, price int64
+		down, up, nearest  int64
+	}{
+		{1e8, 3e8, 33333333, 33333334, 33333333},
+		{2e8, 3e8, 66666666, 66666667, 66666667},
+		{1, 2e8, 0, 1, 1},
+		{1, 3e8, 0, 1, 0},
+		{15e7, 5e7, 3e8, 3e8, 3e8},
+		{0, 1e8, 0, 0, 0},
+	}
+	for _, test := range tests {
+		for mode, want := range map[RoundingMode]int64{RoundDown: test.down, RoundUp: test.up, RoundNearest: test.nearest} {
+			got, err := BaseQuantity(test.quoteAmount, test.price, mode)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got, "%d at %d, %s", test.quoteAmount, test.price, mode)
+		}
+	}
+
+	_, err := BaseQuantity(math.MaxInt64, 1, RoundDown)
+	assert.Error(t, err, "overflow")
+	_, err = BaseQuantity(1e8, 0, RoundDown)
+	assert.Error(t, err, "zero price")
+	_, err = BaseQuantity(1e8, -1, RoundDown)
+	assert.Error(t, err, "negative price")
+	_, err = BaseQuantity(-1, 1e8, RoundDown)
+	assert.Error(t, err)
+}
+
+func TestQuantityForQuoteAmount(t *testing.T) {
+	pair := TradingPair{BaseAssetSymbol: "XYZ-000", QuoteAssetSymbol: "BNB", LotSize: 1e6}
+	tests := []struct {
+		quoteAmount, price int64
+		down, up, nearest  int64
+	}{
+		{1e8, 3e8, 33e6, 34e6, 33e6},
+		{1e8, 1e8, 1e8, 1e8, 1e8},
+		// 33.4999996 lots, rounding to 1e-8 first would make it 33.5 and 34
+		{167499998, 5e8, 33e6, 34e6, 33e6},
+		{0, 1e8, 0, 0, 0},
+	}
+	for _, test := range tests {
+		for mode, want := range map[RoundingMode]int64{RoundDown: test.down, RoundUp: test.up, RoundNearest: test.nearest} {
+			got, err := pair.QuantityForQuoteAmount(test.quoteAmount, test.price, mode)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got, "%d at %d, %s", test.quoteAmount, test.price, mode)
+			assert.Equal(t, int64(0), got%pair.LotSize.ToInt64())
+			if mode == RoundDown {
+				cost, err := QuoteAmount(got, test.price, RoundUp)
+				assert.NoError(t, err)
+				assert.True(t, cost <= test.quoteAmount, "costs %d of %d", cost, test.quoteAmount)
+			}
+		}
+	}
+
+	_, err := TradingPair{LotSize: 1}.QuantityForQuoteAmount(math.MaxInt64, 1, RoundDown)
+	assert.Error(t, err, "overflow")
+	_, err = pair.QuantityForQuoteAmount(1e8, 0, RoundDown)
+	assert.Error(t, err, "zero price")
+	_, err = pair.QuantityForQuoteAmount(1e8, -1, RoundDown)
+	assert.Error(t, err, "negative price")
+	_, err = TradingPair{}.QuantityForQuoteAmount(1e8, 1e8, RoundDown)
+	assert.Error(t, err, "no lot size")
+}
@@ -318,3 +318,10 @@ type QueryProposalParams struct {
 	BaseParams
 	ProposalID int64
 }
+
+// Deposit is what a depositor put on a proposal.
+type Deposit struct {
+	Depositor  AccAddress `json:"depositor"`
+	ProposalID int64      `json:"proposal_id"`
+	Amount     Coins      `json:"amount"`
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"fmt"
+	"math"
+)
+
+// RoundingMode tells how a value that is not a multiple of a step is rounded.
+type RoundingMode int
+
+const (
+	RoundDown RoundingMode = iota
+	RoundUp
+	// RoundNearest rounds halves up.
+	RoundNearest
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundDown:
+		return "down"
+	case RoundUp:
+		return "up"
+	case RoundNearest:
+		return "nearest"
+	}
+	return "unknown"
+}
+
+// RoundToMultiple rounds a non-negative value to a multiple of step.
+func RoundToMultiple(value, step int64, mode RoundingMode) (int64, error) {
+	if step <= 0 {
+		return 0, fmt.Errorf("step must be positive, got %d", step)
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("value must not be negative, got %d", value)
+	}
+	rem := value % step
+	down := value - rem
+	if rem == 0 {
+		return value, nil
+	}
+	switch mode {
+	case RoundDown:
+		return down, nil
+	case RoundUp:
+		if down > math.MaxInt64-step {
+			return 0, fmt.Errorf("%d rounded up to a multiple of %d overflows", value, step)
+		}
+		return down + step, nil
+	case RoundNearest:
+		if rem < step-rem {
+			return down, nil
+		}
+		return RoundToMultiple(value, step, RoundUp)
+	}
+	return 0, fmt.Errorf("unknown rounding mode %d", mode)
+}
+
+// RoundPrice rounds a price to the tick size of the pair.
+func (p TradingPair) RoundPrice(price int64, mode RoundingMode) (int64, error) {
+	return RoundToMultiple(price, p.TickSize.ToInt64(), mode)
+}
+
+// RoundQuantity rounds a quantity to the lot size of the pair.
+func (p TradingPair) RoundQuantity(quantity int64, mode RoundingMode) (int64, error) {
+	return RoundToMultiple(quantity, p.LotSize.ToInt64(), mode)
+}
@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+// PairSnapshot is the state of one trading pair in a MarketSnapshot. Mini tokens
+// are reported as Token, without their token type and uri.
+type PairSnapshot struct {
+	Pair       string       `json:"pair"`
+	Depth      *MarketDepth `json:"depth"`
+	Trades     []Trade      `json:"trades"`
+	BaseToken  *Token       `json:"base_token,omitempty"`
+	QuoteToken *Token       `json:"quote_token,omitempty"`
+}
+
+// MarketSnapshot bundles several pairs fetched at about the same time. Time is
+// taken when the fetching started.
+type MarketSnapshot struct {
+	Time  time.Time                `json:"time"`
+	Pairs map[string]*PairSnapshot `json:"pairs"`
+}
+
+// PairInfo is what a trading UI shows of a pair: its tokens, listing and
+// precision, and the best levels of its order book at Height. Prices and
+// quantities of the book are decimal strings, empty when the side is empty.
+type PairInfo struct {
+	Pair        string `json:"pair"`
+	BaseToken   *Token `json:"base_token,omitempty"`
+	QuoteToken  *Token `json:"quote_token,omitempty"`
+	ListPrice   Fixed8 `json:"list_price"`
+	TickSize    Fixed8 `json:"tick_size"`
+	LotSize     Fixed8 `json:"lot_size"`
+	BidPrice    string `json:"bid_price"`
+	BidQuantity string `json:"bid_quantity"`
+	AskPrice    string `json:"ask_price"`
+	AskQuantity string `json:"ask_quantity"`
+	Height      int64  `json:"height"`
+}
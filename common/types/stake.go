@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/binance-chain/go-sdk/common/bech32"
@@ -60,7 +61,7 @@ func (c Commission) Validate() error {
 
 	case c.MaxRate.GT(OneDec()):
 		// max rate cannot be greater than 100%
-		return fmt.Errorf("Commission maxrate %v can't be greater than 100%", c.MaxRate)
+		return fmt.Errorf("Commission maxrate %v can't be greater than 100%%", c.MaxRate)
 
 	case c.Rate.LT(ZeroDec()):
 		// rate cannot be negative
@@ -351,4 +352,20 @@ type QueryValidatorParams struct {
 type Pool struct {
 	LooseTokens  Dec `json:"loose_tokens"`  // tokens which are not bonded in a validator
 	BondedTokens Dec `json:"bonded_tokens"` // reserve of bonded tokens
+}
+
+// TotalTokens is the bonded and not bonded tokens together.
+func (p Pool) TotalTokens() Dec {
+	return p.LooseTokens.Add(p.BondedTokens)
+}
+
+// BondedRatio is the share of the tokens that are bonded, zero for an empty pool.
+func (p Pool) BondedRatio() Dec {
+	total := p.TotalTokens()
+	if total.int64 <= 0 {
+		return ZeroDec()
+	}
+	ratio := new(big.Int).Mul(big.NewInt(p.BondedTokens.int64), big.NewInt(precisionInt()))
+	ratio.Quo(ratio, big.NewInt(total.int64))
+	return Dec{ratio.Int64()}
 }
\ No newline at end of file
@@ -55,6 +55,26 @@ func (status *SwapStatus) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// SwapRole selects the swaps of an account by the side it is on.
+type SwapRole byte
+
+const (
+	SwapRoleAny SwapRole = iota
+	SwapRoleCreator
+	SwapRoleRecipient
+)
+
+func (role SwapRole) String() string {
+	switch role {
+	case SwapRoleCreator:
+		return "creator"
+	case SwapRoleRecipient:
+		return "recipient"
+	default:
+		return "any"
+	}
+}
+
 type SwapBytes []byte
 
 func (bz SwapBytes) Marshal() ([]byte, error) {
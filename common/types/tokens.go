@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // Token definition
 type Token struct {
 	Name             string     `json:"name"`
@@ -32,3 +34,31 @@ type MiniToken struct {
 	ContractAddress  string     `json:"contract_address,omitempty"`
 	ContractDecimals int8       `json:"contract_decimals,omitempty"`
 }
+
+// BalanceDetail breaks down every part of an account's holding of one symbol
+type BalanceDetail struct {
+	Symbol     string             `json:"symbol"`
+	Free       Fixed8             `json:"free"`
+	Frozen     Fixed8             `json:"frozen"`
+	Locked     Fixed8             `json:"locked"` // locked in open orders
+	TimeLocked Fixed8             `json:"time_locked"`
+	Swapping   Fixed8             `json:"swapping"` // locked in open atomic swaps created by the account
+	TimeLocks  []TimeLockedAmount `json:"time_locks,omitempty"`
+}
+
+// TimeLockedAmount is the share of a timelock record of one symbol
+type TimeLockedAmount struct {
+	Id         int64     `json:"id"`
+	Amount     Fixed8    `json:"amount"`
+	UnlockTime time.Time `json:"unlock_time"`
+}
+
+// Total returns the sum of all the parts of the balance
+func (b BalanceDetail) Total() Fixed8 {
+	return b.Free + b.Frozen + b.Locked + b.TimeLocked + b.Swapping
+}
+
+// Available returns the amount that can be spent right now
+func (b BalanceDetail) Available() Fixed8 {
+	return b.Free
+}
@@ -0,0 +1,289 @@
+// Package compliance exports the transfers decoded by the indexer as records
+// for AML and Travel Rule tooling. Every coin received by an output becomes a
+// record carrying the originators and the beneficiary of the transfer, the
+// memo, the time of the block in UTC and, when a price is known, the amount
+// in a reference quote asset. Records are written as JSON lines in a
+// versioned schema.
+package compliance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/indexer"
+)
+
+// SchemaVersion is the version of the Record schema, bumped on breaking changes.
+const SchemaVersion = "1"
+
+type Direction string
+
+const (
+	Incoming Direction = "incoming"
+	Outgoing Direction = "outgoing"
+	// Internal is a transfer between two watched addresses.
+	Internal Direction = "internal"
+)
+
+// Identity is what is known off chain about the owner of an address, as
+// required by the Travel Rule. Every field is optional.
+type Identity struct {
+	Name      string `json:"name,omitempty"`
+	AccountID string `json:"account_id,omitempty"`
+	// VASP is the virtual asset service provider holding the address.
+	VASP    string `json:"vasp,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// Resolver returns the identity behind an address. An error stops the indexer
+// before the block is checkpointed, so the transfer is exported again.
+type Resolver interface {
+	Resolve(addr types.AccAddress) (identity Identity, ok bool, err error)
+}
+
+// PriceSource returns the price of one unit of denom in quote at a time, in
+// units of 1e-8.
+type PriceSource interface {
+	Price(denom, quote string, at time.Time) (price int64, ok bool, err error)
+}
+
+// StaticPrices is a PriceSource with a fixed price per denom, whatever the
+// quote and time.
+type StaticPrices map[string]int64
+
+func (p StaticPrices) Price(denom, quote string, at time.Time) (int64, bool, error) {
+	price, ok := p[denom]
+	return price, ok, nil
+}
+
+type Party struct {
+	Address  string    `json:"address"`
+	Identity *Identity `json:"identity,omitempty"`
+	// Amount is what an originator sent of the asset of the record.
+	Amount string `json:"amount,omitempty"`
+}
+
+// Valuation is the amount of a record in the reference quote.
+type Valuation struct {
+	Quote  string `json:"quote"`
+	Price  string `json:"price"`
+	Amount string `json:"amount"`
+}
+
+// Record is one coin received by one output of a transfer. Transfers with
+// several inputs do not say which input paid which output, so every input
+// that sent the asset is listed as an originator. Amounts are decimal strings.
+type Record struct {
+	Schema      string     `json:"schema"`
+	ID          string     `json:"id"`
+	ChainID     string     `json:"chain_id,omitempty"`
+	Height      int64      `json:"height"`
+	Time        time.Time  `json:"time"`
+	TxHash      string     `json:"tx_hash"`
+	MsgIndex    int        `json:"msg_index"`
+	Memo        string     `json:"memo"`
+	Direction   Direction  `json:"direction,omitempty"`
+	Originators []Party    `json:"originators"`
+	Beneficiary Party      `json:"beneficiary"`
+	Asset       string     `json:"asset"`
+	Amount      string     `json:"amount"`
+	Reference   *Valuation `json:"reference,omitempty"`
+}
+
+type Config struct {
+	ChainID string
+	// Watch, if set, restricts the export to the transfers touching these
+	// addresses and sets the direction of the records.
+	Watch    []types.AccAddress
+	Resolver Resolver
+	// Prices and Quote value the records, records without a price have no
+	// reference amount.
+	Prices PriceSource
+	Quote  string
+
+	OnRecord func(Record) error
+}
+
+// Exporter turns transfers into records.
+type Exporter struct {
+	cfg   Config
+	watch map[string]bool
+}
+
+func New(cfg Config) *Exporter {
+	e := &Exporter{cfg: cfg}
+	if len(cfg.Watch) > 0 {
+		e.watch = map[string]bool{}
+		for _, addr := range cfg.Watch {
+			e.watch[string(addr)] = true
+		}
+	}
+	return e
+}
+
+// Handlers returns the indexer handlers that feed the exporter.
+func (e *Exporter) Handlers() indexer.Handlers {
+	return indexer.Handlers{OnTransfer: e.Process}
+}
+
+// Process passes the records of a transfer to OnRecord. Failed txs moved
+// nothing and are skipped.
+func (e *Exporter) Process(t indexer.TransferEvent) error {
+	records, err := e.Records(t)
+	if err != nil {
+		return err
+	}
+	if e.cfg.OnRecord == nil {
+		return nil
+	}
+	for _, r := range records {
+		if err := e.cfg.OnRecord(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Records returns the records of a transfer.
+func (e *Exporter) Records(t indexer.TransferEvent) ([]Record, error) {
+	if !t.Success() {
+		return nil, nil
+	}
+	fromWatched := false
+	for _, in := range t.Inputs {
+		fromWatched = fromWatched || e.watched(in.Address)
+	}
+	identities := map[string]*Identity{}
+	var records []Record
+	for i, out := range t.Outputs {
+		direction := e.direction(fromWatched, e.watched(out.Address))
+		if e.watch != nil && direction == "" {
+			continue
+		}
+		beneficiary, err := e.party(identities, out.Address)
+		if err != nil {
+			return nil, err
+		}
+		for _, coin := range out.Coins {
+			r := Record{
+				Schema:      SchemaVersion,
+				ID:          fmt.Sprintf("%s:%d:%d:%s", t.TxHash, t.MsgIndex, i, coin.Denom),
+				ChainID:     e.cfg.ChainID,
+				Height:      t.Height,
+				Time:        t.Time.UTC(),
+				TxHash:      t.TxHash,
+				MsgIndex:    t.MsgIndex,
+				Memo:        t.Memo,
+				Direction:   direction,
+				Beneficiary: beneficiary,
+				Asset:       coin.Denom,
+				Amount:      types.Fixed8(coin.Amount).String(),
+			}
+			for _, in := range t.Inputs {
+				sent := in.Coins.AmountOf(coin.Denom)
+				if sent == 0 {
+					continue
+				}
+				originator, err := e.party(identities, in.Address)
+				if err != nil {
+					return nil, err
+				}
+				originator.Amount = types.Fixed8(sent).String()
+				r.Originators = append(r.Originators, originator)
+			}
+			if r.Reference, err = e.value(coin, t.Time); err != nil {
+				return nil, err
+			}
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+func (e *Exporter) watched(addr types.AccAddress) bool {
+	return e.watch[string(addr)]
+}
+
+func (e *Exporter) direction(fromWatched, toWatched bool) Direction {
+	switch {
+	case fromWatched && toWatched:
+		return Internal
+	case fromWatched:
+		return Outgoing
+	case toWatched:
+		return Incoming
+	}
+	return ""
+}
+
+// party resolves addr once per transfer.
+func (e *Exporter) party(identities map[string]*Identity, addr types.AccAddress) (Party, error) {
+	p := Party{Address: addr.String()}
+	if e.cfg.Resolver == nil {
+		return p, nil
+	}
+	identity, ok := identities[p.Address]
+	if !ok {
+		resolved, found, err := e.cfg.Resolver.Resolve(addr)
+		if err != nil {
+			return p, fmt.Errorf("resolve %s: %v", p.Address, err)
+		}
+		if found {
+			identity = &resolved
+		}
+		identities[p.Address] = identity
+	}
+	p.Identity = identity
+	return p, nil
+}
+
+func (e *Exporter) value(coin types.Coin, at time.Time) (*Valuation, error) {
+	if e.cfg.Prices == nil || e.cfg.Quote == "" {
+		return nil, nil
+	}
+	price := int64(types.Fixed8Decimals)
+	if coin.Denom != e.cfg.Quote {
+		var ok bool
+		var err error
+		price, ok, err = e.cfg.Prices.Price(coin.Denom, e.cfg.Quote, at)
+		if err != nil {
+			return nil, fmt.Errorf("price of %s in %s: %v", coin.Denom, e.cfg.Quote, err)
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+	amount, err := types.QuoteAmount(coin.Amount, price, types.RoundNearest)
+	if err != nil {
+		return nil, err
+	}
+	return &Valuation{Quote: e.cfg.Quote, Price: types.Fixed8(price).String(), Amount: types.Fixed8(amount).String()}, nil
+}
+
+// JSONLines writes records as one JSON document per line. It is safe for
+// concurrent use.
+type JSONLines struct {
+	mtx sync.Mutex
+	w   io.Writer
+}
+
+func NewJSONLines(w io.Writer) *JSONLines {
+	return &JSONLines{w: w}
+}
+
+// Write writes r, it can be used as Config.OnRecord.
+func (j *JSONLines) Write(r Record) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(r); err != nil {
+		return err
+	}
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	_, err := j.w.Write(buf.Bytes())
+	return err
+}
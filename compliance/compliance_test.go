@@ -0,0 +1,96 @@
+package compliance
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/indexer"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func addr(s string) types.AccAddress {
+	return types.AccAddress([]byte(s + "-address-bytes-padding")[:types.AddrLen])
+}
+
+type resolver map[string]Identity
+
+func (r resolver) Resolve(a types.AccAddress) (Identity, bool, error) {
+	identity, ok := r[a.String()]
+	return identity, ok, nil
+}
+
+func TestExport(t *testing.T) {
+	exchange, alice, bob := addr("exchange"), addr("alice"), addr("bob")
+	var buf bytes.Buffer
+	sink := NewJSONLines(&buf)
+	e := New(Config{
+		ChainID:  "Binance-Chain-Tigris",
+		Watch:    []types.AccAddress{exchange},
+		Resolver: resolver{alice.String(): {Name: "Alice", Country: "FR"}},
+		Prices:   StaticPrices{"BNB": 15 * 1e8},
+		Quote:    "USDT-6D8",
+		OnRecord: sink.Write,
+	})
+	loc := time.FixedZone("UTC+8", 8*3600)
+	transfer := indexer.TransferEvent{
+		TxContext: indexer.TxContext{Height: 12, Time: time.Date(2020, 5, 1, 8, 0, 0, 0, loc), TxHash: "AB", MsgIndex: 0, Memo: "1001"},
+		Inputs: []msg.Input{
+			{Address: alice, Coins: types.Coins{{Denom: "BNB", Amount: 3e8}, {Denom: "XYZ-000", Amount: 5}}},
+		},
+		Outputs: []msg.Output{
+			{Address: exchange, Coins: types.Coins{{Denom: "BNB", Amount: 2e8}, {Denom: "XYZ-000", Amount: 5}}},
+			{Address: bob, Coins: types.Coins{{Denom: "BNB", Amount: 1e8}}},
+		},
+	}
+	assert.NoError(t, e.Process(transfer))
+
+	var records []Record
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var r Record
+		assert.NoError(t, dec.Decode(&r))
+		records = append(records, r)
+	}
+	// the output to bob does not touch a watched address
+	assert.Len(t, records, 2)
+	bnb := records[0]
+	assert.Equal(t, "AB:0:0:BNB", bnb.ID)
+	assert.Equal(t, SchemaVersion, bnb.Schema)
+	assert.Equal(t, Incoming, bnb.Direction)
+	assert.Equal(t, time.UTC, bnb.Time.Location())
+	assert.Equal(t, 0, bnb.Time.Hour())
+	assert.Equal(t, "1001", bnb.Memo)
+	assert.Equal(t, "2.00000000", bnb.Amount)
+	assert.Equal(t, exchange.String(), bnb.Beneficiary.Address)
+	assert.Nil(t, bnb.Beneficiary.Identity)
+	assert.Len(t, bnb.Originators, 1)
+	assert.Equal(t, "Alice", bnb.Originators[0].Identity.Name)
+	assert.Equal(t, "3.00000000", bnb.Originators[0].Amount)
+	assert.Equal(t, &Valuation{Quote: "USDT-6D8", Price: "15.00000000", Amount: "30.00000000"}, bnb.Reference)
+	assert.Nil(t, records[1].Reference, "no price")
+
+	// failed txs are skipped
+	transfer.Code = 5
+	records, err := e.Records(transfer)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestExportDirections(t *testing.T) {
+	hot, cold := addr("hot"), addr("cold")
+	e := New(Config{Watch: []types.AccAddress{hot, cold}})
+	coins := types.Coins{{Denom: "BNB", Amount: 1}}
+	records, err := e.Records(indexer.TransferEvent{
+		Inputs:  []msg.Input{{Address: hot, Coins: types.Coins{{Denom: "BNB", Amount: 2}}}},
+		Outputs: []msg.Output{{Address: cold, Coins: coins}, {Address: addr("carol"), Coins: coins}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, Internal, records[0].Direction)
+	assert.Equal(t, Outgoing, records[1].Direction)
+}
@@ -0,0 +1,404 @@
+// Package config loads client settings from a YAML or JSON file and the
+// environment and builds ready to use clients from them.
+//
+// Environment variables override the file:
+//
+//	BNC_NETWORK         mainnet, testnet, ganges or kongo
+//	BNC_NODES           comma separated rpc addresses, tcp://<host>:<port>
+//	BNC_API             host of the api server, for the REST client
+//	BNC_API_KEY
+//	BNC_TIMEOUT         timeout of rpc calls, like 5s
+//	BNC_RETRY_ATTEMPTS  attempts of failed rpc reads, the first one included
+//	BNC_RETRY_BACKOFF   wait before the first retry
+//	BNC_MNEMONIC, BNC_PRIVATE_KEY, or BNC_KEYSTORE with BNC_KEYSTORE_PASSWORD
+//	BNC_ENCRYPTED_KEY or BNC_ENCRYPTED_KEY_FILE, an encrypted mnemonic or keystore
+//	  decrypted with BNC_KEY_PASSPHRASE or the content of BNC_KEY_PASSPHRASE_FILE
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/binance-chain/go-sdk/client"
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+)
+
+// ErrNoKey is returned by KeyManager when no key source is configured.
+var ErrNoKey = errors.New("no key configured, set BNC_MNEMONIC, BNC_PRIVATE_KEY, BNC_KEYSTORE or BNC_ENCRYPTED_KEY")
+
+const (
+	encryptedKeyEnv   = "BNC_ENCRYPTED_KEY"
+	defaultPassphrase = "BNC_KEY_PASSPHRASE"
+)
+
+// Config holds the settings of the clients of a service.
+type Config struct {
+	Network string   `json:"network" yaml:"network"`
+	Nodes   []string `json:"nodes" yaml:"nodes"`
+	API     string   `json:"api,omitempty" yaml:"api,omitempty"`
+	APIKey  string   `json:"api_key,omitempty" yaml:"api_key,omitempty"`
+	Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retry   Retry    `json:"retry" yaml:"retry"`
+	Breaker Breaker  `json:"breaker" yaml:"breaker"`
+	Key     Key      `json:"key" yaml:"key"`
+}
+
+// Retry is the retry policy of rpc reads, see rpc.RetryPolicy.
+type Retry struct {
+	MaxAttempts int      `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+	Backoff     Duration `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	MaxBackoff  Duration `json:"max_backoff,omitempty" yaml:"max_backoff,omitempty"`
+}
+
+// Breaker configures the breakers of a node pool, see rpc.NewNodePool.
+type Breaker struct {
+	Threshold int      `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+	Cooldown  Duration `json:"cooldown,omitempty" yaml:"cooldown,omitempty"`
+}
+
+// Key selects where the signing key comes from. At most one source may be set.
+// Prefer the environment over the file for secrets.
+type Key struct {
+	Mnemonic string `json:"mnemonic,omitempty" yaml:"mnemonic,omitempty"`
+	// HDPath is the derivation path of the mnemonic key, the first Binance
+	// account if empty.
+	HDPath           string `json:"hd_path,omitempty" yaml:"hd_path,omitempty"`
+	PrivateKey       string `json:"private_key,omitempty" yaml:"private_key,omitempty"`
+	Keystore         string `json:"keystore,omitempty" yaml:"keystore,omitempty"`
+	KeystorePassword string `json:"keystore_password,omitempty" yaml:"keystore_password,omitempty"`
+
+	// EncryptedKeyEnv names the environment variable holding an encrypted
+	// mnemonic or keystore, EncryptedKeyFile is a file holding one, like a
+	// mounted secret. See keys.NewEncryptedKeyManager.
+	EncryptedKeyEnv  string `json:"encrypted_key_env,omitempty" yaml:"encrypted_key_env,omitempty"`
+	EncryptedKeyFile string `json:"encrypted_key_file,omitempty" yaml:"encrypted_key_file,omitempty"`
+	// The passphrase of an encrypted key is never part of the configuration. It
+	// is read from PassphraseFile if set, else from the environment variable
+	// PassphraseEnv, BNC_KEY_PASSPHRASE by default.
+	PassphraseEnv  string `json:"passphrase_env,omitempty" yaml:"passphrase_env,omitempty"`
+	PassphraseFile string `json:"passphrase_file,omitempty" yaml:"passphrase_file,omitempty"`
+}
+
+// Duration reads durations written like "5s" or "1m30s".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"5s\": %v", err)
+	}
+	return d.set(s)
+}
+
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return time.Duration(d).String(), nil
+}
+
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return d.set(s)
+}
+
+func (d *Duration) set(s string) error {
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Default is the configuration used for everything a file and the environment
+// leave unset: mainnet through the public data seed.
+func Default() *Config {
+	return &Config{
+		Network: "mainnet",
+		Nodes:   []string{"tcp://dataseed1.binance.org:80"},
+		Timeout: Duration(rpc.DefaultTimeout),
+		Breaker: Breaker{Threshold: rpc.DefaultBreakerThreshold, Cooldown: Duration(rpc.DefaultBreakerCooldown)},
+	}
+}
+
+// Load reads the file at path over the defaults, applies the environment and
+// validates the result. Files ending in .yaml or .yml are YAML, others JSON.
+func Load(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := Default()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(data, c)
+	default:
+		dec := json.NewDecoder(strings.NewReader(string(data)))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(c)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return c, c.finish(os.LookupEnv)
+}
+
+// FromEnv configures the clients from the environment alone.
+func FromEnv() (*Config, error) {
+	c := Default()
+	return c, c.finish(os.LookupEnv)
+}
+
+func (c *Config) finish(lookup func(string) (string, bool)) error {
+	if err := c.applyEnv(lookup); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	if v, ok := lookup("BNC_NETWORK"); ok {
+		c.Network = v
+	}
+	if v, ok := lookup("BNC_NODES"); ok {
+		c.Nodes = nil
+		for _, node := range strings.Split(v, ",") {
+			if node = strings.TrimSpace(node); node != "" {
+				c.Nodes = append(c.Nodes, node)
+			}
+		}
+	}
+	if v, ok := lookup("BNC_API"); ok {
+		c.API = v
+	}
+	if v, ok := lookup("BNC_API_KEY"); ok {
+		c.APIKey = v
+	}
+	if v, ok := lookup("BNC_TIMEOUT"); ok {
+		if err := c.Timeout.set(v); err != nil {
+			return fmt.Errorf("BNC_TIMEOUT: %v", err)
+		}
+	}
+	if v, ok := lookup("BNC_RETRY_ATTEMPTS"); ok {
+		attempts, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("BNC_RETRY_ATTEMPTS: %v", err)
+		}
+		c.Retry.MaxAttempts = attempts
+	}
+	if v, ok := lookup("BNC_RETRY_BACKOFF"); ok {
+		if err := c.Retry.Backoff.set(v); err != nil {
+			return fmt.Errorf("BNC_RETRY_BACKOFF: %v", err)
+		}
+	}
+	c.Key.applyEnv(lookup)
+	return nil
+}
+
+// applyEnv replaces the key source by the one of the environment, if any.
+func (k *Key) applyEnv(lookup func(string) (string, bool)) {
+	if v, ok := lookup("BNC_MNEMONIC"); ok && v != "" {
+		*k = Key{Mnemonic: v, HDPath: k.HDPath}
+	} else if v, ok := lookup("BNC_PRIVATE_KEY"); ok && v != "" {
+		*k = Key{PrivateKey: v}
+	} else if v, ok := lookup("BNC_KEYSTORE"); ok && v != "" {
+		password, _ := lookup("BNC_KEYSTORE_PASSWORD")
+		*k = Key{Keystore: v, KeystorePassword: password}
+	} else if v, ok := lookup(encryptedKeyEnv); ok && v != "" {
+		*k = Key{EncryptedKeyEnv: encryptedKeyEnv, PassphraseEnv: k.PassphraseEnv, PassphraseFile: k.PassphraseFile}
+	} else if v, ok := lookup("BNC_ENCRYPTED_KEY_FILE"); ok && v != "" {
+		*k = Key{EncryptedKeyFile: v, PassphraseEnv: k.PassphraseEnv, PassphraseFile: k.PassphraseFile}
+	}
+	if v, ok := lookup("BNC_KEY_PASSPHRASE_FILE"); ok && v != "" {
+		k.PassphraseFile = v
+	}
+}
+
+// Validate checks the settings without connecting to anything.
+func (c *Config) Validate() error {
+	if _, err := ParseNetwork(c.Network); err != nil {
+		return err
+	}
+	if len(c.Nodes) == 0 && c.API == "" {
+		return errors.New("neither rpc nodes nor an api server are configured")
+	}
+	if c.Timeout < 0 || c.Retry.MaxAttempts < 0 || c.Retry.Backoff < 0 || c.Retry.MaxBackoff < 0 {
+		return errors.New("timeouts, retries and backoffs must not be negative")
+	}
+	sources := 0
+	for _, set := range []bool{c.Key.Mnemonic != "", c.Key.PrivateKey != "", c.Key.Keystore != "", c.Key.EncryptedKeyEnv != "", c.Key.EncryptedKeyFile != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return errors.New("more than one key source is configured")
+	}
+	return nil
+}
+
+// ParseNetwork maps a network name to the chain network.
+func ParseNetwork(name string) (types.ChainNetwork, error) {
+	switch strings.ToLower(name) {
+	case "mainnet", "prod":
+		return types.ProdNetwork, nil
+	case "testnet":
+		return types.TestNetwork, nil
+	case "ganges":
+		return types.GangesNetwork, nil
+	case "kongo":
+		return types.TmpTestNetwork, nil
+	}
+	return 0, fmt.Errorf("unknown network %q", name)
+}
+
+// ChainNetwork returns the configured network.
+func (c *Config) ChainNetwork() (types.ChainNetwork, error) {
+	return ParseNetwork(c.Network)
+}
+
+// KeyManager builds the configured key manager, or fails with ErrNoKey.
+func (c *Config) KeyManager() (keys.KeyManager, error) {
+	return c.Key.KeyManager()
+}
+
+func (k Key) KeyManager() (keys.KeyManager, error) {
+	switch {
+	case k.Mnemonic != "" && k.HDPath != "":
+		return keys.NewMnemonicPathKeyManager(k.Mnemonic, k.HDPath)
+	case k.Mnemonic != "":
+		return keys.NewMnemonicKeyManager(k.Mnemonic)
+	case k.PrivateKey != "":
+		return keys.NewPrivateKeyManager(k.PrivateKey)
+	case k.Keystore != "":
+		return keys.NewKeyStoreKeyManager(k.Keystore, k.KeystorePassword)
+	case k.EncryptedKeyEnv != "":
+		passphrase, err := k.passphrase()
+		if err != nil {
+			return nil, err
+		}
+		return keys.NewEncryptedKeyManagerFromEnv(k.EncryptedKeyEnv, passphrase)
+	case k.EncryptedKeyFile != "":
+		passphrase, err := k.passphrase()
+		if err != nil {
+			return nil, err
+		}
+		return keys.NewEncryptedKeyManagerFromFile(k.EncryptedKeyFile, passphrase)
+	}
+	return nil, ErrNoKey
+}
+
+func (k Key) passphrase() (string, error) {
+	if k.PassphraseFile != "" {
+		return keys.ReadPassphraseFile(k.PassphraseFile)
+	}
+	name := k.PassphraseEnv
+	if name == "" {
+		name = defaultPassphrase
+	}
+	passphrase := os.Getenv(name)
+	if passphrase == "" {
+		return "", fmt.Errorf("the passphrase of the encrypted key is missing, set %s", name)
+	}
+	return passphrase, nil
+}
+
+// KeyFromEnv builds the key manager configured by the environment.
+func KeyFromEnv() (keys.KeyManager, error) {
+	var k Key
+	k.applyEnv(os.LookupEnv)
+	return k.KeyManager()
+}
+
+// RPCOptions translates the settings into options of rpc.NewClient. The key
+// manager is included if a key is configured.
+func (c *Config) RPCOptions() ([]rpc.Option, error) {
+	network, err := c.ChainNetwork()
+	if err != nil {
+		return nil, err
+	}
+	options := []rpc.Option{
+		rpc.WithNetwork(network),
+		rpc.WithRetry(rpc.RetryPolicy{
+			MaxAttempts: c.Retry.MaxAttempts,
+			Backoff:     time.Duration(c.Retry.Backoff),
+			MaxBackoff:  time.Duration(c.Retry.MaxBackoff),
+		}),
+	}
+	if c.Timeout > 0 {
+		options = append(options, rpc.WithTimeout(time.Duration(c.Timeout)))
+	}
+	km, err := c.KeyManager()
+	switch err {
+	case nil:
+		options = append(options, rpc.WithKeyManager(km))
+	case ErrNoKey:
+	default:
+		return nil, err
+	}
+	return options, nil
+}
+
+// RPCClient connects to the first configured node.
+func (c *Config) RPCClient() (*rpc.HTTP, error) {
+	if len(c.Nodes) == 0 {
+		return nil, errors.New("no rpc node configured")
+	}
+	options, err := c.RPCOptions()
+	if err != nil {
+		return nil, err
+	}
+	return rpc.NewClient(c.Nodes[0], options...), nil
+}
+
+// NodePool connects to all configured nodes and fails over between them.
+func (c *Config) NodePool() (*rpc.NodePool, error) {
+	if len(c.Nodes) == 0 {
+		return nil, errors.New("no rpc node configured")
+	}
+	network, err := c.ChainNetwork()
+	if err != nil {
+		return nil, err
+	}
+	options, err := c.RPCOptions()
+	if err != nil {
+		return nil, err
+	}
+	return rpc.NewNodePool(c.Nodes, network, c.Breaker.Threshold, time.Duration(c.Breaker.Cooldown), options...), nil
+}
+
+// DexClient connects to the configured api server. Without a configured key it
+// can only read.
+func (c *Config) DexClient() (client.DexClient, error) {
+	if c.API == "" {
+		return nil, errors.New("no api server configured")
+	}
+	network, err := c.ChainNetwork()
+	if err != nil {
+		return nil, err
+	}
+	km, err := c.KeyManager()
+	if err != nil && err != ErrNoKey {
+		return nil, err
+	}
+	if c.APIKey != "" {
+		return client.NewDexClientWithApiKey(c.API, network, km, c.APIKey)
+	}
+	return client.NewDexClient(c.API, network, km)
+}
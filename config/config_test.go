@@ -0,0 +1,122 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadYAMLAndJSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	yamlPath := writeFile(t, dir, "client.yaml", `
+network: testnet
+nodes:
+  - tcp://127.0.0.1:26657
+  - tcp://127.0.0.2:26657
+timeout: 3s
+retry:
+  max_attempts: 3
+  backoff: 200ms
+`)
+	jsonPath := writeFile(t, dir, "client.json", `{
+  "network": "testnet",
+  "nodes": ["tcp://127.0.0.1:26657", "tcp://127.0.0.2:26657"],
+  "timeout": "3s",
+  "retry": {"max_attempts": 3, "backoff": "200ms"}
+}`)
+	for _, path := range []string{yamlPath, jsonPath} {
+		c, err := Load(path)
+		assert.NoError(t, err, path)
+		network, err := c.ChainNetwork()
+		assert.NoError(t, err)
+		assert.Equal(t, types.TestNetwork, network)
+		assert.Equal(t, []string{"tcp://127.0.0.1:26657", "tcp://127.0.0.2:26657"}, c.Nodes)
+		assert.Equal(t, Duration(3*time.Second), c.Timeout)
+		assert.Equal(t, Retry{MaxAttempts: 3, Backoff: Duration(200 * time.Millisecond)}, c.Retry)
+		// unset settings keep their defaults
+		assert.Equal(t, Default().Breaker, c.Breaker)
+	}
+
+	_, err = Load(writeFile(t, dir, "typo.yaml", "netwrok: testnet\n"))
+	assert.Error(t, err)
+}
+
+func TestEnvironmentOverridesFile(t *testing.T) {
+	env := map[string]string{
+		"BNC_NODES":       "tcp://10.0.0.1:80, tcp://10.0.0.2:80",
+		"BNC_TIMEOUT":     "1s",
+		"BNC_PRIVATE_KEY": "9579fff0cab07a4379e845a890105004ba4c8276f8ad9d22082b2acbf02d884b",
+	}
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+	c := Default()
+	c.Key.Keystore = "/etc/keys/hot.json"
+	assert.NoError(t, c.finish(lookup))
+	assert.Equal(t, []string{"tcp://10.0.0.1:80", "tcp://10.0.0.2:80"}, c.Nodes)
+	assert.Equal(t, Duration(time.Second), c.Timeout)
+	assert.Equal(t, Key{PrivateKey: env["BNC_PRIVATE_KEY"]}, c.Key)
+
+	km, err := c.KeyManager()
+	assert.NoError(t, err)
+	assert.NotNil(t, km.GetAddr())
+	options, err := c.RPCOptions()
+	assert.NoError(t, err)
+	assert.Len(t, options, 4)
+}
+
+func TestValidate(t *testing.T) {
+	c := Default()
+	c.Network = "moon"
+	assert.Error(t, c.Validate())
+
+	c = Default()
+	c.Key = Key{Mnemonic: "word", PrivateKey: "key"}
+	assert.Error(t, c.Validate())
+
+	c = Default()
+	_, err := c.KeyManager()
+	assert.Equal(t, ErrNoKey, err)
+}
+
+func TestEncryptedKeyFromSecretFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	mnemonic := "bottom quick strong ranch section decide pepper broken oven demand coin run jacket curious business achieve mule bamboo remain vote kid rigid bench rubber"
+	encrypted, err := keys.EncryptMnemonic(mnemonic, "s3cret")
+	assert.NoError(t, err)
+	raw, err := json.Marshal(encrypted)
+	assert.NoError(t, err)
+	env := map[string]string{
+		"BNC_ENCRYPTED_KEY_FILE":  writeFile(t, dir, "key.json", string(raw)),
+		"BNC_KEY_PASSPHRASE_FILE": writeFile(t, dir, "passphrase", "s3cret\n"),
+	}
+	c := Default()
+	assert.NoError(t, c.finish(func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}))
+	km, err := c.KeyManager()
+	assert.NoError(t, err)
+	assert.Equal(t, encrypted.Address, km.GetAddr().String())
+}
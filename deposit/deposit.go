@@ -0,0 +1,230 @@
+// Package deposit credits the deposits of an exchange to its users. Exchanges
+// share a few deposit addresses among all their users and tell deposits apart
+// by the memo of the transfer, which holds the id of the user. The Manager
+// reads transfers from the indexer, matches their memo against a pattern and
+// a directory of users, and emits a credit instruction for every deposit it
+// can attribute, or a flag for the ones that need a human.
+package deposit
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/indexer"
+)
+
+// DefaultMemoPattern accepts memos made of 1 to 20 digits.
+var DefaultMemoPattern = regexp.MustCompile(`^[0-9]{1,20}$`)
+
+// Directory resolves the key of a memo to an internal user id. An error stops
+// the indexer before the block is checkpointed, so the deposit is retried.
+type Directory interface {
+	Lookup(key string) (userID string, ok bool, err error)
+}
+
+// MapDirectory is a Directory held in memory, from memo key to user id.
+type MapDirectory map[string]string
+
+func (d MapDirectory) Lookup(key string) (string, bool, error) {
+	userID, ok := d[key]
+	return userID, ok, nil
+}
+
+// Reason is why a deposit was flagged instead of credited.
+type Reason string
+
+const (
+	MissingMemo      Reason = "missing_memo"
+	InvalidMemo      Reason = "invalid_memo"
+	UnknownUser      Reason = "unknown_user"
+	UnsupportedAsset Reason = "unsupported_asset"
+	BelowMinimum     Reason = "below_minimum"
+)
+
+// Deposit is one coin received by a deposit address. ID is stable across
+// replays of the same block, receivers deduplicate on it.
+type Deposit struct {
+	ID       string             `json:"id"`
+	Height   int64              `json:"height"`
+	Time     time.Time          `json:"time"`
+	TxHash   string             `json:"tx_hash"`
+	MsgIndex int                `json:"msg_index"`
+	Address  types.AccAddress   `json:"address"`
+	From     []types.AccAddress `json:"from"`
+	Memo     string             `json:"memo"`
+	Coin     types.Coin         `json:"coin"`
+}
+
+// Credit instructs to credit a deposit to a user.
+type Credit struct {
+	Deposit
+	UserID string `json:"user_id"`
+}
+
+// Flag is a deposit that cannot be credited automatically.
+type Flag struct {
+	Deposit
+	Reason Reason `json:"reason"`
+	// UserID is set when the user was resolved but the coin was refused.
+	UserID string `json:"user_id,omitempty"`
+}
+
+type Config struct {
+	// MemoPattern is what a valid memo looks like, DefaultMemoPattern if nil.
+	// If it has a subexpression the first one is the key looked up in the
+	// directory, otherwise the whole memo is. Memos are trimmed of spaces.
+	MemoPattern *regexp.Regexp
+	Directory   Directory
+	// Assets, if set, are the only denoms credited.
+	Assets []string
+	// MinAmounts are the smallest amounts credited per denom, in units of 1e-8.
+	MinAmounts map[string]int64
+
+	OnCredit func(Credit) error
+	OnFlag   func(Flag) error
+}
+
+// Manager attributes the deposits to its addresses. It is safe for concurrent use.
+type Manager struct {
+	cfg    Config
+	assets map[string]bool
+
+	mtx       sync.RWMutex
+	addresses map[string]bool
+}
+
+func NewManager(cfg Config, addrs ...types.AccAddress) *Manager {
+	if cfg.MemoPattern == nil {
+		cfg.MemoPattern = DefaultMemoPattern
+	}
+	m := &Manager{cfg: cfg, addresses: map[string]bool{}}
+	if len(cfg.Assets) > 0 {
+		m.assets = map[string]bool{}
+		for _, asset := range cfg.Assets {
+			m.assets[asset] = true
+		}
+	}
+	m.AddAddresses(addrs...)
+	return m
+}
+
+// AddAddresses starts accepting deposits to addrs.
+func (m *Manager) AddAddresses(addrs ...types.AccAddress) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, addr := range addrs {
+		m.addresses[string(addr)] = true
+	}
+}
+
+// RemoveAddresses stops accepting deposits to addrs.
+func (m *Manager) RemoveAddresses(addrs ...types.AccAddress) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, addr := range addrs {
+		delete(m.addresses, string(addr))
+	}
+}
+
+func (m *Manager) owns(addr types.AccAddress) bool {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.addresses[string(addr)]
+}
+
+// Handlers returns the indexer handlers that feed the manager.
+func (m *Manager) Handlers() indexer.Handlers {
+	return indexer.Handlers{OnTransfer: m.Process}
+}
+
+// Process credits or flags the coins a transfer sent to the deposit addresses.
+// Failed txs moved nothing and are skipped, and so are transfers sent by a
+// deposit address, which are sweeps and not deposits.
+func (m *Manager) Process(e indexer.TransferEvent) error {
+	if !e.Success() {
+		return nil
+	}
+	from := make([]types.AccAddress, 0, len(e.Inputs))
+	for _, in := range e.Inputs {
+		if m.owns(in.Address) {
+			return nil
+		}
+		from = append(from, in.Address)
+	}
+	memo := strings.TrimSpace(e.Memo)
+	for i, out := range e.Outputs {
+		if !m.owns(out.Address) {
+			continue
+		}
+		for _, coin := range out.Coins {
+			deposit := Deposit{
+				ID:       fmt.Sprintf("%s:%d:%d:%s", e.TxHash, e.MsgIndex, i, coin.Denom),
+				Height:   e.Height,
+				Time:     e.Time,
+				TxHash:   e.TxHash,
+				MsgIndex: e.MsgIndex,
+				Address:  out.Address,
+				From:     from,
+				Memo:     memo,
+				Coin:     coin,
+			}
+			if err := m.attribute(deposit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Manager) attribute(deposit Deposit) error {
+	key, reason := m.memoKey(deposit.Memo)
+	if reason != "" {
+		return m.flag(Flag{Deposit: deposit, Reason: reason})
+	}
+	if m.cfg.Directory == nil {
+		return m.flag(Flag{Deposit: deposit, Reason: UnknownUser})
+	}
+	userID, ok, err := m.cfg.Directory.Lookup(key)
+	if err != nil {
+		return fmt.Errorf("look up memo %q of %s: %v", deposit.Memo, deposit.TxHash, err)
+	}
+	if !ok {
+		return m.flag(Flag{Deposit: deposit, Reason: UnknownUser})
+	}
+	if m.assets != nil && !m.assets[deposit.Coin.Denom] {
+		return m.flag(Flag{Deposit: deposit, Reason: UnsupportedAsset, UserID: userID})
+	}
+	if minimum, ok := m.cfg.MinAmounts[deposit.Coin.Denom]; ok && deposit.Coin.Amount < minimum {
+		return m.flag(Flag{Deposit: deposit, Reason: BelowMinimum, UserID: userID})
+	}
+	if m.cfg.OnCredit != nil {
+		return m.cfg.OnCredit(Credit{Deposit: deposit, UserID: userID})
+	}
+	return nil
+}
+
+// memoKey returns the directory key of a memo, or why it has none.
+func (m *Manager) memoKey(memo string) (string, Reason) {
+	if memo == "" {
+		return "", MissingMemo
+	}
+	match := m.cfg.MemoPattern.FindStringSubmatch(memo)
+	switch {
+	case match == nil:
+		return "", InvalidMemo
+	case len(match) > 1:
+		return match[1], ""
+	}
+	return memo, ""
+}
+
+func (m *Manager) flag(f Flag) error {
+	if m.cfg.OnFlag != nil {
+		return m.cfg.OnFlag(f)
+	}
+	return nil
+}
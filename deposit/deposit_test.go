@@ -0,0 +1,59 @@
+package deposit
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/indexer"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func TestManager(t *testing.T) {
+	hot := types.AccAddress([]byte("hot-wallet-address-1"))
+	user := types.AccAddress([]byte("user-wallet-address1"))
+	var credits []Credit
+	var flags []Flag
+	m := NewManager(Config{
+		MemoPattern: regexp.MustCompile(`^(?:uid-)?([0-9]+)$`),
+		Directory:   MapDirectory{"42": "alice"},
+		MinAmounts:  map[string]int64{"BNB": 1000},
+		OnCredit:    func(c Credit) error { credits = append(credits, c); return nil },
+		OnFlag:      func(f Flag) error { flags = append(flags, f); return nil },
+	}, hot)
+
+	transfer := func(memo string, code uint32, from, to types.AccAddress, coins types.Coins) indexer.TransferEvent {
+		return indexer.TransferEvent{
+			TxContext: indexer.TxContext{Height: 7, TxHash: "AB", Memo: memo, Code: code},
+			Inputs:    []msg.Input{{Address: from, Coins: coins}},
+			Outputs:   []msg.Output{{Address: to, Coins: coins}},
+		}
+	}
+	coins := types.Coins{{Denom: "BNB", Amount: 500}, {Denom: "XYZ-000", Amount: 9}}
+	assert.NoError(t, m.Process(transfer(" uid-42 ", 0, user, hot, coins)))
+	assert.NoError(t, m.Process(transfer("", 0, user, hot, coins[1:])))
+	assert.NoError(t, m.Process(transfer("hello", 0, user, hot, coins[1:])))
+	assert.NoError(t, m.Process(transfer("7", 0, user, hot, coins[1:])))
+	// failed, sweeps and transfers elsewhere are not deposits
+	assert.NoError(t, m.Process(transfer("42", 1, user, hot, coins)))
+	assert.NoError(t, m.Process(transfer("42", 0, hot, hot, coins)))
+	assert.NoError(t, m.Process(transfer("42", 0, hot, user, coins)))
+
+	assert.Len(t, credits, 1)
+	assert.Equal(t, "alice", credits[0].UserID)
+	assert.Equal(t, "AB:0:0:XYZ-000", credits[0].ID)
+	assert.Equal(t, []types.AccAddress{user}, credits[0].From)
+
+	reasons := make([]Reason, len(flags))
+	for i, f := range flags {
+		reasons[i] = f.Reason
+	}
+	assert.Equal(t, []Reason{BelowMinimum, MissingMemo, InvalidMemo, UnknownUser}, reasons)
+	assert.Equal(t, "alice", flags[0].UserID)
+
+	m.RemoveAddresses(hot)
+	assert.NoError(t, m.Process(transfer("42", 0, user, hot, coins)))
+	assert.Len(t, credits, 1)
+}
@@ -274,3 +274,12 @@ func TestGetSideChainAllValidatorsCount(t *testing.T) {
 	_, err := c.GetSideChainAllValidatorsCount(types.RialtoNet, false)
 	assert.Nil(t, err)
 }
+
+func TestGetStakingPool(t *testing.T) {
+	c := rpcClient()
+	pool, err := c.GetStakingPool()
+	assert.Nil(t, err)
+	if pool != nil {
+		assert.True(t, pool.BondedRatio().LTE(ctypes.OneDec()))
+	}
+}
@@ -2,6 +2,7 @@ package e2e
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/tendermint/tendermint/libs/common"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
 	"github.com/tendermint/tendermint/types"
 
 	"github.com/binance-chain/go-sdk/client/rpc"
@@ -110,6 +112,17 @@ func TestRPCGetProposal(t *testing.T) {
 	fmt.Println(string(bz))
 }
 
+func TestRPCGetProposalTallyAndDeposits(t *testing.T) {
+	c := defaultClient()
+	tally, err := c.GetProposalTally(int64(1))
+	assert.NoError(t, err)
+	fmt.Println(tally)
+	deposits, err := c.GetProposalDeposits(int64(1))
+	assert.NoError(t, err)
+	bz, err := json.Marshal(deposits)
+	fmt.Println(string(bz))
+}
+
 func TestRPCStatus(t *testing.T) {
 	c := defaultClient()
 	status, err := c.Status()
@@ -362,6 +375,43 @@ func TestSubscribeEventTwice(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSubscribeWithContext(t *testing.T) {
+	c := defaultClient()
+	query := "tm.event = 'NewBlockHeader'"
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, err := c.SubscribeWithContext(ctx, query, 10)
+	assert.NoError(t, err)
+	received := 0
+	for range out {
+		received++
+	}
+	assert.True(t, received > 0)
+	// the node side subscription is gone, so the query can be subscribed again
+	_, err = c.Subscribe(query, 10)
+	assert.NoError(t, err)
+	assert.NoError(t, c.Unsubscribe(query))
+}
+
+func TestStreamBlocksInOrder(t *testing.T) {
+	c := defaultClient()
+	status, err := c.Status()
+	assert.NoError(t, err)
+	from := status.SyncInfo.LatestBlockHeight - 20
+	quit := make(chan struct{})
+	next := from
+	err = c.StreamBlocks(from, quit, func(block *core_types.ResultBlock) {
+		assert.Equal(t, next, block.Block.Height)
+		next++
+	}, func(err error) {
+		assert.NoError(t, err)
+	})
+	assert.NoError(t, err)
+	time.Sleep(10 * time.Second)
+	close(quit)
+	assert.True(t, next > from+20)
+}
+
 func TestReceiveWithRequestId(t *testing.T) {
 	c := defaultClient()
 	c.SetTimeOut(1 * time.Second)
@@ -411,6 +461,17 @@ func TestGetTokenInfo(t *testing.T) {
 	fmt.Println(string(bz))
 }
 
+func TestResolveSymbol(t *testing.T) {
+	c := defaultClient()
+	resolver := rpc.NewSymbolResolver(c, 0)
+	symbol, err := resolver.Resolve(" bnb ")
+	assert.NoError(t, err)
+	assert.Equal(t, "BNB", symbol)
+	symbol, err = resolver.Resolve(testTradeSymbol)
+	assert.NoError(t, err)
+	assert.Equal(t, testTradeSymbol, symbol)
+}
+
 func TestGetAccount(t *testing.T) {
 	ctypes.Network = ctypes.TestNetwork
 	c := defaultClient()
@@ -478,6 +539,29 @@ func TestNoneExistGetBalance(t *testing.T) {
 	fmt.Println(string(bz))
 }
 
+func TestGetBalanceDetails(t *testing.T) {
+	ctypes.Network = ctypes.TestNetwork
+	c := defaultClient()
+	acc, err := ctypes.AccAddressFromBech32(testAddress)
+	assert.NoError(t, err)
+	details, err := c.GetBalanceDetails(acc)
+	assert.NoError(t, err)
+	for _, d := range details {
+		assert.True(t, d.Total() >= d.Free)
+	}
+	bz, err := json.Marshal(details)
+	fmt.Println(string(bz))
+}
+
+func TestNoneExistGetBalanceDetail(t *testing.T) {
+	ctypes.Network = ctypes.TestNetwork
+	c := defaultClient()
+	acc, _ := keys.NewKeyManager()
+	detail, err := c.GetBalanceDetail(acc.GetAddr(), "BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, ctypes.Fixed8Zero, detail.Total())
+}
+
 func TestGetFees(t *testing.T) {
 	c := defaultClient()
 	fees, err := c.GetFee()
@@ -528,6 +612,24 @@ func TestSendToken(t *testing.T) {
 	fmt.Println(string(bz))
 }
 
+func TestBroadcastIdempotent(t *testing.T) {
+	c := defaultClient()
+	ctypes.Network = ctypes.TestNetwork
+	keyManager, err := keys.NewMnemonicKeyManager(mnemonic)
+	assert.NoError(t, err)
+	c.SetKeyManager(keyManager)
+	testacc, err := ctypes.AccAddressFromBech32(testAddress)
+	assert.NoError(t, err)
+	signed, err := c.SignMsg(msg.CreateSendMsg(keyManager.GetAddr(), ctypes.Coins{{Denom: "BNB", Amount: 100000}},
+		[]msg.Transfer{{ToAddr: testacc, Coins: ctypes.Coins{{Denom: "BNB", Amount: 100000}}}}))
+	assert.NoError(t, err)
+	first, err := c.BroadcastIdempotent(signed, rpc.Sync)
+	assert.NoError(t, err)
+	second, err := c.BroadcastIdempotent(signed, rpc.Sync)
+	assert.NoError(t, err)
+	assert.Equal(t, first.Hash, second.Hash)
+}
+
 func TestQuerySideChainParam(t *testing.T) {
 	c := defaultClient()
 	ctypes.Network = ctypes.TestNetwork
@@ -537,6 +639,27 @@ func TestQuerySideChainParam(t *testing.T) {
 	fmt.Println(string(bz))
 }
 
+func TestGetParams(t *testing.T) {
+	c := defaultClient()
+	params, err := c.GetParams(rpc.FeeParamsModule)
+	assert.NoError(t, err)
+	_, ok := params.([]ctypes.FeeParam)
+	assert.True(t, ok)
+	_, err = c.GetParams("gov")
+	assert.Equal(t, rpc.UnsupportedParamsModuleError, err)
+}
+
+func TestGetSideChainParamSet(t *testing.T) {
+	c := defaultClient()
+	sc, err := c.GetSideChainParamSet("bsc")
+	assert.NoError(t, err)
+	assert.NotNil(t, sc.Stake)
+	assert.NotNil(t, sc.Slash)
+	stake, err := c.GetSideChainStakeParams("bsc")
+	assert.NoError(t, err)
+	assert.Equal(t, sc.Stake.MaxValidators, stake.MaxValidators)
+}
+
 func TestSubmitSideProposal(t *testing.T) {
 	c := defaultClient()
 	ctypes.Network = ctypes.TestNetwork
@@ -628,6 +751,42 @@ func TestTransferTokenOwnership(t *testing.T) {
 	fmt.Println(string(bz))
 }
 
+func TestMintTokenNotOwner(t *testing.T) {
+	c := defaultClient()
+	ctypes.Network = ctypes.TestNetwork
+	keyManager, err := keys.NewMnemonicKeyManager(mnemonic)
+	assert.NoError(t, err)
+	c.SetKeyManager(keyManager)
+	_, err = c.MintToken("BNB", 100000000, rpc.Commit)
+	assert.Equal(t, rpc.NotTokenOwnerError, err)
+}
+
+func TestSimulateSend(t *testing.T) {
+	c := defaultClient()
+	ctypes.Network = ctypes.TestNetwork
+	keyManager, err := keys.NewMnemonicKeyManager(mnemonic)
+	assert.NoError(t, err)
+	c.SetKeyManager(keyManager)
+	from := keyManager.GetAddr()
+	coins := ctypes.Coins{{Denom: "BNB", Amount: 1}}
+	res, err := c.Simulate(msg.CreateSendMsg(from, coins, []msg.Transfer{{ToAddr: from, Coins: coins}}))
+	assert.NoError(t, err)
+	assert.True(t, res.IsOK(), res.Log)
+	assert.True(t, res.Fee.AmountOf("BNB") > 0)
+}
+
+func TestGetCommitAccountWithProof(t *testing.T) {
+	c := defaultClient()
+	ctypes.Network = ctypes.TestNetwork
+	keyManager, err := keys.NewMnemonicKeyManager(mnemonic)
+	assert.NoError(t, err)
+	acc, res, err := c.GetCommitAccountWithOptions(keyManager.GetAddr(), rpc.WithProof())
+	assert.NoError(t, err)
+	assert.NotNil(t, acc)
+	assert.NotNil(t, res.Proof)
+	assert.NoError(t, c.VerifyStoreResult(res))
+}
+
 func TestBroadcastTxCommit(t *testing.T) {
 	c := defaultClient()
 	txbyte, err := hex.DecodeString(testTxStr)
@@ -0,0 +1,181 @@
+// Package eventsink publishes indexer output and websocket events to message
+// brokers as typed JSON envelopes. Brokers are plugged in through small
+// interfaces, so the SDK does not depend on any Kafka or NATS client library.
+package eventsink
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/indexer"
+)
+
+const (
+	TypeTx       = "tx"
+	TypeTransfer = "transfer"
+	TypeTrade    = "trade"
+	TypeSwap     = "swap"
+	TypeProposal = "proposal"
+	TypeBlock    = "block"
+
+	TypeOrderExpiry = "order_expiry"
+)
+
+// Envelope is the JSON document published for every event. Payload holds the
+// event itself, e.g. an indexer.TransferEvent for the "transfer" type.
+type Envelope struct {
+	Type    string      `json:"type"`
+	Key     string      `json:"key,omitempty"`
+	Height  int64       `json:"height,omitempty"`
+	Time    time.Time   `json:"time"`
+	Payload interface{} `json:"payload"`
+}
+
+// Publisher sends one message to a topic. Key is used for partitioning by
+// brokers that support it.
+type Publisher interface {
+	Publish(topic string, key string, value []byte) error
+}
+
+// KafkaProducer is implemented by a thin wrapper around a Kafka client such as
+// a sarama SyncProducer or a kafka-go Writer.
+type KafkaProducer interface {
+	SendMessage(topic string, key, value []byte) error
+}
+
+type kafkaPublisher struct {
+	producer KafkaProducer
+}
+
+func NewKafkaPublisher(producer KafkaProducer) Publisher {
+	return &kafkaPublisher{producer: producer}
+}
+
+func (p *kafkaPublisher) Publish(topic string, key string, value []byte) error {
+	return p.producer.SendMessage(topic, []byte(key), value)
+}
+
+// NATSConn matches the Publish method of *nats.Conn.
+type NATSConn interface {
+	Publish(subj string, data []byte) error
+}
+
+type natsPublisher struct {
+	conn NATSConn
+}
+
+// NewNATSPublisher publishes to the subject named after the topic. NATS has no
+// message keys, so the key is only available inside the envelope.
+func NewNATSPublisher(conn NATSConn) Publisher {
+	return &natsPublisher{conn: conn}
+}
+
+func (p *natsPublisher) Publish(topic string, key string, value []byte) error {
+	return p.conn.Publish(topic, value)
+}
+
+type Sink struct {
+	publisher Publisher
+	prefix    string
+}
+
+type Option func(*Sink)
+
+// WithTopicPrefix sets the prefix of every topic, "bnc" by default. Events of
+// type "transfer" then go to "bnc.transfer".
+func WithTopicPrefix(prefix string) Option {
+	return func(s *Sink) {
+		s.prefix = prefix
+	}
+}
+
+func NewSink(publisher Publisher, options ...Option) *Sink {
+	s := &Sink{publisher: publisher, prefix: "bnc"}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// Topic returns the topic events of the given type are published to.
+func (s *Sink) Topic(eventType string) string {
+	if s.prefix == "" {
+		return eventType
+	}
+	return s.prefix + "." + eventType
+}
+
+// Publish wraps payload in an envelope and publishes it. It can be called from
+// websocket callbacks, e.g. Publish("ws.trade", symbol, 0, trades).
+func (s *Sink) Publish(eventType string, key string, height int64, payload interface{}) error {
+	value, err := json.Marshal(Envelope{
+		Type:    eventType,
+		Key:     key,
+		Height:  height,
+		Time:    time.Now(),
+		Payload: payload,
+	})
+	if err != nil {
+		return err
+	}
+	return s.publisher.Publish(s.Topic(eventType), key, value)
+}
+
+// Handlers returns indexer handlers that publish every decoded event. Msg-level
+// events are keyed by tx hash, swaps by swap id and blocks by block hash.
+func (s *Sink) Handlers() indexer.Handlers {
+	return indexer.Handlers{
+		OnTx: func(e indexer.TxEvent) error {
+			return s.Publish(TypeTx, e.TxHash, e.Height, e)
+		},
+		OnTransfer: func(e indexer.TransferEvent) error {
+			return s.Publish(TypeTransfer, e.TxHash, e.Height, e)
+		},
+		OnTrade: func(e indexer.TradeEvent) error {
+			return s.Publish(TypeTrade, e.TxHash, e.Height, e)
+		},
+		OnSwap: func(e indexer.SwapEvent) error {
+			return s.Publish(TypeSwap, hex.EncodeToString(e.SwapID), e.Height, e)
+		},
+		OnProposal: func(e indexer.ProposalEvent) error {
+			return s.Publish(TypeProposal, e.TxHash, e.Height, e)
+		},
+		OnBlock: func(e indexer.BlockEvent) error {
+			return s.Publish(TypeBlock, e.Hash, e.Height, e)
+		},
+	}
+}
+
+// OrderExpiries returns an OnOrderExpiry for websocket.UserDataHandlers that
+// publishes the expiries and IOC cancels of the order stream, keyed by order
+// id. Blocks do not carry them, the indexer can't see them. Publishing errors
+// are passed to onError if it is set.
+func (s *Sink) OrderExpiries(onError func(error)) func(websocket.OrderExpiry) {
+	return func(e websocket.OrderExpiry) {
+		if err := s.Publish(TypeOrderExpiry, e.OrderID, e.Height, e); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// Forward publishes the events of a node rpc subscription until the channel is
+// closed or quit is closed. Publishing errors are passed to onError if it is set.
+func (s *Sink) Forward(eventType string, events <-chan ctypes.ResultEvent, quit <-chan struct{}, onError func(error)) {
+	for {
+		select {
+		case <-quit:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := s.Publish(eventType, event.Query, 0, event); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package eventsink
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/indexer"
+)
+
+type message struct {
+	topic, key string
+	envelope   map[string]interface{}
+}
+
+type fakeProducer struct {
+	messages []message
+	fail     error
+}
+
+func (p *fakeProducer) SendMessage(topic string, key, value []byte) error {
+	if p.fail != nil {
+		return p.fail
+	}
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(value, &envelope); err != nil {
+		return err
+	}
+	p.messages = append(p.messages, message{topic: topic, key: string(key), envelope: envelope})
+	return nil
+}
+
+func (p *fakeProducer) Publish(subj string, data []byte) error {
+	return p.SendMessage(subj, nil, data)
+}
+
+func TestHandlersPublishInOrder(t *testing.T) {
+	producer := &fakeProducer{}
+	h := NewSink(NewKafkaPublisher(producer)).Handlers()
+	tx := indexer.TxContext{Height: 7, TxHash: "AB"}
+
+	assert.NoError(t, h.OnBlock(indexer.BlockEvent{Height: 7, Hash: "B7"}))
+	assert.NoError(t, h.OnTx(indexer.TxEvent{TxContext: tx, NumMsgs: 2}))
+	assert.NoError(t, h.OnTransfer(indexer.TransferEvent{TxContext: tx}))
+	assert.NoError(t, h.OnTrade(indexer.TradeEvent{TxContext: tx, Symbol: "XYZ-000_BNB"}))
+	assert.NoError(t, h.OnSwap(indexer.SwapEvent{TxContext: tx, SwapID: []byte{1, 2}}))
+	assert.NoError(t, h.OnProposal(indexer.ProposalEvent{TxContext: tx, ProposalID: 3}))
+
+	var got []string
+	for _, m := range producer.messages {
+		got = append(got, m.topic+" "+m.key)
+		assert.Equal(t, float64(7), m.envelope["height"])
+		assert.Equal(t, m.topic, "bnc."+m.envelope["type"].(string))
+	}
+	assert.Equal(t, []string{
+		"bnc.block B7",
+		"bnc.tx AB",
+		"bnc.transfer AB",
+		"bnc.trade AB",
+		"bnc.swap 0102",
+		"bnc.proposal AB",
+	}, got)
+	payload := producer.messages[3].envelope["payload"].(map[string]interface{})
+	assert.Equal(t, "XYZ-000_BNB", payload["symbol"])
+}
+
+func TestTopics(t *testing.T) {
+	producer := &fakeProducer{}
+	assert.NoError(t, NewSink(NewNATSPublisher(producer), WithTopicPrefix("")).Publish("ws.trade", "BNB_BTC", 0, []int{1}))
+	assert.NoError(t, NewSink(NewNATSPublisher(producer), WithTopicPrefix("test")).Publish(TypeBlock, "B1", 1, nil))
+	assert.Len(t, producer.messages, 2)
+	assert.Equal(t, "ws.trade", producer.messages[0].topic)
+	assert.Equal(t, "", producer.messages[0].key, "nats has no keys")
+	assert.Equal(t, "BNB_BTC", producer.messages[0].envelope["key"])
+	assert.Equal(t, "test.block", producer.messages[1].topic)
+}
+
+func TestPublishErrors(t *testing.T) {
+	broken := errors.New("broker down")
+	producer := &fakeProducer{fail: broken}
+	sink := NewSink(NewKafkaPublisher(producer))
+	assert.Equal(t, broken, sink.Handlers().OnTx(indexer.TxEvent{}), "the indexer sees the error")
+
+	producer.fail = nil
+	assert.Error(t, sink.Publish(TypeTx, "AB", 1, make(chan int)), "unencodable payload")
+	assert.Empty(t, producer.messages)
+
+	producer.fail = broken
+	var errs []error
+	sink.OrderExpiries(func(err error) { errs = append(errs, err) })(websocket.OrderExpiry{OrderID: "A-1"})
+	sink.OrderExpiries(nil)(websocket.OrderExpiry{OrderID: "A-2"})
+	assert.Equal(t, []error{broken}, errs)
+
+	producer.fail = nil
+	sink.OrderExpiries(nil)(websocket.OrderExpiry{OrderID: "A-3", Height: 9})
+	assert.Len(t, producer.messages, 1)
+	assert.Equal(t, "bnc.order_expiry", producer.messages[0].topic)
+	assert.Equal(t, "A-3", producer.messages[0].key)
+}
+
+func TestForward(t *testing.T) {
+	producer := &fakeProducer{}
+	sink := NewSink(NewKafkaPublisher(producer))
+	events := make(chan ctypes.ResultEvent, 3)
+	events <- ctypes.ResultEvent{Query: "q1"}
+	events <- ctypes.ResultEvent{Query: "q2"}
+	close(events)
+	sink.Forward("ws.tx", events, nil, nil)
+	assert.Len(t, producer.messages, 2)
+	assert.Equal(t, "q1", producer.messages[0].key)
+	assert.Equal(t, "q2", producer.messages[1].key)
+
+	// errors are reported and forwarding goes on
+	producer.fail = errors.New("broker down")
+	events = make(chan ctypes.ResultEvent, 2)
+	events <- ctypes.ResultEvent{Query: "q3"}
+	events <- ctypes.ResultEvent{Query: "q4"}
+	close(events)
+	var errs []error
+	sink.Forward("ws.tx", events, nil, func(err error) { errs = append(errs, err) })
+	assert.Len(t, errs, 2)
+
+	quit := make(chan struct{})
+	close(quit)
+	sink.Forward("ws.tx", make(chan ctypes.ResultEvent), quit, nil)
+}
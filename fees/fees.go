@@ -0,0 +1,173 @@
+// Package fees attributes the fees charged in a block to the addresses and txs
+// that paid them. Fees never show in the msgs of a tx, the fee of a tx is
+// reported in the "fee" tag of its DeliverTx result. The fees of DEX orders
+// are deducted by the match engine in EndBlock and are not in block results,
+// they are not attributed.
+package fees
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// TxFeeTagKey is the key of the DeliverTx tag with the fee of a tx.
+const TxFeeTagKey = "fee"
+
+// BlockClient is the part of the rpc client Fetch needs. *rpc.HTTP satisfies it.
+type BlockClient interface {
+	Block(height *int64) (*ctypes.ResultBlock, error)
+	BlockResults(height *int64) (*rpc.ResultBlockResults, error)
+}
+
+// Deduction is the fee of a tx, charged to its first signer.
+type Deduction struct {
+	Height  int64            `json:"height"`
+	Address types.AccAddress `json:"address"`
+	TxHash  string           `json:"tx_hash"`
+	// TxIndex is the position of the tx in the block.
+	TxIndex int         `json:"tx_index"`
+	Coins   types.Coins `json:"coins"`
+}
+
+// BlockFees are the deductions of a block, in tx order.
+type BlockFees struct {
+	Height     int64       `json:"height"`
+	Deductions []Deduction `json:"deductions"`
+}
+
+// ByAddress sums the deductions per bech32 address.
+func (b *BlockFees) ByAddress() map[string]types.Coins {
+	sums := map[string]types.Coins{}
+	for _, d := range b.Deductions {
+		addr := d.Address.String()
+		sums[addr] = sums[addr].Plus(d.Coins)
+	}
+	return sums
+}
+
+// ByTx sums the deductions per tx hash.
+func (b *BlockFees) ByTx() map[string]types.Coins {
+	sums := map[string]types.Coins{}
+	for _, d := range b.Deductions {
+		sums[d.TxHash] = sums[d.TxHash].Plus(d.Coins)
+	}
+	return sums
+}
+
+// Total sums all the deductions of the block.
+func (b *BlockFees) Total() types.Coins {
+	var total types.Coins
+	for _, d := range b.Deductions {
+		total = total.Plus(d.Coins)
+	}
+	return total
+}
+
+// Fetch fetches a block and its results and attributes their fees.
+func Fetch(client BlockClient, height int64) (*BlockFees, error) {
+	block, err := client.Block(&height)
+	if err != nil {
+		return nil, fmt.Errorf("fetch block %d: %v", height, err)
+	}
+	results, err := client.BlockResults(&height)
+	if err != nil {
+		return nil, fmt.Errorf("fetch block results %d: %v", height, err)
+	}
+	return Attribute(block, results)
+}
+
+// Attribute attributes the fees of a block. Failed txs are charged too, their
+// fee is taken before the msgs run. The block is needed for the payers and
+// hashes of txs, results alone only carry the amounts.
+func Attribute(block *ctypes.ResultBlock, results *rpc.ResultBlockResults) (*BlockFees, error) {
+	height := block.Block.Header.Height
+	fees := &BlockFees{Height: height}
+	if results == nil || results.Results == nil {
+		return fees, nil
+	}
+	for i, txBytes := range block.Block.Data.Txs {
+		if i >= len(results.Results.DeliverTx) || results.Results.DeliverTx[i] == nil {
+			break
+		}
+		for _, tag := range results.Results.DeliverTx[i].Tags {
+			if string(tag.Key) != TxFeeTagKey {
+				continue
+			}
+			coins, err := ParseFee(string(tag.Value))
+			if err != nil {
+				return nil, fmt.Errorf("fee of tx %d in block %d: %v", i, height, err)
+			}
+			if len(coins) == 0 {
+				continue
+			}
+			payer, err := feePayer(txBytes)
+			if err != nil {
+				return nil, fmt.Errorf("fee of tx %d in block %d: %v", i, height, err)
+			}
+			fees.Deductions = append(fees.Deductions, Deduction{
+				Height:  height,
+				Address: payer,
+				TxHash:  fmt.Sprintf("%X", txBytes.Hash()),
+				TxIndex: i,
+				Coins:   coins,
+			})
+		}
+	}
+	return fees, nil
+}
+
+// feePayer returns the first signer of the first msg, the node charges the fee to it.
+func feePayer(txBytes []byte) (types.AccAddress, error) {
+	parsed, err := rpc.ParseTx(tx.Cdc, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	msgs := parsed.GetMsgs()
+	if len(msgs) == 0 || len(msgs[0].GetSigners()) == 0 {
+		return nil, fmt.Errorf("tx has no signer")
+	}
+	return msgs[0].GetSigners()[0], nil
+}
+
+// ParseFee parses fees the way the node writes them, "BNB:37500;XYZ-000:12",
+// amounts in units of 1e-8. Repeated denoms are summed, zero amounts dropped.
+func ParseFee(s string) (types.Coins, error) {
+	var coins types.Coins
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		sep := strings.LastIndex(part, ":")
+		if sep <= 0 {
+			return nil, fmt.Errorf("invalid fee %q", part)
+		}
+		amount, err := strconv.ParseInt(part[sep+1:], 10, 64)
+		if err != nil || amount < 0 {
+			return nil, fmt.Errorf("invalid fee amount %q", part)
+		}
+		if amount == 0 {
+			continue
+		}
+		coins = append(coins, types.Coin{Denom: part[:sep], Amount: amount})
+	}
+	sort.Sort(coins)
+	// merge repeated denoms
+	merged := coins[:0]
+	for _, c := range coins {
+		if n := len(merged); n > 0 && merged[n-1].Denom == c.Denom {
+			merged[n-1].Amount += c.Amount
+			continue
+		}
+		merged = append(merged, c)
+	}
+	return merged, nil
+}
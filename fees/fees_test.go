@@ -0,0 +1,62 @@
+package fees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+func TestParseFee(t *testing.T) {
+	coins, err := ParseFee("XYZ-000:12;BNB:37500;BNB:500;ABC-111:0")
+	assert.NoError(t, err)
+	assert.Equal(t, types.Coins{{Denom: "BNB", Amount: 38000}, {Denom: "XYZ-000", Amount: 12}}, coins)
+
+	_, err = ParseFee("BNB")
+	assert.Error(t, err)
+	_, err = ParseFee("BNB:-1")
+	assert.Error(t, err)
+}
+
+func TestAttribute(t *testing.T) {
+	defer func(network types.ChainNetwork) { types.Network = network }(types.Network)
+	types.Network = types.TestNetwork
+	payer := types.AccAddress([]byte("payer-address-bytes1"))
+	bz, err := tx.Cdc.MarshalBinaryLengthPrefixed(tx.StdTx{Msgs: []msg.Msg{msg.NewCancelOrderMsg(payer, "BNB_BUSD-BD1", "ref")}})
+	assert.NoError(t, err)
+	txBytes := tmtypes.Tx(bz)
+	txHash := txBytes.Hash()
+
+	other := types.AccAddress([]byte("other-address-bytes1"))
+	bz, err = tx.Cdc.MarshalBinaryLengthPrefixed(tx.StdTx{Msgs: []msg.Msg{msg.NewCancelOrderMsg(other, "BNB_BUSD-BD1", "ref")}})
+	assert.NoError(t, err)
+	otherTx := tmtypes.Tx(bz)
+
+	block := &ctypes.ResultBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: 9}, Data: tmtypes.Data{Txs: tmtypes.Txs{txBytes, otherTx, txBytes}}}}
+	results := &rpc.ResultBlockResults{Height: 9, Results: &rpc.ABCIResponses{
+		DeliverTx: []*rpc.ResponseDeliverTx{
+			{Code: 5, Tags: []cmn.KVPair{{Key: []byte("fee"), Value: []byte("BNB:100000")}}},
+			{Tags: []cmn.KVPair{{Key: []byte("action"), Value: []byte("cancelOrder")}, {Key: []byte("fee"), Value: []byte("BNB:40")}}},
+			{Tags: []cmn.KVPair{{Key: []byte("fee"), Value: []byte("BNB:60")}}},
+		},
+	}}
+
+	fees, err := Attribute(block, results)
+	assert.NoError(t, err)
+	assert.Len(t, fees.Deductions, 3)
+	assert.Equal(t, Deduction{Height: 9, Address: payer, TxHash: cmn.HexBytes(txHash).String(), Coins: types.Coins{{Denom: "BNB", Amount: 100000}}}, fees.Deductions[0])
+	assert.Equal(t, 1, fees.Deductions[1].TxIndex)
+	assert.Equal(t, map[string]types.Coins{
+		payer.String(): {{Denom: "BNB", Amount: 100060}},
+		other.String(): {{Denom: "BNB", Amount: 40}},
+	}, fees.ByAddress())
+	assert.Equal(t, types.Coins{{Denom: "BNB", Amount: 100060}}, fees.ByTx()[cmn.HexBytes(txHash).String()])
+	assert.Equal(t, types.Coins{{Denom: "BNB", Amount: 100100}}, fees.Total())
+}
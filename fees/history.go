@@ -0,0 +1,157 @@
+package fees
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// FeeClient is the part of the rpc client History needs. *rpc.HTTP satisfies
+// it, it must reach a node keeping the state of the heights asked for.
+type FeeClient interface {
+	GetFeeWithOptions(opts ...rpc.QueryOption) ([]types.FeeParam, error)
+}
+
+// Schedule is a fee table and the first height it was charged at.
+type Schedule struct {
+	Height int64            `json:"height"`
+	Params []types.FeeParam `json:"params"`
+}
+
+// History reconstructs the fee tables charged in past blocks. Fee params only
+// change through passed fee change proposals, at the end of a block, so the
+// table charged in a block is the one of the state committed by the block
+// before. It is safe for concurrent use.
+type History struct {
+	client FeeClient
+
+	mtx sync.Mutex
+	// schedules are the changes found over [from, to], oldest first.
+	schedules []Schedule
+	from, to  int64
+}
+
+func NewHistory(client FeeClient) *History {
+	return &History{client: client}
+}
+
+// At returns the fee table charged in the block at height, e.g. for
+// rpc.CalculateFixedFee to price the txs of that block.
+func (h *History) At(height int64) ([]types.FeeParam, error) {
+	if height < 2 {
+		return nil, fmt.Errorf("no fees are charged at height %d", height)
+	}
+	h.mtx.Lock()
+	if s, ok := h.cached(height); ok {
+		h.mtx.Unlock()
+		return s.Params, nil
+	}
+	h.mtx.Unlock()
+	return h.query(height)
+}
+
+// Changes returns the fee tables charged from height from to height to: the
+// table at from, then every change with the height it was first charged at.
+//
+// Changes are found by bisection, comparing the tables at the ends of a range
+// and splitting it while they differ, so a change reverted within a range whose
+// ends agree is not seen. Fees are changed rarely and never back and forth in
+// practice, the queries needed grow with the log of the range per change.
+func (h *History) Changes(from, to int64) ([]Schedule, error) {
+	if from < 2 || to < from {
+		return nil, fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+	first, err := h.query(from)
+	if err != nil {
+		return nil, err
+	}
+	last, err := h.query(to)
+	if err != nil {
+		return nil, err
+	}
+	schedules := []Schedule{{Height: from, Params: first}}
+	if err := h.bisect(from, first, to, last, &schedules); err != nil {
+		return nil, err
+	}
+	h.remember(from, to, schedules)
+	return schedules, nil
+}
+
+// bisect appends the changes between lo and hi, whose tables are known.
+func (h *History) bisect(lo int64, loParams []types.FeeParam, hi int64, hiParams []types.FeeParam, schedules *[]Schedule) error {
+	if reflect.DeepEqual(loParams, hiParams) {
+		return nil
+	}
+	if hi-lo == 1 {
+		*schedules = append(*schedules, Schedule{Height: hi, Params: hiParams})
+		return nil
+	}
+	mid := lo + (hi-lo)/2
+	midParams, err := h.query(mid)
+	if err != nil {
+		return err
+	}
+	if err := h.bisect(lo, loParams, mid, midParams, schedules); err != nil {
+		return err
+	}
+	return h.bisect(mid, midParams, hi, hiParams, schedules)
+}
+
+func (h *History) query(height int64) ([]types.FeeParam, error) {
+	return h.client.GetFeeWithOptions(rpc.WithHeight(height - 1))
+}
+
+// cached returns the schedule of height if it is within the range of the last
+// Changes, h.mtx must be held.
+func (h *History) cached(height int64) (Schedule, bool) {
+	if len(h.schedules) == 0 || height < h.from || height > h.to {
+		return Schedule{}, false
+	}
+	i := sort.Search(len(h.schedules), func(i int) bool {
+		return h.schedules[i].Height > height
+	})
+	return h.schedules[i-1], true
+}
+
+// remember keeps the schedules of the widest range seen, merging ranges that
+// overlap or touch.
+func (h *History) remember(from, to int64, schedules []Schedule) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if len(h.schedules) == 0 || to+1 < h.from || from > h.to+1 {
+		if len(h.schedules) == 0 || to-from > h.to-h.from {
+			h.schedules, h.from, h.to = schedules, from, to
+		}
+		return
+	}
+	merged := make([]Schedule, 0, len(h.schedules)+len(schedules))
+	for _, s := range h.schedules {
+		if s.Height < from {
+			merged = append(merged, s)
+		}
+	}
+	merged = append(merged, schedules...)
+	for _, s := range h.schedules {
+		if s.Height > to {
+			merged = append(merged, s)
+		}
+	}
+	// drop the starts of ranges that did not change the table
+	compacted := merged[:1]
+	for _, s := range merged[1:] {
+		if !reflect.DeepEqual(s.Params, compacted[len(compacted)-1].Params) {
+			compacted = append(compacted, s)
+		}
+	}
+	if from > h.from {
+		from = h.from
+	}
+	if to < h.to {
+		to = h.to
+	}
+	h.schedules, h.from, h.to = compacted, from, to
+}
@@ -0,0 +1,71 @@
+package fees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// fakeFees changes the send fee in the state committed at the given heights.
+type fakeFees struct {
+	changes []int64
+	queries int
+}
+
+func (f *fakeFees) GetFeeWithOptions(opts ...rpc.QueryOption) ([]types.FeeParam, error) {
+	f.queries++
+	height := rpc.ApplyQueryOptions(opts...).Height
+	fee := int64(1000)
+	for _, change := range f.changes {
+		if height >= change {
+			fee /= 2
+		}
+	}
+	return []types.FeeParam{&types.FixedFeeParams{MsgType: "send", Fee: fee, FeeFor: types.FeeForProposer}}, nil
+}
+
+func sendFee(params []types.FeeParam) int64 {
+	return params[0].(*types.FixedFeeParams).Fee
+}
+
+func TestHistory(t *testing.T) {
+	client := &fakeFees{changes: []int64{100, 700}}
+	h := NewHistory(client)
+
+	// the change committed by block 100 is charged from block 101 on
+	params, err := h.At(100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), sendFee(params))
+	params, err = h.At(101)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), sendFee(params))
+
+	schedules, err := h.Changes(2, 1000)
+	assert.NoError(t, err)
+	assert.Len(t, schedules, 3)
+	assert.Equal(t, []int64{2, 101, 701}, []int64{schedules[0].Height, schedules[1].Height, schedules[2].Height})
+	assert.Equal(t, int64(250), sendFee(schedules[2].Params))
+	assert.True(t, client.queries < 30, "bisected in %d queries", client.queries)
+
+	// served from the changes found
+	queries := client.queries
+	params, err = h.At(700)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), sendFee(params))
+	assert.Equal(t, queries, client.queries)
+
+	schedules, err = h.Changes(900, 1200)
+	assert.NoError(t, err)
+	assert.Len(t, schedules, 1)
+	// merged with the range seen before
+	assert.Len(t, h.schedules, 3)
+	assert.Equal(t, int64(1200), h.to)
+
+	_, err = h.Changes(10, 5)
+	assert.Error(t, err)
+	_, err = h.At(1)
+	assert.Error(t, err)
+}
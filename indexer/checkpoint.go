@@ -0,0 +1,77 @@
+package indexer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CheckpointStore persists the last height that was fully processed.
+type CheckpointStore interface {
+	// Load returns the last checkpointed height, or 0 if there is none.
+	Load() (int64, error)
+	Save(height int64) error
+}
+
+type MemoryCheckpointStore struct {
+	mtx    sync.Mutex
+	height int64
+}
+
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{}
+}
+
+func (s *MemoryCheckpointStore) Load() (int64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.height, nil
+}
+
+func (s *MemoryCheckpointStore) Save(height int64) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.height = height
+	return nil
+}
+
+// FileCheckpointStore keeps the height as text in a single file. Writes go to a
+// temporary file first so a crash never leaves a truncated checkpoint.
+type FileCheckpointStore struct {
+	path string
+}
+
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (s *FileCheckpointStore) Load() (int64, error) {
+	bz, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(bz)), 10, 64)
+}
+
+func (s *FileCheckpointStore) Save(height int64) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.WriteString(strconv.FormatInt(height, 10)); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,128 @@
+package indexer
+
+import (
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+// TxContext locates a decoded msg on chain. MsgIndex is zero for tx-level events.
+type TxContext struct {
+	Height   int64     `json:"height"`
+	Time     time.Time `json:"time"`
+	TxHash   string    `json:"tx_hash"`
+	TxIndex  int       `json:"tx_index"`
+	MsgIndex int       `json:"msg_index"`
+	Memo     string    `json:"memo"`
+	Code     uint32    `json:"code"`
+}
+
+// Success reports whether the tx carrying the msg was delivered successfully.
+func (c TxContext) Success() bool {
+	return c.Code == 0
+}
+
+// BlockEvent is emitted once every other handler has processed the block.
+type BlockEvent struct {
+	Height int64     `json:"height"`
+	Time   time.Time `json:"time"`
+	Hash   string    `json:"hash"`
+	NumTxs int       `json:"num_txs"`
+}
+
+// TxEvent is emitted once for every tx in the block.
+type TxEvent struct {
+	TxContext
+	NumMsgs int    `json:"num_msgs"`
+	Log     string `json:"log,omitempty"`
+}
+
+// TransferEvent is emitted for every send msg.
+type TransferEvent struct {
+	TxContext
+	Inputs  []msg.Input  `json:"inputs"`
+	Outputs []msg.Output `json:"outputs"`
+}
+
+type TradeKind string
+
+const (
+	TradeNewOrder    TradeKind = "new_order"
+	TradeCancelOrder TradeKind = "cancel_order"
+)
+
+// TradeEvent is emitted for order placement and cancellation. Fills are produced by
+// the match engine and are not part of block results, so they are not reported here.
+type TradeEvent struct {
+	TxContext
+	Kind        TradeKind        `json:"kind"`
+	Sender      types.AccAddress `json:"sender"`
+	Symbol      string           `json:"symbol"`
+	OrderID     string           `json:"order_id"` // the ref id for cancellations
+	OrderType   int8             `json:"order_type,omitempty"`
+	Side        int8             `json:"side,omitempty"`
+	Price       int64            `json:"price,omitempty"`
+	Quantity    int64            `json:"quantity,omitempty"`
+	TimeInForce int8             `json:"time_in_force,omitempty"`
+}
+
+type SwapKind string
+
+const (
+	SwapCreate  SwapKind = "create"
+	SwapDeposit SwapKind = "deposit"
+	SwapClaim   SwapKind = "claim"
+	SwapRefund  SwapKind = "refund"
+)
+
+// SwapEvent is emitted for every HTLT msg.
+type SwapEvent struct {
+	TxContext
+	Kind             SwapKind         `json:"kind"`
+	SwapID           types.SwapBytes  `json:"swap_id"`
+	From             types.AccAddress `json:"from"`
+	To               types.AccAddress `json:"to,omitempty"`
+	Amount           types.Coins      `json:"amount,omitempty"`
+	RandomNumberHash types.SwapBytes  `json:"random_number_hash,omitempty"`
+	RandomNumber     types.SwapBytes  `json:"random_number,omitempty"`
+	HeightSpan       int64            `json:"height_span,omitempty"`
+	CrossChain       bool             `json:"cross_chain,omitempty"`
+}
+
+type ProposalKind string
+
+const (
+	ProposalSubmit  ProposalKind = "submit"
+	ProposalDeposit ProposalKind = "deposit"
+	ProposalVote    ProposalKind = "vote"
+)
+
+// ProposalEvent is emitted for governance msgs on the main chain and side chains.
+// ProposalID is zero for submissions whose result does not carry the new id.
+type ProposalEvent struct {
+	TxContext
+	Kind         ProposalKind     `json:"kind"`
+	ProposalID   int64            `json:"proposal_id"`
+	SideChainId  string           `json:"side_chain_id,omitempty"`
+	Account      types.AccAddress `json:"account"`
+	Title        string           `json:"title,omitempty"`
+	ProposalType msg.ProposalKind `json:"proposal_type,omitempty"`
+	Amount       types.Coins      `json:"amount,omitempty"`
+	Option       msg.VoteOption   `json:"option,omitempty"`
+}
+
+// Handlers holds the typed callbacks of an indexer. Nil handlers are skipped.
+// A returned error stops the indexer before the block is checkpointed.
+type Handlers struct {
+	OnTx       func(TxEvent) error
+	OnTransfer func(TransferEvent) error
+	OnTrade    func(TradeEvent) error
+	OnSwap     func(SwapEvent) error
+	OnProposal func(ProposalEvent) error
+	OnBlock    func(BlockEvent) error
+
+	// OnDecodeError is called for txs that cannot be decoded. If it is nil or
+	// returns an error, the indexer stops.
+	OnDecodeError func(height int64, txIndex int, err error) error
+}
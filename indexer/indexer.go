@@ -0,0 +1,345 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+const defaultConcurrency = 4
+
+// Fetcher is the subset of the node rpc client the indexer needs. *rpc.HTTP satisfies it.
+type Fetcher interface {
+	Block(height *int64) (*ctypes.ResultBlock, error)
+	BlockResults(height *int64) (*rpc.ResultBlockResults, error)
+}
+
+// Indexer walks a range of blocks and feeds the decoded msgs to typed handlers.
+//
+// Blocks are fetched and decoded concurrently, but handlers always see blocks in
+// height order. Within a block the different handlers run concurrently, and each
+// handler receives its events in tx and msg order. The next block is only
+// dispatched, and the checkpoint only advanced, once every handler is done.
+type Indexer struct {
+	fetcher     Fetcher
+	handlers    Handlers
+	store       CheckpointStore
+	concurrency int
+	includeFail bool
+}
+
+type Option func(*Indexer)
+
+// WithConcurrency sets how many blocks are fetched in parallel.
+func WithConcurrency(n int) Option {
+	return func(idx *Indexer) {
+		if n > 0 {
+			idx.concurrency = n
+		}
+	}
+}
+
+// WithFailedTxs makes the indexer emit events for txs that failed in DeliverTx.
+// TxContext.Code tells them apart.
+func WithFailedTxs() Option {
+	return func(idx *Indexer) {
+		idx.includeFail = true
+	}
+}
+
+// New creates an indexer. A nil store keeps checkpoints in memory.
+func New(fetcher Fetcher, handlers Handlers, store CheckpointStore, options ...Option) *Indexer {
+	if store == nil {
+		store = NewMemoryCheckpointStore()
+	}
+	idx := &Indexer{
+		fetcher:     fetcher,
+		handlers:    handlers,
+		store:       store,
+		concurrency: defaultConcurrency,
+	}
+	for _, option := range options {
+		option(idx)
+	}
+	return idx
+}
+
+// Run indexes the blocks in [from, to]. If the checkpoint store is already past
+// from, indexing resumes right after the checkpoint.
+func (idx *Indexer) Run(ctx context.Context, from, to int64) error {
+	if err := rpc.ValidateHeightRange(from, to); err != nil {
+		return err
+	}
+	checkpoint, err := idx.store.Load()
+	if err != nil {
+		return err
+	}
+	if checkpoint >= from {
+		from = checkpoint + 1
+	}
+	if from > to {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type fetched struct {
+		block *decodedBlock
+		err   error
+	}
+	pending := make(chan chan fetched, idx.concurrency)
+	go func() {
+		defer close(pending)
+		sem := make(chan struct{}, idx.concurrency)
+		for height := from; height <= to; height++ {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			result := make(chan fetched, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+			go func(height int64) {
+				defer func() { <-sem }()
+				block, err := idx.fetch(height)
+				result <- fetched{block, err}
+			}(height)
+		}
+	}()
+
+	for result := range pending {
+		var r fetched
+		select {
+		case r = <-result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if r.err != nil {
+			return r.err
+		}
+		if err := idx.dispatch(r.block); err != nil {
+			return err
+		}
+		if err := idx.store.Save(r.block.event.Height); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+type decodedBlock struct {
+	event     BlockEvent
+	txs       []TxEvent
+	transfers []TransferEvent
+	trades    []TradeEvent
+	swaps     []SwapEvent
+	proposals []ProposalEvent
+}
+
+func (idx *Indexer) fetch(height int64) (*decodedBlock, error) {
+	block, err := idx.fetcher.Block(&height)
+	if err != nil {
+		return nil, fmt.Errorf("fetch block %d: %v", height, err)
+	}
+	results, err := idx.fetcher.BlockResults(&height)
+	if err != nil {
+		return nil, fmt.Errorf("fetch block results %d: %v", height, err)
+	}
+	return idx.decode(block, results)
+}
+
+func (idx *Indexer) decode(block *ctypes.ResultBlock, results *rpc.ResultBlockResults) (*decodedBlock, error) {
+	header := block.Block.Header
+	decoded := &decodedBlock{
+		event: BlockEvent{
+			Height: header.Height,
+			Time:   header.Time,
+			Hash:   block.BlockMeta.BlockID.Hash.String(),
+			NumTxs: len(block.Block.Data.Txs),
+		},
+	}
+	for i, txBytes := range block.Block.Data.Txs {
+		var deliver *rpc.ResponseDeliverTx
+		if results != nil && results.Results != nil && i < len(results.Results.DeliverTx) {
+			deliver = results.Results.DeliverTx[i]
+		}
+		if deliver != nil && deliver.Code != 0 && !idx.includeFail {
+			continue
+		}
+		parsed, err := rpc.ParseTx(tx.Cdc, txBytes)
+		if err != nil {
+			if idx.handlers.OnDecodeError == nil {
+				return nil, fmt.Errorf("decode tx %d in block %d: %v", i, header.Height, err)
+			}
+			if err := idx.handlers.OnDecodeError(header.Height, i, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		stdTx := parsed.(tx.StdTx)
+		txCtx := TxContext{
+			Height:  header.Height,
+			Time:    header.Time,
+			TxHash:  fmt.Sprintf("%X", txBytes.Hash()),
+			TxIndex: i,
+			Memo:    stdTx.Memo,
+		}
+		txEvent := TxEvent{TxContext: txCtx, NumMsgs: len(stdTx.Msgs)}
+		if deliver != nil {
+			txCtx.Code = deliver.Code
+			txEvent.Code = deliver.Code
+			txEvent.Log = deliver.Log
+		}
+		decoded.txs = append(decoded.txs, txEvent)
+		for j, m := range stdTx.Msgs {
+			txCtx.MsgIndex = j
+			decoded.add(txCtx, m, deliver)
+		}
+	}
+	return decoded, nil
+}
+
+func (b *decodedBlock) add(txCtx TxContext, m msg.Msg, deliver *rpc.ResponseDeliverTx) {
+	switch m := m.(type) {
+	case msg.SendMsg:
+		b.transfers = append(b.transfers, TransferEvent{TxContext: txCtx, Inputs: m.Inputs, Outputs: m.Outputs})
+	case msg.CreateOrderMsg:
+		b.trades = append(b.trades, TradeEvent{TxContext: txCtx, Kind: TradeNewOrder, Sender: m.Sender, Symbol: m.Symbol,
+			OrderID: m.ID, OrderType: m.OrderType, Side: m.Side, Price: m.Price, Quantity: m.Quantity, TimeInForce: m.TimeInForce})
+	case msg.CancelOrderMsg:
+		b.trades = append(b.trades, TradeEvent{TxContext: txCtx, Kind: TradeCancelOrder, Sender: m.Sender, Symbol: m.Symbol, OrderID: m.RefID})
+	case msg.HTLTMsg:
+		b.swaps = append(b.swaps, SwapEvent{TxContext: txCtx, Kind: SwapCreate,
+			SwapID: msg.CalculateSwapID(m.RandomNumberHash, m.From, m.SenderOtherChain),
+			From:   m.From, To: m.To, Amount: m.Amount, RandomNumberHash: m.RandomNumberHash,
+			HeightSpan: m.HeightSpan, CrossChain: m.CrossChain})
+	case msg.DepositHTLTMsg:
+		b.swaps = append(b.swaps, SwapEvent{TxContext: txCtx, Kind: SwapDeposit, SwapID: m.SwapID, From: m.From, Amount: m.Amount})
+	case msg.ClaimHTLTMsg:
+		b.swaps = append(b.swaps, SwapEvent{TxContext: txCtx, Kind: SwapClaim, SwapID: m.SwapID, From: m.From, RandomNumber: m.RandomNumber})
+	case msg.RefundHTLTMsg:
+		b.swaps = append(b.swaps, SwapEvent{TxContext: txCtx, Kind: SwapRefund, SwapID: m.SwapID, From: m.From})
+	case msg.SubmitProposalMsg:
+		b.proposals = append(b.proposals, ProposalEvent{TxContext: txCtx, Kind: ProposalSubmit, ProposalID: proposalIDFromTags(deliver),
+			Account: m.Proposer, Title: m.Title, ProposalType: m.ProposalType, Amount: m.InitialDeposit})
+	case msg.SideChainSubmitProposalMsg:
+		b.proposals = append(b.proposals, ProposalEvent{TxContext: txCtx, Kind: ProposalSubmit, ProposalID: proposalIDFromTags(deliver),
+			SideChainId: m.SideChainId, Account: m.Proposer, Title: m.Title, ProposalType: m.ProposalType, Amount: m.InitialDeposit})
+	case msg.DepositMsg:
+		b.proposals = append(b.proposals, ProposalEvent{TxContext: txCtx, Kind: ProposalDeposit, ProposalID: m.ProposalID,
+			Account: m.Depositer, Amount: m.Amount})
+	case msg.SideChainDepositMsg:
+		b.proposals = append(b.proposals, ProposalEvent{TxContext: txCtx, Kind: ProposalDeposit, ProposalID: m.ProposalID,
+			SideChainId: m.SideChainId, Account: m.Depositer, Amount: m.Amount})
+	case msg.VoteMsg:
+		b.proposals = append(b.proposals, ProposalEvent{TxContext: txCtx, Kind: ProposalVote, ProposalID: m.ProposalID,
+			Account: m.Voter, Option: m.Option})
+	case msg.SideChainVoteMsg:
+		b.proposals = append(b.proposals, ProposalEvent{TxContext: txCtx, Kind: ProposalVote, ProposalID: m.ProposalID,
+			SideChainId: m.SideChainId, Account: m.Voter, Option: m.Option})
+	}
+}
+
+func proposalIDFromTags(deliver *rpc.ResponseDeliverTx) int64 {
+	if deliver == nil {
+		return 0
+	}
+	for _, tag := range deliver.Tags {
+		if string(tag.Key) == "proposal-id" {
+			id, err := strconv.ParseInt(string(tag.Value), 10, 64)
+			if err == nil {
+				return id
+			}
+		}
+	}
+	return 0
+}
+
+func (idx *Indexer) dispatch(b *decodedBlock) error {
+	var (
+		wg   sync.WaitGroup
+		mtx  sync.Mutex
+		errs []error
+	)
+	run := func(fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mtx.Lock()
+				errs = append(errs, err)
+				mtx.Unlock()
+			}
+		}()
+	}
+	if h := idx.handlers.OnTx; h != nil && len(b.txs) > 0 {
+		run(func() error {
+			for _, e := range b.txs {
+				if err := h(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if h := idx.handlers.OnTransfer; h != nil && len(b.transfers) > 0 {
+		run(func() error {
+			for _, e := range b.transfers {
+				if err := h(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if h := idx.handlers.OnTrade; h != nil && len(b.trades) > 0 {
+		run(func() error {
+			for _, e := range b.trades {
+				if err := h(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if h := idx.handlers.OnSwap; h != nil && len(b.swaps) > 0 {
+		run(func() error {
+			for _, e := range b.swaps {
+				if err := h(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if h := idx.handlers.OnProposal; h != nil && len(b.proposals) > 0 {
+		run(func() error {
+			for _, e := range b.proposals {
+				if err := h(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return fmt.Errorf("block %d: %v", b.event.Height, errs[0])
+	}
+	if idx.handlers.OnBlock != nil {
+		return idx.handlers.OnBlock(b.event)
+	}
+	return nil
+}
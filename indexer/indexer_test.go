@@ -0,0 +1,96 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type fakeFetcher struct {
+	txs map[int64]tmtypes.Txs
+}
+
+func (f *fakeFetcher) Block(height *int64) (*ctypes.ResultBlock, error) {
+	return &ctypes.ResultBlock{
+		BlockMeta: &tmtypes.BlockMeta{},
+		Block: &tmtypes.Block{
+			Header: tmtypes.Header{Height: *height},
+			Data:   tmtypes.Data{Txs: f.txs[*height]},
+		},
+	}, nil
+}
+
+func (f *fakeFetcher) BlockResults(height *int64) (*rpc.ResultBlockResults, error) {
+	deliver := make([]*rpc.ResponseDeliverTx, len(f.txs[*height]))
+	for i := range deliver {
+		deliver[i] = &rpc.ResponseDeliverTx{}
+	}
+	return &rpc.ResultBlockResults{Height: *height, Results: &rpc.ABCIResponses{DeliverTx: deliver}}, nil
+}
+
+func encodeTx(t *testing.T, msgs ...msg.Msg) tmtypes.Tx {
+	bz, err := tx.Cdc.MarshalBinaryLengthPrefixed(tx.StdTx{Msgs: msgs})
+	assert.NoError(t, err)
+	return bz
+}
+
+func TestIndexerOrderingAndCheckpoint(t *testing.T) {
+	from := types.AccAddress([]byte("from-address-bytes-1"))
+	to := types.AccAddress([]byte("to-address-bytes-0001"))
+	fetcher := &fakeFetcher{txs: map[int64]tmtypes.Txs{}}
+	for h := int64(1); h <= 20; h++ {
+		coins := types.Coins{{Denom: "BNB", Amount: h}}
+		send := msg.CreateSendMsg(from, coins, []msg.Transfer{{ToAddr: to, Coins: coins}})
+		cancel := msg.NewCancelOrderMsg(from, "BNB_BUSD-BD1", "ref")
+		fetcher.txs[h] = tmtypes.Txs{encodeTx(t, send, cancel)}
+	}
+
+	var heights []int64
+	var trades int
+	store := NewMemoryCheckpointStore()
+	idx := New(fetcher, Handlers{
+		OnTransfer: func(e TransferEvent) error {
+			heights = append(heights, e.Height)
+			assert.Equal(t, e.Height, e.Outputs[0].Coins[0].Amount)
+			return nil
+		},
+		OnTrade: func(e TradeEvent) error {
+			assert.Equal(t, TradeCancelOrder, e.Kind)
+			assert.Equal(t, 1, e.MsgIndex)
+			trades++
+			return nil
+		},
+	}, store, WithConcurrency(8))
+
+	assert.NoError(t, idx.Run(context.Background(), 1, 10))
+	assert.NoError(t, idx.Run(context.Background(), 1, 20))
+	for i, h := range heights {
+		assert.Equal(t, int64(i+1), h)
+	}
+	assert.Equal(t, 20, len(heights))
+	assert.Equal(t, 20, trades)
+	checkpoint, _ := store.Load()
+	assert.Equal(t, int64(20), checkpoint)
+}
+
+func TestIndexerHandlerErrorStopsBeforeCheckpoint(t *testing.T) {
+	fetcher := &fakeFetcher{txs: map[int64]tmtypes.Txs{}}
+	fetcher.txs[3] = tmtypes.Txs{encodeTx(t, msg.NewCancelOrderMsg(types.AccAddress([]byte("addr")), "BNB_BUSD-BD1", "ref"))}
+	store := NewMemoryCheckpointStore()
+	idx := New(fetcher, Handlers{
+		OnTrade: func(e TradeEvent) error { return errors.New("boom") },
+	}, store)
+
+	assert.Error(t, idx.Run(context.Background(), 1, 5))
+	checkpoint, _ := store.Load()
+	assert.Equal(t, int64(2), checkpoint)
+}
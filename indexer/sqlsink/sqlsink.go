@@ -0,0 +1,202 @@
+// Package sqlsink mirrors indexed blocks, txs, transfers and orders into a SQL
+// database through database/sql. Register the driver of your choice (e.g. sqlite3
+// or postgres) in your own program and pass the opened *sql.DB to NewSink.
+package sqlsink
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/binance-chain/go-sdk/indexer"
+)
+
+type Dialect int
+
+const (
+	SQLite Dialect = iota
+	Postgres
+)
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS blocks (
+		height BIGINT PRIMARY KEY,
+		hash TEXT NOT NULL,
+		time TIMESTAMP NOT NULL,
+		num_txs INTEGER NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS txs (
+		hash TEXT NOT NULL,
+		height BIGINT NOT NULL,
+		tx_index INTEGER NOT NULL,
+		code INTEGER NOT NULL,
+		memo TEXT NOT NULL,
+		num_msgs INTEGER NOT NULL,
+		log TEXT NOT NULL,
+		PRIMARY KEY (height, tx_index)
+	)`,
+	`CREATE INDEX IF NOT EXISTS txs_hash ON txs (hash)`,
+	`CREATE TABLE IF NOT EXISTS transfers (
+		height BIGINT NOT NULL,
+		tx_hash TEXT NOT NULL,
+		tx_index INTEGER NOT NULL,
+		msg_index INTEGER NOT NULL,
+		direction TEXT NOT NULL,
+		io_index INTEGER NOT NULL,
+		address TEXT NOT NULL,
+		denom TEXT NOT NULL,
+		amount BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS transfers_address ON transfers (address, height)`,
+	`CREATE INDEX IF NOT EXISTS transfers_height ON transfers (height)`,
+	`CREATE TABLE IF NOT EXISTS orders (
+		height BIGINT NOT NULL,
+		tx_hash TEXT NOT NULL,
+		tx_index INTEGER NOT NULL,
+		msg_index INTEGER NOT NULL,
+		kind TEXT NOT NULL,
+		sender TEXT NOT NULL,
+		symbol TEXT NOT NULL,
+		order_id TEXT NOT NULL,
+		order_type INTEGER NOT NULL,
+		side INTEGER NOT NULL,
+		price BIGINT NOT NULL,
+		quantity BIGINT NOT NULL,
+		time_in_force INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS orders_symbol ON orders (symbol, height)`,
+	`CREATE INDEX IF NOT EXISTS orders_height ON orders (height)`,
+}
+
+// Sink buffers the events of the block being indexed and writes them in a single
+// SQL transaction from OnBlock. Rows of a height are replaced when the height is
+// indexed again, so replays after a crash do not duplicate data.
+type Sink struct {
+	db      *sql.DB
+	dialect Dialect
+
+	mtx       sync.Mutex
+	txs       []indexer.TxEvent
+	transfers []indexer.TransferEvent
+	// orders are the new and cancel order msgs, fills are not part of blocks.
+	orders []indexer.TradeEvent
+}
+
+func NewSink(db *sql.DB, dialect Dialect) *Sink {
+	return &Sink{db: db, dialect: dialect}
+}
+
+// CreateSchema creates the tables and indexes if they do not exist yet.
+func (s *Sink) CreateSchema() error {
+	for _, stmt := range schema {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handlers returns the indexer handlers that feed the sink.
+func (s *Sink) Handlers() indexer.Handlers {
+	return indexer.Handlers{
+		OnTx: func(e indexer.TxEvent) error {
+			s.mtx.Lock()
+			s.txs = append(s.txs, e)
+			s.mtx.Unlock()
+			return nil
+		},
+		OnTransfer: func(e indexer.TransferEvent) error {
+			s.mtx.Lock()
+			s.transfers = append(s.transfers, e)
+			s.mtx.Unlock()
+			return nil
+		},
+		OnTrade: func(e indexer.TradeEvent) error {
+			s.mtx.Lock()
+			s.orders = append(s.orders, e)
+			s.mtx.Unlock()
+			return nil
+		},
+		OnBlock: s.writeBlock,
+	}
+}
+
+func (s *Sink) writeBlock(block indexer.BlockEvent) (err error) {
+	s.mtx.Lock()
+	txs, transfers, orders := s.txs, s.transfers, s.orders
+	s.txs, s.transfers, s.orders = nil, nil, nil
+	s.mtx.Unlock()
+
+	dbTx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			dbTx.Rollback()
+			return
+		}
+		err = dbTx.Commit()
+	}()
+
+	for _, table := range []string{"blocks", "txs", "transfers", "orders"} {
+		if _, err = dbTx.Exec(s.rebind(fmt.Sprintf("DELETE FROM %s WHERE height = ?", table)), block.Height); err != nil {
+			return err
+		}
+	}
+	if _, err = dbTx.Exec(s.rebind("INSERT INTO blocks (height, hash, time, num_txs) VALUES (?, ?, ?, ?)"),
+		block.Height, block.Hash, block.Time, block.NumTxs); err != nil {
+		return err
+	}
+	for _, t := range txs {
+		if _, err = dbTx.Exec(s.rebind("INSERT INTO txs (hash, height, tx_index, code, memo, num_msgs, log) VALUES (?, ?, ?, ?, ?, ?, ?)"),
+			t.TxHash, t.Height, t.TxIndex, int64(t.Code), t.Memo, t.NumMsgs, t.Log); err != nil {
+			return err
+		}
+	}
+	insertTransfer := s.rebind("INSERT INTO transfers (height, tx_hash, tx_index, msg_index, direction, io_index, address, denom, amount) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	for _, t := range transfers {
+		for i, in := range t.Inputs {
+			for _, coin := range in.Coins {
+				if _, err = dbTx.Exec(insertTransfer, t.Height, t.TxHash, t.TxIndex, t.MsgIndex, "in", i, in.Address.String(), coin.Denom, coin.Amount); err != nil {
+					return err
+				}
+			}
+		}
+		for i, out := range t.Outputs {
+			for _, coin := range out.Coins {
+				if _, err = dbTx.Exec(insertTransfer, t.Height, t.TxHash, t.TxIndex, t.MsgIndex, "out", i, out.Address.String(), coin.Denom, coin.Amount); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	insertOrder := s.rebind("INSERT INTO orders (height, tx_hash, tx_index, msg_index, kind, sender, symbol, order_id, order_type, side, price, quantity, time_in_force) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	for _, t := range orders {
+		if _, err = dbTx.Exec(insertOrder, t.Height, t.TxHash, t.TxIndex, t.MsgIndex, string(t.Kind), t.Sender.String(), t.Symbol,
+			t.OrderID, t.OrderType, t.Side, t.Price, t.Quantity, t.TimeInForce); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebind turns '?' placeholders into the '$n' form postgres expects.
+func (s *Sink) rebind(query string) string {
+	if s.dialect != Postgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
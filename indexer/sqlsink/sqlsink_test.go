@@ -0,0 +1,191 @@
+package sqlsink
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/indexer"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		query   string
+		want    string
+	}{
+		{SQLite, "DELETE FROM txs WHERE height = ?", "DELETE FROM txs WHERE height = ?"},
+		{Postgres, "DELETE FROM txs WHERE height = ?", "DELETE FROM txs WHERE height = $1"},
+		{Postgres, "INSERT INTO blocks (height, hash) VALUES (?, ?)", "INSERT INTO blocks (height, hash) VALUES ($1, $2)"},
+		{Postgres, "SELECT 1", "SELECT 1"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, NewSink(nil, test.dialect).rebind(test.query))
+	}
+}
+
+// fakeDB is a database/sql driver keeping the rows of each table in memory. It
+// runs the DELETE ... WHERE height = ? and INSERT INTO statements of the sink
+// only, and applies them when their transaction commits.
+type fakeDB struct {
+	mtx      sync.Mutex
+	tables   map[string][][]driver.Value
+	failNext string // an insert into this table fails
+	commits  int
+}
+
+var (
+	fakeDBs      sync.Map
+	registerOnce sync.Once
+)
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	db, _ := fakeDBs.Load(name)
+	return &fakeConn{db: db.(*fakeDB)}, nil
+}
+
+func openFakeDB(t *testing.T) (*sql.DB, *fakeDB) {
+	registerOnce.Do(func() { sql.Register("sqlsinkfake", fakeDriver{}) })
+	db := &fakeDB{tables: map[string][][]driver.Value{}}
+	fakeDBs.Store(t.Name(), db)
+	sqlDB, err := sql.Open("sqlsinkfake", t.Name())
+	assert.NoError(t, err)
+	return sqlDB, db
+}
+
+type fakeConn struct {
+	db      *fakeDB
+	pending []func()
+	inTx    bool
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.inTx, c.pending = true, nil
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.db.mtx.Lock()
+	defer c.db.mtx.Unlock()
+	for _, apply := range c.pending {
+		apply()
+	}
+	c.db.commits++
+	c.inTx, c.pending = false, nil
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.inTx, c.pending = false, nil
+	return nil
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("queries are not supported")
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	db := s.conn.db
+	var apply func()
+	switch fields := strings.Fields(s.query); {
+	case fields[0] == "DELETE":
+		table, height := fields[2], args[0]
+		apply = func() {
+			var kept [][]driver.Value
+			for _, row := range db.tables[table] {
+				if row[0] != height {
+					kept = append(kept, row)
+				}
+			}
+			db.tables[table] = kept
+		}
+	case fields[0] == "INSERT":
+		table := fields[2]
+		db.mtx.Lock()
+		fail := db.failNext == table
+		db.mtx.Unlock()
+		if fail {
+			return nil, fmt.Errorf("insert into %s failed", table)
+		}
+		// height is the first column of every table but txs
+		row := append([]driver.Value(nil), args...)
+		if table == "txs" {
+			row[0], row[1] = row[1], row[0]
+		}
+		apply = func() { db.tables[table] = append(db.tables[table], row) }
+	default:
+		return nil, fmt.Errorf("unexpected statement %q", s.query)
+	}
+	if !s.conn.inTx {
+		return nil, errors.New("the sink writes in a transaction only")
+	}
+	s.conn.pending = append(s.conn.pending, apply)
+	return driver.RowsAffected(1), nil
+}
+
+func (db *fakeDB) count(table string) int {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	return len(db.tables[table])
+}
+
+func TestWriteBlockReplayIsIdempotent(t *testing.T) {
+	sqlDB, db := openFakeDB(t)
+	defer sqlDB.Close()
+	sink := NewSink(sqlDB, Postgres)
+	handlers := sink.Handlers()
+	addr := types.AccAddress([]byte("sql-sink-sender-0001"))
+
+	index := func(height int64) error {
+		ctx := indexer.TxContext{Height: height, TxHash: "AB"}
+		assert.NoError(t, handlers.OnTx(indexer.TxEvent{TxContext: ctx, NumMsgs: 2}))
+		assert.NoError(t, handlers.OnTransfer(indexer.TransferEvent{TxContext: ctx,
+			Inputs:  []msg.Input{{Address: addr, Coins: types.Coins{{Denom: "BNB", Amount: 1}}}},
+			Outputs: []msg.Output{{Address: addr, Coins: types.Coins{{Denom: "BNB", Amount: 1}}}}}))
+		assert.NoError(t, handlers.OnTrade(indexer.TradeEvent{TxContext: ctx, Kind: indexer.TradeCancelOrder, Sender: addr, Symbol: "XYZ-000_BNB"}))
+		return handlers.OnBlock(indexer.BlockEvent{Height: height, Time: time.Unix(0, 0), Hash: "CD", NumTxs: 1})
+	}
+	counts := func() []int {
+		return []int{db.count("blocks"), db.count("txs"), db.count("transfers"), db.count("orders")}
+	}
+
+	assert.NoError(t, index(1))
+	assert.NoError(t, index(2))
+	assert.Equal(t, []int{2, 2, 4, 2}, counts())
+
+	// a replay of a height after a crash replaces its rows
+	assert.NoError(t, index(2))
+	assert.Equal(t, []int{2, 2, 4, 2}, counts())
+	assert.Equal(t, 3, db.commits)
+
+	// a replay failing halfway leaves the rows of the height as they were
+	db.failNext = "orders"
+	assert.Error(t, index(2))
+	assert.Equal(t, []int{2, 2, 4, 2}, counts())
+	assert.Equal(t, 3, db.commits)
+}
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// DeadLetter is a delivery that exhausted its retries.
+type DeadLetter struct {
+	URL      string    `json:"url"`
+	Body     []byte    `json:"body"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// DeadLetterStore keeps failed deliveries so they can be inspected or replayed
+// with Dispatcher.Redeliver.
+type DeadLetterStore interface {
+	Put(DeadLetter) error
+	// Drain removes and returns every stored dead letter.
+	Drain() ([]DeadLetter, error)
+}
+
+type MemoryDeadLetterStore struct {
+	mtx     sync.Mutex
+	letters []DeadLetter
+}
+
+func NewMemoryDeadLetterStore() *MemoryDeadLetterStore {
+	return &MemoryDeadLetterStore{}
+}
+
+func (s *MemoryDeadLetterStore) Put(letter DeadLetter) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.letters = append(s.letters, letter)
+	return nil
+}
+
+func (s *MemoryDeadLetterStore) Drain() ([]DeadLetter, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	letters := s.letters
+	s.letters = nil
+	return letters, nil
+}
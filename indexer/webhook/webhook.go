@@ -0,0 +1,436 @@
+// Package webhook forwards selected indexer events to HTTP endpoints. Requests
+// are signed with HMAC-SHA256, retried with exponential backoff and handed to a
+// dead-letter store once the retries are exhausted.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/indexer"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second
+	defaultQueueSize   = 1024
+)
+
+type EventType string
+
+const (
+	EventDeposit        EventType = "deposit"
+	EventSwapClaim      EventType = "swap_claim"
+	EventProposalStatus EventType = "proposal_status"
+	// EventAlert is published by the alert package, see alert.Webhook.
+	EventAlert EventType = "alert"
+)
+
+// Endpoint is a webhook target. An empty Events list subscribes to every event
+// type and an empty Secret disables signing.
+type Endpoint struct {
+	URL    string
+	Secret string
+	Events []EventType
+}
+
+func (e Endpoint) accepts(t EventType) bool {
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, event := range e.Events {
+		if event == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload is the JSON body posted to endpoints. ID is stable across retries and
+// replays so receivers can deduplicate.
+type Payload struct {
+	ID     string      `json:"id"`
+	Type   EventType   `json:"type"`
+	Height int64       `json:"height"`
+	Time   time.Time   `json:"time"`
+	Data   interface{} `json:"data"`
+}
+
+type DepositData struct {
+	Address  string      `json:"address"`
+	TxHash   string      `json:"tx_hash"`
+	MsgIndex int         `json:"msg_index"`
+	Coins    types.Coins `json:"coins"`
+	Memo     string      `json:"memo"`
+}
+
+type ProposalStatusData struct {
+	ProposalID  int64  `json:"proposal_id"`
+	SideChainId string `json:"side_chain_id,omitempty"`
+	OldStatus   string `json:"old_status"`
+	NewStatus   string `json:"new_status"`
+}
+
+// ProposalSource is used to poll the status of proposals seen by the indexer.
+// The rpc client satisfies it.
+type ProposalSource interface {
+	GetProposal(proposalId int64) (types.Proposal, error)
+	GetSideChainProposal(proposalId int64, sideChainId string) (types.Proposal, error)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<body>".
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type proposalKey struct {
+	id          int64
+	sideChainId string
+}
+
+type delivery struct {
+	endpoint Endpoint
+	body     []byte
+}
+
+type Dispatcher struct {
+	endpoints    []Endpoint
+	httpClient   *http.Client
+	maxAttempts  int
+	backoff      time.Duration
+	deadLetters  DeadLetterStore
+	onError      func(error)
+	proposals    ProposalSource
+	pollInterval int64
+
+	mtx     sync.Mutex
+	watched map[string]bool
+	tracked map[proposalKey]types.ProposalStatus
+
+	queues []chan delivery
+	wg     sync.WaitGroup
+}
+
+type Option func(*Dispatcher)
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.httpClient = client
+	}
+}
+
+// WithRetry sets how many times a delivery is attempted and the initial backoff,
+// which doubles after every failed attempt.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(d *Dispatcher) {
+		if maxAttempts > 0 {
+			d.maxAttempts = maxAttempts
+		}
+		if backoff >= 0 {
+			d.backoff = backoff
+		}
+	}
+}
+
+func WithDeadLetterStore(store DeadLetterStore) Option {
+	return func(d *Dispatcher) {
+		d.deadLetters = store
+	}
+}
+
+// WithErrorHandler sets the function the delivery workers report the deliveries
+// they could not store as dead letters to, which are lost otherwise.
+func WithErrorHandler(onError func(error)) Option {
+	return func(d *Dispatcher) {
+		d.onError = onError
+	}
+}
+
+// WithProposalSource enables proposal_status events. Proposals are polled every
+// pollInterval blocks until they are passed or rejected.
+func WithProposalSource(source ProposalSource, pollInterval int64) Option {
+	return func(d *Dispatcher) {
+		d.proposals = source
+		if pollInterval > 0 {
+			d.pollInterval = pollInterval
+		}
+	}
+}
+
+// NewDispatcher starts one delivery worker per endpoint, so every endpoint
+// receives its events in order. Call Close to flush the queues.
+func NewDispatcher(endpoints []Endpoint, options ...Option) *Dispatcher {
+	d := &Dispatcher{
+		endpoints:    endpoints,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		maxAttempts:  defaultMaxAttempts,
+		backoff:      defaultBackoff,
+		deadLetters:  NewMemoryDeadLetterStore(),
+		pollInterval: 1,
+		watched:      make(map[string]bool),
+		tracked:      make(map[proposalKey]types.ProposalStatus),
+	}
+	for _, option := range options {
+		option(d)
+	}
+	for range endpoints {
+		queue := make(chan delivery, defaultQueueSize)
+		d.queues = append(d.queues, queue)
+		d.wg.Add(1)
+		go func(queue chan delivery) {
+			defer d.wg.Done()
+			for item := range queue {
+				if err := d.deliver(item); err != nil && d.onError != nil {
+					d.onError(err)
+				}
+			}
+		}(queue)
+	}
+	return d
+}
+
+// Watch adds addresses whose incoming transfers are reported as deposits.
+func (d *Dispatcher) Watch(addrs ...types.AccAddress) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for _, addr := range addrs {
+		d.watched[addr.String()] = true
+	}
+}
+
+func (d *Dispatcher) Unwatch(addrs ...types.AccAddress) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for _, addr := range addrs {
+		delete(d.watched, addr.String())
+	}
+}
+
+// Handlers returns the indexer handlers that feed the dispatcher.
+func (d *Dispatcher) Handlers() indexer.Handlers {
+	return indexer.Handlers{
+		OnTransfer: d.onTransfer,
+		OnSwap:     d.onSwap,
+		OnProposal: d.onProposal,
+		OnBlock:    d.onBlock,
+	}
+}
+
+// Publish queues the payload for every endpoint subscribed to its type.
+func (d *Dispatcher) Publish(p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	for i, endpoint := range d.endpoints {
+		if endpoint.accepts(p.Type) {
+			d.queues[i] <- delivery{endpoint: endpoint, body: body}
+		}
+	}
+	return nil
+}
+
+// Redeliver replays every dead letter. Deliveries that fail again go back to
+// the dead-letter store, the first of them the store fails to take is returned.
+func (d *Dispatcher) Redeliver() error {
+	letters, err := d.deadLetters.Drain()
+	if err != nil {
+		return err
+	}
+	for _, letter := range letters {
+		endpoint := Endpoint{URL: letter.URL}
+		for _, e := range d.endpoints {
+			if e.URL == letter.URL {
+				endpoint = e
+				break
+			}
+		}
+		if putErr := d.deliver(delivery{endpoint: endpoint, body: letter.Body}); putErr != nil && err == nil {
+			err = putErr
+		}
+	}
+	return err
+}
+
+// Close waits until the queued deliveries are done. Publish must not be called afterwards.
+func (d *Dispatcher) Close() {
+	for _, queue := range d.queues {
+		close(queue)
+	}
+	d.wg.Wait()
+}
+
+// deliver posts item, retrying, and stores it as a dead letter when every
+// attempt failed. The error is the one of the dead-letter store.
+func (d *Dispatcher) deliver(item delivery) error {
+	backoff := d.backoff
+	var err error
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		if err = d.post(item); err == nil {
+			return nil
+		}
+		if attempt < d.maxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	if putErr := d.deadLetters.Put(DeadLetter{
+		URL:      item.endpoint.URL,
+		Body:     item.body,
+		Attempts: d.maxAttempts,
+		Error:    err.Error(),
+		FailedAt: time.Now(),
+	}); putErr != nil {
+		return fmt.Errorf("store the failed delivery to %s (%v) as a dead letter: %v", item.endpoint.URL, err, putErr)
+	}
+	return nil
+}
+
+func (d *Dispatcher) post(item delivery) error {
+	req, err := http.NewRequest(http.MethodPost, item.endpoint.URL, bytes.NewReader(item.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if item.endpoint.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign([]byte(item.endpoint.Secret), timestamp, item.body))
+	}
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status %d", item.endpoint.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+func (d *Dispatcher) onTransfer(e indexer.TransferEvent) error {
+	d.mtx.Lock()
+	var deposits []DepositData
+	for _, out := range e.Outputs {
+		addr := out.Address.String()
+		if d.watched[addr] {
+			deposits = append(deposits, DepositData{Address: addr, TxHash: e.TxHash, MsgIndex: e.MsgIndex, Coins: out.Coins, Memo: e.Memo})
+		}
+	}
+	d.mtx.Unlock()
+	for i, deposit := range deposits {
+		err := d.Publish(Payload{
+			ID:     fmt.Sprintf("%s:%s:%d:%d", EventDeposit, e.TxHash, e.MsgIndex, i),
+			Type:   EventDeposit,
+			Height: e.Height,
+			Time:   e.Time,
+			Data:   deposit,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) onSwap(e indexer.SwapEvent) error {
+	if e.Kind != indexer.SwapClaim {
+		return nil
+	}
+	return d.Publish(Payload{
+		ID:     fmt.Sprintf("%s:%s:%d", EventSwapClaim, e.TxHash, e.MsgIndex),
+		Type:   EventSwapClaim,
+		Height: e.Height,
+		Time:   e.Time,
+		Data:   e,
+	})
+}
+
+func (d *Dispatcher) onProposal(e indexer.ProposalEvent) error {
+	if d.proposals == nil || e.ProposalID == 0 {
+		return nil
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	key := proposalKey{id: e.ProposalID, sideChainId: e.SideChainId}
+	if _, ok := d.tracked[key]; !ok {
+		d.tracked[key] = types.StatusNil
+	}
+	return nil
+}
+
+func (d *Dispatcher) onBlock(e indexer.BlockEvent) error {
+	if d.proposals == nil || e.Height%d.pollInterval != 0 {
+		return nil
+	}
+	d.mtx.Lock()
+	tracked := make(map[proposalKey]types.ProposalStatus, len(d.tracked))
+	for key, status := range d.tracked {
+		tracked[key] = status
+	}
+	d.mtx.Unlock()
+
+	for key, old := range tracked {
+		var proposal types.Proposal
+		var err error
+		if key.sideChainId == "" {
+			proposal, err = d.proposals.GetProposal(key.id)
+		} else {
+			proposal, err = d.proposals.GetSideChainProposal(key.id, key.sideChainId)
+		}
+		if err != nil {
+			return err
+		}
+		status := proposal.GetStatus()
+		if status == old {
+			continue
+		}
+		final := status == types.StatusPassed || status == types.StatusRejected
+		d.mtx.Lock()
+		if final {
+			delete(d.tracked, key)
+		} else {
+			d.tracked[key] = status
+		}
+		d.mtx.Unlock()
+		// the first poll only records the current status unless the proposal is already decided
+		if old == types.StatusNil && !final {
+			continue
+		}
+		err = d.Publish(Payload{
+			ID:     fmt.Sprintf("%s:%s:%d:%s", EventProposalStatus, key.sideChainId, key.id, status),
+			Type:   EventProposalStatus,
+			Height: e.Height,
+			Time:   e.Time,
+			Data: ProposalStatusData{
+				ProposalID:  key.id,
+				SideChainId: key.sideChainId,
+				OldStatus:   old.String(),
+				NewStatus:   status.String(),
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
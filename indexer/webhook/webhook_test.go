@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/indexer"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func TestDepositDeliveryIsSignedAndRetried(t *testing.T) {
+	var calls int32
+	var signatureOK int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if Sign([]byte("secret"), r.Header.Get(TimestampHeader), body) == r.Header.Get(SignatureHeader) {
+			atomic.StoreInt32(&signatureOK, 1)
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	watched := types.AccAddress([]byte("watched-address-0001"))
+	d := NewDispatcher([]Endpoint{{URL: server.URL, Secret: "secret", Events: []EventType{EventDeposit}}}, WithRetry(3, 0))
+	d.Watch(watched)
+	err := d.Handlers().OnTransfer(indexer.TransferEvent{
+		Outputs: []msg.Output{{Address: watched, Coins: types.Coins{{Denom: "BNB", Amount: 1}}}},
+	})
+	assert.NoError(t, err)
+	d.Close()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&signatureOK))
+	letters, _ := d.deadLetters.Drain()
+	assert.Empty(t, letters)
+}
+
+func TestFailedDeliveryGoesToDeadLetters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	store := NewMemoryDeadLetterStore()
+	d := NewDispatcher([]Endpoint{{URL: server.URL}}, WithRetry(2, 0), WithDeadLetterStore(store))
+	assert.NoError(t, d.Handlers().OnSwap(indexer.SwapEvent{Kind: indexer.SwapClaim}))
+	assert.NoError(t, d.Handlers().OnSwap(indexer.SwapEvent{Kind: indexer.SwapRefund}))
+	d.Close()
+
+	letters, _ := store.Drain()
+	assert.Len(t, letters, 1)
+	assert.Equal(t, 2, letters[0].Attempts)
+}
+
+// failingStore fails to store dead letters, what it drains is set by the test.
+type failingStore struct {
+	letters []DeadLetter
+}
+
+func (s *failingStore) Put(DeadLetter) error {
+	return errors.New("disk full")
+}
+
+func (s *failingStore) Drain() ([]DeadLetter, error) {
+	letters := s.letters
+	s.letters = nil
+	return letters, nil
+}
+
+func TestDeadLetterStoreErrorsAreReported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	store := &failingStore{}
+	var reported []error
+	d := NewDispatcher([]Endpoint{{URL: server.URL}}, WithRetry(1, 0), WithDeadLetterStore(store),
+		WithErrorHandler(func(err error) { reported = append(reported, err) }))
+	assert.NoError(t, d.Handlers().OnSwap(indexer.SwapEvent{Kind: indexer.SwapClaim}))
+	d.Close()
+	if assert.Len(t, reported, 1, "the worker reports the lost delivery") {
+		assert.Contains(t, reported[0].Error(), "disk full")
+	}
+
+	store.letters = []DeadLetter{{URL: server.URL, Body: []byte("{}")}}
+	err := d.Redeliver()
+	if assert.Error(t, err, "Redeliver returns the lost delivery") {
+		assert.Contains(t, err.Error(), "disk full")
+	}
+}
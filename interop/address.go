@@ -0,0 +1,27 @@
+package interop
+
+import (
+	"fmt"
+
+	"github.com/binance-chain/go-sdk/common/bech32"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+const addrLen = 20
+
+// AddressToBech32 encodes addr with another chain's prefix, like "cosmos".
+func AddressToBech32(addr types.AccAddress, prefix string) (string, error) {
+	return bech32.ConvertAndEncode(prefix, addr.Bytes())
+}
+
+// AccAddressFromBech32 decodes an address of any prefix and returns the prefix with it.
+func AccAddressFromBech32(address string) (types.AccAddress, string, error) {
+	prefix, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(bz) != addrLen {
+		return nil, "", fmt.Errorf("address %s is %d bytes, expected %d", address, len(bz), addrLen)
+	}
+	return types.AccAddress(bz), prefix, nil
+}
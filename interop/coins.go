@@ -0,0 +1,38 @@
+package interop
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// Coin is a cosmos-sdk coin, the amount is an integer string. Amounts keep the
+// 1e8 scale of Binance Chain, so 1 BNB is "100000000".
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+type Coins []Coin
+
+func FromCoins(coins types.Coins) Coins {
+	out := make(Coins, 0, len(coins))
+	for _, c := range coins {
+		out = append(out, Coin{Denom: c.Denom, Amount: strconv.FormatInt(c.Amount, 10)})
+	}
+	return out
+}
+
+// ToCoins fails for amounts that do not fit in an int64.
+func ToCoins(coins Coins) (types.Coins, error) {
+	out := make(types.Coins, 0, len(coins))
+	for _, c := range coins {
+		amount, err := strconv.ParseInt(c.Amount, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount %q of %s: %v", c.Amount, c.Denom, err)
+		}
+		out = append(out, types.Coin{Denom: c.Denom, Amount: amount})
+	}
+	return out.Sort(), nil
+}
@@ -0,0 +1,5 @@
+// Package interop converts Binance Chain types to the json shapes of current
+// cosmos-sdk types and back, so tools built around cosmos-sdk can read Binance
+// Chain data. It mirrors the cosmos-sdk json encoding instead of importing
+// cosmos-sdk, whose dependency tree does not fit this module.
+package interop
@@ -0,0 +1,55 @@
+package interop
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/testvectors"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+func TestAddress(t *testing.T) {
+	addr, prefix, err := AccAddressFromBech32(testvectors.Keys[0].Address)
+	assert.NoError(t, err)
+	assert.Equal(t, "bnb", prefix)
+	cosmos, err := AddressToBech32(addr, "cosmos")
+	assert.NoError(t, err)
+	back, prefix, err := AccAddressFromBech32(cosmos)
+	assert.NoError(t, err)
+	assert.Equal(t, "cosmos", prefix)
+	assert.Equal(t, addr, back)
+}
+
+func TestTxRoundTrip(t *testing.T) {
+	types.Network = types.ProdNetwork
+	for _, v := range testvectors.Txs {
+		bz, err := hex.DecodeString(v.SignedTxHex)
+		assert.NoError(t, err)
+		var stdTx tx.StdTx
+		assert.NoError(t, tx.Cdc.UnmarshalBinaryLengthPrefixed(bz, &stdTx))
+
+		converted, err := Converter{}.FromStdTx(stdTx)
+		assert.NoError(t, err, v.Name)
+		out, err := json.Marshal(converted)
+		assert.NoError(t, err)
+		var decoded Tx
+		assert.NoError(t, json.Unmarshal(out, &decoded))
+		if v.Name != "send" {
+			assert.Contains(t, decoded.Body.Messages[0].TypeURL, "/binance.")
+			continue
+		}
+		assert.Equal(t, TypeURLMsgSend, decoded.Body.Messages[0].TypeURL)
+
+		back, err := Converter{}.ToStdTx(&decoded)
+		assert.NoError(t, err)
+		back.Signatures[0].AccountNumber = v.AccountNumber
+		back.Data = stdTx.Data
+		rebuilt, err := tx.Cdc.MarshalBinaryLengthPrefixed(back)
+		assert.NoError(t, err)
+		assert.Equal(t, v.SignedTxHex, hex.EncodeToString(rebuilt))
+	}
+}
@@ -0,0 +1,297 @@
+package interop
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+const (
+	TypeURLMsgSend      = "/cosmos.bank.v1beta1.MsgSend"
+	TypeURLMsgMultiSend = "/cosmos.bank.v1beta1.MsgMultiSend"
+	TypeURLSecp256k1    = "/cosmos.crypto.secp256k1.PubKey"
+
+	// SignModeLegacyAminoJSON is the closest cosmos-sdk sign mode to the json sign
+	// bytes of Binance Chain.
+	SignModeLegacyAminoJSON = "SIGN_MODE_LEGACY_AMINO_JSON"
+)
+
+// Any is a protobuf Any in its json form: the message fields next to "@type".
+type Any struct {
+	TypeURL string
+	Value   json.RawMessage
+}
+
+func (a Any) MarshalJSON() ([]byte, error) {
+	fields := map[string]json.RawMessage{}
+	if len(a.Value) > 0 {
+		if err := json.Unmarshal(a.Value, &fields); err != nil {
+			return nil, err
+		}
+	}
+	typeURL, err := json.Marshal(a.TypeURL)
+	if err != nil {
+		return nil, err
+	}
+	fields["@type"] = typeURL
+	return json.Marshal(fields)
+}
+
+func (a *Any) UnmarshalJSON(bz []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(bz, &fields); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields["@type"], &a.TypeURL); err != nil {
+		return fmt.Errorf("invalid @type: %v", err)
+	}
+	delete(fields, "@type")
+	value, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	a.Value = value
+	return nil
+}
+
+// Tx is the json form of a cosmos-sdk cosmos.tx.v1beta1.Tx.
+type Tx struct {
+	Body       TxBody   `json:"body"`
+	AuthInfo   AuthInfo `json:"auth_info"`
+	Signatures [][]byte `json:"signatures"`
+}
+
+type TxBody struct {
+	Messages                    []Any  `json:"messages"`
+	Memo                        string `json:"memo"`
+	TimeoutHeight               string `json:"timeout_height"`
+	ExtensionOptions            []Any  `json:"extension_options"`
+	NonCriticalExtensionOptions []Any  `json:"non_critical_extension_options"`
+}
+
+type AuthInfo struct {
+	SignerInfos []SignerInfo `json:"signer_infos"`
+	Fee         Fee          `json:"fee"`
+}
+
+type SignerInfo struct {
+	PublicKey *Any     `json:"public_key"`
+	ModeInfo  ModeInfo `json:"mode_info"`
+	Sequence  string   `json:"sequence"`
+}
+
+type ModeInfo struct {
+	Single struct {
+		Mode string `json:"mode"`
+	} `json:"single"`
+}
+
+// Fee is always empty, Binance Chain fees are fixed per msg type and not part of the tx.
+type Fee struct {
+	Amount   Coins  `json:"amount"`
+	GasLimit string `json:"gas_limit"`
+	Payer    string `json:"payer"`
+	Granter  string `json:"granter"`
+}
+
+type msgSend struct {
+	FromAddress string `json:"from_address"`
+	ToAddress   string `json:"to_address"`
+	Amount      Coins  `json:"amount"`
+}
+
+type bankIO struct {
+	Address string `json:"address"`
+	Coins   Coins  `json:"coins"`
+}
+
+type msgMultiSend struct {
+	Inputs  []bankIO `json:"inputs"`
+	Outputs []bankIO `json:"outputs"`
+}
+
+type pubKey struct {
+	Key []byte `json:"key"`
+}
+
+// Converter converts txs between Binance Chain and cosmos-sdk. Transfers become
+// bank messages, every other msg keeps its Binance Chain json under a
+// "/binance.<route>.<type>" type url and can not be converted back. The Data
+// field of StdTx has no cosmos-sdk counterpart and is dropped.
+type Converter struct {
+	// Prefix is the bech32 prefix of addresses in bank messages, empty keeps the
+	// prefix of the current network.
+	Prefix string
+}
+
+func (c Converter) address(addr types.AccAddress) (string, error) {
+	if c.Prefix == "" {
+		return addr.String(), nil
+	}
+	return AddressToBech32(addr, c.Prefix)
+}
+
+func (c Converter) FromStdTx(stdTx tx.StdTx) (*Tx, error) {
+	t := &Tx{
+		Body:     TxBody{Memo: stdTx.Memo, TimeoutHeight: "0", Messages: []Any{}},
+		AuthInfo: AuthInfo{Fee: Fee{Amount: Coins{}, GasLimit: "0"}, SignerInfos: []SignerInfo{}},
+	}
+	for _, m := range stdTx.Msgs {
+		a, err := c.fromMsg(m)
+		if err != nil {
+			return nil, err
+		}
+		t.Body.Messages = append(t.Body.Messages, a)
+	}
+	for _, sig := range stdTx.Signatures {
+		info := SignerInfo{Sequence: strconv.FormatInt(sig.Sequence, 10)}
+		info.ModeInfo.Single.Mode = SignModeLegacyAminoJSON
+		if pk, ok := sig.PubKey.(secp256k1.PubKeySecp256k1); ok {
+			value, err := json.Marshal(pubKey{Key: pk[:]})
+			if err != nil {
+				return nil, err
+			}
+			info.PublicKey = &Any{TypeURL: TypeURLSecp256k1, Value: value}
+		} else if sig.PubKey != nil {
+			return nil, fmt.Errorf("unsupported public key type %T", sig.PubKey)
+		}
+		t.AuthInfo.SignerInfos = append(t.AuthInfo.SignerInfos, info)
+		t.Signatures = append(t.Signatures, sig.Signature)
+	}
+	return t, nil
+}
+
+func (c Converter) fromMsg(m msg.Msg) (Any, error) {
+	send, ok := m.(msg.SendMsg)
+	if !ok {
+		return Any{TypeURL: fmt.Sprintf("/binance.%s.%s", m.Route(), m.Type()), Value: m.GetSignBytes()}, nil
+	}
+	if len(send.Inputs) == 1 && len(send.Outputs) == 1 && send.Inputs[0].Coins.IsEqual(send.Outputs[0].Coins) {
+		from, err := c.address(send.Inputs[0].Address)
+		if err != nil {
+			return Any{}, err
+		}
+		to, err := c.address(send.Outputs[0].Address)
+		if err != nil {
+			return Any{}, err
+		}
+		value, err := json.Marshal(msgSend{FromAddress: from, ToAddress: to, Amount: FromCoins(send.Outputs[0].Coins)})
+		return Any{TypeURL: TypeURLMsgSend, Value: value}, err
+	}
+	multi := msgMultiSend{}
+	for _, in := range send.Inputs {
+		addr, err := c.address(in.Address)
+		if err != nil {
+			return Any{}, err
+		}
+		multi.Inputs = append(multi.Inputs, bankIO{Address: addr, Coins: FromCoins(in.Coins)})
+	}
+	for _, out := range send.Outputs {
+		addr, err := c.address(out.Address)
+		if err != nil {
+			return Any{}, err
+		}
+		multi.Outputs = append(multi.Outputs, bankIO{Address: addr, Coins: FromCoins(out.Coins)})
+	}
+	value, err := json.Marshal(multi)
+	return Any{TypeURL: TypeURLMsgMultiSend, Value: value}, err
+}
+
+// ToStdTx converts a tx with bank messages back. Cosmos-sdk txs carry no account
+// numbers, they have to be set on the signatures before the tx can be verified.
+func (c Converter) ToStdTx(t *Tx) (tx.StdTx, error) {
+	stdTx := tx.StdTx{Memo: t.Body.Memo, Source: tx.Source}
+	for _, a := range t.Body.Messages {
+		m, err := toMsg(a)
+		if err != nil {
+			return tx.StdTx{}, err
+		}
+		stdTx.Msgs = append(stdTx.Msgs, m)
+	}
+	if len(t.Signatures) != len(t.AuthInfo.SignerInfos) {
+		return tx.StdTx{}, fmt.Errorf("%d signatures for %d signers", len(t.Signatures), len(t.AuthInfo.SignerInfos))
+	}
+	for i, info := range t.AuthInfo.SignerInfos {
+		sequence, err := strconv.ParseInt(info.Sequence, 10, 64)
+		if err != nil {
+			return tx.StdTx{}, fmt.Errorf("invalid sequence %q: %v", info.Sequence, err)
+		}
+		sig := tx.StdSignature{Signature: t.Signatures[i], Sequence: sequence}
+		if info.PublicKey != nil {
+			if info.PublicKey.TypeURL != TypeURLSecp256k1 {
+				return tx.StdTx{}, fmt.Errorf("unsupported public key type %s", info.PublicKey.TypeURL)
+			}
+			var pk pubKey
+			if err := json.Unmarshal(info.PublicKey.Value, &pk); err != nil {
+				return tx.StdTx{}, err
+			}
+			var key secp256k1.PubKeySecp256k1
+			if len(pk.Key) != len(key) {
+				return tx.StdTx{}, fmt.Errorf("invalid secp256k1 public key length %d", len(pk.Key))
+			}
+			copy(key[:], pk.Key)
+			sig.PubKey = key
+		}
+		stdTx.Signatures = append(stdTx.Signatures, sig)
+	}
+	return stdTx, nil
+}
+
+func toMsg(a Any) (msg.Msg, error) {
+	switch a.TypeURL {
+	case TypeURLMsgSend:
+		var m msgSend
+		if err := json.Unmarshal(a.Value, &m); err != nil {
+			return nil, err
+		}
+		from, err := toIO(bankIO{Address: m.FromAddress, Coins: m.Amount})
+		if err != nil {
+			return nil, err
+		}
+		to, err := toIO(bankIO{Address: m.ToAddress, Coins: m.Amount})
+		if err != nil {
+			return nil, err
+		}
+		return msg.SendMsg{Inputs: []msg.Input{msg.Input(from)}, Outputs: []msg.Output{to}}, nil
+	case TypeURLMsgMultiSend:
+		var m msgMultiSend
+		if err := json.Unmarshal(a.Value, &m); err != nil {
+			return nil, err
+		}
+		send := msg.SendMsg{}
+		for _, in := range m.Inputs {
+			io, err := toIO(in)
+			if err != nil {
+				return nil, err
+			}
+			send.Inputs = append(send.Inputs, msg.Input(io))
+		}
+		for _, out := range m.Outputs {
+			io, err := toIO(out)
+			if err != nil {
+				return nil, err
+			}
+			send.Outputs = append(send.Outputs, io)
+		}
+		return send, nil
+	}
+	return nil, fmt.Errorf("msg type %s can not be converted", a.TypeURL)
+}
+
+func toIO(io bankIO) (msg.Output, error) {
+	addr, _, err := AccAddressFromBech32(io.Address)
+	if err != nil {
+		return msg.Output{}, err
+	}
+	coins, err := ToCoins(io.Coins)
+	if err != nil {
+		return msg.Output{}, err
+	}
+	return msg.Output{Address: addr, Coins: coins}, nil
+}
@@ -0,0 +1,294 @@
+// Package inventory keeps the per-asset inventory of an account current from
+// its user data stream: fills of its orders and transfers in and out. Limits
+// on the inventory of an asset report breaches as they happen, for market
+// makers to stop quoting before the exchange or the risk desk does it for them.
+package inventory
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+// maxSeenFills bounds the fills, and the blocks whose commission was charged,
+// remembered to drop those a reconnecting stream delivers again.
+const maxSeenFills = 10000
+
+// Limit bounds the inventory of an asset, in units of 1e-8. A Max of zero
+// leaves it unbounded above, a Min of zero is only breached by debts.
+type Limit struct {
+	Asset string
+	Min   int64
+	Max   int64
+}
+
+// Breach is reported when the inventory of an asset leaves its limit, and
+// again with Resolved set when it is back within.
+type Breach struct {
+	Asset    string
+	Balance  int64
+	Limit    Limit
+	Above    bool
+	Resolved bool
+	Time     time.Time
+}
+
+type Config struct {
+	// Address is the account whose stream is tracked.
+	Address string
+	Limits  []Limit
+	// OnBreach is called outside of the lock of the tracker, it may read it.
+	OnBreach func(Breach)
+	// OnFill gets every fill once it is applied, e.g. for pnl accounting. The
+	// commission of a block is in the Fees of its first fill only.
+	OnFill func(types.Fill)
+	// OnError gets the fills of the stream that can't be accounted, of a pair
+	// or side it can't read.
+	OnError func(error)
+}
+
+// Tracker holds the inventory. It is safe for concurrent use.
+type Tracker struct {
+	cfg    Config
+	limits map[string]Limit
+
+	mtx      sync.Mutex
+	balances map[string]int64
+	breached map[string]bool
+	seen     map[string]bool
+	seenFIFO []string
+}
+
+func NewTracker(cfg Config) *Tracker {
+	t := &Tracker{cfg: cfg, limits: map[string]Limit{}, balances: map[string]int64{}, breached: map[string]bool{}, seen: map[string]bool{}}
+	for _, limit := range cfg.Limits {
+		t.limits[limit.Asset] = limit
+	}
+	return t
+}
+
+// Balance returns the inventory of asset.
+func (t *Tracker) Balance(asset string) int64 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.balances[asset]
+}
+
+// Balances returns a copy of the inventory of every asset seen.
+func (t *Tracker) Balances() map[string]int64 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	balances := make(map[string]int64, len(t.balances))
+	for asset, balance := range t.balances {
+		balances[asset] = balance
+	}
+	return balances
+}
+
+// Sync sets the inventory to the balances of the account, free, locked in
+// orders and frozen. Start from it before subscribing, and after the stream
+// was down for longer than a reconnect.
+func (t *Tracker) Sync(account *types.BalanceAccount) {
+	deltas := map[string]int64{}
+	t.mtx.Lock()
+	for asset := range t.balances {
+		deltas[asset] = -t.balances[asset]
+	}
+	for _, b := range account.Balances {
+		deltas[b.Symbol] += b.Free.ToInt64() + b.Locked.ToInt64() + b.Frozen.ToInt64()
+	}
+	breaches := t.apply(deltas, time.Now())
+	t.mtx.Unlock()
+	t.report(breaches)
+}
+
+// Handlers returns the user data handlers that feed the tracker, to be merged
+// with the handlers of the caller.
+func (t *Tracker) Handlers() websocket.UserDataHandlers {
+	return websocket.UserDataHandlers{OnOrders: t.ProcessOrders, OnTransfer: t.ProcessTransfer}
+}
+
+// Subscribe feeds the tracker with the user data stream of Config.Address.
+func (t *Tracker) Subscribe(ws websocket.WSClient, quit chan struct{}, onError func(err error)) error {
+	handlers := t.Handlers()
+	handlers.OnError = onError
+	return ws.SubscribeUserDataEvent(t.cfg.Address, quit, handlers)
+}
+
+// ProcessOrders applies the fills among order updates. The commission of an
+// update is what the account paid for all its trades of the block, repeated on
+// every update of the block, so it goes with the first fill of each block.
+func (t *Tracker) ProcessOrders(events []*websocket.OrderEvent) {
+	for _, ev := range events {
+		if ev.LastExecutedQty <= 0 {
+			continue
+		}
+		commissionKey := fmt.Sprintf("commission/%d", ev.TransactionTime)
+		fees := ParseCommission(ev.CommissionAmount)
+		t.mtx.Lock()
+		if t.seen[commissionKey] {
+			fees = nil
+		}
+		t.mtx.Unlock()
+		fill := types.Fill{
+			Symbol:   ev.Symbol,
+			OrderID:  ev.OrderID,
+			TradeID:  ev.TradeID,
+			Side:     ev.Side,
+			Price:    ev.LastExecutedPrice.ToInt64(),
+			Quantity: ev.LastExecutedQty.ToInt64(),
+			Fees:     fees,
+			Time:     time.Unix(0, ev.EventTime*int64(time.Millisecond)),
+		}
+		if err := t.ApplyFill(fill); err != nil {
+			if t.cfg.OnError != nil {
+				t.cfg.OnError(err)
+			}
+			continue
+		}
+		if len(fees) > 0 {
+			t.mtx.Lock()
+			t.remember(commissionKey)
+			t.mtx.Unlock()
+		}
+	}
+}
+
+// ApplyFill adds a fill to the inventory, a fill seen before is ignored.
+func (t *Tracker) ApplyFill(fill types.Fill) error {
+	deltas, err := FillDeltas(fill)
+	if err != nil {
+		return err
+	}
+	t.mtx.Lock()
+	if !t.remember(fill.OrderID + "/" + fill.TradeID) {
+		t.mtx.Unlock()
+		return nil
+	}
+	breaches := t.apply(deltas, fill.Time)
+	t.mtx.Unlock()
+	if t.cfg.OnFill != nil {
+		t.cfg.OnFill(fill)
+	}
+	t.report(breaches)
+	return nil
+}
+
+// ProcessTransfer applies the coins the account sent or received.
+func (t *Tracker) ProcessTransfer(ev *websocket.TransferEvent) {
+	deltas := map[string]int64{}
+	for _, out := range ev.To {
+		for _, coin := range out.Coins {
+			if ev.From == t.cfg.Address {
+				deltas[coin.Asset] -= coin.Amount.ToInt64()
+			}
+			if out.Address == t.cfg.Address {
+				deltas[coin.Asset] += coin.Amount.ToInt64()
+			}
+		}
+	}
+	t.mtx.Lock()
+	breaches := t.apply(deltas, time.Now())
+	t.mtx.Unlock()
+	t.report(breaches)
+}
+
+// remember marks key seen and reports whether it is new, t.mtx must be held.
+func (t *Tracker) remember(key string) bool {
+	if t.seen[key] {
+		return false
+	}
+	t.seen[key] = true
+	t.seenFIFO = append(t.seenFIFO, key)
+	if len(t.seenFIFO) > maxSeenFills {
+		delete(t.seen, t.seenFIFO[0])
+		t.seenFIFO = t.seenFIFO[1:]
+	}
+	return true
+}
+
+// apply changes the balances and returns the breaches it caused or resolved,
+// t.mtx must be held.
+func (t *Tracker) apply(deltas map[string]int64, at time.Time) []Breach {
+	var breaches []Breach
+	for asset, delta := range deltas {
+		if delta == 0 {
+			continue
+		}
+		balance := t.balances[asset] + delta
+		t.balances[asset] = balance
+		limit, ok := t.limits[asset]
+		if !ok {
+			continue
+		}
+		above := limit.Max != 0 && balance > limit.Max
+		breached := above || balance < limit.Min
+		if breached == t.breached[asset] {
+			continue
+		}
+		t.breached[asset] = breached
+		breaches = append(breaches, Breach{Asset: asset, Balance: balance, Limit: limit, Above: above, Resolved: !breached, Time: at})
+	}
+	return breaches
+}
+
+func (t *Tracker) report(breaches []Breach) {
+	if t.cfg.OnBreach == nil {
+		return
+	}
+	for _, b := range breaches {
+		t.cfg.OnBreach(b)
+	}
+}
+
+// FillDeltas returns what a fill changes in the inventory: the base asset
+// bought or sold, the quote asset paid or received, and the fees.
+func FillDeltas(fill types.Fill) (map[string]int64, error) {
+	parts := strings.Split(fill.Symbol, "_")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("the pair %s should be in format 'symbol1_symbol2'", fill.Symbol)
+	}
+	base, quote := parts[0], parts[1]
+	amount, err := types.QuoteAmount(fill.Quantity, fill.Price, types.RoundDown)
+	if err != nil {
+		return nil, err
+	}
+	deltas := map[string]int64{}
+	switch fill.Side {
+	case msg.OrderSide.BUY:
+		deltas[base] += fill.Quantity
+		deltas[quote] -= amount
+	case msg.OrderSide.SELL:
+		deltas[base] -= fill.Quantity
+		deltas[quote] += amount
+	default:
+		return nil, fmt.Errorf("invalid side %d of fill %s", fill.Side, fill.TradeID)
+	}
+	for _, fee := range fill.Fees {
+		deltas[fee.Denom] -= fee.Amount
+	}
+	return deltas, nil
+}
+
+// ParseCommission reads the commission of an order update, "BNB:0.00100000"
+// and several such separated by ";". Unreadable parts are left out.
+func ParseCommission(commission string) types.Coins {
+	var fees types.Coins
+	for _, part := range strings.Split(commission, ";") {
+		kv := strings.SplitN(strings.TrimSpace(part), ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		amount, err := types.Fixed8DecodeString(kv[1])
+		if err != nil || amount <= 0 {
+			continue
+		}
+		fees = append(fees, types.Coin{Denom: kv[0], Amount: amount.ToInt64()})
+	}
+	return fees
+}
@@ -0,0 +1,95 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func TestTracker(t *testing.T) {
+	var breaches []Breach
+	var fills []types.Fill
+	tracker := NewTracker(Config{
+		Address:  "bnb1me",
+		Limits:   []Limit{{Asset: "XYZ-000", Max: 150e8}, {Asset: "BNB", Min: 1e8}},
+		OnBreach: func(b Breach) { breaches = append(breaches, b) },
+		OnFill:   func(f types.Fill) { fills = append(fills, f) },
+	})
+	tracker.Sync(&types.BalanceAccount{Balances: []types.TokenBalance{
+		{Symbol: "BNB", Free: 5e8, Locked: 1e8},
+		{Symbol: "XYZ-000", Free: 100e8},
+	}})
+	assert.Equal(t, int64(6e8), tracker.Balance("BNB"))
+	assert.Empty(t, breaches)
+
+	buy := &websocket.OrderEvent{Symbol: "XYZ-000_BNB", Side: msg.OrderSide.BUY, OrderID: "O-1", TradeID: "T-1",
+		LastExecutedQty: 60e8, LastExecutedPrice: 0.05e8, CommissionAmount: "BNB:0.00100000;", EventTime: 1000}
+	ack := &websocket.OrderEvent{Symbol: "XYZ-000_BNB", Side: msg.OrderSide.SELL, OrderID: "O-2"}
+	tracker.ProcessOrders([]*websocket.OrderEvent{buy, ack})
+	// delivered again after a reconnect
+	tracker.ProcessOrders([]*websocket.OrderEvent{buy})
+	assert.Equal(t, int64(160e8), tracker.Balance("XYZ-000"))
+	assert.Equal(t, int64(6e8-3e8-0.001e8), tracker.Balance("BNB"))
+	assert.Len(t, fills, 1)
+	assert.Equal(t, types.Coins{{Denom: "BNB", Amount: 0.001e8}}, fills[0].Fees)
+	assert.Len(t, breaches, 1)
+	assert.Equal(t, Breach{Asset: "XYZ-000", Balance: 160e8, Limit: Limit{Asset: "XYZ-000", Max: 150e8}, Above: true, Time: fills[0].Time}, breaches[0])
+
+	tracker.ProcessTransfer(&websocket.TransferEvent{From: "bnb1me", To: []websocket.TransferOutput{
+		{Address: "bnb1other", Coins: []websocket.TransferCoin{{Asset: "XYZ-000", Amount: 20e8}, {Asset: "BNB", Amount: 2.5e8}}},
+	}})
+	assert.Equal(t, int64(140e8), tracker.Balance("XYZ-000"))
+	assert.Len(t, breaches, 3)
+	for _, b := range breaches[1:] {
+		switch b.Asset {
+		case "XYZ-000":
+			assert.True(t, b.Resolved)
+		case "BNB":
+			assert.False(t, b.Resolved)
+			assert.False(t, b.Above)
+		}
+	}
+
+	tracker.ProcessTransfer(&websocket.TransferEvent{From: "bnb1other", To: []websocket.TransferOutput{
+		{Address: "bnb1me", Coins: []websocket.TransferCoin{{Asset: "BNB", Amount: 10e8}}},
+	}})
+	assert.Len(t, breaches, 4)
+	assert.True(t, breaches[3].Resolved)
+	assert.Equal(t, map[string]int64{"BNB": 10.499e8, "XYZ-000": 140e8}, tracker.Balances())
+
+	var errs []error
+	tracker = NewTracker(Config{OnError: func(err error) { errs = append(errs, err) }})
+	tracker.ProcessOrders([]*websocket.OrderEvent{{Symbol: "XYZ", LastExecutedQty: 1, LastExecutedPrice: 1}})
+	assert.Len(t, errs, 1)
+}
+
+func TestCommissionOncePerBlock(t *testing.T) {
+	var fills []types.Fill
+	tracker := NewTracker(Config{Address: "bnb1me", OnFill: func(f types.Fill) { fills = append(fills, f) }})
+	fill := func(height int64, orderID string) *websocket.OrderEvent {
+		return &websocket.OrderEvent{Symbol: "XYZ-000_BNB", Side: msg.OrderSide.BUY, OrderID: orderID, TradeID: orderID + "-t",
+			LastExecutedQty: 1e8, LastExecutedPrice: 1e8, CommissionAmount: "BNB:0.00200000;", TransactionTime: height}
+	}
+	// the commission of the block is on both orders, it is charged once
+	tracker.ProcessOrders([]*websocket.OrderEvent{fill(5, "O-1"), fill(5, "O-2")})
+	tracker.ProcessOrders([]*websocket.OrderEvent{fill(5, "O-1")})
+	assert.Equal(t, int64(-2e8-0.002e8), tracker.Balance("BNB"))
+	tracker.ProcessOrders([]*websocket.OrderEvent{fill(6, "O-3")})
+	assert.Equal(t, int64(-3e8-0.004e8), tracker.Balance("BNB"))
+	if assert.Len(t, fills, 3) {
+		assert.Len(t, fills[0].Fees, 1)
+		assert.Empty(t, fills[1].Fees)
+		assert.Len(t, fills[2].Fees, 1)
+	}
+}
+
+func TestParseCommission(t *testing.T) {
+	assert.Equal(t, types.Coins{{Denom: "BNB", Amount: 12345}, {Denom: "XYZ-000", Amount: 1e8}},
+		ParseCommission("BNB:0.00012345;XYZ-000:1.00000000;"))
+	assert.Empty(t, ParseCommission("0"))
+	assert.Empty(t, ParseCommission(""))
+}
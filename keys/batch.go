@@ -0,0 +1,107 @@
+package keys
+
+import (
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// BatchVerifier verifies the signatures of many transactions concurrently, as
+// auditors and light verifiers checking whole blocks need. It parses each
+// secp256k1 public key once, however many transactions of the batch it signed,
+// which is the bulk of the work besides the verification itself.
+//
+// A BatchVerifier may be reused across blocks, its key cache then keeps
+// growing with the distinct signers seen; create a new one to drop it.
+type BatchVerifier struct {
+	workers int
+
+	mtx     sync.RWMutex
+	pubKeys map[secp256k1.PubKeySecp256k1]*btcec.PublicKey
+}
+
+// NewBatchVerifier verifies with workers goroutines, runtime.NumCPU() when
+// workers is not positive.
+func NewBatchVerifier(workers int) *BatchVerifier {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return &BatchVerifier{workers: workers, pubKeys: make(map[secp256k1.PubKeySecp256k1]*btcec.PublicKey)}
+}
+
+// VerifyStdTxs verifies every signature of txs for the given chain. The result
+// holds the error of each tx by index, nil when all its signatures are valid,
+// like VerifyStdTx would return.
+func (v *BatchVerifier) VerifyStdTxs(chainID string, txs []tx.StdTx) []error {
+	errs := make([]error, len(txs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	workers := v.workers
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = verifyStdTx(chainID, txs[i], v.verifySignature)
+			}
+		}()
+	}
+	for i := range txs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return errs
+}
+
+// VerifyStdTxs verifies txs with a throwaway BatchVerifier using all cpus.
+func VerifyStdTxs(chainID string, txs []tx.StdTx) []error {
+	return NewBatchVerifier(0).VerifyStdTxs(chainID, txs)
+}
+
+// verifySignature is VerifySignature with the parsed key taken from the cache.
+func (v *BatchVerifier) verifySignature(pubKey crypto.PubKey, signBytes []byte, sig []byte) error {
+	secpKey, ok := pubKey.(secp256k1.PubKeySecp256k1)
+	if !ok || len(sig) != signatureLength {
+		return VerifySignature(pubKey, signBytes, sig)
+	}
+	if !IsLowS(sig) {
+		return fmt.Errorf("signature is not in canonical low-S form")
+	}
+	pub, err := v.parsePubKey(secpKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %v", err)
+	}
+	signature := btcec.Signature{R: new(big.Int).SetBytes(sig[:32]), S: new(big.Int).SetBytes(sig[32:])}
+	if !signature.Verify(crypto.Sha256(signBytes), pub) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
+
+func (v *BatchVerifier) parsePubKey(key secp256k1.PubKeySecp256k1) (*btcec.PublicKey, error) {
+	v.mtx.RLock()
+	pub, ok := v.pubKeys[key]
+	v.mtx.RUnlock()
+	if ok {
+		return pub, nil
+	}
+	pub, err := btcec.ParsePubKey(key[:], btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	v.mtx.Lock()
+	v.pubKeys[key] = pub
+	v.mtx.Unlock()
+	return pub, nil
+}
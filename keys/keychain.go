@@ -0,0 +1,127 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// DefaultKeychainService is the service name key managers are saved under in
+// the keychain unless another one is given.
+const DefaultKeychainService = "binance-chain-go-sdk"
+
+// ErrKeychainItemNotFound is returned when the keychain has no secret for the
+// service and account.
+var ErrKeychainItemNotFound = errors.New("keychain item not found")
+
+// Keychain is a secret store of the operating system, which keeps secrets
+// encrypted at rest and unlocks them for the logged in user. Secrets are
+// identified by a service, the application, and an account within it.
+type Keychain interface {
+	Set(service, account string, secret []byte) error
+	Get(service, account string) ([]byte, error)
+	Delete(service, account string) error
+}
+
+// OSKeychain returns the keychain of the platform: the login keychain on
+// macOS, the Secret Service (gnome-keyring, KWallet) through libsecret's
+// secret-tool on Linux, and files encrypted with DPAPI for the current user on
+// Windows.
+func OSKeychain() (Keychain, error) {
+	return osKeychain()
+}
+
+// SaveToKeychain stores the key of km under account, as its mnemonic when it
+// was recovered from one on the default path and as its private key
+// otherwise. Ledger and external signer keys cannot be saved.
+func SaveToKeychain(kc Keychain, service, account string, km KeyManager) error {
+	if err := checkKeychainName(service, account); err != nil {
+		return err
+	}
+	var secret []byte
+	if mnemonic, err := km.ExportAsMnemonic(); err == nil {
+		if addr, err := secretAddress([]byte(mnemonic)); err == nil && bytes.Equal(addr, km.GetAddr()) {
+			secret = []byte(mnemonic)
+		}
+	}
+	if secret == nil {
+		priKey, err := km.ExportAsPrivateKey()
+		if err != nil {
+			return err
+		}
+		if secret, err = hex.DecodeString(priKey); err != nil {
+			return err
+		}
+	}
+	defer wipe(secret)
+	return kc.Set(service, account, secret)
+}
+
+// NewKeychainKeyManager unlocks the key saved with SaveToKeychain. The
+// platform may ask the user to allow the access.
+func NewKeychainKeyManager(kc Keychain, service, account string) (KeyManager, error) {
+	if err := checkKeychainName(service, account); err != nil {
+		return nil, err
+	}
+	secret, err := kc.Get(service, account)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(secret)
+	k := keyManager{}
+	if err := k.recoveryFromSecret(secret); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// checkKeychainName keeps service and account safe to pass to the platform
+// tools, which take them on a command line.
+func checkKeychainName(service, account string) error {
+	if service == "" || account == "" {
+		return fmt.Errorf("keychain service and account are required")
+	}
+	if strings.ContainsAny(service+account, "\"\\\n\r\x00") {
+		return fmt.Errorf("keychain service and account may not contain quotes, backslashes or line breaks")
+	}
+	return nil
+}
+
+// keychainToolError is a platform tool that exited with a failure.
+type keychainToolError struct {
+	tool   string
+	code   int
+	stderr string
+}
+
+func (e *keychainToolError) Error() string {
+	return fmt.Sprintf("%s exited with %d: %s", e.tool, e.code, e.stderr)
+}
+
+// exitCode returns the exit code of a failed platform tool, -1 for other errors.
+func exitCode(err error) int {
+	if toolErr, ok := err.(*keychainToolError); ok {
+		return toolErr.code
+	}
+	return -1
+}
+
+// runKeychainTool runs a platform tool with stdin, which carries the secret so
+// that it never shows up in the process list, and returns its trimmed output.
+func runKeychainTool(stdin string, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return nil, &keychainToolError{tool: name, code: exitErr.ExitCode(), stderr: strings.TrimSpace(stderr.String())}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(stdout.Bytes()), nil
+}
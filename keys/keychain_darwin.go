@@ -0,0 +1,52 @@
+package keys
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// securityItemNotFound is the exit code of security(1) for a missing item.
+const securityItemNotFound = 44
+
+// darwinKeychain keeps secrets as generic passwords of the login keychain,
+// through security(1). Commands are fed on stdin in interactive mode so the
+// secret is not passed as an argument.
+type darwinKeychain struct{}
+
+func osKeychain() (Keychain, error) {
+	return darwinKeychain{}, nil
+}
+
+func (darwinKeychain) Set(service, account string, secret []byte) error {
+	if err := checkKeychainName(service, account); err != nil {
+		return err
+	}
+	command := fmt.Sprintf("add-generic-password -U -s \"%s\" -a \"%s\" -w \"%s\"\n", service, account, hex.EncodeToString(secret))
+	_, err := runKeychainTool(command, "/usr/bin/security", "-i")
+	return err
+}
+
+func (darwinKeychain) Get(service, account string) ([]byte, error) {
+	if err := checkKeychainName(service, account); err != nil {
+		return nil, err
+	}
+	out, err := runKeychainTool("", "/usr/bin/security", "find-generic-password", "-s", service, "-a", account, "-w")
+	if exitCode(err) == securityItemNotFound {
+		return nil, ErrKeychainItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(string(out))
+}
+
+func (darwinKeychain) Delete(service, account string) error {
+	if err := checkKeychainName(service, account); err != nil {
+		return err
+	}
+	_, err := runKeychainTool("", "/usr/bin/security", "delete-generic-password", "-s", service, "-a", account)
+	if exitCode(err) == securityItemNotFound {
+		return ErrKeychainItemNotFound
+	}
+	return err
+}
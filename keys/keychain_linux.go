@@ -0,0 +1,47 @@
+package keys
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// secretServiceKeychain keeps secrets in the Secret Service of the desktop,
+// gnome-keyring or KWallet, through libsecret's secret-tool, which must be
+// installed. The secret is written to its stdin.
+type secretServiceKeychain struct{}
+
+func osKeychain() (Keychain, error) {
+	return secretServiceKeychain{}, nil
+}
+
+func (secretServiceKeychain) Set(service, account string, secret []byte) error {
+	if err := checkKeychainName(service, account); err != nil {
+		return err
+	}
+	label := fmt.Sprintf("--label=%s (%s)", service, account)
+	_, err := runKeychainTool(hex.EncodeToString(secret), "secret-tool", "store", label, "service", service, "account", account)
+	return err
+}
+
+func (secretServiceKeychain) Get(service, account string) ([]byte, error) {
+	if err := checkKeychainName(service, account); err != nil {
+		return nil, err
+	}
+	out, err := runKeychainTool("", "secret-tool", "lookup", "service", service, "account", account)
+	// secret-tool exits with 1 and no message for a missing item
+	if toolErr, ok := err.(*keychainToolError); ok && toolErr.code == 1 && toolErr.stderr == "" {
+		return nil, ErrKeychainItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(string(out))
+}
+
+func (k secretServiceKeychain) Delete(service, account string) error {
+	if _, err := k.Get(service, account); err != nil {
+		return err
+	}
+	_, err := runKeychainTool("", "secret-tool", "clear", "service", service, "account", account)
+	return err
+}
@@ -0,0 +1,13 @@
+//go:build !darwin && !linux && !windows
+// +build !darwin,!linux,!windows
+
+package keys
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func osKeychain() (Keychain, error) {
+	return nil, fmt.Errorf("no keychain support on %s", runtime.GOOS)
+}
@@ -0,0 +1,122 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+const cryptProtectUIForbidden = 0x1
+
+var (
+	crypt32                = syscall.NewLazyDLL("crypt32.dll")
+	kernel32               = syscall.NewLazyDLL("kernel32.dll")
+	procCryptProtectData   = crypt32.NewProc("CryptProtectData")
+	procCryptUnprotectData = crypt32.NewProc("CryptUnprotectData")
+	procLocalFree          = kernel32.NewProc("LocalFree")
+)
+
+type dataBlob struct {
+	cbData uint32
+	pbData *byte
+}
+
+func newDataBlob(d []byte) *dataBlob {
+	if len(d) == 0 {
+		return &dataBlob{}
+	}
+	return &dataBlob{cbData: uint32(len(d)), pbData: &d[0]}
+}
+
+func (b *dataBlob) bytes() []byte {
+	d := make([]byte, b.cbData)
+	copy(d, (*[1 << 30]byte)(unsafe.Pointer(b.pbData))[:b.cbData:b.cbData])
+	return d
+}
+
+// dpapiKeychain keeps secrets in files under the user config directory,
+// encrypted with DPAPI so only the same Windows user can decrypt them. The
+// service and account are bound to each file as DPAPI entropy, so files
+// cannot be swapped between entries.
+type dpapiKeychain struct {
+	dir string
+}
+
+func osKeychain() (Keychain, error) {
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return dpapiKeychain{dir: filepath.Join(config, DefaultKeychainService, "keychain")}, nil
+}
+
+func (k dpapiKeychain) path(service, account string) string {
+	sum := sha256.Sum256([]byte(service + "\x00" + account))
+	return filepath.Join(k.dir, hex.EncodeToString(sum[:])+".dpapi")
+}
+
+func (k dpapiKeychain) Set(service, account string, secret []byte) error {
+	if err := checkKeychainName(service, account); err != nil {
+		return err
+	}
+	entropy := []byte(service + "\x00" + account)
+	var out dataBlob
+	r, _, err := procCryptProtectData.Call(
+		uintptr(unsafe.Pointer(newDataBlob(secret))), 0,
+		uintptr(unsafe.Pointer(newDataBlob(entropy))), 0, 0,
+		cryptProtectUIForbidden, uintptr(unsafe.Pointer(&out)))
+	if r == 0 {
+		return err
+	}
+	defer procLocalFree.Call(uintptr(unsafe.Pointer(out.pbData)))
+	if err := os.MkdirAll(k.dir, 0700); err != nil {
+		return err
+	}
+	path := k.path(service, account)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, out.bytes(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (k dpapiKeychain) Get(service, account string) ([]byte, error) {
+	if err := checkKeychainName(service, account); err != nil {
+		return nil, err
+	}
+	encrypted, err := ioutil.ReadFile(k.path(service, account))
+	if os.IsNotExist(err) {
+		return nil, ErrKeychainItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	entropy := []byte(service + "\x00" + account)
+	var out dataBlob
+	r, _, err := procCryptUnprotectData.Call(
+		uintptr(unsafe.Pointer(newDataBlob(encrypted))), 0,
+		uintptr(unsafe.Pointer(newDataBlob(entropy))), 0, 0,
+		cryptProtectUIForbidden, uintptr(unsafe.Pointer(&out)))
+	if r == 0 {
+		return nil, err
+	}
+	defer procLocalFree.Call(uintptr(unsafe.Pointer(out.pbData)))
+	secret := out.bytes()
+	wipe((*[1 << 30]byte)(unsafe.Pointer(out.pbData))[:out.cbData:out.cbData])
+	return secret, nil
+}
+
+func (k dpapiKeychain) Delete(service, account string) error {
+	if err := checkKeychainName(service, account); err != nil {
+		return err
+	}
+	err := os.Remove(k.path(service, account))
+	if os.IsNotExist(err) {
+		return ErrKeychainItemNotFound
+	}
+	return err
+}
@@ -3,13 +3,13 @@ package keys
 import (
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"strings"
 
 	"github.com/cosmos/go-bip39"
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 	"golang.org/x/crypto/sha3"
 
 	"github.com/tendermint/tendermint/crypto/secp256k1"
@@ -37,6 +37,13 @@ type KeyManager interface {
 	ExportAsKeyStore(password string) (*EncryptedKeyJSON, error)
 }
 
+// LowSSetter is implemented by the key managers of this package. SetLowS
+// controls whether signatures are normalized to low-S form before they are put
+// into a tx, it is on by default.
+type LowSSetter interface {
+	SetLowS(enabled bool)
+}
+
 func NewMnemonicKeyManager(mnemonic string) (KeyManager, error) {
 	k := keyManager{}
 	err := k.recoveryFromMnemonic(mnemonic, FullPath)
@@ -69,10 +76,30 @@ func NewLedgerKeyManager(path ledger.DerivationPath) (KeyManager, error) {
 	return &k, err
 }
 
+// NewExternalSignerKeyManager creates a key manager that signs with sign, which gets
+// the sign bytes of a tx and returns a secp256k1 signature of their sha256 digest,
+// either as 64 byte R || S or DER encoded. The signature is normalized to low-S.
+func NewExternalSignerKeyManager(pubKey crypto.PubKey, sign func(signBytes []byte) ([]byte, error)) (KeyManager, error) {
+	if pubKey == nil || sign == nil {
+		return nil, fmt.Errorf("both the public key and the sign function are required")
+	}
+	k := keyManager{
+		privKey: externalPrivKey{pubKey: pubKey, sign: sign},
+		addr:    types.AccAddress(pubKey.Address()),
+	}
+	return &k, nil
+}
+
 type keyManager struct {
 	privKey  crypto.PrivKey
 	addr     ctypes.AccAddress
 	mnemonic string
+	// allowHighS turns off low-S normalization, so the zero value normalizes.
+	allowHighS bool
+}
+
+func (m *keyManager) SetLowS(enabled bool) {
+	m.allowHighS = !enabled
 }
 
 func (m *keyManager) ExportAsMnemonic() (string, error) {
@@ -91,7 +118,13 @@ func (m *keyManager) ExportAsPrivateKey() (string, error) {
 }
 
 func (m *keyManager) ExportAsKeyStore(password string) (*EncryptedKeyJSON, error) {
-	return generateKeyStore(m.GetPrivKey(), password)
+	return generateKeyStore(m.GetPrivKey(), password, ctypes.Network)
+}
+
+// ExportKeyStore is ExportAsKeyStore with the address of the keystore in the
+// prefix of network, rather than of the process wide types.Network.
+func ExportKeyStore(km KeyManager, network ctypes.ChainNetwork, password string) (*EncryptedKeyJSON, error) {
+	return generateKeyStore(km.GetPrivKey(), password, network)
 }
 
 func NewKeyManager() (KeyManager, error) {
@@ -140,15 +173,15 @@ func (m *keyManager) recoveryFromKeyStore(keystoreFile string, auth string) erro
 	if err != nil {
 		return err
 	}
-	var encryptedKey EncryptedKeyJSON
-	err = json.Unmarshal(keyJson, &encryptedKey)
-	if err != nil {
-		return err
-	}
-	keyBytes, err := decryptKey(&encryptedKey, auth)
+	keyBytes, err := decryptKeyJSON(keyJson, auth)
 	if err != nil {
 		return err
 	}
+	defer wipe(keyBytes)
+	return m.recoveryFromKeyBytes(keyBytes)
+}
+
+func (m *keyManager) recoveryFromKeyBytes(keyBytes []byte) error {
 	if len(keyBytes) != 32 {
 		return fmt.Errorf("Len of Keybytes is not equal to 32 ")
 	}
@@ -221,14 +254,30 @@ func (m *keyManager) GetAddr() ctypes.AccAddress {
 	return m.addr
 }
 
+// networkSigner is a key that shows the signing address when it signs, like a
+// Ledger, so it needs the network the sign bytes are for.
+type networkSigner interface {
+	NetworkSign(msg []byte, network ctypes.ChainNetwork) ([]byte, error)
+}
+
 func (m *keyManager) makeSignature(msg tx.StdSignMsg) (sig tx.StdSignature, err error) {
 	if err != nil {
 		return
 	}
-	sigBytes, err := m.privKey.Sign(msg.Bytes())
+	var sigBytes []byte
+	if signer, ok := m.privKey.(networkSigner); ok && msg.Network != nil {
+		sigBytes, err = signer.NetworkSign(msg.Bytes(), *msg.Network)
+	} else {
+		sigBytes, err = m.privKey.Sign(msg.Bytes())
+	}
 	if err != nil {
 		return
 	}
+	if !m.allowHighS {
+		if sigBytes, err = NormalizeSignature(sigBytes); err != nil {
+			return
+		}
+	}
 	return tx.StdSignature{
 		AccountNumber: msg.AccountNumber,
 		Sequence:      msg.Sequence,
@@ -237,8 +286,19 @@ func (m *keyManager) makeSignature(msg tx.StdSignMsg) (sig tx.StdSignature, err
 	}, nil
 }
 
-func generateKeyStore(privateKey crypto.PrivKey, password string) (*EncryptedKeyJSON, error) {
+func generateKeyStore(privateKey crypto.PrivKey, password string, network ctypes.ChainNetwork) (*EncryptedKeyJSON, error) {
+	secpPrivateKey, ok := privateKey.(secp256k1.PrivKeySecp256k1)
+	if !ok {
+		return nil, fmt.Errorf(" Only PrivKeySecp256k1 key is supported ")
+	}
 	addr := ctypes.AccAddress(privateKey.PubKey().Address())
+	return encryptSecret(network.FormatAddress(addr), secpPrivateKey[:], password, KeystoreOptions{})
+}
+
+// encryptSecret encrypts a private key or a mnemonic in the keystore format
+// selected by opts, with address as its address field.
+func encryptSecret(address string, secret []byte, password string, opts KeystoreOptions) (*EncryptedKeyJSON, error) {
+	opts = opts.withDefaults()
 	salt, err := common.GenerateRandomBytes(32)
 	if err != nil {
 		return nil, err
@@ -247,25 +307,42 @@ func generateKeyStore(privateKey crypto.PrivKey, password string) (*EncryptedKey
 	if err != nil {
 		return nil, err
 	}
-	scryptParamsJSON := make(map[string]interface{}, 4)
-	scryptParamsJSON["prf"] = "hmac-sha256"
-	scryptParamsJSON["dklen"] = 32
-	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
-	scryptParamsJSON["c"] = 262144
+	kdfParamsJSON := make(map[string]interface{}, 5)
+	kdfParamsJSON["dklen"] = 32
+	kdfParamsJSON["salt"] = hex.EncodeToString(salt)
+
+	var derivedKey, encryptKey []byte
+	kdf, cipherName, version := "pbkdf2", "aes-256-ctr", 1
+	switch opts.Format {
+	case KeystoreSDK:
+		kdfParamsJSON["prf"] = "hmac-sha256"
+		kdfParamsJSON["c"] = opts.Iterations
+		derivedKey = pbkdf2.Key([]byte(password), salt, opts.Iterations, 32, sha256.New)
+		encryptKey = derivedKey[:32]
+	case KeystoreV3:
+		kdfParamsJSON["n"] = opts.ScryptN
+		kdfParamsJSON["r"] = opts.ScryptR
+		kdfParamsJSON["p"] = opts.ScryptP
+		derivedKey, err = scrypt.Key([]byte(password), salt, opts.ScryptN, opts.ScryptR, opts.ScryptP, 32)
+		if err != nil {
+			return nil, err
+		}
+		encryptKey = derivedKey[:16]
+		kdf, cipherName, version = "scrypt", "aes-128-ctr", 3
+	default:
+		return nil, fmt.Errorf("unknown keystore format %d", opts.Format)
+	}
 
 	cipherParamsJSON := cipherparamsJSON{IV: hex.EncodeToString(iv)}
-	derivedKey := pbkdf2.Key([]byte(password), salt, 262144, 32, sha256.New)
-	encryptKey := derivedKey[:32]
-	secpPrivateKey, ok := privateKey.(secp256k1.PrivKeySecp256k1)
-	if !ok {
-		return nil, fmt.Errorf(" Only PrivKeySecp256k1 key is supported ")
-	}
-	cipherText, err := aesCTRXOR(encryptKey, secpPrivateKey[:], iv)
+	cipherText, err := aesCTRXOR(encryptKey, secret, iv)
 	if err != nil {
 		return nil, err
 	}
 
 	hasher := sha3.NewLegacyKeccak512()
+	if opts.Format == KeystoreV3 {
+		hasher = sha3.NewLegacyKeccak256()
+	}
 	hasher.Write(derivedKey[16:32])
 	hasher.Write(cipherText)
 	mac := hasher.Sum(nil)
@@ -275,17 +352,17 @@ func generateKeyStore(privateKey crypto.PrivKey, password string) (*EncryptedKey
 		return nil, err
 	}
 	cryptoStruct := CryptoJSON{
-		Cipher:       "aes-256-ctr",
+		Cipher:       cipherName,
 		CipherText:   hex.EncodeToString(cipherText),
 		CipherParams: cipherParamsJSON,
-		KDF:          "pbkdf2",
-		KDFParams:    scryptParamsJSON,
+		KDF:          kdf,
+		KDFParams:    kdfParamsJSON,
 		MAC:          hex.EncodeToString(mac),
 	}
 	return &EncryptedKeyJSON{
-		Address: addr.String(),
+		Address: address,
 		Crypto:  cryptoStruct,
 		Id:      id.String(),
-		Version: 1,
+		Version: version,
 	}, nil
 }
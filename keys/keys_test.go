@@ -2,13 +2,16 @@ package keys
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/stretchr/testify/assert"
 
 	ctypes "github.com/binance-chain/go-sdk/common/types"
@@ -235,3 +238,234 @@ func TestExportAsMnemonicyError(t *testing.T) {
 	_, err = km.ExportAsMnemonic()
 	assert.Error(t, err)
 }
+
+func TestExternalSignerNormalizesHighS(t *testing.T) {
+	km, err := NewPrivateKeyManager("9579fff0cab07a4379e845a890105004ba4c8276f8ad9d22082b2acbf02d884b")
+	assert.NoError(t, err)
+	highS := func(signBytes []byte) ([]byte, error) {
+		sig, err := km.GetPrivKey().Sign(signBytes)
+		if err != nil {
+			return nil, err
+		}
+		s := new(big.Int).Sub(btcec.S256().N, new(big.Int).SetBytes(sig[32:]))
+		return append(sig[:32:32], s.Bytes()...), nil
+	}
+	signMsg := tx.StdSignMsg{
+		ChainID: "test-chain",
+		Msgs:    []msg.Msg{msg.NewMsgVote(km.GetAddr(), 1, msg.OptionYes)},
+	}
+
+	external, err := NewExternalSignerKeyManager(km.GetPrivKey().PubKey(), highS)
+	assert.NoError(t, err)
+	assert.Equal(t, km.GetAddr(), external.GetAddr())
+	bz, err := external.Sign(signMsg)
+	assert.NoError(t, err)
+	var signed tx.StdTx
+	assert.NoError(t, tx.Cdc.UnmarshalBinaryLengthPrefixed(bz, &signed))
+	assert.True(t, IsLowS(signed.Signatures[0].Signature))
+	assert.NoError(t, VerifyStdTx(signMsg.ChainID, signed))
+
+	external.(LowSSetter).SetLowS(false)
+	bz, err = external.Sign(signMsg)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Cdc.UnmarshalBinaryLengthPrefixed(bz, &signed))
+	assert.False(t, IsLowS(signed.Signatures[0].Signature))
+	assert.Error(t, VerifyStdTx(signMsg.ChainID, signed))
+	assert.Error(t, VerifyStdTx("other-chain", signed))
+}
+
+func TestEncryptedKeyManager(t *testing.T) {
+	mnemonic := "bottom quick strong ranch section decide pepper broken oven demand coin run jacket curious business achieve mule bamboo remain vote kid rigid bench rubber"
+	encrypted, err := EncryptMnemonic(mnemonic, "s3cret")
+	assert.NoError(t, err)
+	assert.Equal(t, "bnb1ddt3ls9fjcd8mh69ujdg3fxc89qle2a7km33aa", encrypted.Address)
+	raw, err := json.Marshal(encrypted)
+	assert.NoError(t, err)
+
+	os.Setenv("TEST_ENCRYPTED_MNEMONIC", base64.StdEncoding.EncodeToString(raw))
+	defer os.Unsetenv("TEST_ENCRYPTED_MNEMONIC")
+	km, err := NewEncryptedKeyManagerFromEnv("TEST_ENCRYPTED_MNEMONIC", "s3cret")
+	assert.NoError(t, err)
+	assert.Equal(t, "bnb1ddt3ls9fjcd8mh69ujdg3fxc89qle2a7km33aa", km.GetAddr().String())
+	exported, err := km.ExportAsMnemonic()
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, exported)
+
+	_, err = NewEncryptedKeyManager(raw, "wrong")
+	assert.Equal(t, ErrDecrypt, err)
+
+	// a keystore of a private key loads the same way
+	keystore, err := ioutil.ReadFile("testkeystore.json")
+	assert.NoError(t, err)
+	km, err = NewEncryptedKeyManager(keystore, "Zjubfd@123")
+	assert.NoError(t, err)
+	fromFile, err := NewKeyStoreKeyManager("testkeystore.json", "Zjubfd@123")
+	assert.NoError(t, err)
+	assert.Equal(t, fromFile.GetAddr(), km.GetAddr())
+}
+
+func TestMigrateKeyStore(t *testing.T) {
+	keystore, err := ioutil.ReadFile("testkeystore.json")
+	assert.NoError(t, err)
+	fromFile, err := NewKeyStoreKeyManager("testkeystore.json", "Zjubfd@123")
+	assert.NoError(t, err)
+
+	v3, err := MigrateKeyStore(keystore, "Zjubfd@123", "n3w", KeystoreOptions{Format: KeystoreV3, ScryptN: 1 << 12})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v3.Version)
+	assert.Equal(t, "scrypt", v3.Crypto.KDF)
+	assert.Equal(t, "aes-128-ctr", v3.Crypto.Cipher)
+	assert.Equal(t, fromFile.GetAddr().String(), v3.Address)
+	raw, err := json.Marshal(v3)
+	assert.NoError(t, err)
+	km, err := NewEncryptedKeyManager(raw, "n3w")
+	assert.NoError(t, err)
+	assert.Equal(t, fromFile.GetAddr(), km.GetAddr())
+
+	// and back, keeping the password
+	sdk, err := MigrateKeyStore(raw, "n3w", "", KeystoreOptions{Iterations: 1024})
+	assert.NoError(t, err)
+	assert.Equal(t, "pbkdf2", sdk.Crypto.KDF)
+	assert.Equal(t, fromFile.GetAddr().String(), sdk.Address)
+
+	_, err = MigrateKeyStore(keystore, "wrong", "", KeystoreOptions{})
+	assert.Equal(t, ErrDecrypt, err)
+
+	v3.Address = "bnb1ddt3ls9fjcd8mh69ujdg3fxc89qle2a7km33aa"
+	raw, err = json.Marshal(v3)
+	assert.NoError(t, err)
+	_, err = MigrateKeyStore(raw, "n3w", "", KeystoreOptions{})
+	assert.Error(t, err)
+}
+
+func TestMigrateWeb3KeyStore(t *testing.T) {
+	// web3 tools write the hex Ethereum address of the key
+	key := make([]byte, 32)
+	key[31] = 1
+	km, err := NewPrivateKeyManager(hex.EncodeToString(key))
+	assert.NoError(t, err)
+	web3, err := encryptSecret(km.GetAddr().String(), key, "pw", KeystoreOptions{Format: KeystoreV3, ScryptN: 1 << 12})
+	assert.NoError(t, err)
+
+	for _, address := range []string{"7e5f4552091a69125d5dfcb7b8c2659029395bdf", "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"} {
+		web3.Address = address
+		raw, err := json.Marshal(web3)
+		assert.NoError(t, err)
+		sdk, err := MigrateKeyStore(raw, "pw", "", KeystoreOptions{Iterations: 1024})
+		assert.NoError(t, err, address)
+		if assert.NotNil(t, sdk) {
+			assert.Equal(t, km.GetAddr().String(), sdk.Address)
+		}
+	}
+
+	web3.Address = "7e5f4552091a69125d5dfcb7b8c2659029395bde"
+	raw, err := json.Marshal(web3)
+	assert.NoError(t, err)
+	_, err = MigrateKeyStore(raw, "pw", "", KeystoreOptions{})
+	assert.Error(t, err)
+}
+
+func TestKeyStoreNetwork(t *testing.T) {
+	defer func(network ctypes.ChainNetwork) { ctypes.Network = network }(ctypes.Network)
+	ctypes.Network = ctypes.ProdNetwork
+	km, err := NewPrivateKeyManager("9579fff0cab07a4379e845a890105004ba4c8276f8ad9d22082b2acbf02d884b")
+	assert.NoError(t, err)
+
+	testnet, err := ExportKeyStore(km, ctypes.TestNetwork, "pw")
+	assert.NoError(t, err)
+	assert.Equal(t, ctypes.TestNetwork.FormatAddress(km.GetAddr()), testnet.Address)
+	prod, err := km.ExportAsKeyStore("pw")
+	assert.NoError(t, err)
+	assert.Equal(t, ctypes.ProdNetwork.FormatAddress(km.GetAddr()), prod.Address)
+
+	// a keystore stays on its network when migrated
+	raw, err := json.Marshal(testnet)
+	assert.NoError(t, err)
+	migrated, err := MigrateKeyStore(raw, "pw", "", KeystoreOptions{Iterations: 1024})
+	assert.NoError(t, err)
+	if assert.NotNil(t, migrated) {
+		assert.Equal(t, testnet.Address, migrated.Address)
+	}
+}
+
+func TestBatchVerifier(t *testing.T) {
+	km, err := NewPrivateKeyManager("9579fff0cab07a4379e845a890105004ba4c8276f8ad9d22082b2acbf02d884b")
+	assert.NoError(t, err)
+	other, err := NewKeyManager()
+	assert.NoError(t, err)
+	var txs []tx.StdTx
+	for i, k := range []KeyManager{km, other, km, other, km} {
+		signMsg := tx.StdSignMsg{
+			ChainID:  "test-chain",
+			Sequence: int64(i),
+			Msgs:     []msg.Msg{msg.NewMsgVote(k.GetAddr(), 1, msg.OptionYes)},
+		}
+		bz, err := k.Sign(signMsg)
+		assert.NoError(t, err)
+		var signed tx.StdTx
+		assert.NoError(t, tx.Cdc.UnmarshalBinaryLengthPrefixed(bz, &signed))
+		txs = append(txs, signed)
+	}
+	// signed by the wrong key and not signed at all
+	txs[1].Signatures[0].PubKey = km.GetPrivKey().PubKey()
+	txs[3].Signatures = nil
+
+	v := NewBatchVerifier(2)
+	errs := v.VerifyStdTxs("test-chain", txs)
+	assert.Len(t, errs, len(txs))
+	for i, err := range errs {
+		assert.Equal(t, VerifyStdTx("test-chain", txs[i]) == nil, err == nil, "tx %d", i)
+	}
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1])
+	assert.Error(t, errs[3])
+	// the key of km was parsed once for all its signatures
+	assert.Len(t, v.pubKeys, 1)
+	assert.Empty(t, VerifyStdTxs("test-chain", nil))
+}
+
+type memoryKeychain map[string][]byte
+
+func (m memoryKeychain) Set(service, account string, secret []byte) error {
+	m[service+"/"+account] = append([]byte(nil), secret...)
+	return nil
+}
+
+func (m memoryKeychain) Get(service, account string) ([]byte, error) {
+	secret, ok := m[service+"/"+account]
+	if !ok {
+		return nil, ErrKeychainItemNotFound
+	}
+	return append([]byte(nil), secret...), nil
+}
+
+func (m memoryKeychain) Delete(service, account string) error {
+	delete(m, service+"/"+account)
+	return nil
+}
+
+func TestKeychainKeyManager(t *testing.T) {
+	kc := memoryKeychain{}
+	mnemonic := "bottom quick strong ranch section decide pepper broken oven demand coin run jacket curious business achieve mule bamboo remain vote kid rigid bench rubber"
+	fromMnemonic, err := NewMnemonicKeyManager(mnemonic)
+	assert.NoError(t, err)
+	assert.NoError(t, SaveToKeychain(kc, DefaultKeychainService, "hot", fromMnemonic))
+	km, err := NewKeychainKeyManager(kc, DefaultKeychainService, "hot")
+	assert.NoError(t, err)
+	assert.Equal(t, fromMnemonic.GetAddr(), km.GetAddr())
+	exported, err := km.ExportAsMnemonic()
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, exported)
+
+	// a mnemonic on another path is kept as its private key
+	onPath, err := NewMnemonicPathKeyManager(mnemonic, "0'/0/1")
+	assert.NoError(t, err)
+	assert.NoError(t, SaveToKeychain(kc, DefaultKeychainService, "path", onPath))
+	km, err = NewKeychainKeyManager(kc, DefaultKeychainService, "path")
+	assert.NoError(t, err)
+	assert.Equal(t, onPath.GetAddr(), km.GetAddr())
+
+	_, err = NewKeychainKeyManager(kc, DefaultKeychainService, "cold")
+	assert.Equal(t, ErrKeychainItemNotFound, err)
+	assert.Error(t, SaveToKeychain(kc, DefaultKeychainService, "bad\"name", fromMnemonic))
+}
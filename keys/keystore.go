@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -74,12 +75,18 @@ func decryptKey(keyProtected *EncryptedKeyJSON, auth string) ([]byte, error) {
 	if !bytes.Equal(calculatedMAC[:], mac) {
 		// to compatible previous sha256 algorithm
 		calculatedMAC256 := sha256.Sum256([]byte((bufferValue)))
-		if !bytes.Equal(calculatedMAC256[:], mac) {
+		// and with web3 v3 keystores
+		keccak256 := sha3.NewLegacyKeccak256()
+		keccak256.Write(bufferValue)
+		if !bytes.Equal(calculatedMAC256[:], mac) && !bytes.Equal(keccak256.Sum(nil), mac) {
 			return nil, ErrDecrypt
 		}
-		calculatedMAC = calculatedMAC256[:]
 	}
-	plainText, err := aesCTRXOR(derivedKey[:32], cipherText, iv)
+	encryptKey, err := cipherKey(keyProtected.Crypto.Cipher, derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	plainText, err := aesCTRXOR(encryptKey, cipherText, iv)
 	if err != nil {
 		return nil, err
 	}
@@ -103,9 +110,30 @@ func getKDFKey(cryptoJSON CryptoJSON, auth string) ([]byte, error) {
 		key := pbkdf2.Key(authArray, salt, c, dkLen, sha256.New)
 		return key, nil
 	}
+	if cryptoJSON.KDF == "scrypt" {
+		n := ensureInt(cryptoJSON.KDFParams["n"])
+		r := ensureInt(cryptoJSON.KDFParams["r"])
+		p := ensureInt(cryptoJSON.KDFParams["p"])
+		return scrypt.Key(authArray, salt, n, r, p, dkLen)
+	}
 	return nil, fmt.Errorf("Unsupported KDF: %s", cryptoJSON.KDF)
 }
 
+// cipherKey picks the part of the derived key the cipher uses: the SDK keystore
+// encrypts with all 32 bytes, web3 v3 keystores with the first 16.
+func cipherKey(cipherName string, derivedKey []byte) ([]byte, error) {
+	switch cipherName {
+	case "aes-256-ctr", "":
+		if len(derivedKey) < 32 {
+			return nil, fmt.Errorf("derived key too short for %s", cipherName)
+		}
+		return derivedKey[:32], nil
+	case "aes-128-ctr":
+		return derivedKey[:16], nil
+	}
+	return nil, fmt.Errorf("Unsupported cipher: %s", cipherName)
+}
+
 func ensureInt(x interface{}) int {
 	res, ok := x.(int)
 	if !ok {
@@ -0,0 +1,182 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/sha3"
+
+	ctypes "github.com/binance-chain/go-sdk/common/types"
+)
+
+// KeystoreFormat selects how a keystore is encrypted.
+type KeystoreFormat int
+
+const (
+	// KeystoreSDK is the format written by this SDK: pbkdf2, aes-256-ctr and a
+	// keccak512 mac, version 1.
+	KeystoreSDK KeystoreFormat = iota
+	// KeystoreV3 is the web3 secret storage format: scrypt, aes-128-ctr and a
+	// keccak256 mac, version 3.
+	KeystoreV3
+)
+
+const (
+	DefaultPBKDF2Iterations = 262144
+	DefaultScryptN          = 1 << 18
+	DefaultScryptR          = 8
+	DefaultScryptP          = 1
+)
+
+// ParseKeystoreFormat parses "sdk" or "v3".
+func ParseKeystoreFormat(s string) (KeystoreFormat, error) {
+	switch s {
+	case "sdk":
+		return KeystoreSDK, nil
+	case "v3":
+		return KeystoreV3, nil
+	}
+	return 0, fmt.Errorf("unknown keystore format %q, want sdk or v3", s)
+}
+
+func (f KeystoreFormat) String() string {
+	switch f {
+	case KeystoreSDK:
+		return "sdk"
+	case KeystoreV3:
+		return "v3"
+	}
+	return fmt.Sprintf("KeystoreFormat(%d)", int(f))
+}
+
+// KeystoreOptions are the format and kdf parameters of a keystore. Parameters
+// left zero take the defaults above; only those of the format are used.
+type KeystoreOptions struct {
+	Format     KeystoreFormat
+	Iterations int
+	ScryptN    int
+	ScryptR    int
+	ScryptP    int
+}
+
+func (o KeystoreOptions) withDefaults() KeystoreOptions {
+	if o.Iterations <= 0 {
+		o.Iterations = DefaultPBKDF2Iterations
+	}
+	if o.ScryptN <= 0 {
+		o.ScryptN = DefaultScryptN
+	}
+	if o.ScryptR <= 0 {
+		o.ScryptR = DefaultScryptR
+	}
+	if o.ScryptP <= 0 {
+		o.ScryptP = DefaultScryptP
+	}
+	return o
+}
+
+// MigrateKeyStore re-encrypts a keystore, or a mnemonic encrypted with
+// EncryptMnemonic, in the format and kdf parameters of opts, with newPassword
+// or with oldPassword when newPassword is empty. The new keystore is decrypted
+// again and must derive the same address as the old one, and as its address
+// field when set, before it is returned. Web3 keystores hold the hex Ethereum
+// address of the key in that field, it is checked as such.
+func MigrateKeyStore(keyJSON []byte, oldPassword, newPassword string, opts KeystoreOptions) (*EncryptedKeyJSON, error) {
+	if oldPassword == "" {
+		return nil, fmt.Errorf("Password is missing ")
+	}
+	if newPassword == "" {
+		newPassword = oldPassword
+	}
+	var old EncryptedKeyJSON
+	if err := json.Unmarshal(keyJSON, &old); err != nil {
+		return nil, err
+	}
+	secret, err := decryptKey(&old, oldPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(secret)
+	addr, err := secretAddress(secret)
+	if err != nil {
+		return nil, err
+	}
+	address, err := checkKeystoreAddress(old.Address, addr, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, err := encryptSecret(address, secret, newPassword, opts)
+	if err != nil {
+		return nil, err
+	}
+	check, err := decryptKey(migrated, newPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the migrated keystore: %v", err)
+	}
+	defer wipe(check)
+	checkAddr, err := secretAddress(check)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(checkAddr, addr) {
+		return nil, fmt.Errorf("migrated keystore derives %s instead of %s", checkAddr, addr)
+	}
+	return migrated, nil
+}
+
+// checkKeystoreAddress compares the address field of a keystore with the key
+// it holds, either a bech32 address of any network or the hex Ethereum address
+// of a private key written by web3 tools. It returns the address field of the
+// migrated keystore: a bech32 field as is, so the keystore stays on its
+// network, addr with the prefix of types.Network otherwise.
+func checkKeystoreAddress(field string, addr ctypes.AccAddress, secret []byte) (string, error) {
+	if field == "" {
+		return addr.String(), nil
+	}
+	if ethAddr, ok := parseEthAddress(field); ok {
+		if len(secret) != 32 {
+			return "", fmt.Errorf("keystore address %s is an Ethereum address but the keystore holds a mnemonic", field)
+		}
+		if derived := ethAddress(secret); !bytes.Equal(ethAddr, derived) {
+			return "", fmt.Errorf("keystore address %s does not match the derived address 0x%x", field, derived)
+		}
+		return addr.String(), nil
+	}
+	if fieldAddr, err := ctypes.AccAddressFromAnyBech32(field); err != nil || !bytes.Equal(fieldAddr, addr) {
+		return "", fmt.Errorf("keystore address %s does not match the derived address %s", field, addr)
+	}
+	return field, nil
+}
+
+// parseEthAddress decodes a 20 byte hex address, with or without 0x.
+func parseEthAddress(s string) ([]byte, bool) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(s) != 40 {
+		return nil, false
+	}
+	b, err := hex.DecodeString(s)
+	return b, err == nil
+}
+
+// ethAddress is the Ethereum address of a secp256k1 private key, the last 20
+// bytes of the keccak256 hash of the uncompressed public key.
+func ethAddress(privKey []byte) []byte {
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), privKey)
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(pub.SerializeUncompressed()[1:])
+	return hasher.Sum(nil)[12:]
+}
+
+// secretAddress derives the address of a decrypted private key or mnemonic.
+func secretAddress(secret []byte) (ctypes.AccAddress, error) {
+	var k keyManager
+	if err := k.recoveryFromSecret(secret); err != nil {
+		return nil, err
+	}
+	return k.addr, nil
+}
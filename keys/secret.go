@@ -0,0 +1,116 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+)
+
+// EncryptMnemonic encrypts a mnemonic with password in the keystore format, so
+// it can be kept in an environment variable or a secret file and loaded with
+// NewEncryptedKeyManager. Unlike a keystore of the private key it keeps the
+// mnemonic exportable.
+func EncryptMnemonic(mnemonic, password string) (*EncryptedKeyJSON, error) {
+	if password == "" {
+		return nil, fmt.Errorf("Password is missing ")
+	}
+	var k keyManager
+	if err := k.recoveryFromMnemonic(mnemonic, FullPath); err != nil {
+		return nil, err
+	}
+	return encryptSecret(k.addr.String(), []byte(mnemonic), password, KeystoreOptions{})
+}
+
+// NewEncryptedKeyManager decrypts a keystore, or a mnemonic encrypted with
+// EncryptMnemonic, with passphrase. The json may also be base64 encoded, as is
+// convenient for environment variables.
+func NewEncryptedKeyManager(encrypted []byte, passphrase string) (KeyManager, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("Password is missing ")
+	}
+	secret, err := decryptKeyJSON(encrypted, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(secret)
+	k := keyManager{}
+	if err := k.recoveryFromSecret(secret); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// NewEncryptedKeyManagerFromEnv reads the encrypted key from the environment
+// variable name, see NewEncryptedKeyManager.
+func NewEncryptedKeyManagerFromEnv(name, passphrase string) (KeyManager, error) {
+	encrypted, ok := os.LookupEnv(name)
+	if !ok || encrypted == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+	return NewEncryptedKeyManager([]byte(encrypted), passphrase)
+}
+
+// NewEncryptedKeyManagerFromFile reads the encrypted key from a file, like a
+// mounted secret, see NewEncryptedKeyManager.
+func NewEncryptedKeyManagerFromFile(path, passphrase string) (KeyManager, error) {
+	encrypted, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewEncryptedKeyManager(encrypted, passphrase)
+}
+
+// ReadPassphraseFile reads a passphrase kept in a secret file. A single
+// trailing newline, as left by most editors and echo, is dropped.
+func ReadPassphraseFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	passphrase := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase file %s is empty", path)
+	}
+	return passphrase, nil
+}
+
+// recoveryFromSecret recovers from a decrypted private key or mnemonic.
+func (m *keyManager) recoveryFromSecret(secret []byte) error {
+	if len(secret) == 32 {
+		return m.recoveryFromKeyBytes(secret)
+	}
+	mnemonic := string(secret)
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return fmt.Errorf("the decrypted secret is neither a private key nor a mnemonic")
+	}
+	return m.recoveryFromMnemonic(mnemonic, FullPath)
+}
+
+func decryptKeyJSON(keyJSON []byte, auth string) ([]byte, error) {
+	keyJSON = bytes.TrimSpace(keyJSON)
+	if len(keyJSON) > 0 && keyJSON[0] != '{' {
+		decoded, err := base64.StdEncoding.DecodeString(string(keyJSON))
+		if err != nil {
+			return nil, fmt.Errorf("the encrypted key is neither json nor base64 encoded json")
+		}
+		keyJSON = decoded
+	}
+	var encryptedKey EncryptedKeyJSON
+	if err := json.Unmarshal(keyJSON, &encryptedKey); err != nil {
+		return nil, err
+	}
+	return decryptKey(&encryptedKey, auth)
+}
+
+// wipe clears decrypted key material once it is no longer needed.
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,120 @@
+package keys
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+const signatureLength = 64
+
+var halfOrder = new(big.Int).Rsh(btcec.S256().N, 1)
+
+// NormalizeSignature returns the 64 byte R || S form of a secp256k1 signature with
+// S in the lower half of the curve order, the only form nodes accept. It takes
+// either that form or a DER encoded signature, as hardware and remote signers
+// usually produce.
+func NormalizeSignature(sig []byte) ([]byte, error) {
+	r, s, err := parseSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+	if s.Cmp(halfOrder) > 0 {
+		s = new(big.Int).Sub(btcec.S256().N, s)
+	}
+	out := make([]byte, signatureLength)
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(out[32-len(rb):32], rb)
+	copy(out[signatureLength-len(sb):], sb)
+	return out, nil
+}
+
+// IsLowS reports whether sig is a 64 byte R || S signature with a low S.
+func IsLowS(sig []byte) bool {
+	if len(sig) != signatureLength {
+		return false
+	}
+	s := new(big.Int).SetBytes(sig[32:])
+	return s.Sign() > 0 && s.Cmp(halfOrder) <= 0
+}
+
+func parseSignature(sig []byte) (r, s *big.Int, err error) {
+	if len(sig) == signatureLength {
+		r, s = new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	} else {
+		parsed, err := btcec.ParseDERSignature(sig, btcec.S256())
+		if err != nil {
+			return nil, nil, fmt.Errorf("signature is neither %d bytes nor valid DER: %v", signatureLength, err)
+		}
+		r, s = parsed.R, parsed.S
+	}
+	n := btcec.S256().N
+	if r.Sign() <= 0 || r.Cmp(n) >= 0 || s.Sign() <= 0 || s.Cmp(n) >= 0 {
+		return nil, nil, fmt.Errorf("signature values out of range")
+	}
+	return r, s, nil
+}
+
+// VerifySignature checks sig over signBytes the way a node does: high S
+// signatures are rejected even though they are mathematically valid.
+func VerifySignature(pubKey crypto.PubKey, signBytes []byte, sig []byte) error {
+	if pubKey == nil {
+		return fmt.Errorf("missing public key")
+	}
+	if len(sig) == signatureLength && !IsLowS(sig) {
+		return fmt.Errorf("signature is not in canonical low-S form")
+	}
+	if !pubKey.VerifyBytes(signBytes, sig) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
+
+// VerifyStdTx verifies every signature of stdTx for the given chain.
+func VerifyStdTx(chainID string, stdTx tx.StdTx) error {
+	return verifyStdTx(chainID, stdTx, VerifySignature)
+}
+
+func verifyStdTx(chainID string, stdTx tx.StdTx, verify func(crypto.PubKey, []byte, []byte) error) error {
+	if len(stdTx.Signatures) == 0 {
+		return fmt.Errorf("transaction is not signed")
+	}
+	for i, sig := range stdTx.Signatures {
+		signBytes := tx.StdSignBytes(chainID, sig.AccountNumber, sig.Sequence, stdTx.Msgs, stdTx.Memo, stdTx.Source, stdTx.Data)
+		if err := verify(sig.PubKey, signBytes, sig.Signature); err != nil {
+			return fmt.Errorf("signature %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// externalPrivKey hands signing to a function, e.g. a remote signer or an HSM.
+// Like the ledger key it cannot export its private bytes.
+type externalPrivKey struct {
+	crypto.PrivKey
+	pubKey crypto.PubKey
+	sign   func(signBytes []byte) ([]byte, error)
+}
+
+func (k externalPrivKey) Bytes() []byte {
+	return nil
+}
+
+func (k externalPrivKey) Sign(msg []byte) ([]byte, error) {
+	return k.sign(msg)
+}
+
+func (k externalPrivKey) PubKey() crypto.PubKey {
+	return k.pubKey
+}
+
+func (k externalPrivKey) Equals(other crypto.PrivKey) bool {
+	if external, ok := other.(externalPrivKey); ok {
+		return k.pubKey.Equals(external.pubKey)
+	}
+	return false
+}
@@ -0,0 +1,274 @@
+// Package liquidity watches the order books of pairs and raises alerts when
+// the spread widens or the depth at the top of the book thins out past the
+// limits of a pair, when the book is crossed, and when it stops updating. It
+// is fed by the depth stream, so it runs the same against a backtest replay.
+package liquidity
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/common"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/indexer/webhook"
+)
+
+type Kind string
+
+const (
+	WideSpread Kind = "wide_spread"
+	ThinBid    Kind = "thin_bid"
+	ThinAsk    Kind = "thin_ask"
+	Crossed    Kind = "crossed"
+	Stale      Kind = "stale"
+)
+
+// Rule sets the limits of a pair, like "BNB_BTC". Zero values disable a limit.
+type Rule struct {
+	Symbol string
+	// MaxSpread is the spread between the best bid and ask, in basis points of
+	// their mid price.
+	MaxSpread float64
+	// MinDepth is the quote amount, in units of 1e-8, the first Levels levels
+	// of each side must hold. Levels defaults to 1, the top of the book.
+	MinDepth int64
+	Levels   int
+	// StaleAfter is how long the book may go without an update.
+	StaleAfter time.Duration
+}
+
+// Alert is raised once when a limit is breached, and again with Resolved set
+// when the book is back within it. Value and Limit are in the units of the
+// limit, basis points or quote amount, or seconds for stale books.
+type Alert struct {
+	ID       string    `json:"id"`
+	Kind     Kind      `json:"kind"`
+	Symbol   string    `json:"symbol"`
+	Value    float64   `json:"value"`
+	Limit    float64   `json:"limit"`
+	Resolved bool      `json:"resolved,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+type Config struct {
+	Rules []Rule
+	// OnAlert gets every alert and its resolution, an error is handed to the
+	// onError of the subscription.
+	OnAlert func(Alert) error
+	// Now is the clock books are timed with, time.Now if nil. Pass the Now of
+	// a backtest.Replayer to replay.
+	Now func() time.Time
+}
+
+// Stats is the last book seen of a pair. Spread is zero while a side is empty.
+type Stats struct {
+	Symbol   string
+	Bid, Ask types.Fixed8
+	Spread   float64
+	BidDepth int64
+	AskDepth int64
+	Updated  time.Time
+}
+
+// Monitor raises the alerts of its pairs. It is safe for concurrent use.
+type Monitor struct {
+	cfg Config
+
+	mtx      sync.Mutex
+	rules    map[string]Rule
+	stats    map[string]*Stats
+	breached map[breachKey]bool
+}
+
+type breachKey struct {
+	symbol string
+	kind   Kind
+}
+
+func NewMonitor(cfg Config) *Monitor {
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	m := &Monitor{cfg: cfg, rules: map[string]Rule{}, stats: map[string]*Stats{}, breached: map[breachKey]bool{}}
+	for _, rule := range cfg.Rules {
+		if rule.Levels <= 0 {
+			rule.Levels = 1
+		}
+		m.rules[rule.Symbol] = rule
+	}
+	return m
+}
+
+// Stats returns the last book seen of symbol.
+func (m *Monitor) Stats(symbol string) (Stats, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	s, ok := m.stats[symbol]
+	if !ok {
+		return Stats{}, false
+	}
+	return *s, true
+}
+
+// Subscribe feeds the monitor with the depth stream of a pair. Errors of the
+// stream and of OnAlert go to onError, which may be nil.
+func (m *Monitor) Subscribe(ws websocket.WSClient, baseAssetSymbol, quoteAssetSymbol string, quit chan struct{}, onError func(err error)) error {
+	if onError == nil {
+		onError = func(error) {}
+	}
+	symbol := common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol)
+	return ws.SubscribeMarketDepthEvent(baseAssetSymbol, quoteAssetSymbol, quit, func(event *websocket.MarketDepthEvent) {
+		err := m.Process(symbol, event.Bids, event.Asks)
+		websocket.ReleaseMarketDepthEvent(event)
+		if err != nil {
+			onError(err)
+		}
+	}, onError, nil)
+}
+
+// Process checks a book, levels are [price, quantity] pairs from the best
+// price down, as in websocket.MarketDepthEvent and types.MarketDepth.
+func (m *Monitor) Process(symbol string, bids, asks [][]types.Fixed8) error {
+	return m.raise(m.check(symbol, bids, asks))
+}
+
+// CheckStale raises Stale alerts for the books that did not update in time.
+// Books are otherwise only checked on updates, call it periodically or use Run.
+func (m *Monitor) CheckStale() error {
+	return m.raise(m.checkStale())
+}
+
+// Run calls CheckStale every interval until ctx is done, and returns the first
+// error of OnAlert.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := m.CheckStale(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (m *Monitor) raise(alerts []Alert) error {
+	if m.cfg.OnAlert == nil {
+		return nil
+	}
+	for _, a := range alerts {
+		if err := m.cfg.OnAlert(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Monitor) check(symbol string, bids, asks [][]types.Fixed8) []Alert {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	rule, ok := m.rules[symbol]
+	if !ok {
+		return nil
+	}
+	now := m.cfg.Now()
+	s := &Stats{Symbol: symbol, Updated: now}
+	m.stats[symbol] = s
+	if len(bids) > 0 && len(bids[0]) == 2 {
+		s.Bid = bids[0][0]
+	}
+	if len(asks) > 0 && len(asks[0]) == 2 {
+		s.Ask = asks[0][0]
+	}
+	s.BidDepth, s.AskDepth = depth(bids, rule.Levels), depth(asks, rule.Levels)
+
+	var alerts []Alert
+	crossed := s.Bid > 0 && s.Ask > 0 && s.Bid >= s.Ask
+	alerts = m.transition(alerts, symbol, Crossed, crossed, float64(s.Bid-s.Ask), 0, now)
+	if s.Bid > 0 && s.Ask > 0 && !crossed {
+		s.Spread = float64(s.Ask-s.Bid) * 20000 / (float64(s.Ask) + float64(s.Bid))
+	}
+	if rule.MaxSpread > 0 {
+		// a crossed book has no meaningful spread, it keeps its state
+		if !crossed && s.Spread > 0 {
+			alerts = m.transition(alerts, symbol, WideSpread, s.Spread > rule.MaxSpread, s.Spread, rule.MaxSpread, now)
+		}
+	}
+	if rule.MinDepth > 0 {
+		alerts = m.transition(alerts, symbol, ThinBid, s.BidDepth < rule.MinDepth, float64(s.BidDepth), float64(rule.MinDepth), now)
+		alerts = m.transition(alerts, symbol, ThinAsk, s.AskDepth < rule.MinDepth, float64(s.AskDepth), float64(rule.MinDepth), now)
+	}
+	if rule.StaleAfter > 0 {
+		alerts = m.transition(alerts, symbol, Stale, false, 0, rule.StaleAfter.Seconds(), now)
+	}
+	return alerts
+}
+
+func (m *Monitor) checkStale() []Alert {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	now := m.cfg.Now()
+	var alerts []Alert
+	for symbol, rule := range m.rules {
+		if rule.StaleAfter <= 0 {
+			continue
+		}
+		s, ok := m.stats[symbol]
+		if !ok {
+			// not seen yet, the monitor may have just started
+			continue
+		}
+		age := now.Sub(s.Updated)
+		alerts = m.transition(alerts, symbol, Stale, age > rule.StaleAfter, age.Seconds(), rule.StaleAfter.Seconds(), now)
+	}
+	return alerts
+}
+
+// transition appends an alert when the breach state of kind changes.
+func (m *Monitor) transition(alerts []Alert, symbol string, kind Kind, breached bool, value, limit float64, now time.Time) []Alert {
+	key := breachKey{symbol, kind}
+	if m.breached[key] == breached {
+		return alerts
+	}
+	if breached {
+		m.breached[key] = true
+	} else {
+		delete(m.breached, key)
+	}
+	a := Alert{Kind: kind, Symbol: symbol, Value: value, Limit: limit, Resolved: !breached, Time: now}
+	a.ID = fmt.Sprintf("%s:%s:%d", kind, symbol, now.UnixNano())
+	return append(alerts, a)
+}
+
+var fixed8Decimals = big.NewInt(int64(types.Fixed8Decimals))
+
+// depth sums the quote amount of the first levels of a side.
+func depth(side [][]types.Fixed8, levels int) int64 {
+	total := new(big.Int)
+	for i := 0; i < levels && i < len(side); i++ {
+		if len(side[i]) != 2 {
+			continue
+		}
+		amount := new(big.Int).Mul(big.NewInt(side[i][0].ToInt64()), big.NewInt(side[i][1].ToInt64()))
+		total.Add(total, amount.Quo(amount, fixed8Decimals))
+	}
+	if !total.IsInt64() {
+		return 1<<63 - 1
+	}
+	return total.Int64()
+}
+
+// Webhook returns an OnAlert that publishes alerts to the endpoints of d
+// subscribed to webhook.EventAlert.
+func Webhook(d *webhook.Dispatcher) func(Alert) error {
+	return func(a Alert) error {
+		return d.Publish(webhook.Payload{ID: a.ID, Type: webhook.EventAlert, Time: a.Time, Data: a})
+	}
+}
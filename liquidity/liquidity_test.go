@@ -0,0 +1,99 @@
+package liquidity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/backtest"
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+func book(bid, bidQty, ask, askQty types.Fixed8) ([][]types.Fixed8, [][]types.Fixed8) {
+	return [][]types.Fixed8{{bid, bidQty}}, [][]types.Fixed8{{ask, askQty}}
+}
+
+func process(m *Monitor, symbol string, bid, bidQty, ask, askQty types.Fixed8) error {
+	bids, asks := book(bid, bidQty, ask, askQty)
+	return m.Process(symbol, bids, asks)
+}
+
+func TestMonitor(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var alerts []Alert
+	m := NewMonitor(Config{
+		Rules:   []Rule{{Symbol: "BNB_BTC", MaxSpread: 50, MinDepth: 10e8, StaleAfter: time.Minute}},
+		OnAlert: func(a Alert) error { alerts = append(alerts, a); return nil },
+		Now:     func() time.Time { return now },
+	})
+
+	// 1.00 / 1.002 is 20 bps, 20 BTC on each side
+	assert.NoError(t, process(m, "BNB_BTC", 1e8, 20e8, 1.002e8, 20e8))
+	assert.Empty(t, alerts)
+	stats, ok := m.Stats("BNB_BTC")
+	assert.True(t, ok)
+	assert.InDelta(t, 19.98, stats.Spread, 0.01)
+	assert.Equal(t, int64(20.04e8), stats.AskDepth)
+
+	// 1.00 / 1.01 is about 100 bps and the bid thins out, raised once
+	assert.NoError(t, process(m, "BNB_BTC", 1e8, 5e8, 1.01e8, 20e8))
+	assert.NoError(t, process(m, "BNB_BTC", 1e8, 5e8, 1.01e8, 20e8))
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, WideSpread, alerts[0].Kind)
+	assert.Equal(t, ThinBid, alerts[1].Kind)
+	assert.Equal(t, float64(5e8), alerts[1].Value)
+
+	alerts = nil
+	assert.NoError(t, process(m, "BNB_BTC", 1.02e8, 20e8, 1.01e8, 20e8))
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, Crossed, alerts[0].Kind)
+	assert.Equal(t, ThinBid, alerts[1].Kind)
+	assert.True(t, alerts[1].Resolved)
+
+	alerts = nil
+	assert.NoError(t, process(m, "BNB_BTC", 1e8, 20e8, 1.001e8, 20e8))
+	assert.Len(t, alerts, 2)
+	assert.True(t, alerts[0].Resolved && alerts[0].Kind == Crossed)
+	assert.True(t, alerts[1].Resolved && alerts[1].Kind == WideSpread)
+
+	alerts = nil
+	now = now.Add(2 * time.Minute)
+	assert.NoError(t, m.CheckStale())
+	assert.NoError(t, m.CheckStale())
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, Stale, alerts[0].Kind)
+	assert.NoError(t, process(m, "BNB_BTC", 1e8, 20e8, 1.001e8, 20e8))
+	assert.Len(t, alerts, 2)
+	assert.True(t, alerts[1].Resolved && alerts[1].Kind == Stale)
+
+	// pairs without rules are not tracked
+	assert.NoError(t, process(m, "ETH_BTC", 1e8, 1, 2e8, 1))
+	_, ok = m.Stats("ETH_BTC")
+	assert.False(t, ok)
+}
+
+func TestMonitorReplay(t *testing.T) {
+	depth := func(sec int64, ask types.Fixed8) backtest.Event {
+		bids, asks := book(1e8, 20e8, ask, 20e8)
+		return backtest.Event{Time: time.Unix(sec, 0), Symbol: "BNB_BTC",
+			Depth: &websocket.MarketDepthEvent{Bids: bids, Asks: asks}}
+	}
+	r := backtest.NewReplayer(backtest.NewSliceSource([]backtest.Event{
+		depth(1, 1.001e8), depth(2, 1.1e8), depth(3, 1.001e8),
+	}))
+	var alerts []Alert
+	m := NewMonitor(Config{
+		Rules:   []Rule{{Symbol: "BNB_BTC", MaxSpread: 50}},
+		OnAlert: func(a Alert) error { alerts = append(alerts, a); return nil },
+		Now:     r.Now,
+	})
+	assert.NoError(t, m.Subscribe(r, "BNB", "BTC", make(chan struct{}), func(err error) { t.Error(err) }))
+	assert.NoError(t, r.Run(context.Background()))
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, int64(2), alerts[0].Time.Unix())
+	assert.Equal(t, "wide_spread:BNB_BTC:2000000000", alerts[0].ID)
+	assert.True(t, alerts[1].Resolved)
+}
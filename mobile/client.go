@@ -0,0 +1,202 @@
+package mobile
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/config"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// Order sides of CreateOrder, the values of msg.OrderSide.
+const (
+	SideBuy  = 1
+	SideSell = 2
+)
+
+// Client talks to a node over rpc. Txs are broadcast once they pass the
+// checks of the node, without waiting for the block.
+type Client struct {
+	rpc    *rpc.HTTP
+	wallet *Wallet
+}
+
+// NewClient connects to the node at nodeURI, tcp://<host>:<port>, on network:
+// mainnet, testnet, ganges or kongo. Addresses of all wallets then use the
+// prefix of that network.
+func NewClient(nodeURI, network string) (*Client, error) {
+	n, err := config.ParseNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rpc: rpc.NewClient(nodeURI, rpc.WithNetwork(n))}, nil
+}
+
+// SetWallet selects the wallet txs are signed with.
+func (c *Client) SetWallet(w *Wallet) {
+	c.wallet = w
+	c.rpc.SetKeyManager(w.km)
+}
+
+// Close disconnects from the node.
+func (c *Client) Close() {
+	c.rpc.Stop()
+}
+
+// Balance is the balance of one token, amounts are decimal strings.
+type Balance struct {
+	Symbol string
+	Free   string
+	Locked string
+	Frozen string
+}
+
+// BalanceList is a list of balances, gomobile cannot bind slices of structs.
+type BalanceList struct {
+	balances []*Balance
+}
+
+func (l *BalanceList) Size() int {
+	return len(l.balances)
+}
+
+// Get returns the balance at index, nil when out of range.
+func (l *BalanceList) Get(index int) *Balance {
+	if index < 0 || index >= len(l.balances) {
+		return nil
+	}
+	return l.balances[index]
+}
+
+func newBalance(b types.TokenBalance) *Balance {
+	return &Balance{Symbol: b.Symbol, Free: b.Free.String(), Locked: b.Locked.String(), Frozen: b.Frozen.String()}
+}
+
+// GetBalances returns all balances of address.
+func (c *Client) GetBalances(address string) (*BalanceList, error) {
+	addr, err := types.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := c.rpc.GetBalances(addr)
+	if err != nil {
+		return nil, err
+	}
+	l := &BalanceList{balances: make([]*Balance, 0, len(balances))}
+	for _, b := range balances {
+		l.balances = append(l.balances, newBalance(b))
+	}
+	return l, nil
+}
+
+// GetBalance returns the balance of symbol of address.
+func (c *Client) GetBalance(address, symbol string) (*Balance, error) {
+	addr, err := types.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := c.rpc.GetBalance(addr, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return newBalance(*balance), nil
+}
+
+// TxResult is the outcome of a broadcast. Code 0 means the node accepted the
+// tx, otherwise Log tells why it did not.
+type TxResult struct {
+	Hash    string
+	Code    int
+	Log     string
+	OrderID string
+}
+
+func newTxResult(res *core_types.ResultBroadcastTx) *TxResult {
+	result := &TxResult{Hash: res.Hash.String(), Code: int(res.Code), Log: res.Log}
+	var data struct {
+		OrderId string `json:"order_id"`
+	}
+	if res.Code == 0 && len(res.Data) > 0 && json.Unmarshal(res.Data, &data) == nil {
+		result.OrderID = data.OrderId
+	}
+	return result
+}
+
+// Transfer sends amount, a decimal string like "1.5", of symbol to the address to.
+func (c *Client) Transfer(to, symbol, amount, memo string) (*TxResult, error) {
+	if c.wallet == nil {
+		return nil, rpc.KeyMissingError
+	}
+	toAddr, err := types.AccAddressFromBech32(to)
+	if err != nil {
+		return nil, err
+	}
+	value, err := parseAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	transfers := []msg.Transfer{{ToAddr: toAddr, Coins: types.Coins{{Denom: symbol, Amount: value}}}}
+	res, err := c.rpc.SendToken(transfers, rpc.Sync, tx.WithMemo(memo))
+	if err != nil {
+		return nil, err
+	}
+	return newTxResult(res), nil
+}
+
+// CreateOrder places a limit order of quantity base asset at price, both
+// decimal strings, on the base_quote pair. side is SideBuy or SideSell. The
+// OrderID of the result is needed to cancel the order.
+func (c *Client) CreateOrder(baseAsset, quoteAsset string, side int, price, quantity string) (*TxResult, error) {
+	if c.wallet == nil {
+		return nil, rpc.KeyMissingError
+	}
+	if side != SideBuy && side != SideSell {
+		return nil, fmt.Errorf("side must be SideBuy or SideSell, not %d", side)
+	}
+	p, err := parseAmount(price)
+	if err != nil {
+		return nil, err
+	}
+	q, err := parseAmount(quantity)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.rpc.CreateOrder(baseAsset, quoteAsset, int8(side), p, q, rpc.Sync)
+	if err != nil {
+		return nil, err
+	}
+	return newTxResult(res), nil
+}
+
+// CancelOrder cancels the order with orderID on the base_quote pair.
+func (c *Client) CancelOrder(baseAsset, quoteAsset, orderID string) (*TxResult, error) {
+	if c.wallet == nil {
+		return nil, rpc.KeyMissingError
+	}
+	res, err := c.rpc.CancelOrder(baseAsset, quoteAsset, orderID, rpc.Sync)
+	if err != nil {
+		return nil, err
+	}
+	return newTxResult(res), nil
+}
+
+func parseAmount(amount string) (int64, error) {
+	// more than 8 decimals would be misread rather than rejected by the parser
+	if i := strings.IndexByte(amount, '.'); i >= 0 && len(amount)-i-1 > 8 {
+		return 0, fmt.Errorf("invalid amount %q: more than 8 decimals", amount)
+	}
+	value, err := types.Fixed8DecodeString(amount)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %v", amount, err)
+	}
+	if value <= 0 {
+		return 0, fmt.Errorf("amount must be positive, got %q", amount)
+	}
+	return value.ToInt64(), nil
+}
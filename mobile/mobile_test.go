@@ -0,0 +1,59 @@
+package mobile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func TestWalletExportRoundTrip(t *testing.T) {
+	w, err := NewWallet()
+	assert.NoError(t, err)
+	mnemonic, err := w.Mnemonic()
+	assert.NoError(t, err)
+	recovered, err := NewWalletFromMnemonic(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, w.Address(), recovered.Address())
+
+	encrypted, err := w.ExportEncryptedMnemonic("s3cret")
+	assert.NoError(t, err)
+	fromMnemonic, err := NewWalletFromKeystore(encrypted, "s3cret")
+	assert.NoError(t, err)
+	exported, err := fromMnemonic.Mnemonic()
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, exported)
+
+	keystore, err := w.ExportKeystore("s3cret")
+	assert.NoError(t, err)
+	fromKeystore, err := NewWalletFromKeystore(keystore, "s3cret")
+	assert.NoError(t, err)
+	assert.Equal(t, w.Address(), fromKeystore.Address())
+
+	privateKey, err := w.PrivateKey()
+	assert.NoError(t, err)
+	fromPrivateKey, err := NewWalletFromPrivateKey(privateKey)
+	assert.NoError(t, err)
+	assert.Equal(t, w.Address(), fromPrivateKey.Address())
+}
+
+func TestParseAmount(t *testing.T) {
+	value, err := parseAmount("1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(150000000), value)
+	for _, bad := range []string{"", "0", "-1", "abc", "0.000000001"} {
+		_, err := parseAmount(bad)
+		assert.Error(t, err, bad)
+	}
+	assert.Equal(t, int(msg.OrderSide.BUY), SideBuy)
+	assert.Equal(t, int(msg.OrderSide.SELL), SideSell)
+}
+
+func TestBalanceList(t *testing.T) {
+	l := &BalanceList{balances: []*Balance{{Symbol: "BNB", Free: "1.00000000"}}}
+	assert.Equal(t, 1, l.Size())
+	assert.Equal(t, "BNB", l.Get(0).Symbol)
+	assert.Nil(t, l.Get(1))
+	assert.Nil(t, l.Get(-1))
+}
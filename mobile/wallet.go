@@ -0,0 +1,97 @@
+// Package mobile is a small API over the SDK for iOS and Android wallets,
+// built with
+//
+//	gomobile bind -target=android github.com/binance-chain/go-sdk/mobile
+//	gomobile bind -target=ios github.com/binance-chain/go-sdk/mobile
+//
+// gomobile only binds strings, bools, signed integers, floats, []byte, errors
+// and pointers to structs of those, so this package sticks to them: amounts
+// are decimal strings like "1.5", lists are wrapped in types with Size and Get,
+// and nothing takes or returns channels, maps or slices of structs.
+package mobile
+
+import (
+	"encoding/json"
+
+	"github.com/binance-chain/go-sdk/keys"
+)
+
+// Wallet holds a key. Its secrets stay in Go memory unless exported.
+type Wallet struct {
+	km keys.KeyManager
+}
+
+// NewWallet creates a wallet with a random key, back it up with Mnemonic.
+func NewWallet() (*Wallet, error) {
+	km, err := keys.NewKeyManager()
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{km: km}, nil
+}
+
+// NewWalletFromMnemonic recovers a wallet from a bip39 mnemonic on the default path.
+func NewWalletFromMnemonic(mnemonic string) (*Wallet, error) {
+	km, err := keys.NewMnemonicKeyManager(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{km: km}, nil
+}
+
+// NewWalletFromPrivateKey recovers a wallet from a hex encoded private key.
+func NewWalletFromPrivateKey(privateKey string) (*Wallet, error) {
+	km, err := keys.NewPrivateKeyManager(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{km: km}, nil
+}
+
+// NewWalletFromKeystore decrypts a keystore, or a mnemonic encrypted with
+// ExportEncryptedMnemonic, as returned by the export methods.
+func NewWalletFromKeystore(keystore []byte, password string) (*Wallet, error) {
+	km, err := keys.NewEncryptedKeyManager(keystore, password)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{km: km}, nil
+}
+
+// Address is the bech32 address of the wallet on the network of the last
+// created Client, mainnet by default.
+func (w *Wallet) Address() string {
+	return w.km.GetAddr().String()
+}
+
+// Mnemonic fails for wallets not created or recovered from a mnemonic.
+func (w *Wallet) Mnemonic() (string, error) {
+	return w.km.ExportAsMnemonic()
+}
+
+func (w *Wallet) PrivateKey() (string, error) {
+	return w.km.ExportAsPrivateKey()
+}
+
+// ExportKeystore encrypts the private key with password, as keystore json.
+func (w *Wallet) ExportKeystore(password string) ([]byte, error) {
+	keystore, err := w.km.ExportAsKeyStore(password)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(keystore)
+}
+
+// ExportEncryptedMnemonic encrypts the mnemonic with password, as keystore json
+// that NewWalletFromKeystore reads back with the mnemonic exportable.
+func (w *Wallet) ExportEncryptedMnemonic(password string) ([]byte, error) {
+	mnemonic, err := w.km.ExportAsMnemonic()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := keys.EncryptMnemonic(mnemonic, password)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encrypted)
+}
@@ -0,0 +1,244 @@
+// Package quoter keeps two-sided quotes of a market maker around a fair value.
+// Every cycle it computes the orders the quotes call for, compares them with
+// the open orders of the account and sends only the cancels and new orders
+// needed to get from one to the other, several cancels per tx.
+package quoter
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+const (
+	DefaultMaxMsgsPerTx = 8
+	openOrdersLimit     = 1000
+)
+
+// OrderSource is the part of the query client the quoter reads open orders
+// with. query.QueryClient satisfies it.
+type OrderSource interface {
+	GetOpenOrders(query *types.OpenOrdersQuery) (*types.OpenOrders, error)
+}
+
+// Executor sends msgs as one tx. A tx holds at most one new order, it comes
+// without an ID, the executor sets it from the sequence the tx is signed with.
+type Executor interface {
+	Execute(ctx context.Context, msgs []msg.Msg) error
+}
+
+// ExecutorFunc is an Executor of a function.
+type ExecutorFunc func(ctx context.Context, msgs []msg.Msg) error
+
+func (f ExecutorFunc) Execute(ctx context.Context, msgs []msg.Msg) error {
+	return f(ctx, msgs)
+}
+
+// Level is a pair of orders, a bid and an ask Spread basis points away from
+// the fair value.
+type Level struct {
+	Spread   float64
+	Quantity int64
+}
+
+// Config describes the quotes. Prices and quantities are Fixed8 values.
+type Config struct {
+	// Symbol is the pair, like "BNB_BTC".
+	Symbol string
+	// Sender owns the orders.
+	Sender types.AccAddress
+	Levels []Level
+	// TickSize and LotSize of the pair, bids are rounded down to a tick and
+	// asks up, quantities down to a lot.
+	TickSize int64
+	LotSize  int64
+	// Tolerance is how far, in basis points of the target, an open order may
+	// drift before it is replaced. Zero replaces on any price change.
+	Tolerance float64
+	// MaxMsgsPerTx bounds the msgs of a tx, DefaultMaxMsgsPerTx if zero.
+	MaxMsgsPerTx int
+}
+
+// Target is an order the quotes call for.
+type Target struct {
+	Side     int8
+	Price    int64
+	Quantity int64
+}
+
+// Plan is what a cycle changes: the open orders to cancel and the orders to
+// place.
+type Plan struct {
+	Cancels []types.Order
+	Creates []Target
+}
+
+// Empty reports whether the quotes are in place.
+func (p Plan) Empty() bool {
+	return len(p.Cancels) == 0 && len(p.Creates) == 0
+}
+
+// Quoter maintains the quotes of Config. It is not safe for concurrent use,
+// cycles must not overlap.
+type Quoter struct {
+	cfg      Config
+	orders   OrderSource
+	executor Executor
+}
+
+func NewQuoter(cfg Config, orders OrderSource, executor Executor) (*Quoter, error) {
+	if cfg.Symbol == "" || len(cfg.Sender) == 0 {
+		return nil, fmt.Errorf("the quoter needs a symbol and a sender")
+	}
+	if cfg.TickSize <= 0 || cfg.LotSize <= 0 {
+		return nil, fmt.Errorf("invalid tick size %d or lot size %d", cfg.TickSize, cfg.LotSize)
+	}
+	if cfg.MaxMsgsPerTx <= 0 {
+		cfg.MaxMsgsPerTx = DefaultMaxMsgsPerTx
+	}
+	return &Quoter{cfg: cfg, orders: orders, executor: executor}, nil
+}
+
+// Targets returns the orders quoting around fair, bids then asks from the
+// innermost level. Levels rounding to no quantity or no price are left out.
+func (q *Quoter) Targets(fair int64) []Target {
+	var bids, asks []Target
+	for _, level := range q.cfg.Levels {
+		quantity := level.Quantity / q.cfg.LotSize * q.cfg.LotSize
+		if quantity <= 0 {
+			continue
+		}
+		offset := float64(fair) * level.Spread / 10000
+		bid := int64(math.Floor((float64(fair)-offset)/float64(q.cfg.TickSize))) * q.cfg.TickSize
+		ask := int64(math.Ceil((float64(fair)+offset)/float64(q.cfg.TickSize))) * q.cfg.TickSize
+		if bid > 0 {
+			bids = append(bids, Target{Side: msg.OrderSide.BUY, Price: bid, Quantity: quantity})
+		}
+		asks = append(asks, Target{Side: msg.OrderSide.SELL, Price: ask, Quantity: quantity})
+	}
+	return append(bids, asks...)
+}
+
+// Diff plans the least changes from open to targets. An open order is kept
+// for a target of its side within Tolerance of its price and with the same
+// quantity left, partially filled orders are replaced.
+func (q *Quoter) Diff(targets []Target, open []types.Order) Plan {
+	var plan Plan
+	matched := make([]bool, len(targets))
+	for _, order := range open {
+		if order.Symbol != q.cfg.Symbol {
+			continue
+		}
+		price, errPrice := parseFixed8(order.Price)
+		quantity, errQuantity := parseFixed8(order.Quantity)
+		filled, errFilled := parseFixed8(order.CumulateQuantity)
+		kept := false
+		if errPrice == nil && errQuantity == nil && errFilled == nil {
+			for i, target := range targets {
+				if matched[i] || int(target.Side) != order.Side || target.Quantity != quantity-filled {
+					continue
+				}
+				if math.Abs(float64(price-target.Price)) <= float64(target.Price)*q.cfg.Tolerance/10000 {
+					matched[i], kept = true, true
+					break
+				}
+			}
+		}
+		if !kept {
+			plan.Cancels = append(plan.Cancels, order)
+		}
+	}
+	for i, target := range targets {
+		if !matched[i] {
+			plan.Creates = append(plan.Creates, target)
+		}
+	}
+	return plan
+}
+
+// parseFixed8 reads an amount of the api, which leaves out zero amounts.
+func parseFixed8(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	f, err := types.Fixed8DecodeString(s)
+	return f.ToInt64(), err
+}
+
+// Txs batches the msgs of plan into txs of at most MaxMsgsPerTx msgs and at
+// most one new order: order ids are made of the sender and the sequence of the
+// tx, two new orders of a tx would get the same id. A cancel goes in the tx of
+// a new order of its side when there is one, so the book is not left without
+// quotes between txs. Cancels without a replacement go first, together.
+func (q *Quoter) Txs(plan Plan) [][]msg.Msg {
+	cancels := map[int8][]types.Order{}
+	for _, order := range plan.Cancels {
+		cancels[int8(order.Side)] = append(cancels[int8(order.Side)], order)
+	}
+	creates := map[int8][]Target{}
+	for _, target := range plan.Creates {
+		creates[target.Side] = append(creates[target.Side], target)
+	}
+
+	// units are kept in one tx unless MaxMsgsPerTx is 1
+	var alone, units [][]msg.Msg
+	for _, side := range []int8{msg.OrderSide.BUY, msg.OrderSide.SELL} {
+		for i := 0; i < len(cancels[side]) || i < len(creates[side]); i++ {
+			var unit []msg.Msg
+			if i < len(cancels[side]) {
+				unit = append(unit, msg.NewCancelOrderMsg(q.cfg.Sender, q.cfg.Symbol, cancels[side][i].ID))
+			}
+			if i >= len(creates[side]) {
+				alone = append(alone, unit)
+				continue
+			}
+			t := creates[side][i]
+			unit = append(unit, msg.NewCreateOrderMsg(q.cfg.Sender, "", t.Side, q.cfg.Symbol, t.Price, t.Quantity))
+			if len(unit) > q.cfg.MaxMsgsPerTx {
+				units = append(units, unit[:1], unit[1:])
+			} else {
+				units = append(units, unit)
+			}
+		}
+	}
+	var txs [][]msg.Msg
+	var current []msg.Msg
+	hasCreate := false
+	for _, unit := range append(alone, units...) {
+		_, create := unit[len(unit)-1].(msg.CreateOrderMsg)
+		if len(current)+len(unit) > q.cfg.MaxMsgsPerTx || create && hasCreate {
+			txs = append(txs, current)
+			current, hasCreate = nil, false
+		}
+		current = append(current, unit...)
+		hasCreate = hasCreate || create
+	}
+	if len(current) > 0 {
+		txs = append(txs, current)
+	}
+	return txs
+}
+
+// Cycle moves the quotes to fair: it reads the open orders, plans the changes
+// and executes their txs in order. It returns the plan, and stops at the first
+// tx that fails, the next cycle picks up from the orders then open.
+func (q *Quoter) Cycle(ctx context.Context, fair int64) (Plan, error) {
+	query := types.NewOpenOrdersQuery(q.cfg.Sender.String(), false).WithSymbol(q.cfg.Symbol).WithLimit(openOrdersLimit)
+	open, err := q.orders.GetOpenOrders(query)
+	if err != nil {
+		return Plan{}, err
+	}
+	plan := q.Diff(q.Targets(fair), open.Order)
+	for _, msgs := range q.Txs(plan) {
+		if err := ctx.Err(); err != nil {
+			return plan, err
+		}
+		if err := q.executor.Execute(ctx, msgs); err != nil {
+			return plan, err
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,135 @@
+package quoter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+type fakeOrders struct {
+	open  []types.Order
+	query *types.OpenOrdersQuery
+}
+
+func (f *fakeOrders) GetOpenOrders(query *types.OpenOrdersQuery) (*types.OpenOrders, error) {
+	f.query = query
+	return &types.OpenOrders{Order: f.open}, nil
+}
+
+func order(id string, side int8, price, quantity, filled string) types.Order {
+	return types.Order{ID: id, Symbol: "BNB_BTC", Side: int(side), Price: price, Quantity: quantity, CumulateQuantity: filled}
+}
+
+func TestQuoter(t *testing.T) {
+	sender := types.AccAddress([]byte("quoter-address-bytes"))
+	cfg := Config{
+		Symbol:       "BNB_BTC",
+		Sender:       sender,
+		Levels:       []Level{{Spread: 10, Quantity: 10e8}, {Spread: 50, Quantity: 20.5e8}},
+		TickSize:     1e5,
+		LotSize:      1e8,
+		Tolerance:    5,
+		MaxMsgsPerTx: 3,
+	}
+	orders := &fakeOrders{}
+	var txs [][]msg.Msg
+	q, err := NewQuoter(cfg, orders, ExecutorFunc(func(ctx context.Context, msgs []msg.Msg) error {
+		txs = append(txs, msgs)
+		return nil
+	}))
+	assert.NoError(t, err)
+
+	targets := q.Targets(1e8)
+	assert.Equal(t, []Target{
+		{Side: msg.OrderSide.BUY, Price: 0.999e8, Quantity: 10e8},
+		{Side: msg.OrderSide.BUY, Price: 0.995e8, Quantity: 20e8},
+		{Side: msg.OrderSide.SELL, Price: 1.001e8, Quantity: 10e8},
+		{Side: msg.OrderSide.SELL, Price: 1.005e8, Quantity: 20e8},
+	}, targets)
+
+	orders.open = []types.Order{
+		// within tolerance, kept
+		order("A", msg.OrderSide.BUY, "0.99920000", "10.00000000", "0.00000000"),
+		// partially filled, replaced
+		order("B", msg.OrderSide.BUY, "0.99500000", "20.00000000", "1.00000000"),
+		// too far, replaced
+		order("C", msg.OrderSide.SELL, "1.01000000", "10.00000000", ""),
+		order("D", msg.OrderSide.SELL, "1.00500000", "20.00000000", ""),
+	}
+	plan, err := q.Cycle(context.Background(), 1e8)
+	assert.NoError(t, err)
+	assert.Equal(t, "BNB_BTC", orders.query.Symbol)
+	assert.Len(t, plan.Cancels, 2)
+	assert.Equal(t, []Target{targets[1], targets[2]}, plan.Creates)
+
+	// cancels travel with their replacements
+	assert.Len(t, txs, 2)
+	assert.Equal(t, "B", txs[0][0].(msg.CancelOrderMsg).RefID)
+	assert.Equal(t, int64(0.995e8), txs[0][1].(msg.CreateOrderMsg).Price)
+	assert.Equal(t, "C", txs[1][0].(msg.CancelOrderMsg).RefID)
+	assert.Equal(t, msg.OrderSide.SELL, txs[1][1].(msg.CreateOrderMsg).Side)
+
+	assert.True(t, q.Diff(targets[:1], orders.open[:1]).Empty())
+
+	failing, err := NewQuoter(cfg, orders, ExecutorFunc(func(context.Context, []msg.Msg) error {
+		return errors.New("rejected")
+	}))
+	assert.NoError(t, err)
+	_, err = failing.Cycle(context.Background(), 1e8)
+	assert.Error(t, err)
+
+	_, err = NewQuoter(Config{Symbol: "BNB_BTC", Sender: sender}, orders, nil)
+	assert.Error(t, err)
+}
+
+func TestTxs(t *testing.T) {
+	cfg := Config{Symbol: "BNB_BTC", Sender: types.AccAddress([]byte("quoter-address-bytes")), TickSize: 1, LotSize: 1}
+	q, err := NewQuoter(cfg, &fakeOrders{}, nil)
+	assert.NoError(t, err)
+	plan := Plan{
+		Cancels: []types.Order{order("B1", msg.OrderSide.BUY, "1", "1", ""), order("B2", msg.OrderSide.BUY, "1", "1", "")},
+		Creates: []Target{
+			{Side: msg.OrderSide.SELL, Price: 3, Quantity: 1},
+			{Side: msg.OrderSide.BUY, Price: 1, Quantity: 1},
+			{Side: msg.OrderSide.SELL, Price: 4, Quantity: 1},
+		},
+	}
+	describe := func(txs [][]msg.Msg) [][]string {
+		var got [][]string
+		for _, tx := range txs {
+			var d []string
+			for _, m := range tx {
+				switch m := m.(type) {
+				case msg.CancelOrderMsg:
+					d = append(d, "cancel "+m.RefID)
+				case msg.CreateOrderMsg:
+					d = append(d, fmt.Sprintf("create %d@%d", m.Side, m.Price))
+				}
+			}
+			got = append(got, d)
+		}
+		return got
+	}
+
+	// a buy is only replaced by a buy, and a tx has one new order at most
+	assert.Equal(t, [][]string{
+		{"cancel B2", "cancel B1", "create 1@1"},
+		{"create 2@3"},
+		{"create 2@4"},
+	}, describe(q.Txs(plan)))
+
+	q.cfg.MaxMsgsPerTx = 1
+	assert.Equal(t, [][]string{
+		{"cancel B2"},
+		{"cancel B1"},
+		{"create 1@1"},
+		{"create 2@3"},
+		{"create 2@4"},
+	}, describe(q.Txs(plan)))
+}
@@ -0,0 +1,247 @@
+// Package reconcile audits the balance of an address over a range of blocks.
+// It replays what the blocks did to the address, transfers, tx fees, swaps and
+// timelocks, into the balance it should have at each height, and compares it
+// with the balance the chain stored at that height. A difference is money that
+// moved in a way the replay does not know of, or a bug.
+//
+// Trades and the fees of orders are not replayed: the match engine settles
+// them in EndBlock without reporting them in block results. Snapshots taken
+// while the address had orders are marked Trading, their differences are not
+// reconcilable from block data.
+package reconcile
+
+import (
+	"fmt"
+	"sort"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// Client is the part of the rpc client the reconciler needs. *rpc.HTTP satisfies it.
+type Client interface {
+	GetHeader(height int64) (*tmtypes.Header, error)
+	Block(height *int64) (*ctypes.ResultBlock, error)
+	BlockResults(height *int64) (*rpc.ResultBlockResults, error)
+	GetCommitAccountWithOptions(addr types.AccAddress, opts ...rpc.QueryOption) (types.Account, *rpc.StoreQueryResult, error)
+	GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error)
+}
+
+type Config struct {
+	// CheckEveryHeight compares with the chain at every height. By default
+	// only heights that changed the balance, or had events that could not be
+	// replayed, are compared, and the last one.
+	CheckEveryHeight bool
+	// OnSnapshot, if set, gets every snapshot as it is taken.
+	OnSnapshot func(Snapshot)
+}
+
+// Snapshot compares the expected and the actual balance of the address after a
+// block. Balances are totals per denom, free, locked in orders and frozen.
+type Snapshot struct {
+	Height   int64       `json:"height"`
+	Expected types.Coins `json:"expected"`
+	Actual   types.Coins `json:"actual"`
+	// Diff is Actual minus Expected, only the denoms that differ.
+	Diff types.Coins `json:"diff,omitempty"`
+	// Unresolved describes the events of the block whose effect could not be
+	// replayed, they likely explain Diff.
+	Unresolved []string `json:"unresolved,omitempty"`
+	// Trading is set when the address had open orders, coins locked, since
+	// the previous snapshot, or placed or cancelled orders. Diff may then be
+	// trades and order fees.
+	Trading bool `json:"trading,omitempty"`
+}
+
+// Balanced reports whether the chain holds the expected balance.
+func (s Snapshot) Balanced() bool {
+	return len(s.Diff) == 0
+}
+
+// Report is the result of a reconciliation.
+type Report struct {
+	Address   types.AccAddress `json:"address"`
+	From      int64            `json:"from"`
+	To        int64            `json:"to"`
+	Start     types.Coins      `json:"start"`
+	Snapshots []Snapshot       `json:"snapshots"`
+}
+
+// Mismatches returns the snapshots that are not balanced, and not explained by
+// trading.
+func (r *Report) Mismatches() []Snapshot {
+	var mismatches []Snapshot
+	for _, s := range r.Snapshots {
+		if !s.Balanced() && !s.Trading {
+			mismatches = append(mismatches, s)
+		}
+	}
+	return mismatches
+}
+
+// Unreconcilable returns the snapshots that are not balanced while the address
+// was trading.
+func (r *Report) Unreconcilable() []Snapshot {
+	var snapshots []Snapshot
+	for _, s := range r.Snapshots {
+		if !s.Balanced() && s.Trading {
+			snapshots = append(snapshots, s)
+		}
+	}
+	return snapshots
+}
+
+// Reconciler replays blocks for an address. It caches the swaps it looks up, so
+// reusing it for several addresses or ranges saves queries. It is not safe for
+// concurrent use.
+type Reconciler struct {
+	client Client
+	cfg    Config
+	swaps  map[string]*types.AtomicSwap
+}
+
+func New(client Client, cfg Config) *Reconciler {
+	return &Reconciler{client: client, cfg: cfg, swaps: map[string]*types.AtomicSwap{}}
+}
+
+// Run reconciles the balance of addr over the blocks in [from, to], starting
+// from the balance stored at from-1.
+//
+// After a mismatch the replay goes on from the actual balance, so each
+// difference is reported once, at the height it appeared.
+func (r *Reconciler) Run(addr types.AccAddress, from, to int64) (*Report, error) {
+	if err := rpc.ValidateHeightRange(from, to); err != nil {
+		return nil, err
+	}
+	expected, locked, err := r.balanceAt(addr, from-1)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Address: addr, From: from, To: to, Start: expected.coins()}
+	replay := &replay{reconciler: r, addr: addr, timelocks: map[int64]types.Coins{}}
+	trading := false
+	for height := from; height <= to; height++ {
+		block, err := r.block(height)
+		if err != nil {
+			return nil, err
+		}
+		h := height
+		results, err := r.client.BlockResults(&h)
+		if err != nil {
+			return nil, fmt.Errorf("fetch block results %d: %v", height, err)
+		}
+		changed, ordered, unresolved, err := replay.block(expected, block, results)
+		if err != nil {
+			return nil, err
+		}
+		// open orders may match in any block
+		trading = trading || locked || ordered
+		if !changed && !ordered && len(unresolved) == 0 && !r.cfg.CheckEveryHeight && height != to {
+			continue
+		}
+		actual, actualLocked, err := r.balanceAt(addr, height)
+		if err != nil {
+			return nil, err
+		}
+		snapshot := Snapshot{
+			Height:     height,
+			Expected:   expected.coins(),
+			Actual:     actual.coins(),
+			Diff:       actual.minus(expected),
+			Unresolved: unresolved,
+			Trading:    trading || actualLocked,
+		}
+		report.Snapshots = append(report.Snapshots, snapshot)
+		if r.cfg.OnSnapshot != nil {
+			r.cfg.OnSnapshot(snapshot)
+		}
+		expected, locked, trading = actual, actualLocked, false
+	}
+	return report, nil
+}
+
+// block fetches the block at height, only its header when it has no txs.
+func (r *Reconciler) block(height int64) (*ctypes.ResultBlock, error) {
+	header, err := r.client.GetHeader(height)
+	if err != nil {
+		return nil, fmt.Errorf("fetch header %d: %v", height, err)
+	}
+	if header.NumTxs == 0 {
+		return &ctypes.ResultBlock{Block: &tmtypes.Block{Header: *header}}, nil
+	}
+	block, err := r.client.Block(&height)
+	if err != nil {
+		return nil, fmt.Errorf("fetch block %d: %v", height, err)
+	}
+	return block, nil
+}
+
+// balanceAt returns the total balance of addr stored after the block at height,
+// and whether coins were locked in orders.
+func (r *Reconciler) balanceAt(addr types.AccAddress, height int64) (ledger, bool, error) {
+	balance := ledger{}
+	if height < 1 {
+		return balance, false, nil
+	}
+	acc, _, err := r.client.GetCommitAccountWithOptions(addr, rpc.WithHeight(height))
+	if err != nil {
+		return nil, false, fmt.Errorf("balance of %s at height %d: %v", addr, height, err)
+	}
+	if acc == nil {
+		return balance, false, nil
+	}
+	balance.add(acc.GetCoins())
+	locked := false
+	if named, ok := acc.(types.NamedAccount); ok {
+		balance.add(named.GetLockedCoins())
+		balance.add(named.GetFrozenCoins())
+		locked = named.GetLockedCoins().IsPositive()
+	}
+	return balance, locked, nil
+}
+
+// ledger is a balance per denom, amounts in units of 1e-8.
+type ledger map[string]int64
+
+func (l ledger) add(coins types.Coins) {
+	for _, c := range coins {
+		l[c.Denom] += c.Amount
+	}
+}
+
+func (l ledger) sub(coins types.Coins) {
+	for _, c := range coins {
+		l[c.Denom] -= c.Amount
+	}
+}
+
+// coins returns the non-zero balances, sorted by denom.
+func (l ledger) coins() types.Coins {
+	coins := types.Coins{}
+	for denom, amount := range l {
+		if amount != 0 {
+			coins = append(coins, types.Coin{Denom: denom, Amount: amount})
+		}
+	}
+	sort.Sort(coins)
+	return coins
+}
+
+// minus returns l - other for the denoms that differ.
+func (l ledger) minus(other ledger) types.Coins {
+	diff := ledger{}
+	for denom, amount := range l {
+		diff[denom] += amount
+	}
+	for denom, amount := range other {
+		diff[denom] -= amount
+	}
+	coins := diff.coins()
+	if len(coins) == 0 {
+		return nil
+	}
+	return coins
+}
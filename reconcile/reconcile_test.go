@@ -0,0 +1,122 @@
+package reconcile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type fakeChain struct {
+	txs      map[int64]tmtypes.Txs
+	txTags   map[int64][]cmn.KVPair
+	balances map[int64]types.Coins
+	locked   map[int64]types.Coins
+	queried  []int64
+	fetched  []int64
+}
+
+func (c *fakeChain) GetHeader(height int64) (*tmtypes.Header, error) {
+	return &tmtypes.Header{Height: height, NumTxs: int64(len(c.txs[height]))}, nil
+}
+
+func (c *fakeChain) Block(height *int64) (*ctypes.ResultBlock, error) {
+	c.fetched = append(c.fetched, *height)
+	return &ctypes.ResultBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: *height}, Data: tmtypes.Data{Txs: c.txs[*height]}}}, nil
+}
+
+func (c *fakeChain) BlockResults(height *int64) (*rpc.ResultBlockResults, error) {
+	deliver := make([]*rpc.ResponseDeliverTx, len(c.txs[*height]))
+	for i := range deliver {
+		deliver[i] = &rpc.ResponseDeliverTx{Tags: c.txTags[*height]}
+	}
+	return &rpc.ResultBlockResults{Height: *height, Results: &rpc.ABCIResponses{
+		DeliverTx: deliver,
+	}}, nil
+}
+
+func (c *fakeChain) GetCommitAccountWithOptions(addr types.AccAddress, opts ...rpc.QueryOption) (types.Account, *rpc.StoreQueryResult, error) {
+	q := rpc.ApplyQueryOptions(opts...)
+	c.queried = append(c.queried, q.Height)
+	acc := &types.AppAccount{BaseAccount: types.BaseAccount{Address: addr, Coins: c.balances[q.Height]}, LockedCoins: c.locked[q.Height]}
+	return acc, nil, nil
+}
+
+func (c *fakeChain) GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error) {
+	return types.AtomicSwap{}, rpc.ZeroRecordsError
+}
+
+func encodeTx(t *testing.T, msgs ...msg.Msg) tmtypes.Tx {
+	bz, err := tx.Cdc.MarshalBinaryLengthPrefixed(tx.StdTx{Msgs: msgs})
+	assert.NoError(t, err)
+	return bz
+}
+
+func TestReconcile(t *testing.T) {
+	defer func(network types.ChainNetwork) { types.Network = network }(types.Network)
+	types.Network = types.TestNetwork
+	addr := types.AccAddress([]byte("audited-address-0001"))
+	other := types.AccAddress([]byte("other-address-000001"))
+	bnb := func(amount int64) types.Coins { return types.Coins{{Denom: "BNB", Amount: amount}} }
+
+	chain := &fakeChain{
+		txs: map[int64]tmtypes.Txs{
+			2: {encodeTx(t, msg.CreateSendMsg(addr, bnb(1000), []msg.Transfer{{ToAddr: other, Coins: bnb(1000)}}))},
+			3: {encodeTx(t, msg.NewCreateOrderMsg(addr, "", msg.OrderSide.BUY, "XYZ-000_BNB", 50000000, 200))},
+			5: {encodeTx(t, msg.NewTimeUnlockMsg(addr, 3))},
+			7: {encodeTx(t, msg.NewCreateOrderMsg(addr, "", msg.OrderSide.SELL, "XYZ-000_BNB", 50000000, 100))},
+		},
+		txTags: map[int64][]cmn.KVPair{2: {{Key: []byte("fee"), Value: []byte("BNB:10")}}},
+		balances: map[int64]types.Coins{
+			1: bnb(5000),
+			2: bnb(3990),
+			// the order filled within its block
+			3: {{Denom: "BNB", Amount: 3890}, {Denom: "XYZ-000", Amount: 200}},
+			5: {{Denom: "BNB", Amount: 4890}, {Denom: "XYZ-000", Amount: 200}},
+			6: {{Denom: "BNB", Amount: 4880}, {Denom: "XYZ-000", Amount: 200}},
+			7: {{Denom: "BNB", Amount: 4880}, {Denom: "XYZ-000", Amount: 100}},
+			// the rest of the order filled in a block of the counterparty
+			8: {{Denom: "BNB", Amount: 4930}, {Denom: "XYZ-000", Amount: 100}},
+		},
+		locked: map[int64]types.Coins{7: {{Denom: "XYZ-000", Amount: 100}}},
+	}
+	report, err := New(chain, Config{}).Run(addr, 2, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, bnb(5000), report.Start)
+	assert.Equal(t, []int64{1, 2, 3, 5, 6}, chain.queried, "only heights with activity and the last")
+	assert.Equal(t, []int64{2, 3, 5}, chain.fetched, "blocks without txs are not fetched")
+
+	// the send with its fee reconciles
+	assert.True(t, report.Snapshots[0].Balanced())
+	assert.False(t, report.Snapshots[0].Trading)
+	// trades are not in block data, they are reported apart
+	unreconcilable := report.Unreconcilable()
+	assert.Len(t, unreconcilable, 1)
+	assert.Equal(t, int64(3), unreconcilable[0].Height)
+	assert.Equal(t, types.Coins{{Denom: "BNB", Amount: -100}, {Denom: "XYZ-000", Amount: 200}}, unreconcilable[0].Diff)
+
+	mismatches := report.Mismatches()
+	assert.Len(t, mismatches, 2)
+	assert.Equal(t, int64(5), mismatches[0].Height)
+	assert.Equal(t, bnb(1000), mismatches[0].Diff)
+	assert.Equal(t, []string{"unlock of timelock 3, locked before the range"}, mismatches[0].Unresolved)
+	// replayed from the actual balance, the unexplained drop is reported alone
+	assert.Equal(t, int64(6), mismatches[1].Height)
+	assert.Equal(t, bnb(-10), mismatches[1].Diff)
+
+	// an order resting at 7 fills in a block without txs of the address
+	report, err = New(chain, Config{}).Run(addr, 7, 8)
+	assert.NoError(t, err)
+	assert.Empty(t, report.Mismatches())
+	unreconcilable = report.Unreconcilable()
+	if assert.Len(t, unreconcilable, 1) {
+		assert.Equal(t, int64(8), unreconcilable[0].Height)
+	}
+}
@@ -0,0 +1,189 @@
+package reconcile
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/fees"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// replay applies blocks to the expected balance of one address.
+type replay struct {
+	reconciler *Reconciler
+	addr       types.AccAddress
+	// timelocks are the amounts of the records locked during the replay, by
+	// id. Records locked before it are unknown, unlocking them is unresolved.
+	timelocks map[int64]types.Coins
+
+	touched    bool
+	ordered    bool
+	unresolved []string
+}
+
+// block applies a block to balance. It reports whether the block touched the
+// address, whether the address placed or cancelled orders in it, and the
+// events whose effect it could not compute.
+func (p *replay) block(balance ledger, block *ctypes.ResultBlock, results *rpc.ResultBlockResults) (bool, bool, []string, error) {
+	p.touched, p.ordered, p.unresolved = false, false, nil
+	height := block.Block.Header.Height
+
+	blockFees, err := fees.Attribute(block, results)
+	if err != nil {
+		return false, false, nil, err
+	}
+	for _, d := range blockFees.Deductions {
+		if p.is(d.Address) {
+			balance.sub(d.Coins)
+			p.touched = true
+		}
+	}
+
+	for i, txBytes := range block.Block.Data.Txs {
+		var deliver *rpc.ResponseDeliverTx
+		if results != nil && results.Results != nil && i < len(results.Results.DeliverTx) {
+			deliver = results.Results.DeliverTx[i]
+		}
+		if deliver == nil || deliver.Code != 0 {
+			// failed txs only pay their fee
+			continue
+		}
+		parsed, err := rpc.ParseTx(tx.Cdc, txBytes)
+		if err != nil {
+			return false, false, nil, fmt.Errorf("decode tx %d in block %d: %v", i, height, err)
+		}
+		for _, m := range parsed.GetMsgs() {
+			if err := p.msg(balance, m, deliver); err != nil {
+				return false, false, nil, fmt.Errorf("tx %d in block %d: %v", i, height, err)
+			}
+		}
+	}
+
+	return p.touched, p.ordered, p.unresolved, nil
+}
+
+func (p *replay) msg(balance ledger, m msg.Msg, deliver *rpc.ResponseDeliverTx) error {
+	switch m := m.(type) {
+	case msg.CreateOrderMsg:
+		// locks coins, the total does not change until the order matches
+		p.ordered = p.ordered || p.is(m.Sender)
+	case msg.CancelOrderMsg:
+		p.ordered = p.ordered || p.is(m.Sender)
+	case msg.SendMsg:
+		for _, in := range m.Inputs {
+			if p.is(in.Address) {
+				balance.sub(in.Coins)
+				p.touched = true
+			}
+		}
+		for _, out := range m.Outputs {
+			if p.is(out.Address) {
+				balance.add(out.Coins)
+				p.touched = true
+			}
+		}
+	case msg.TimeLockMsg:
+		if !p.is(m.From) {
+			return nil
+		}
+		balance.sub(m.Amount)
+		p.touched = true
+		if id, err := strconv.ParseInt(string(deliver.Data), 10, 64); err == nil {
+			p.timelocks[id] = m.Amount
+		}
+	case msg.TimeRelockMsg:
+		if !p.is(m.From) || len(m.Amount) == 0 {
+			return nil
+		}
+		p.touched = true
+		locked, ok := p.timelocks[m.Id]
+		if !ok {
+			p.unresolve("relock of timelock %d, locked before the range", m.Id)
+			return nil
+		}
+		balance.add(locked)
+		balance.sub(m.Amount)
+		p.timelocks[m.Id] = m.Amount
+	case msg.TimeUnlockMsg:
+		if !p.is(m.From) {
+			return nil
+		}
+		p.touched = true
+		locked, ok := p.timelocks[m.Id]
+		if !ok {
+			p.unresolve("unlock of timelock %d, locked before the range", m.Id)
+			return nil
+		}
+		balance.add(locked)
+		delete(p.timelocks, m.Id)
+	case msg.HTLTMsg:
+		if p.is(m.From) {
+			balance.sub(m.Amount)
+			p.touched = true
+		}
+	case msg.DepositHTLTMsg:
+		if p.is(m.From) {
+			balance.sub(m.Amount)
+			p.touched = true
+		}
+	case msg.ClaimHTLTMsg:
+		return p.closeSwap(balance, m.SwapID, true)
+	case msg.RefundHTLTMsg:
+		return p.closeSwap(balance, m.SwapID, false)
+	}
+	return nil
+}
+
+// closeSwap pays out a swap. A claim pays the locked amount to the recipient
+// and the deposits to the creator, a refund returns both. Anyone may send
+// them, so the swap is looked up to know who is paid.
+func (p *replay) closeSwap(balance ledger, swapID types.SwapBytes, claim bool) error {
+	swap, err := p.reconciler.swap(swapID)
+	if err == rpc.ZeroRecordsError {
+		p.unresolve("swap %X not found", []byte(swapID))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	toFrom, toTo := swap.InAmount, swap.OutAmount
+	if !claim {
+		toFrom, toTo = swap.OutAmount, swap.InAmount
+	}
+	if p.is(swap.From) {
+		balance.add(toFrom)
+		p.touched = true
+	}
+	if p.is(swap.To) {
+		balance.add(toTo)
+		p.touched = true
+	}
+	return nil
+}
+
+func (p *replay) is(addr types.AccAddress) bool {
+	return bytes.Equal(addr, p.addr)
+}
+
+func (p *replay) unresolve(format string, args ...interface{}) {
+	p.unresolved = append(p.unresolved, fmt.Sprintf(format, args...))
+}
+
+func (r *Reconciler) swap(swapID types.SwapBytes) (*types.AtomicSwap, error) {
+	key := string(swapID)
+	if swap, ok := r.swaps[key]; ok {
+		return swap, nil
+	}
+	swap, err := r.client.GetSwapByID(swapID)
+	if err != nil {
+		return nil, err
+	}
+	r.swaps[key] = &swap
+	return &swap, nil
+}
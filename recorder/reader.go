@@ -0,0 +1,180 @@
+package recorder
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/binance-chain/go-sdk/backtest"
+)
+
+// Reader reads a recording. It is a backtest.Source, Next returns the events
+// from the position of the last Seek, the start of the recording at first.
+type Reader struct {
+	r      io.ReaderAt
+	closer io.Closer
+	index  []indexEntry
+	end    int64 // the offset of the index, where the last block ends
+
+	next    int // the block loaded by the next call to load
+	pending []backtest.Event
+}
+
+var _ backtest.Source = (*Reader)(nil)
+
+// NewReader reads the recording of size bytes in r.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < magicSize+trailerSize {
+		return nil, fmt.Errorf("not a recording, %d bytes", size)
+	}
+	head := make([]byte, magicSize)
+	if _, err := r.ReadAt(head, 0); err != nil {
+		return nil, err
+	}
+	trailer := make([]byte, trailerSize)
+	if _, err := r.ReadAt(trailer, size-trailerSize); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(head, magic[:]) {
+		return nil, fmt.Errorf("not a recording")
+	}
+	if !bytes.Equal(trailer[12:], magic[:]) {
+		return nil, fmt.Errorf("the recording has no index, it was not closed")
+	}
+	end := int64(binary.BigEndian.Uint64(trailer[0:]))
+	count := int64(binary.BigEndian.Uint32(trailer[8:]))
+	if end < magicSize || end+count*indexEntrySize != size-trailerSize {
+		return nil, fmt.Errorf("corrupted recording index")
+	}
+	buf := make([]byte, count*indexEntrySize)
+	if _, err := r.ReadAt(buf, end); err != nil {
+		return nil, err
+	}
+	index := make([]indexEntry, count)
+	for i := range index {
+		entry := buf[i*indexEntrySize:]
+		index[i] = indexEntry{
+			offset: int64(binary.BigEndian.Uint64(entry[0:])),
+			first:  int64(binary.BigEndian.Uint64(entry[8:])),
+			last:   int64(binary.BigEndian.Uint64(entry[16:])),
+			count:  binary.BigEndian.Uint32(entry[24:]),
+		}
+	}
+	return &Reader{r: r, index: index, end: end}, nil
+}
+
+// Open reads a recording file.
+func Open(name string) (*Reader, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r, err := NewReader(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r.closer = f
+	return r, nil
+}
+
+// Close closes the file of a Reader from Open.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Len is the number of events in the recording.
+func (r *Reader) Len() int {
+	n := 0
+	for _, entry := range r.index {
+		n += int(entry.count)
+	}
+	return n
+}
+
+// Start and End are the times of the first and the last event, zero if the
+// recording is empty.
+func (r *Reader) Start() time.Time {
+	if len(r.index) == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, r.index[0].first)
+}
+
+func (r *Reader) End() time.Time {
+	if len(r.index) == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, r.index[len(r.index)-1].last)
+}
+
+// Seek moves to the first event at or after t. Only the block holding it is
+// decompressed.
+func (r *Reader) Seek(t time.Time) error {
+	r.next = sort.Search(len(r.index), func(i int) bool {
+		return !time.Unix(0, r.index[i].last).Before(t)
+	})
+	r.pending = nil
+	if r.next == len(r.index) {
+		return nil
+	}
+	if err := r.load(); err != nil {
+		return err
+	}
+	for len(r.pending) > 0 && r.pending[0].Time.Before(t) {
+		r.pending = r.pending[1:]
+	}
+	return nil
+}
+
+// Next returns the next event, io.EOF at the end of the recording.
+func (r *Reader) Next() (*backtest.Event, error) {
+	for len(r.pending) == 0 {
+		if r.next == len(r.index) {
+			return nil, io.EOF
+		}
+		if err := r.load(); err != nil {
+			return nil, err
+		}
+	}
+	ev := &r.pending[0]
+	r.pending = r.pending[1:]
+	return ev, nil
+}
+
+// load decompresses the next block into pending.
+func (r *Reader) load() error {
+	entry := r.index[r.next]
+	end := r.end
+	if r.next+1 < len(r.index) {
+		end = r.index[r.next+1].offset
+	}
+	if end < entry.offset {
+		return fmt.Errorf("corrupted recording index at block %d", r.next)
+	}
+	block := io.NewSectionReader(r.r, entry.offset, end-entry.offset)
+	decoder := json.NewDecoder(flate.NewReader(block))
+	events := make([]backtest.Event, entry.count)
+	for i := range events {
+		if err := decoder.Decode(&events[i]); err != nil {
+			return fmt.Errorf("decode block %d: %v", r.next, err)
+		}
+	}
+	r.next++
+	r.pending = events
+	return nil
+}
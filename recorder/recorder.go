@@ -0,0 +1,298 @@
+// Package recorder captures depth and trade streams to disk and reads them back.
+//
+// A recording is a sequence of flate compressed blocks of events, followed by
+// an index holding the offset and time range of every block, so a reader can
+// seek to a time without decompressing what comes before. Events are stored as
+// backtest.Event and a Reader is a backtest.Source, recordings replay through
+// backtest.NewReplayer.
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/backtest"
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/common"
+)
+
+const (
+	DefaultBlockEvents   = 1024
+	DefaultBlockInterval = time.Minute
+)
+
+const (
+	magicSize      = 8
+	indexEntrySize = 8 + 8 + 8 + 4
+	trailerSize    = 8 + 4 + magicSize
+)
+
+// magic starts and ends every recording.
+var magic = [magicSize]byte{'b', 'n', 'c', 'r', 'e', 'c', '0', '1'}
+
+// Stream selects the streams Subscribe records.
+type Stream int
+
+const (
+	StreamDepth Stream = 1 << iota
+	StreamDiff
+	StreamTrades
+)
+
+// Config tunes a Recorder. Zero values take the defaults.
+type Config struct {
+	// BlockEvents and BlockInterval bound the events and the time span of a
+	// block, the unit a reader decompresses when it seeks.
+	BlockEvents   int
+	BlockInterval time.Duration
+	// Level is the flate compression level, flate.DefaultCompression if zero.
+	Level int
+	// Now stamps the events received by Subscribe, time.Now if nil.
+	Now func() time.Time
+}
+
+type indexEntry struct {
+	offset      int64
+	first, last int64
+	count       uint32
+}
+
+// Recorder writes events to a recording. It is safe for concurrent use, events
+// of several subscriptions are written in the order they are received.
+type Recorder struct {
+	config Config
+
+	mtx    sync.Mutex
+	w      *bufio.Writer
+	closer io.Closer
+	offset int64
+	block  bytes.Buffer
+	entry  indexEntry
+	index  []indexEntry
+	last   time.Time
+	err    error
+	closed bool
+}
+
+// NewRecorder writes a recording to w. Close must be called to write the index,
+// a recording that was not closed can't be read.
+func NewRecorder(w io.Writer, config Config) (*Recorder, error) {
+	if config.BlockEvents <= 0 {
+		config.BlockEvents = DefaultBlockEvents
+	}
+	if config.BlockInterval <= 0 {
+		config.BlockInterval = DefaultBlockInterval
+	}
+	if config.Level == 0 {
+		config.Level = flate.DefaultCompression
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	if _, err := flate.NewWriter(nil, config.Level); err != nil {
+		return nil, err
+	}
+	r := &Recorder{config: config, w: bufio.NewWriter(w)}
+	if err := r.write(magic[:]); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Create writes a recording to a new file.
+func Create(name string, config Config) (*Recorder, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewRecorder(f, config)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r.closer = f
+	return r, nil
+}
+
+// Write appends an event. Events must come in time order.
+func (r *Recorder) Write(ev *backtest.Event) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.append(ev)
+}
+
+func (r *Recorder) append(ev *backtest.Event) error {
+	if r.closed {
+		return fmt.Errorf("recorder is closed")
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if ev.Time.Before(r.last) {
+		return fmt.Errorf("event at %v is before the last one at %v", ev.Time, r.last)
+	}
+	t := ev.Time.UnixNano()
+	if r.entry.count > 0 && (int(r.entry.count) >= r.config.BlockEvents || time.Duration(t-r.entry.first) >= r.config.BlockInterval) {
+		if err := r.flush(); err != nil {
+			return err
+		}
+	}
+	bz, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	if r.entry.count == 0 {
+		r.entry = indexEntry{offset: r.offset, first: t}
+	}
+	r.block.Write(bz)
+	r.block.WriteByte('\n')
+	r.entry.last = t
+	r.entry.count++
+	r.last = ev.Time
+	return nil
+}
+
+// flush compresses the pending block and writes it out.
+func (r *Recorder) flush() error {
+	if r.entry.count == 0 {
+		return nil
+	}
+	var compressed bytes.Buffer
+	fw, _ := flate.NewWriter(&compressed, r.config.Level)
+	fw.Write(r.block.Bytes())
+	if err := fw.Close(); err != nil {
+		return r.fail(err)
+	}
+	if err := r.write(compressed.Bytes()); err != nil {
+		return err
+	}
+	r.index = append(r.index, r.entry)
+	r.entry = indexEntry{}
+	r.block.Reset()
+	return nil
+}
+
+func (r *Recorder) write(bz []byte) error {
+	n, err := r.w.Write(bz)
+	r.offset += int64(n)
+	if err != nil {
+		return r.fail(err)
+	}
+	return nil
+}
+
+// fail makes a write error permanent, the recording is broken past it.
+func (r *Recorder) fail(err error) error {
+	r.err = err
+	return err
+}
+
+// Close writes the last block and the index.
+func (r *Recorder) Close() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	err := r.finish()
+	if r.closer != nil {
+		if closeErr := r.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (r *Recorder) finish() error {
+	if r.err != nil {
+		return r.err
+	}
+	if err := r.flush(); err != nil {
+		return err
+	}
+	indexOffset := r.offset
+	buf := make([]byte, indexEntrySize)
+	for _, entry := range r.index {
+		binary.BigEndian.PutUint64(buf[0:], uint64(entry.offset))
+		binary.BigEndian.PutUint64(buf[8:], uint64(entry.first))
+		binary.BigEndian.PutUint64(buf[16:], uint64(entry.last))
+		binary.BigEndian.PutUint32(buf[24:], entry.count)
+		if err := r.write(buf); err != nil {
+			return err
+		}
+	}
+	trailer := make([]byte, trailerSize)
+	binary.BigEndian.PutUint64(trailer[0:], uint64(indexOffset))
+	binary.BigEndian.PutUint32(trailer[8:], uint32(len(r.index)))
+	copy(trailer[12:], magic[:])
+	if err := r.write(trailer); err != nil {
+		return err
+	}
+	if err := r.w.Flush(); err != nil {
+		return r.fail(err)
+	}
+	return nil
+}
+
+// Subscribe records the streams of a pair, stamping the events with
+// Config.Now as they arrive. Events are released to the websocket pools once
+// written. Failing writes and stream errors go to onError, which may be nil.
+func (r *Recorder) Subscribe(ws websocket.WSClient, baseAssetSymbol, quoteAssetSymbol string, streams Stream, quit chan struct{}, onError func(err error)) error {
+	if onError == nil {
+		onError = func(error) {}
+	}
+	pair := common.CombineSymbol(baseAssetSymbol, quoteAssetSymbol)
+	record := func(ev *backtest.Event) {
+		r.mtx.Lock()
+		ev.Time = r.config.Now()
+		if ev.Time.Before(r.last) {
+			// the clock went back, keep the recording ordered
+			ev.Time = r.last
+		}
+		err := r.append(ev)
+		r.mtx.Unlock()
+		if err != nil {
+			onError(err)
+		}
+	}
+	if streams&StreamDepth != 0 {
+		err := ws.SubscribeMarketDepthEvent(baseAssetSymbol, quoteAssetSymbol, quit, func(event *websocket.MarketDepthEvent) {
+			record(&backtest.Event{Symbol: pair, Depth: event})
+			websocket.ReleaseMarketDepthEvent(event)
+		}, onError, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if streams&StreamDiff != 0 {
+		err := ws.SubscribeMarketDiffEvent(baseAssetSymbol, quoteAssetSymbol, quit, func(event *websocket.MarketDeltaEvent) {
+			record(&backtest.Event{Symbol: pair, Delta: event})
+			websocket.ReleaseMarketDeltaEvent(event)
+		}, onError, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if streams&StreamTrades != 0 {
+		err := ws.SubscribeTradeEvent(baseAssetSymbol, quoteAssetSymbol, quit, func(events []*websocket.TradeEvent) {
+			if len(events) == 0 {
+				return
+			}
+			record(&backtest.Event{Symbol: pair, Trades: events})
+			websocket.ReleaseTradeEvents(events)
+		}, onError, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
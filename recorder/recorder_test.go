@@ -0,0 +1,103 @@
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/backtest"
+	"github.com/binance-chain/go-sdk/client/websocket"
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+func depth(sec int64, bid types.Fixed8) backtest.Event {
+	return backtest.Event{Time: time.Unix(sec, 0), Symbol: "BNB_BTC", Depth: &websocket.MarketDepthEvent{
+		Symbol: "BNB_BTC", Bids: [][]types.Fixed8{{bid, 1e8}}}}
+}
+
+func readAll(t *testing.T, r *Reader) []int64 {
+	var secs []int64
+	for {
+		ev, err := r.Next()
+		if err == io.EOF {
+			return secs
+		}
+		assert.NoError(t, err)
+		secs = append(secs, ev.Time.Unix())
+	}
+}
+
+func TestRecordAndSeek(t *testing.T) {
+	var buf bytes.Buffer
+	rec, err := NewRecorder(&buf, Config{BlockEvents: 2})
+	assert.NoError(t, err)
+	for _, sec := range []int64{10, 11, 12, 13, 14} {
+		ev := depth(sec, types.Fixed8(sec))
+		assert.NoError(t, rec.Write(&ev))
+	}
+	old := depth(1, 1)
+	assert.Error(t, rec.Write(&old), "out of order")
+	assert.NoError(t, rec.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, r.Len())
+	assert.Len(t, r.index, 3)
+	assert.Equal(t, int64(10), r.Start().Unix())
+	assert.Equal(t, int64(14), r.End().Unix())
+	assert.Equal(t, []int64{10, 11, 12, 13, 14}, readAll(t, r))
+
+	assert.NoError(t, r.Seek(time.Unix(13, 0)))
+	assert.Equal(t, []int64{13, 14}, readAll(t, r))
+	assert.NoError(t, r.Seek(time.Unix(11, 500)))
+	ev, err := r.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, types.Fixed8(12), ev.Depth.Bids[0][0])
+	assert.NoError(t, r.Seek(time.Unix(20, 0)))
+	assert.Empty(t, readAll(t, r))
+
+	_, err = NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), int64(buf.Len()-1))
+	assert.Error(t, err, "truncated")
+}
+
+func TestRecordStreams(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recorder")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "bnb_btc.rec")
+
+	// record the streams of a replay, as from a live client
+	live := backtest.NewReplayer(backtest.NewSliceSource([]backtest.Event{
+		depth(10, 5),
+		{Time: time.Unix(11, 0), Symbol: "BNB_BTC", Trades: []*websocket.TradeEvent{{TradeID: "1-0", Price: 5}}},
+		{Time: time.Unix(12, 0), Symbol: "BNB_BTC", Delta: &websocket.MarketDeltaEvent{}},
+	}))
+	rec, err := Create(name, Config{Now: live.Now})
+	assert.NoError(t, err)
+	var errs []error
+	assert.NoError(t, rec.Subscribe(live, "BNB", "BTC", StreamDepth|StreamTrades, make(chan struct{}), func(err error) {
+		errs = append(errs, err)
+	}))
+	assert.NoError(t, live.Run(context.Background()))
+	assert.NoError(t, rec.Close())
+	assert.Empty(t, errs)
+
+	r, err := Open(name)
+	assert.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, []int64{10, 11}, readAll(t, r))
+	assert.NoError(t, r.Seek(time.Time{}))
+	_, err = r.Next()
+	assert.NoError(t, err)
+	ev, err := r.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "BNB_BTC", ev.Symbol)
+	assert.Equal(t, "1-0", ev.Trades[0].TradeID)
+}
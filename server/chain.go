@@ -0,0 +1,220 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/server/pb"
+)
+
+type chainServer struct {
+	*Server
+}
+
+var _ pb.ChainServer = chainServer{}
+
+func (s chainServer) Status(ctx context.Context, _ *pb.StatusRequest) (*pb.StatusResponse, error) {
+	var res *pb.StatusResponse
+	err := call(ctx, func() error {
+		st, err := s.backend.Status()
+		if err != nil {
+			return err
+		}
+		res = &pb.StatusResponse{
+			ChainId:           st.NodeInfo.Network,
+			Moniker:           st.NodeInfo.Moniker,
+			LatestBlockHeight: st.SyncInfo.LatestBlockHeight,
+			LatestBlockTime:   st.SyncInfo.LatestBlockTime.UnixNano() / 1e6,
+			CatchingUp:        st.SyncInfo.CatchingUp,
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (s chainServer) GetAccount(ctx context.Context, req *pb.AccountRequest) (*pb.Account, error) {
+	addr, err := parseAddress(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	var res *pb.Account
+	err = call(ctx, func() error {
+		acc, err := s.backend.GetAccount(addr)
+		if err != nil {
+			return err
+		}
+		if acc == nil {
+			return status.Errorf(codes.NotFound, "account %s not found", req.Address)
+		}
+		res = &pb.Account{
+			Address:       acc.GetAddress().String(),
+			AccountNumber: acc.GetAccountNumber(),
+			Sequence:      acc.GetSequence(),
+			Coins:         pbCoins(acc.GetCoins()),
+			Flags:         acc.GetFlags(),
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (s chainServer) GetBalances(ctx context.Context, req *pb.AccountRequest) (*pb.BalancesResponse, error) {
+	addr, err := parseAddress(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	var res *pb.BalancesResponse
+	err = call(ctx, func() error {
+		balances, err := s.backend.GetBalances(addr)
+		if err != nil {
+			return err
+		}
+		res = &pb.BalancesResponse{}
+		for _, b := range balances {
+			res.Balances = append(res.Balances, &pb.Balance{
+				Symbol: b.Symbol,
+				Free:   b.Free.ToInt64(),
+				Locked: b.Locked.ToInt64(),
+				Frozen: b.Frozen.ToInt64(),
+			})
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (s chainServer) GetTx(ctx context.Context, req *pb.TxRequest) (*pb.TxResponse, error) {
+	if len(req.Hash) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "hash is empty")
+	}
+	var res *pb.TxResponse
+	err := call(ctx, func() error {
+		tx, err := s.backend.Tx(req.Hash, false)
+		if err != nil {
+			return err
+		}
+		res = &pb.TxResponse{
+			Hash:   tx.Hash,
+			Height: tx.Height,
+			Code:   tx.TxResult.Code,
+			Log:    tx.TxResult.Log,
+			Data:   tx.TxResult.Data,
+			Tx:     tx.Tx,
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (s chainServer) GetDepth(ctx context.Context, req *pb.DepthRequest) (*pb.Depth, error) {
+	var res *pb.Depth
+	err := call(ctx, func() error {
+		book, err := s.backend.GetDepth(req.Pair, int(req.Level))
+		if err != nil {
+			return err
+		}
+		res = &pb.Depth{Height: book.Height, PendingMatch: book.PendingMatch}
+		for _, l := range book.Levels {
+			res.Levels = append(res.Levels, &pb.PriceLevel{
+				BuyPrice:  l.BuyPrice.ToInt64(),
+				BuyQty:    l.BuyQty.ToInt64(),
+				SellPrice: l.SellPrice.ToInt64(),
+				SellQty:   l.SellQty.ToInt64(),
+			})
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (s chainServer) GetOpenOrders(ctx context.Context, req *pb.OpenOrdersRequest) (*pb.OpenOrdersResponse, error) {
+	addr, err := parseAddress(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	var res *pb.OpenOrdersResponse
+	err = call(ctx, func() error {
+		orders, err := s.backend.GetOpenOrders(addr, req.Pair)
+		if err != nil {
+			return err
+		}
+		res = &pb.OpenOrdersResponse{}
+		for _, o := range orders {
+			res.Orders = append(res.Orders, &pb.OpenOrder{
+				Id:                   o.Id,
+				Symbol:               o.Symbol,
+				Price:                o.Price.ToInt64(),
+				Quantity:             o.Quantity.ToInt64(),
+				CumQty:               o.CumQty.ToInt64(),
+				CreatedHeight:        o.CreatedHeight,
+				CreatedTimestamp:     o.CreatedTimestamp,
+				LastUpdatedHeight:    o.LastUpdatedHeight,
+				LastUpdatedTimestamp: o.LastUpdatedTimestamp,
+			})
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (s chainServer) ListTokens(ctx context.Context, req *pb.ListTokensRequest) (*pb.ListTokensResponse, error) {
+	var res *pb.ListTokensResponse
+	err := call(ctx, func() error {
+		tokens, err := s.backend.ListAllTokens(int(req.Offset), int(req.Limit))
+		if err != nil {
+			return err
+		}
+		res = &pb.ListTokensResponse{}
+		for _, t := range tokens {
+			res.Tokens = append(res.Tokens, &pb.Token{
+				Name:           t.Name,
+				Symbol:         t.Symbol,
+				OriginalSymbol: t.OrigSymbol,
+				TotalSupply:    t.TotalSupply.ToInt64(),
+				Owner:          t.Owner.String(),
+				Mintable:       t.Mintable,
+			})
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (s chainServer) BroadcastTx(ctx context.Context, req *pb.BroadcastTxRequest) (*pb.BroadcastResponse, error) {
+	if len(req.Tx) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tx is empty")
+	}
+	mode, err := syncType(req.Mode)
+	if err != nil {
+		return nil, err
+	}
+	var res *pb.BroadcastResponse
+	err = call(ctx, func() error {
+		r, err := s.backend.BroadcastIdempotent(req.Tx, mode)
+		if err != nil {
+			return err
+		}
+		res = broadcastResponse(r)
+		return nil
+	})
+	return res, err
+}
+
+func parseAddress(bech32 string) (types.AccAddress, error) {
+	addr, err := types.AccAddressFromBech32(bech32)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %q: %v", bech32, err)
+	}
+	return addr, nil
+}
+
+func pbCoins(coins types.Coins) []*pb.Coin {
+	res := make([]*pb.Coin, 0, len(coins))
+	for _, c := range coins {
+		res = append(res, &pb.Coin{Denom: c.Denom, Amount: c.Amount})
+	}
+	return res
+}
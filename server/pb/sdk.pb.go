@@ -0,0 +1,2107 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: sdk.proto
+
+package pb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// BroadcastMode is how long a broadcast waits: until the tx is in the
+// mempool of the node (SYNC), not at all (ASYNC) or until it is committed.
+type BroadcastMode int32
+
+const (
+	BroadcastMode_SYNC   BroadcastMode = 0
+	BroadcastMode_ASYNC  BroadcastMode = 1
+	BroadcastMode_COMMIT BroadcastMode = 2
+)
+
+var BroadcastMode_name = map[int32]string{
+	0: "SYNC",
+	1: "ASYNC",
+	2: "COMMIT",
+}
+
+var BroadcastMode_value = map[string]int32{
+	"SYNC":   0,
+	"ASYNC":  1,
+	"COMMIT": 2,
+}
+
+func (x BroadcastMode) String() string {
+	return proto.EnumName(BroadcastMode_name, int32(x))
+}
+
+func (BroadcastMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{0}
+}
+
+type Side int32
+
+const (
+	Side_SIDE_UNSPECIFIED Side = 0
+	Side_BUY              Side = 1
+	Side_SELL             Side = 2
+)
+
+var Side_name = map[int32]string{
+	0: "SIDE_UNSPECIFIED",
+	1: "BUY",
+	2: "SELL",
+}
+
+var Side_value = map[string]int32{
+	"SIDE_UNSPECIFIED": 0,
+	"BUY":              1,
+	"SELL":             2,
+}
+
+func (x Side) String() string {
+	return proto.EnumName(Side_name, int32(x))
+}
+
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{1}
+}
+
+type StatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
+func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()    {}
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{0}
+}
+
+func (m *StatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusRequest.Unmarshal(m, b)
+}
+func (m *StatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusRequest.Marshal(b, m, deterministic)
+}
+func (m *StatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusRequest.Merge(m, src)
+}
+func (m *StatusRequest) XXX_Size() int {
+	return xxx_messageInfo_StatusRequest.Size(m)
+}
+func (m *StatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusRequest proto.InternalMessageInfo
+
+type StatusResponse struct {
+	ChainId           string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Moniker           string `protobuf:"bytes,2,opt,name=moniker,proto3" json:"moniker,omitempty"`
+	LatestBlockHeight int64  `protobuf:"varint,3,opt,name=latest_block_height,json=latestBlockHeight,proto3" json:"latest_block_height,omitempty"`
+	// Unix time in milliseconds.
+	LatestBlockTime      int64    `protobuf:"varint,4,opt,name=latest_block_time,json=latestBlockTime,proto3" json:"latest_block_time,omitempty"`
+	CatchingUp           bool     `protobuf:"varint,5,opt,name=catching_up,json=catchingUp,proto3" json:"catching_up,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
+func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()    {}
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{1}
+}
+
+func (m *StatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusResponse.Unmarshal(m, b)
+}
+func (m *StatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusResponse.Marshal(b, m, deterministic)
+}
+func (m *StatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusResponse.Merge(m, src)
+}
+func (m *StatusResponse) XXX_Size() int {
+	return xxx_messageInfo_StatusResponse.Size(m)
+}
+func (m *StatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusResponse proto.InternalMessageInfo
+
+func (m *StatusResponse) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *StatusResponse) GetMoniker() string {
+	if m != nil {
+		return m.Moniker
+	}
+	return ""
+}
+
+func (m *StatusResponse) GetLatestBlockHeight() int64 {
+	if m != nil {
+		return m.LatestBlockHeight
+	}
+	return 0
+}
+
+func (m *StatusResponse) GetLatestBlockTime() int64 {
+	if m != nil {
+		return m.LatestBlockTime
+	}
+	return 0
+}
+
+func (m *StatusResponse) GetCatchingUp() bool {
+	if m != nil {
+		return m.CatchingUp
+	}
+	return false
+}
+
+type AccountRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountRequest) Reset()         { *m = AccountRequest{} }
+func (m *AccountRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountRequest) ProtoMessage()    {}
+func (*AccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{2}
+}
+
+func (m *AccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountRequest.Unmarshal(m, b)
+}
+func (m *AccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountRequest.Marshal(b, m, deterministic)
+}
+func (m *AccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountRequest.Merge(m, src)
+}
+func (m *AccountRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountRequest.Size(m)
+}
+func (m *AccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountRequest proto.InternalMessageInfo
+
+func (m *AccountRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type Coin struct {
+	Denom                string   `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty"`
+	Amount               int64    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Coin) Reset()         { *m = Coin{} }
+func (m *Coin) String() string { return proto.CompactTextString(m) }
+func (*Coin) ProtoMessage()    {}
+func (*Coin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{3}
+}
+
+func (m *Coin) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Coin.Unmarshal(m, b)
+}
+func (m *Coin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Coin.Marshal(b, m, deterministic)
+}
+func (m *Coin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Coin.Merge(m, src)
+}
+func (m *Coin) XXX_Size() int {
+	return xxx_messageInfo_Coin.Size(m)
+}
+func (m *Coin) XXX_DiscardUnknown() {
+	xxx_messageInfo_Coin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Coin proto.InternalMessageInfo
+
+func (m *Coin) GetDenom() string {
+	if m != nil {
+		return m.Denom
+	}
+	return ""
+}
+
+func (m *Coin) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type Account struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	AccountNumber        int64    `protobuf:"varint,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	Sequence             int64    `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Coins                []*Coin  `protobuf:"bytes,4,rep,name=coins,proto3" json:"coins,omitempty"`
+	Flags                uint64   `protobuf:"varint,5,opt,name=flags,proto3" json:"flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Account) Reset()         { *m = Account{} }
+func (m *Account) String() string { return proto.CompactTextString(m) }
+func (*Account) ProtoMessage()    {}
+func (*Account) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{4}
+}
+
+func (m *Account) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Account.Unmarshal(m, b)
+}
+func (m *Account) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Account.Marshal(b, m, deterministic)
+}
+func (m *Account) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Account.Merge(m, src)
+}
+func (m *Account) XXX_Size() int {
+	return xxx_messageInfo_Account.Size(m)
+}
+func (m *Account) XXX_DiscardUnknown() {
+	xxx_messageInfo_Account.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Account proto.InternalMessageInfo
+
+func (m *Account) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Account) GetAccountNumber() int64 {
+	if m != nil {
+		return m.AccountNumber
+	}
+	return 0
+}
+
+func (m *Account) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Account) GetCoins() []*Coin {
+	if m != nil {
+		return m.Coins
+	}
+	return nil
+}
+
+func (m *Account) GetFlags() uint64 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+type Balance struct {
+	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Free                 int64    `protobuf:"varint,2,opt,name=free,proto3" json:"free,omitempty"`
+	Locked               int64    `protobuf:"varint,3,opt,name=locked,proto3" json:"locked,omitempty"`
+	Frozen               int64    `protobuf:"varint,4,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Balance) Reset()         { *m = Balance{} }
+func (m *Balance) String() string { return proto.CompactTextString(m) }
+func (*Balance) ProtoMessage()    {}
+func (*Balance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{5}
+}
+
+func (m *Balance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Balance.Unmarshal(m, b)
+}
+func (m *Balance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Balance.Marshal(b, m, deterministic)
+}
+func (m *Balance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Balance.Merge(m, src)
+}
+func (m *Balance) XXX_Size() int {
+	return xxx_messageInfo_Balance.Size(m)
+}
+func (m *Balance) XXX_DiscardUnknown() {
+	xxx_messageInfo_Balance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Balance proto.InternalMessageInfo
+
+func (m *Balance) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *Balance) GetFree() int64 {
+	if m != nil {
+		return m.Free
+	}
+	return 0
+}
+
+func (m *Balance) GetLocked() int64 {
+	if m != nil {
+		return m.Locked
+	}
+	return 0
+}
+
+func (m *Balance) GetFrozen() int64 {
+	if m != nil {
+		return m.Frozen
+	}
+	return 0
+}
+
+type BalancesResponse struct {
+	Balances             []*Balance `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BalancesResponse) Reset()         { *m = BalancesResponse{} }
+func (m *BalancesResponse) String() string { return proto.CompactTextString(m) }
+func (*BalancesResponse) ProtoMessage()    {}
+func (*BalancesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{6}
+}
+
+func (m *BalancesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalancesResponse.Unmarshal(m, b)
+}
+func (m *BalancesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalancesResponse.Marshal(b, m, deterministic)
+}
+func (m *BalancesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalancesResponse.Merge(m, src)
+}
+func (m *BalancesResponse) XXX_Size() int {
+	return xxx_messageInfo_BalancesResponse.Size(m)
+}
+func (m *BalancesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalancesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalancesResponse proto.InternalMessageInfo
+
+func (m *BalancesResponse) GetBalances() []*Balance {
+	if m != nil {
+		return m.Balances
+	}
+	return nil
+}
+
+type TxRequest struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxRequest) Reset()         { *m = TxRequest{} }
+func (m *TxRequest) String() string { return proto.CompactTextString(m) }
+func (*TxRequest) ProtoMessage()    {}
+func (*TxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{7}
+}
+
+func (m *TxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxRequest.Unmarshal(m, b)
+}
+func (m *TxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxRequest.Marshal(b, m, deterministic)
+}
+func (m *TxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxRequest.Merge(m, src)
+}
+func (m *TxRequest) XXX_Size() int {
+	return xxx_messageInfo_TxRequest.Size(m)
+}
+func (m *TxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxRequest proto.InternalMessageInfo
+
+func (m *TxRequest) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+type TxResponse struct {
+	Hash   []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height int64  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Code   uint32 `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Log    string `protobuf:"bytes,4,opt,name=log,proto3" json:"log,omitempty"`
+	Data   []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	// The amino encoded tx.
+	Tx                   []byte   `protobuf:"bytes,6,opt,name=tx,proto3" json:"tx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxResponse) Reset()         { *m = TxResponse{} }
+func (m *TxResponse) String() string { return proto.CompactTextString(m) }
+func (*TxResponse) ProtoMessage()    {}
+func (*TxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{8}
+}
+
+func (m *TxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxResponse.Unmarshal(m, b)
+}
+func (m *TxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxResponse.Marshal(b, m, deterministic)
+}
+func (m *TxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxResponse.Merge(m, src)
+}
+func (m *TxResponse) XXX_Size() int {
+	return xxx_messageInfo_TxResponse.Size(m)
+}
+func (m *TxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxResponse proto.InternalMessageInfo
+
+func (m *TxResponse) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *TxResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *TxResponse) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *TxResponse) GetLog() string {
+	if m != nil {
+		return m.Log
+	}
+	return ""
+}
+
+func (m *TxResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *TxResponse) GetTx() []byte {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+type DepthRequest struct {
+	// The trading pair, like BNB_BTCB-1DE.
+	Pair                 string   `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Level                int32    `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DepthRequest) Reset()         { *m = DepthRequest{} }
+func (m *DepthRequest) String() string { return proto.CompactTextString(m) }
+func (*DepthRequest) ProtoMessage()    {}
+func (*DepthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{9}
+}
+
+func (m *DepthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DepthRequest.Unmarshal(m, b)
+}
+func (m *DepthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DepthRequest.Marshal(b, m, deterministic)
+}
+func (m *DepthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DepthRequest.Merge(m, src)
+}
+func (m *DepthRequest) XXX_Size() int {
+	return xxx_messageInfo_DepthRequest.Size(m)
+}
+func (m *DepthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DepthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DepthRequest proto.InternalMessageInfo
+
+func (m *DepthRequest) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *DepthRequest) GetLevel() int32 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+type PriceLevel struct {
+	BuyPrice             int64    `protobuf:"varint,1,opt,name=buy_price,json=buyPrice,proto3" json:"buy_price,omitempty"`
+	BuyQty               int64    `protobuf:"varint,2,opt,name=buy_qty,json=buyQty,proto3" json:"buy_qty,omitempty"`
+	SellPrice            int64    `protobuf:"varint,3,opt,name=sell_price,json=sellPrice,proto3" json:"sell_price,omitempty"`
+	SellQty              int64    `protobuf:"varint,4,opt,name=sell_qty,json=sellQty,proto3" json:"sell_qty,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PriceLevel) Reset()         { *m = PriceLevel{} }
+func (m *PriceLevel) String() string { return proto.CompactTextString(m) }
+func (*PriceLevel) ProtoMessage()    {}
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{10}
+}
+
+func (m *PriceLevel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PriceLevel.Unmarshal(m, b)
+}
+func (m *PriceLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PriceLevel.Marshal(b, m, deterministic)
+}
+func (m *PriceLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PriceLevel.Merge(m, src)
+}
+func (m *PriceLevel) XXX_Size() int {
+	return xxx_messageInfo_PriceLevel.Size(m)
+}
+func (m *PriceLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_PriceLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PriceLevel proto.InternalMessageInfo
+
+func (m *PriceLevel) GetBuyPrice() int64 {
+	if m != nil {
+		return m.BuyPrice
+	}
+	return 0
+}
+
+func (m *PriceLevel) GetBuyQty() int64 {
+	if m != nil {
+		return m.BuyQty
+	}
+	return 0
+}
+
+func (m *PriceLevel) GetSellPrice() int64 {
+	if m != nil {
+		return m.SellPrice
+	}
+	return 0
+}
+
+func (m *PriceLevel) GetSellQty() int64 {
+	if m != nil {
+		return m.SellQty
+	}
+	return 0
+}
+
+type Depth struct {
+	Height               int64         `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Levels               []*PriceLevel `protobuf:"bytes,2,rep,name=levels,proto3" json:"levels,omitempty"`
+	PendingMatch         bool          `protobuf:"varint,3,opt,name=pending_match,json=pendingMatch,proto3" json:"pending_match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Depth) Reset()         { *m = Depth{} }
+func (m *Depth) String() string { return proto.CompactTextString(m) }
+func (*Depth) ProtoMessage()    {}
+func (*Depth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{11}
+}
+
+func (m *Depth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Depth.Unmarshal(m, b)
+}
+func (m *Depth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Depth.Marshal(b, m, deterministic)
+}
+func (m *Depth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Depth.Merge(m, src)
+}
+func (m *Depth) XXX_Size() int {
+	return xxx_messageInfo_Depth.Size(m)
+}
+func (m *Depth) XXX_DiscardUnknown() {
+	xxx_messageInfo_Depth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Depth proto.InternalMessageInfo
+
+func (m *Depth) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Depth) GetLevels() []*PriceLevel {
+	if m != nil {
+		return m.Levels
+	}
+	return nil
+}
+
+func (m *Depth) GetPendingMatch() bool {
+	if m != nil {
+		return m.PendingMatch
+	}
+	return false
+}
+
+type OpenOrdersRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Pair                 string   `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenOrdersRequest) Reset()         { *m = OpenOrdersRequest{} }
+func (m *OpenOrdersRequest) String() string { return proto.CompactTextString(m) }
+func (*OpenOrdersRequest) ProtoMessage()    {}
+func (*OpenOrdersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{12}
+}
+
+func (m *OpenOrdersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenOrdersRequest.Unmarshal(m, b)
+}
+func (m *OpenOrdersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenOrdersRequest.Marshal(b, m, deterministic)
+}
+func (m *OpenOrdersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenOrdersRequest.Merge(m, src)
+}
+func (m *OpenOrdersRequest) XXX_Size() int {
+	return xxx_messageInfo_OpenOrdersRequest.Size(m)
+}
+func (m *OpenOrdersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenOrdersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenOrdersRequest proto.InternalMessageInfo
+
+func (m *OpenOrdersRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *OpenOrdersRequest) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+type OpenOrder struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol               string   `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Price                int64    `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity             int64    `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CumQty               int64    `protobuf:"varint,5,opt,name=cum_qty,json=cumQty,proto3" json:"cum_qty,omitempty"`
+	CreatedHeight        int64    `protobuf:"varint,6,opt,name=created_height,json=createdHeight,proto3" json:"created_height,omitempty"`
+	CreatedTimestamp     int64    `protobuf:"varint,7,opt,name=created_timestamp,json=createdTimestamp,proto3" json:"created_timestamp,omitempty"`
+	LastUpdatedHeight    int64    `protobuf:"varint,8,opt,name=last_updated_height,json=lastUpdatedHeight,proto3" json:"last_updated_height,omitempty"`
+	LastUpdatedTimestamp int64    `protobuf:"varint,9,opt,name=last_updated_timestamp,json=lastUpdatedTimestamp,proto3" json:"last_updated_timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenOrder) Reset()         { *m = OpenOrder{} }
+func (m *OpenOrder) String() string { return proto.CompactTextString(m) }
+func (*OpenOrder) ProtoMessage()    {}
+func (*OpenOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{13}
+}
+
+func (m *OpenOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenOrder.Unmarshal(m, b)
+}
+func (m *OpenOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenOrder.Marshal(b, m, deterministic)
+}
+func (m *OpenOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenOrder.Merge(m, src)
+}
+func (m *OpenOrder) XXX_Size() int {
+	return xxx_messageInfo_OpenOrder.Size(m)
+}
+func (m *OpenOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenOrder proto.InternalMessageInfo
+
+func (m *OpenOrder) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *OpenOrder) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *OpenOrder) GetPrice() int64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *OpenOrder) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *OpenOrder) GetCumQty() int64 {
+	if m != nil {
+		return m.CumQty
+	}
+	return 0
+}
+
+func (m *OpenOrder) GetCreatedHeight() int64 {
+	if m != nil {
+		return m.CreatedHeight
+	}
+	return 0
+}
+
+func (m *OpenOrder) GetCreatedTimestamp() int64 {
+	if m != nil {
+		return m.CreatedTimestamp
+	}
+	return 0
+}
+
+func (m *OpenOrder) GetLastUpdatedHeight() int64 {
+	if m != nil {
+		return m.LastUpdatedHeight
+	}
+	return 0
+}
+
+func (m *OpenOrder) GetLastUpdatedTimestamp() int64 {
+	if m != nil {
+		return m.LastUpdatedTimestamp
+	}
+	return 0
+}
+
+type OpenOrdersResponse struct {
+	Orders               []*OpenOrder `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *OpenOrdersResponse) Reset()         { *m = OpenOrdersResponse{} }
+func (m *OpenOrdersResponse) String() string { return proto.CompactTextString(m) }
+func (*OpenOrdersResponse) ProtoMessage()    {}
+func (*OpenOrdersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{14}
+}
+
+func (m *OpenOrdersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenOrdersResponse.Unmarshal(m, b)
+}
+func (m *OpenOrdersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenOrdersResponse.Marshal(b, m, deterministic)
+}
+func (m *OpenOrdersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenOrdersResponse.Merge(m, src)
+}
+func (m *OpenOrdersResponse) XXX_Size() int {
+	return xxx_messageInfo_OpenOrdersResponse.Size(m)
+}
+func (m *OpenOrdersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenOrdersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenOrdersResponse proto.InternalMessageInfo
+
+func (m *OpenOrdersResponse) GetOrders() []*OpenOrder {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type ListTokensRequest struct {
+	Offset               int32    `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTokensRequest) Reset()         { *m = ListTokensRequest{} }
+func (m *ListTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTokensRequest) ProtoMessage()    {}
+func (*ListTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{15}
+}
+
+func (m *ListTokensRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTokensRequest.Unmarshal(m, b)
+}
+func (m *ListTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTokensRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTokensRequest.Merge(m, src)
+}
+func (m *ListTokensRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTokensRequest.Size(m)
+}
+func (m *ListTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTokensRequest proto.InternalMessageInfo
+
+func (m *ListTokensRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListTokensRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type Token struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol               string   `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OriginalSymbol       string   `protobuf:"bytes,3,opt,name=original_symbol,json=originalSymbol,proto3" json:"original_symbol,omitempty"`
+	TotalSupply          int64    `protobuf:"varint,4,opt,name=total_supply,json=totalSupply,proto3" json:"total_supply,omitempty"`
+	Owner                string   `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	Mintable             bool     `protobuf:"varint,6,opt,name=mintable,proto3" json:"mintable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Token) Reset()         { *m = Token{} }
+func (m *Token) String() string { return proto.CompactTextString(m) }
+func (*Token) ProtoMessage()    {}
+func (*Token) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{16}
+}
+
+func (m *Token) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Token.Unmarshal(m, b)
+}
+func (m *Token) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Token.Marshal(b, m, deterministic)
+}
+func (m *Token) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Token.Merge(m, src)
+}
+func (m *Token) XXX_Size() int {
+	return xxx_messageInfo_Token.Size(m)
+}
+func (m *Token) XXX_DiscardUnknown() {
+	xxx_messageInfo_Token.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Token proto.InternalMessageInfo
+
+func (m *Token) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Token) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *Token) GetOriginalSymbol() string {
+	if m != nil {
+		return m.OriginalSymbol
+	}
+	return ""
+}
+
+func (m *Token) GetTotalSupply() int64 {
+	if m != nil {
+		return m.TotalSupply
+	}
+	return 0
+}
+
+func (m *Token) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Token) GetMintable() bool {
+	if m != nil {
+		return m.Mintable
+	}
+	return false
+}
+
+type ListTokensResponse struct {
+	Tokens               []*Token `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTokensResponse) Reset()         { *m = ListTokensResponse{} }
+func (m *ListTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTokensResponse) ProtoMessage()    {}
+func (*ListTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{17}
+}
+
+func (m *ListTokensResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTokensResponse.Unmarshal(m, b)
+}
+func (m *ListTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTokensResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTokensResponse.Merge(m, src)
+}
+func (m *ListTokensResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTokensResponse.Size(m)
+}
+func (m *ListTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTokensResponse proto.InternalMessageInfo
+
+func (m *ListTokensResponse) GetTokens() []*Token {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+type BroadcastTxRequest struct {
+	Tx                   []byte        `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Mode                 BroadcastMode `protobuf:"varint,2,opt,name=mode,proto3,enum=binance.sdk.v1.BroadcastMode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BroadcastTxRequest) Reset()         { *m = BroadcastTxRequest{} }
+func (m *BroadcastTxRequest) String() string { return proto.CompactTextString(m) }
+func (*BroadcastTxRequest) ProtoMessage()    {}
+func (*BroadcastTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{18}
+}
+
+func (m *BroadcastTxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BroadcastTxRequest.Unmarshal(m, b)
+}
+func (m *BroadcastTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BroadcastTxRequest.Marshal(b, m, deterministic)
+}
+func (m *BroadcastTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BroadcastTxRequest.Merge(m, src)
+}
+func (m *BroadcastTxRequest) XXX_Size() int {
+	return xxx_messageInfo_BroadcastTxRequest.Size(m)
+}
+func (m *BroadcastTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BroadcastTxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BroadcastTxRequest proto.InternalMessageInfo
+
+func (m *BroadcastTxRequest) GetTx() []byte {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+func (m *BroadcastTxRequest) GetMode() BroadcastMode {
+	if m != nil {
+		return m.Mode
+	}
+	return BroadcastMode_SYNC
+}
+
+type BroadcastResponse struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Code                 uint32   `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Log                  string   `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	Data                 []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BroadcastResponse) Reset()         { *m = BroadcastResponse{} }
+func (m *BroadcastResponse) String() string { return proto.CompactTextString(m) }
+func (*BroadcastResponse) ProtoMessage()    {}
+func (*BroadcastResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{19}
+}
+
+func (m *BroadcastResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BroadcastResponse.Unmarshal(m, b)
+}
+func (m *BroadcastResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BroadcastResponse.Marshal(b, m, deterministic)
+}
+func (m *BroadcastResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BroadcastResponse.Merge(m, src)
+}
+func (m *BroadcastResponse) XXX_Size() int {
+	return xxx_messageInfo_BroadcastResponse.Size(m)
+}
+func (m *BroadcastResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BroadcastResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BroadcastResponse proto.InternalMessageInfo
+
+func (m *BroadcastResponse) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *BroadcastResponse) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *BroadcastResponse) GetLog() string {
+	if m != nil {
+		return m.Log
+	}
+	return ""
+}
+
+func (m *BroadcastResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type AddressRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddressRequest) Reset()         { *m = AddressRequest{} }
+func (m *AddressRequest) String() string { return proto.CompactTextString(m) }
+func (*AddressRequest) ProtoMessage()    {}
+func (*AddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{20}
+}
+
+func (m *AddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddressRequest.Unmarshal(m, b)
+}
+func (m *AddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddressRequest.Marshal(b, m, deterministic)
+}
+func (m *AddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddressRequest.Merge(m, src)
+}
+func (m *AddressRequest) XXX_Size() int {
+	return xxx_messageInfo_AddressRequest.Size(m)
+}
+func (m *AddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddressRequest proto.InternalMessageInfo
+
+type AddressResponse struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddressResponse) Reset()         { *m = AddressResponse{} }
+func (m *AddressResponse) String() string { return proto.CompactTextString(m) }
+func (*AddressResponse) ProtoMessage()    {}
+func (*AddressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{21}
+}
+
+func (m *AddressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddressResponse.Unmarshal(m, b)
+}
+func (m *AddressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddressResponse.Marshal(b, m, deterministic)
+}
+func (m *AddressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddressResponse.Merge(m, src)
+}
+func (m *AddressResponse) XXX_Size() int {
+	return xxx_messageInfo_AddressResponse.Size(m)
+}
+func (m *AddressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddressResponse proto.InternalMessageInfo
+
+func (m *AddressResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type TransferRequest struct {
+	To                   string        `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"`
+	Coins                []*Coin       `protobuf:"bytes,2,rep,name=coins,proto3" json:"coins,omitempty"`
+	Memo                 string        `protobuf:"bytes,3,opt,name=memo,proto3" json:"memo,omitempty"`
+	Mode                 BroadcastMode `protobuf:"varint,4,opt,name=mode,proto3,enum=binance.sdk.v1.BroadcastMode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
+func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferRequest) ProtoMessage()    {}
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{22}
+}
+
+func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferRequest.Unmarshal(m, b)
+}
+func (m *TransferRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferRequest.Marshal(b, m, deterministic)
+}
+func (m *TransferRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferRequest.Merge(m, src)
+}
+func (m *TransferRequest) XXX_Size() int {
+	return xxx_messageInfo_TransferRequest.Size(m)
+}
+func (m *TransferRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferRequest proto.InternalMessageInfo
+
+func (m *TransferRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *TransferRequest) GetCoins() []*Coin {
+	if m != nil {
+		return m.Coins
+	}
+	return nil
+}
+
+func (m *TransferRequest) GetMemo() string {
+	if m != nil {
+		return m.Memo
+	}
+	return ""
+}
+
+func (m *TransferRequest) GetMode() BroadcastMode {
+	if m != nil {
+		return m.Mode
+	}
+	return BroadcastMode_SYNC
+}
+
+type CreateOrderRequest struct {
+	Base                 string        `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Quote                string        `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	Side                 Side          `protobuf:"varint,3,opt,name=side,proto3,enum=binance.sdk.v1.Side" json:"side,omitempty"`
+	Price                int64         `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity             int64         `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Mode                 BroadcastMode `protobuf:"varint,6,opt,name=mode,proto3,enum=binance.sdk.v1.BroadcastMode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CreateOrderRequest) Reset()         { *m = CreateOrderRequest{} }
+func (m *CreateOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateOrderRequest) ProtoMessage()    {}
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{23}
+}
+
+func (m *CreateOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateOrderRequest.Unmarshal(m, b)
+}
+func (m *CreateOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateOrderRequest.Merge(m, src)
+}
+func (m *CreateOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateOrderRequest.Size(m)
+}
+func (m *CreateOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateOrderRequest proto.InternalMessageInfo
+
+func (m *CreateOrderRequest) GetBase() string {
+	if m != nil {
+		return m.Base
+	}
+	return ""
+}
+
+func (m *CreateOrderRequest) GetQuote() string {
+	if m != nil {
+		return m.Quote
+	}
+	return ""
+}
+
+func (m *CreateOrderRequest) GetSide() Side {
+	if m != nil {
+		return m.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (m *CreateOrderRequest) GetPrice() int64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *CreateOrderRequest) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *CreateOrderRequest) GetMode() BroadcastMode {
+	if m != nil {
+		return m.Mode
+	}
+	return BroadcastMode_SYNC
+}
+
+type CancelOrderRequest struct {
+	Base                 string        `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Quote                string        `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	OrderId              string        `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Mode                 BroadcastMode `protobuf:"varint,4,opt,name=mode,proto3,enum=binance.sdk.v1.BroadcastMode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CancelOrderRequest) Reset()         { *m = CancelOrderRequest{} }
+func (m *CancelOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelOrderRequest) ProtoMessage()    {}
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70decb0fb6f436df, []int{24}
+}
+
+func (m *CancelOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOrderRequest.Unmarshal(m, b)
+}
+func (m *CancelOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOrderRequest.Merge(m, src)
+}
+func (m *CancelOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelOrderRequest.Size(m)
+}
+func (m *CancelOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOrderRequest proto.InternalMessageInfo
+
+func (m *CancelOrderRequest) GetBase() string {
+	if m != nil {
+		return m.Base
+	}
+	return ""
+}
+
+func (m *CancelOrderRequest) GetQuote() string {
+	if m != nil {
+		return m.Quote
+	}
+	return ""
+}
+
+func (m *CancelOrderRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *CancelOrderRequest) GetMode() BroadcastMode {
+	if m != nil {
+		return m.Mode
+	}
+	return BroadcastMode_SYNC
+}
+
+func init() {
+	proto.RegisterEnum("binance.sdk.v1.BroadcastMode", BroadcastMode_name, BroadcastMode_value)
+	proto.RegisterEnum("binance.sdk.v1.Side", Side_name, Side_value)
+	proto.RegisterType((*StatusRequest)(nil), "binance.sdk.v1.StatusRequest")
+	proto.RegisterType((*StatusResponse)(nil), "binance.sdk.v1.StatusResponse")
+	proto.RegisterType((*AccountRequest)(nil), "binance.sdk.v1.AccountRequest")
+	proto.RegisterType((*Coin)(nil), "binance.sdk.v1.Coin")
+	proto.RegisterType((*Account)(nil), "binance.sdk.v1.Account")
+	proto.RegisterType((*Balance)(nil), "binance.sdk.v1.Balance")
+	proto.RegisterType((*BalancesResponse)(nil), "binance.sdk.v1.BalancesResponse")
+	proto.RegisterType((*TxRequest)(nil), "binance.sdk.v1.TxRequest")
+	proto.RegisterType((*TxResponse)(nil), "binance.sdk.v1.TxResponse")
+	proto.RegisterType((*DepthRequest)(nil), "binance.sdk.v1.DepthRequest")
+	proto.RegisterType((*PriceLevel)(nil), "binance.sdk.v1.PriceLevel")
+	proto.RegisterType((*Depth)(nil), "binance.sdk.v1.Depth")
+	proto.RegisterType((*OpenOrdersRequest)(nil), "binance.sdk.v1.OpenOrdersRequest")
+	proto.RegisterType((*OpenOrder)(nil), "binance.sdk.v1.OpenOrder")
+	proto.RegisterType((*OpenOrdersResponse)(nil), "binance.sdk.v1.OpenOrdersResponse")
+	proto.RegisterType((*ListTokensRequest)(nil), "binance.sdk.v1.ListTokensRequest")
+	proto.RegisterType((*Token)(nil), "binance.sdk.v1.Token")
+	proto.RegisterType((*ListTokensResponse)(nil), "binance.sdk.v1.ListTokensResponse")
+	proto.RegisterType((*BroadcastTxRequest)(nil), "binance.sdk.v1.BroadcastTxRequest")
+	proto.RegisterType((*BroadcastResponse)(nil), "binance.sdk.v1.BroadcastResponse")
+	proto.RegisterType((*AddressRequest)(nil), "binance.sdk.v1.AddressRequest")
+	proto.RegisterType((*AddressResponse)(nil), "binance.sdk.v1.AddressResponse")
+	proto.RegisterType((*TransferRequest)(nil), "binance.sdk.v1.TransferRequest")
+	proto.RegisterType((*CreateOrderRequest)(nil), "binance.sdk.v1.CreateOrderRequest")
+	proto.RegisterType((*CancelOrderRequest)(nil), "binance.sdk.v1.CancelOrderRequest")
+}
+
+func init() { proto.RegisterFile("sdk.proto", fileDescriptor_70decb0fb6f436df) }
+
+var fileDescriptor_70decb0fb6f436df = []byte{
+	// 1400 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x4b, 0x73, 0x1b, 0x45,
+	0x10, 0xce, 0xae, 0x56, 0xaf, 0xb6, 0x2d, 0xcb, 0x83, 0x93, 0xc8, 0x82, 0x10, 0x67, 0xa9, 0x14,
+	0x2e, 0xa7, 0x30, 0xc4, 0xc9, 0x81, 0x13, 0x94, 0xad, 0x18, 0x61, 0xca, 0x8f, 0x64, 0x25, 0x43,
+	0x85, 0x8b, 0x6a, 0xb5, 0x3b, 0xb6, 0xb6, 0xbc, 0x2f, 0xef, 0xce, 0x06, 0x8b, 0xe2, 0xc4, 0x99,
+	0x23, 0x3f, 0x82, 0x2b, 0x37, 0xce, 0xdc, 0xa9, 0xe2, 0x87, 0xf0, 0x23, 0xa8, 0x9e, 0x99, 0x7d,
+	0x48, 0x6b, 0x25, 0xae, 0xdc, 0xa6, 0x7b, 0xbe, 0x99, 0xed, 0xfe, 0x7a, 0xfa, 0x9b, 0x59, 0x68,
+	0xc6, 0xf6, 0xe5, 0x4e, 0x18, 0x05, 0x2c, 0x20, 0xad, 0xb1, 0xe3, 0x9b, 0xbe, 0x45, 0x77, 0xd0,
+	0xf5, 0xe6, 0xa9, 0xbe, 0x0a, 0x2b, 0x03, 0x66, 0xb2, 0x24, 0x36, 0xe8, 0x55, 0x42, 0x63, 0xa6,
+	0xff, 0xad, 0x40, 0x2b, 0xf5, 0xc4, 0x61, 0xe0, 0xc7, 0x94, 0x6c, 0x40, 0xc3, 0x9a, 0x98, 0x8e,
+	0x3f, 0x72, 0xec, 0x8e, 0xb2, 0xa9, 0x6c, 0x35, 0x8d, 0x3a, 0xb7, 0x0f, 0x6d, 0xd2, 0x81, 0xba,
+	0x17, 0xf8, 0xce, 0x25, 0x8d, 0x3a, 0xaa, 0x98, 0x91, 0x26, 0xd9, 0x81, 0x0f, 0x5c, 0x93, 0xd1,
+	0x98, 0x8d, 0xc6, 0x6e, 0x60, 0x5d, 0x8e, 0x26, 0xd4, 0xb9, 0x98, 0xb0, 0x4e, 0x65, 0x53, 0xd9,
+	0xaa, 0x18, 0x6b, 0x62, 0x6a, 0x1f, 0x67, 0xbe, 0xe5, 0x13, 0x64, 0x1b, 0xd6, 0x66, 0xf0, 0xcc,
+	0xf1, 0x68, 0x47, 0xe3, 0xe8, 0xd5, 0x02, 0x7a, 0xe8, 0x78, 0x94, 0x3c, 0x84, 0x25, 0xcb, 0x64,
+	0xd6, 0xc4, 0xf1, 0x2f, 0x46, 0x49, 0xd8, 0xa9, 0x6e, 0x2a, 0x5b, 0x0d, 0x03, 0x52, 0xd7, 0x59,
+	0xa8, 0x6f, 0x43, 0x6b, 0xcf, 0xb2, 0x82, 0xc4, 0x67, 0x32, 0x2d, 0x0c, 0xd4, 0xb4, 0xed, 0x88,
+	0xc6, 0x71, 0x9a, 0x82, 0x34, 0xf5, 0xe7, 0xa0, 0xf5, 0x02, 0xc7, 0x27, 0xeb, 0x50, 0xb5, 0xa9,
+	0x1f, 0x78, 0x72, 0x5e, 0x18, 0xe4, 0x1e, 0xd4, 0x4c, 0x0f, 0x37, 0xe2, 0xf9, 0x55, 0x0c, 0x69,
+	0xe9, 0x7f, 0x28, 0x50, 0x97, 0x9f, 0x58, 0xbc, 0x37, 0x79, 0x0c, 0x2d, 0x53, 0x80, 0x46, 0x7e,
+	0xe2, 0x8d, 0x25, 0x4b, 0x15, 0x63, 0x45, 0x7a, 0x4f, 0xb8, 0x93, 0x74, 0xa1, 0x11, 0x63, 0x9c,
+	0xbe, 0x45, 0x25, 0x41, 0x99, 0x4d, 0xb6, 0xa1, 0x6a, 0x05, 0x8e, 0x1f, 0x77, 0xb4, 0xcd, 0xca,
+	0xd6, 0xd2, 0xee, 0xfa, 0xce, 0x6c, 0x01, 0x77, 0x30, 0x76, 0x43, 0x40, 0x30, 0x85, 0x73, 0xd7,
+	0xbc, 0x88, 0x39, 0x23, 0x9a, 0x21, 0x0c, 0x9d, 0x42, 0x7d, 0xdf, 0x74, 0x71, 0x0d, 0x66, 0x13,
+	0x4f, 0xbd, 0x71, 0xe0, 0xca, 0x40, 0xa5, 0x45, 0x08, 0x68, 0xe7, 0x11, 0xa5, 0x32, 0x3a, 0x3e,
+	0x46, 0x2c, 0x12, 0x4e, 0x6d, 0x19, 0x92, 0xb4, 0xd0, 0x7f, 0x1e, 0x05, 0x3f, 0x53, 0x5f, 0x56,
+	0x47, 0x5a, 0x7a, 0x1f, 0xda, 0xf2, 0x33, 0xf9, 0xc9, 0x79, 0x06, 0x8d, 0xb1, 0xf4, 0x75, 0x14,
+	0x1e, 0xff, 0xfd, 0xf9, 0xf8, 0xe5, 0x1a, 0x23, 0x03, 0xea, 0x0f, 0xa1, 0x39, 0xbc, 0x4e, 0xeb,
+	0x46, 0x40, 0x9b, 0x98, 0xf1, 0x84, 0xc7, 0xbb, 0x6c, 0xf0, 0xb1, 0xfe, 0xab, 0x02, 0x30, 0xbc,
+	0xce, 0x3e, 0x72, 0x03, 0x04, 0x83, 0x94, 0x07, 0x4e, 0x96, 0x4d, 0x58, 0x88, 0xb5, 0x02, 0x5b,
+	0xb0, 0xbc, 0x62, 0xf0, 0x31, 0x69, 0x43, 0xc5, 0x0d, 0x2e, 0x78, 0x36, 0x4d, 0x03, 0x87, 0x88,
+	0xb2, 0x4d, 0x66, 0x72, 0x1a, 0x97, 0x0d, 0x3e, 0x26, 0x2d, 0x50, 0xd9, 0x75, 0xa7, 0xc6, 0x3d,
+	0x2a, 0xbb, 0xd6, 0xbf, 0x84, 0xe5, 0x17, 0x34, 0x64, 0x93, 0x42, 0xa0, 0xa1, 0xe9, 0x44, 0x92,
+	0x58, 0x3e, 0xc6, 0x7a, 0xb8, 0xf4, 0x0d, 0x75, 0x79, 0x10, 0x55, 0x43, 0x18, 0xfa, 0x2f, 0x00,
+	0x2f, 0x23, 0xc7, 0xa2, 0x47, 0x68, 0x91, 0x0f, 0xa1, 0x39, 0x4e, 0xa6, 0xa3, 0x10, 0x3d, 0x7c,
+	0x71, 0xc5, 0x68, 0x8c, 0x93, 0x29, 0x47, 0x90, 0xfb, 0x50, 0xc7, 0xc9, 0x2b, 0x36, 0x4d, 0xf3,
+	0x18, 0x27, 0xd3, 0x57, 0x6c, 0x4a, 0x1e, 0x00, 0xc4, 0xd4, 0x75, 0xe5, 0x32, 0x51, 0xa0, 0x26,
+	0x7a, 0xc4, 0xba, 0x0d, 0x3c, 0x50, 0xae, 0xcb, 0x17, 0x8a, 0x2a, 0xd5, 0xd1, 0x7e, 0xc5, 0xa6,
+	0xfa, 0x35, 0x54, 0x79, 0xdc, 0x05, 0x8a, 0x94, 0x19, 0x8a, 0x76, 0xa1, 0xc6, 0xe3, 0x8c, 0x3b,
+	0x2a, 0xaf, 0x58, 0x77, 0xbe, 0x62, 0x79, 0xf0, 0x86, 0x44, 0x92, 0x4f, 0x60, 0x25, 0xa4, 0xbe,
+	0x8d, 0xfd, 0xe8, 0x61, 0x17, 0xf2, 0x88, 0x1a, 0xc6, 0xb2, 0x74, 0x1e, 0xa3, 0x4f, 0xdf, 0x83,
+	0xb5, 0xd3, 0x90, 0xfa, 0xa7, 0x91, 0x4d, 0xa3, 0xf8, 0x9d, 0x7d, 0x99, 0x11, 0xaa, 0xe6, 0x84,
+	0xea, 0x7f, 0xa9, 0xd0, 0xcc, 0xf6, 0xc0, 0x92, 0x64, 0x8a, 0xa4, 0x3a, 0x76, 0xe1, 0x74, 0xab,
+	0x33, 0xa7, 0x7b, 0x1d, 0xaa, 0x45, 0x9e, 0x84, 0x81, 0x4d, 0x77, 0x95, 0x98, 0x3e, 0x73, 0x32,
+	0x8e, 0x32, 0x1b, 0x79, 0xb7, 0x12, 0x8f, 0xd3, 0x57, 0x15, 0xe4, 0x58, 0x89, 0x87, 0xbc, 0x3f,
+	0x86, 0x96, 0x15, 0x51, 0x93, 0x51, 0x3b, 0x15, 0xb4, 0x9a, 0x68, 0x68, 0xe9, 0x95, 0x62, 0xf6,
+	0x04, 0xd6, 0x52, 0x18, 0xea, 0x58, 0xcc, 0x4c, 0x2f, 0xec, 0xd4, 0x39, 0xb2, 0x2d, 0x27, 0x86,
+	0xa9, 0x5f, 0x28, 0x65, 0xcc, 0x46, 0x49, 0x68, 0x17, 0x37, 0x6e, 0xa4, 0x4a, 0x19, 0xb3, 0xb3,
+	0xd0, 0x2e, 0x6c, 0xfe, 0x1c, 0xee, 0xcd, 0xe0, 0xf3, 0x2f, 0x34, 0xf9, 0x92, 0xf5, 0xc2, 0x92,
+	0xec, 0x2b, 0x7a, 0x1f, 0x48, 0x91, 0x7d, 0xd9, 0x3b, 0x4f, 0xa1, 0x16, 0x70, 0x8f, 0x6c, 0xcf,
+	0x8d, 0xf9, 0x62, 0x67, 0x6b, 0x0c, 0x09, 0xc4, 0x32, 0x1e, 0x39, 0x31, 0x1b, 0x06, 0x97, 0xd4,
+	0xcf, 0xca, 0x78, 0x0f, 0x6a, 0xc1, 0xf9, 0x79, 0x4c, 0xc5, 0x61, 0xaa, 0x1a, 0xd2, 0xe2, 0x1d,
+	0xe0, 0x78, 0x0e, 0xcb, 0x3a, 0x00, 0x0d, 0xfd, 0x4f, 0x05, 0xaa, 0x7c, 0x3d, 0x16, 0xd9, 0x37,
+	0x3d, 0x9a, 0x76, 0x0d, 0x8e, 0x17, 0x96, 0xf1, 0x53, 0x58, 0x0d, 0x22, 0xe7, 0xc2, 0xf1, 0x4d,
+	0x77, 0x24, 0x01, 0x15, 0x0e, 0x68, 0xa5, 0xee, 0x81, 0x00, 0x3e, 0x82, 0x65, 0x16, 0x30, 0x44,
+	0x25, 0x61, 0xe8, 0xa6, 0xd5, 0x5d, 0xe2, 0xbe, 0x01, 0x77, 0x61, 0x5c, 0xc1, 0x4f, 0x3e, 0x8d,
+	0x78, 0x79, 0x9b, 0x86, 0x30, 0xf0, 0x48, 0x78, 0x8e, 0xcf, 0xcc, 0xb1, 0x4b, 0x79, 0x5d, 0x1b,
+	0x46, 0x66, 0xeb, 0x3d, 0x20, 0xc5, 0xb4, 0x25, 0x7f, 0x9f, 0x41, 0x8d, 0x71, 0x8f, 0xe4, 0xef,
+	0xee, 0x3c, 0x7f, 0x1c, 0x6f, 0x48, 0x90, 0xfe, 0x03, 0x90, 0xfd, 0x28, 0x30, 0x6d, 0xcb, 0x8c,
+	0x59, 0xae, 0x71, 0x42, 0x5a, 0x94, 0x54, 0x5a, 0xc8, 0x53, 0xd0, 0x3c, 0x14, 0x29, 0x4c, 0xbf,
+	0xb5, 0xfb, 0xa0, 0xa4, 0x98, 0xe9, 0x0e, 0xc7, 0x81, 0x4d, 0x0d, 0x0e, 0xd5, 0x4d, 0x58, 0xcb,
+	0xdc, 0x6f, 0x15, 0xc6, 0x54, 0x00, 0xd5, 0xb2, 0x00, 0x56, 0xca, 0x02, 0xa8, 0xe5, 0x02, 0xa8,
+	0xb7, 0xa1, 0xb5, 0x27, 0x5a, 0x33, 0x7d, 0x2a, 0x3c, 0x81, 0xd5, 0xcc, 0x23, 0x3f, 0xb9, 0xf8,
+	0x9a, 0xfd, 0x5d, 0x81, 0xd5, 0x61, 0x64, 0xfa, 0xf1, 0x39, 0x8d, 0x8a, 0x89, 0x07, 0x69, 0x03,
+	0xb3, 0x20, 0xbf, 0xeb, 0xd4, 0x77, 0xdf, 0x75, 0x04, 0x34, 0x8f, 0x7a, 0x81, 0x8c, 0x9a, 0x8f,
+	0x33, 0xe2, 0xb4, 0xdb, 0x13, 0xf7, 0x8f, 0x02, 0xa4, 0xc7, 0x3b, 0x52, 0x9c, 0xf2, 0x5c, 0xcd,
+	0xc7, 0x66, 0x9c, 0x9d, 0x4b, 0x1c, 0xe3, 0x99, 0xb9, 0x4a, 0x02, 0x46, 0xe5, 0xb1, 0x14, 0x06,
+	0xd9, 0x02, 0x2d, 0x76, 0xe4, 0x8d, 0xd2, 0x2a, 0x87, 0x3c, 0x70, 0xf0, 0x53, 0x88, 0xc8, 0x65,
+	0x48, 0x5b, 0x24, 0x43, 0xd5, 0x39, 0x19, 0x4a, 0xf3, 0xa9, 0xdd, 0x3e, 0x9f, 0xdf, 0x30, 0x1f,
+	0x04, 0xb9, 0xef, 0x99, 0xcf, 0x06, 0x34, 0x78, 0xa3, 0xe3, 0x63, 0x4f, 0x70, 0x5b, 0xe7, 0xf6,
+	0xa1, 0xfd, 0x1e, 0xf4, 0x6e, 0x7f, 0x01, 0x2b, 0x33, 0x6e, 0xd2, 0x00, 0x6d, 0xf0, 0xfa, 0xa4,
+	0xd7, 0xbe, 0x43, 0x9a, 0x50, 0xdd, 0xe3, 0x43, 0x85, 0x00, 0xd4, 0x7a, 0xa7, 0xc7, 0xc7, 0x87,
+	0xc3, 0xb6, 0xba, 0xfd, 0x39, 0x68, 0x03, 0xc1, 0x56, 0x7b, 0x70, 0xf8, 0xe2, 0x60, 0x74, 0x76,
+	0x32, 0x78, 0x79, 0xd0, 0x3b, 0xfc, 0xe6, 0xf0, 0xe0, 0x45, 0xfb, 0x0e, 0xa9, 0x43, 0x65, 0xff,
+	0xec, 0x75, 0x5b, 0xe1, 0xfb, 0x1c, 0x1c, 0x1d, 0xb5, 0xd5, 0xdd, 0xff, 0x34, 0xa8, 0xf6, 0xf0,
+	0x39, 0x4a, 0xfa, 0x50, 0x13, 0x2f, 0x57, 0x52, 0x8a, 0x6d, 0xe6, 0x8d, 0xdb, 0xfd, 0x78, 0xd1,
+	0xb4, 0x3c, 0xc5, 0x07, 0x00, 0x7d, 0xca, 0xd2, 0xe7, 0x5d, 0x09, 0x3d, 0xfb, 0xb4, 0xec, 0xde,
+	0x5f, 0x30, 0x4f, 0x4e, 0x61, 0xa9, 0x4f, 0x59, 0xfa, 0x28, 0x7a, 0xe7, 0x3e, 0x9b, 0x0b, 0x9e,
+	0x46, 0x79, 0x5c, 0x5f, 0x41, 0xb5, 0x4f, 0xd9, 0xf0, 0x9a, 0x94, 0x64, 0x3a, 0x13, 0x93, 0x6e,
+	0xf7, 0xa6, 0x29, 0xb9, 0xfe, 0x6b, 0x68, 0xf4, 0x29, 0x13, 0xd7, 0xff, 0x47, 0xf3, 0xb8, 0xe2,
+	0x6b, 0xa6, 0x7b, 0xf7, 0xc6, 0x59, 0xf2, 0x3d, 0xac, 0xf4, 0x29, 0xcb, 0xef, 0x11, 0xf2, 0x68,
+	0xe1, 0x7d, 0x91, 0x91, 0xad, 0xbf, 0x0d, 0x22, 0x03, 0x1b, 0x00, 0xe4, 0xe2, 0x5a, 0xde, 0xb4,
+	0x74, 0xdf, 0x74, 0xf5, 0xb7, 0x41, 0xe4, 0xa6, 0x43, 0x58, 0x2a, 0x88, 0x2d, 0xd1, 0x17, 0x9e,
+	0xd7, 0x9c, 0xbc, 0x47, 0x0b, 0x31, 0xe9, 0xae, 0xbb, 0xff, 0xaa, 0x50, 0x1b, 0x38, 0x17, 0x78,
+	0x5d, 0x7c, 0x07, 0x75, 0xa9, 0x7f, 0x37, 0xd4, 0x76, 0x46, 0x2a, 0xbb, 0x0f, 0x17, 0xce, 0xcb,
+	0x60, 0x4f, 0xa0, 0x91, 0xaa, 0x23, 0x29, 0x81, 0xe7, 0x74, 0xf3, 0x16, 0x61, 0x62, 0xf2, 0x05,
+	0x59, 0x2b, 0x27, 0x5f, 0xd6, 0xbc, 0xdb, 0xee, 0x9a, 0x8b, 0xcb, 0x0d, 0xbb, 0x96, 0x94, 0xe7,
+	0x16, 0xbb, 0xee, 0x6b, 0x3f, 0xaa, 0xe1, 0x78, 0x5c, 0xe3, 0x3f, 0xa8, 0xcf, 0xfe, 0x1f, 0x00,
+	0xdc, 0xba, 0x67, 0xdd, 0xad, 0x0e, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ChainClient is the client API for Chain service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChainClient interface {
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*Account, error)
+	GetBalances(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*BalancesResponse, error)
+	GetTx(ctx context.Context, in *TxRequest, opts ...grpc.CallOption) (*TxResponse, error)
+	GetDepth(ctx context.Context, in *DepthRequest, opts ...grpc.CallOption) (*Depth, error)
+	GetOpenOrders(ctx context.Context, in *OpenOrdersRequest, opts ...grpc.CallOption) (*OpenOrdersResponse, error)
+	ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error)
+	// BroadcastTx broadcasts a signed, amino encoded tx.
+	BroadcastTx(ctx context.Context, in *BroadcastTxRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+}
+
+type chainClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChainClient(cc *grpc.ClientConn) ChainClient {
+	return &chainClient{cc}
+}
+
+func (c *chainClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Chain/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) GetAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Chain/GetAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) GetBalances(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*BalancesResponse, error) {
+	out := new(BalancesResponse)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Chain/GetBalances", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) GetTx(ctx context.Context, in *TxRequest, opts ...grpc.CallOption) (*TxResponse, error) {
+	out := new(TxResponse)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Chain/GetTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) GetDepth(ctx context.Context, in *DepthRequest, opts ...grpc.CallOption) (*Depth, error) {
+	out := new(Depth)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Chain/GetDepth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) GetOpenOrders(ctx context.Context, in *OpenOrdersRequest, opts ...grpc.CallOption) (*OpenOrdersResponse, error) {
+	out := new(OpenOrdersResponse)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Chain/GetOpenOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error) {
+	out := new(ListTokensResponse)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Chain/ListTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) BroadcastTx(ctx context.Context, in *BroadcastTxRequest, opts ...grpc.CallOption) (*BroadcastResponse, error) {
+	out := new(BroadcastResponse)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Chain/BroadcastTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChainServer is the server API for Chain service.
+type ChainServer interface {
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	GetAccount(context.Context, *AccountRequest) (*Account, error)
+	GetBalances(context.Context, *AccountRequest) (*BalancesResponse, error)
+	GetTx(context.Context, *TxRequest) (*TxResponse, error)
+	GetDepth(context.Context, *DepthRequest) (*Depth, error)
+	GetOpenOrders(context.Context, *OpenOrdersRequest) (*OpenOrdersResponse, error)
+	ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error)
+	// BroadcastTx broadcasts a signed, amino encoded tx.
+	BroadcastTx(context.Context, *BroadcastTxRequest) (*BroadcastResponse, error)
+}
+
+// UnimplementedChainServer can be embedded to have forward compatible implementations.
+type UnimplementedChainServer struct {
+}
+
+func (*UnimplementedChainServer) Status(ctx context.Context, req *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (*UnimplementedChainServer) GetAccount(ctx context.Context, req *AccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (*UnimplementedChainServer) GetBalances(ctx context.Context, req *AccountRequest) (*BalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalances not implemented")
+}
+func (*UnimplementedChainServer) GetTx(ctx context.Context, req *TxRequest) (*TxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
+}
+func (*UnimplementedChainServer) GetDepth(ctx context.Context, req *DepthRequest) (*Depth, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDepth not implemented")
+}
+func (*UnimplementedChainServer) GetOpenOrders(ctx context.Context, req *OpenOrdersRequest) (*OpenOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpenOrders not implemented")
+}
+func (*UnimplementedChainServer) ListTokens(ctx context.Context, req *ListTokensRequest) (*ListTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTokens not implemented")
+}
+func (*UnimplementedChainServer) BroadcastTx(ctx context.Context, req *BroadcastTxRequest) (*BroadcastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BroadcastTx not implemented")
+}
+
+func RegisterChainServer(s *grpc.Server, srv ChainServer) {
+	s.RegisterService(&_Chain_serviceDesc, srv)
+}
+
+func _Chain_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Chain/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Chain/GetAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).GetAccount(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_GetBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).GetBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Chain/GetBalances",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).GetBalances(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).GetTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Chain/GetTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).GetTx(ctx, req.(*TxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_GetDepth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).GetDepth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Chain/GetDepth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).GetDepth(ctx, req.(*DepthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_GetOpenOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).GetOpenOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Chain/GetOpenOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).GetOpenOrders(ctx, req.(*OpenOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).ListTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Chain/ListTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).ListTokens(ctx, req.(*ListTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_BroadcastTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).BroadcastTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Chain/BroadcastTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).BroadcastTx(ctx, req.(*BroadcastTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Chain_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "binance.sdk.v1.Chain",
+	HandlerType: (*ChainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Status",
+			Handler:    _Chain_Status_Handler,
+		},
+		{
+			MethodName: "GetAccount",
+			Handler:    _Chain_GetAccount_Handler,
+		},
+		{
+			MethodName: "GetBalances",
+			Handler:    _Chain_GetBalances_Handler,
+		},
+		{
+			MethodName: "GetTx",
+			Handler:    _Chain_GetTx_Handler,
+		},
+		{
+			MethodName: "GetDepth",
+			Handler:    _Chain_GetDepth_Handler,
+		},
+		{
+			MethodName: "GetOpenOrders",
+			Handler:    _Chain_GetOpenOrders_Handler,
+		},
+		{
+			MethodName: "ListTokens",
+			Handler:    _Chain_ListTokens_Handler,
+		},
+		{
+			MethodName: "BroadcastTx",
+			Handler:    _Chain_BroadcastTx_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sdk.proto",
+}
+
+// SignerClient is the client API for Signer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SignerClient interface {
+	Address(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*AddressResponse, error)
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+}
+
+type signerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSignerClient(cc *grpc.ClientConn) SignerClient {
+	return &signerClient{cc}
+}
+
+func (c *signerClient) Address(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*AddressResponse, error) {
+	out := new(AddressResponse)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Signer/Address", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*BroadcastResponse, error) {
+	out := new(BroadcastResponse)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Signer/Transfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*BroadcastResponse, error) {
+	out := new(BroadcastResponse)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Signer/CreateOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*BroadcastResponse, error) {
+	out := new(BroadcastResponse)
+	err := c.cc.Invoke(ctx, "/binance.sdk.v1.Signer/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SignerServer is the server API for Signer service.
+type SignerServer interface {
+	Address(context.Context, *AddressRequest) (*AddressResponse, error)
+	Transfer(context.Context, *TransferRequest) (*BroadcastResponse, error)
+	CreateOrder(context.Context, *CreateOrderRequest) (*BroadcastResponse, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*BroadcastResponse, error)
+}
+
+// UnimplementedSignerServer can be embedded to have forward compatible implementations.
+type UnimplementedSignerServer struct {
+}
+
+func (*UnimplementedSignerServer) Address(ctx context.Context, req *AddressRequest) (*AddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Address not implemented")
+}
+func (*UnimplementedSignerServer) Transfer(ctx context.Context, req *TransferRequest) (*BroadcastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
+func (*UnimplementedSignerServer) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*BroadcastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (*UnimplementedSignerServer) CancelOrder(ctx context.Context, req *CancelOrderRequest) (*BroadcastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+
+func RegisterSignerServer(s *grpc.Server, srv SignerServer) {
+	s.RegisterService(&_Signer_serviceDesc, srv)
+}
+
+func _Signer_Address_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).Address(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Signer/Address",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).Address(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Signer/Transfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Signer/CreateOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/binance.sdk.v1.Signer/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Signer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "binance.sdk.v1.Signer",
+	HandlerType: (*SignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Address",
+			Handler:    _Signer_Address_Handler,
+		},
+		{
+			MethodName: "Transfer",
+			Handler:    _Signer_Transfer_Handler,
+		},
+		{
+			MethodName: "CreateOrder",
+			Handler:    _Signer_CreateOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _Signer_CancelOrder_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sdk.proto",
+}
@@ -0,0 +1,133 @@
+// Package server exposes the sdk as a gRPC service, so services of a trading
+// stack written in other languages can share one chain access layer, with
+// the retries, breakers and validation of the rpc client, instead of each
+// talking to the node on its own.
+//
+// The services are defined in pb/sdk.proto. Chain serves queries and
+// broadcasts txs signed elsewhere. Signer signs with the key of the server
+// and is only registered when signing is enabled, clients of a server
+// without it get codes.Unimplemented.
+//
+//	c := rpc.NewClient("tcp://127.0.0.1:27147", rpc.WithNetwork(types.TestNetwork))
+//	srv := server.New(c, server.WithSigning(km))
+//	g := grpc.NewServer()
+//	srv.Register(g)
+//	g.Serve(lis)
+package server
+
+import (
+	"context"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/server/pb"
+	"github.com/binance-chain/go-sdk/types"
+)
+
+// Backend is the part of the rpc client the services use, *rpc.HTTP
+// satisfies it.
+type Backend interface {
+	Status() (*ctypes.ResultStatus, error)
+	Tx(hash []byte, prove bool) (*rpc.ResultTx, error)
+	rpc.QueryClient
+	rpc.BroadcastClient
+	rpc.SigningClient
+}
+
+var _ Backend = (*rpc.HTTP)(nil)
+
+// Server implements the Chain and Signer services on a Backend.
+type Server struct {
+	backend Backend
+	key     keys.KeyManager
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithSigning enables the Signer service, which signs txs with km. The key
+// manager of the backend is replaced with km.
+func WithSigning(km keys.KeyManager) Option {
+	return func(s *Server) {
+		s.key = km
+	}
+}
+
+// New returns a server answering from backend.
+func New(backend Backend, options ...Option) *Server {
+	s := &Server{backend: backend}
+	for _, option := range options {
+		option(s)
+	}
+	if s.key != nil {
+		backend.SetKeyManager(s.key)
+	}
+	return s
+}
+
+// Register registers the services on g, Signer only if signing is enabled.
+func (s *Server) Register(g *grpc.Server) {
+	pb.RegisterChainServer(g, chainServer{s})
+	if s.key != nil {
+		pb.RegisterSignerServer(g, signerServer{s})
+	}
+}
+
+// statusError maps the class of err to a gRPC code, so clients can tell
+// what is worth retrying without parsing messages.
+func statusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var code codes.Code
+	switch types.Classify(err) {
+	case types.ErrorClassNetwork, types.ErrorClassUnavailable:
+		code = codes.Unavailable
+	case types.ErrorClassTimeout:
+		code = codes.DeadlineExceeded
+	case types.ErrorClassSequence:
+		code = codes.Aborted
+	case types.ErrorClassInsufficientFunds:
+		code = codes.FailedPrecondition
+	case types.ErrorClassInvalidSymbol, types.ErrorClassInvalidRequest:
+		code = codes.InvalidArgument
+	case types.ErrorClassUnauthorized:
+		code = codes.PermissionDenied
+	default:
+		code = codes.Unknown
+	}
+	return status.Error(code, err.Error())
+}
+
+// call runs fn unless ctx is already done, the rpc client has timeouts of
+// its own but no way to be cancelled.
+func call(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	if err := fn(); err != nil {
+		return statusError(err)
+	}
+	return nil
+}
+
+func syncType(mode pb.BroadcastMode) (rpc.SyncType, error) {
+	switch mode {
+	case pb.BroadcastMode_SYNC:
+		return rpc.Sync, nil
+	case pb.BroadcastMode_ASYNC:
+		return rpc.Async, nil
+	case pb.BroadcastMode_COMMIT:
+		return rpc.Commit, nil
+	}
+	return 0, status.Errorf(codes.InvalidArgument, "unknown broadcast mode %d", mode)
+}
+
+func broadcastResponse(res *ctypes.ResultBroadcastTx) *pb.BroadcastResponse {
+	return &pb.BroadcastResponse{Hash: res.Hash, Code: res.Code, Log: res.Log, Data: res.Data}
+}
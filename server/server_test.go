@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	ctypes "github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/server/pb"
+	"github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type fakeBackend struct {
+	Backend
+
+	balancesErr error
+	transfers   []msg.Transfer
+	memo        string
+}
+
+func (b *fakeBackend) GetBalances(addr ctypes.AccAddress) ([]ctypes.TokenBalance, error) {
+	if b.balancesErr != nil {
+		return nil, b.balancesErr
+	}
+	return []ctypes.TokenBalance{{Symbol: "BNB", Free: ctypes.NewFixed8(2), Locked: 5}}, nil
+}
+
+func (b *fakeBackend) SetKeyManager(keys.KeyManager) {}
+
+func (b *fakeBackend) SendToken(transfers []msg.Transfer, syncType rpc.SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	b.transfers = transfers
+	signMsg := &tx.StdSignMsg{}
+	for _, option := range options {
+		signMsg = option(signMsg)
+	}
+	b.memo = signMsg.Memo
+	return &core_types.ResultBroadcastTx{Hash: []byte{1, 2}, Log: "ok"}, nil
+}
+
+func serve(t *testing.T, srv *Server) (*grpc.ClientConn, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	g := grpc.NewServer()
+	srv.Register(g)
+	go g.Serve(lis)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	return conn, func() {
+		conn.Close()
+		g.Stop()
+	}
+}
+
+func TestChainService(t *testing.T) {
+	defer func(network ctypes.ChainNetwork) { ctypes.Network = network }(ctypes.Network)
+	ctypes.Network = ctypes.TestNetwork
+	backend := &fakeBackend{}
+	conn, stop := serve(t, New(backend))
+	defer stop()
+	chain := pb.NewChainClient(conn)
+	ctx := context.Background()
+
+	addr := "tbnb1hgm0p7khfk85zpz5v0j8wnej3a90w709zzlffd"
+	res, err := chain.GetBalances(ctx, &pb.AccountRequest{Address: addr})
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.Balance{{Symbol: "BNB", Free: 200000000, Locked: 5}}, res.Balances)
+
+	_, err = chain.GetBalances(ctx, &pb.AccountRequest{Address: "bnb1nope"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	backend.balancesErr = types.NewError(types.ErrorClassUnavailable, errors.New("node is down"))
+	_, err = chain.GetBalances(ctx, &pb.AccountRequest{Address: addr})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, "node is down", status.Convert(err).Message())
+
+	_, err = chain.BroadcastTx(ctx, &pb.BroadcastTxRequest{Tx: []byte{1}, Mode: 7})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// signing is off
+	_, err = pb.NewSignerClient(conn).Address(ctx, &pb.AddressRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestSignerService(t *testing.T) {
+	defer func(network ctypes.ChainNetwork) { ctypes.Network = network }(ctypes.Network)
+	ctypes.Network = ctypes.TestNetwork
+	km, err := keys.NewKeyManager()
+	assert.NoError(t, err)
+	backend := &fakeBackend{}
+	conn, stop := serve(t, New(backend, WithSigning(km)))
+	defer stop()
+	signer := pb.NewSignerClient(conn)
+	ctx := context.Background()
+
+	res, err := signer.Address(ctx, &pb.AddressRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, km.GetAddr().String(), res.Address)
+
+	to := "tbnb1hgm0p7khfk85zpz5v0j8wnej3a90w709zzlffd"
+	sent, err := signer.Transfer(ctx, &pb.TransferRequest{
+		To:    to,
+		Coins: []*pb.Coin{{Denom: "XYZ-000", Amount: 1}, {Denom: "BNB", Amount: 2}},
+		Memo:  "invoice 7",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, sent.Hash)
+	assert.Equal(t, "invoice 7", backend.memo)
+	assert.Len(t, backend.transfers, 1)
+	assert.Equal(t, ctypes.Coins{{Denom: "BNB", Amount: 2}, {Denom: "XYZ-000", Amount: 1}}, backend.transfers[0].Coins)
+	assert.Equal(t, to, backend.transfers[0].ToAddr.String())
+
+	_, err = signer.CreateOrder(ctx, &pb.CreateOrderRequest{Base: "BNB", Quote: "BTCB-1DE", Price: 1, Quantity: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "side is required")
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/server/pb"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type signerServer struct {
+	*Server
+}
+
+var _ pb.SignerServer = signerServer{}
+
+func (s signerServer) Address(context.Context, *pb.AddressRequest) (*pb.AddressResponse, error) {
+	return &pb.AddressResponse{Address: s.key.GetAddr().String()}, nil
+}
+
+func (s signerServer) Transfer(ctx context.Context, req *pb.TransferRequest) (*pb.BroadcastResponse, error) {
+	to, err := parseAddress(req.To)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Coins) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no coins to transfer")
+	}
+	coins := make(types.Coins, 0, len(req.Coins))
+	for _, c := range req.Coins {
+		coins = append(coins, types.Coin{Denom: c.Denom, Amount: c.Amount})
+	}
+	coins = coins.Sort()
+	if !coins.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid coins %s", coins)
+	}
+	mode, err := syncType(req.Mode)
+	if err != nil {
+		return nil, err
+	}
+	return s.broadcast(ctx, func() (*pb.BroadcastResponse, error) {
+		res, err := s.backend.SendToken([]msg.Transfer{{ToAddr: to, Coins: coins}}, mode, tx.WithMemo(req.Memo))
+		if err != nil {
+			return nil, err
+		}
+		return broadcastResponse(res), nil
+	})
+}
+
+func (s signerServer) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.BroadcastResponse, error) {
+	var side int8
+	switch req.Side {
+	case pb.Side_BUY:
+		side = msg.OrderSide.BUY
+	case pb.Side_SELL:
+		side = msg.OrderSide.SELL
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid side %s", req.Side)
+	}
+	if req.Price <= 0 || req.Quantity <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "price and quantity must be positive")
+	}
+	mode, err := syncType(req.Mode)
+	if err != nil {
+		return nil, err
+	}
+	return s.broadcast(ctx, func() (*pb.BroadcastResponse, error) {
+		res, err := s.backend.CreateOrder(req.Base, req.Quote, side, req.Price, req.Quantity, mode)
+		if err != nil {
+			return nil, err
+		}
+		return broadcastResponse(res), nil
+	})
+}
+
+func (s signerServer) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.BroadcastResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id is empty")
+	}
+	mode, err := syncType(req.Mode)
+	if err != nil {
+		return nil, err
+	}
+	return s.broadcast(ctx, func() (*pb.BroadcastResponse, error) {
+		res, err := s.backend.CancelOrder(req.Base, req.Quote, req.OrderId, mode)
+		if err != nil {
+			return nil, err
+		}
+		return broadcastResponse(res), nil
+	})
+}
+
+func (s signerServer) broadcast(ctx context.Context, fn func() (*pb.BroadcastResponse, error)) (*pb.BroadcastResponse, error) {
+	var res *pb.BroadcastResponse
+	err := call(ctx, func() (err error) {
+		res, err = fn()
+		return err
+	})
+	return res, err
+}
@@ -0,0 +1,277 @@
+// Package sweep keeps the balances of a hot wallet low by moving what exceeds
+// a threshold to a cold address. Sweeps are signed by the key of the client,
+// or, for hot wallets whose key is kept offline, handed to an air-gapped
+// signer as an unsigned tx and broadcast once the signed tx comes back.
+package sweep
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// Client is the part of the rpc client the sweeper needs. *rpc.HTTP satisfies
+// it, SignMsg is only used without an OfflineSigner.
+type Client interface {
+	GetAccount(addr types.AccAddress) (types.Account, error)
+	SignMsg(m msg.Msg, options ...tx.Option) ([]byte, error)
+	BroadcastIdempotent(signedTx []byte, syncType rpc.SyncType) (*core_types.ResultBroadcastTx, error)
+}
+
+// Threshold is when a denom is swept, amounts in units of 1e-8.
+type Threshold struct {
+	Denom string
+	// Max is the free balance above which the hot wallet is swept.
+	Max int64
+	// Target is the balance left after a sweep, Max if 0. Keep some BNB for
+	// the fees of the hot wallet.
+	Target int64
+	// MinSweep is the smallest amount worth a sweep.
+	MinSweep int64
+}
+
+// SignatureRequest is a sweep waiting for an offline signature. SignMsg is
+// what to sign, Preview renders it for checking on the signing device.
+type SignatureRequest struct {
+	ID      string          `json:"id"`
+	SignMsg tx.StdSignMsg   `json:"sign_msg"`
+	Preview *tx.SignPreview `json:"preview"`
+}
+
+// OfflineSigner hands sweeps to a signer without network access. It returns
+// once the request is handed over, the signed tx comes back through
+// Sweeper.SubmitSigned.
+type OfflineSigner interface {
+	RequestSignature(req SignatureRequest) error
+}
+
+type Status string
+
+const (
+	// Broadcast is a sweep sent to the node.
+	Broadcast Status = "broadcast"
+	// Requested is a sweep waiting for its offline signature.
+	Requested Status = "requested"
+	// Expired is a requested sweep whose sequence was used by another tx, it
+	// can no longer be signed.
+	Expired Status = "expired"
+	Failed  Status = "failed"
+)
+
+// Sweep reports a sweep.
+type Sweep struct {
+	ID     string      `json:"id"`
+	Time   time.Time   `json:"time"`
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	Coins  types.Coins `json:"coins"`
+	Status Status      `json:"status"`
+	TxHash string      `json:"tx_hash,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+type Config struct {
+	Hot        types.AccAddress
+	Cold       types.AccAddress
+	Thresholds []Threshold
+	// ChainID is required with an OfflineSigner, the client sets it otherwise.
+	ChainID string
+	// OfflineSigner, if set, signs the sweeps instead of the client.
+	OfflineSigner OfflineSigner
+	// OnSweep, if set, gets every sweep and change of its status.
+	OnSweep func(Sweep)
+}
+
+// Sweeper sweeps one hot wallet. It is safe for concurrent use.
+type Sweeper struct {
+	client Client
+	cfg    Config
+	now    func() time.Time
+
+	mtx     sync.Mutex
+	pending *pendingSweep
+}
+
+type pendingSweep struct {
+	sweep   Sweep
+	signMsg tx.StdSignMsg
+}
+
+func New(client Client, cfg Config) (*Sweeper, error) {
+	if cfg.OfflineSigner != nil && cfg.ChainID == "" {
+		return nil, fmt.Errorf("an offline signer needs the chain id")
+	}
+	for _, t := range cfg.Thresholds {
+		if t.Max <= 0 || t.Target < 0 || t.Target > t.Max {
+			return nil, fmt.Errorf("invalid threshold for %s: max %d, target %d", t.Denom, t.Max, t.Target)
+		}
+	}
+	return &Sweeper{client: client, cfg: cfg, now: time.Now}, nil
+}
+
+// Run checks the balances every interval until ctx is done. Errors go to
+// onError if it is set.
+func (s *Sweeper) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Check(); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check sweeps the denoms above their threshold in one tx, it returns nil if
+// none is. While an offline signature is pending nothing else is swept.
+func (s *Sweeper) Check() (*Sweep, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	acc, err := s.client.GetAccount(s.cfg.Hot)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		return nil, nil
+	}
+	if s.pending != nil {
+		if acc.GetSequence() <= s.pending.signMsg.Sequence {
+			return nil, nil
+		}
+		s.pending.sweep.Status, s.pending.sweep.Error = Expired, "sequence used by another tx"
+		s.report(s.pending.sweep)
+		s.pending = nil
+	}
+	coins := s.excess(acc.GetCoins())
+	if len(coins) == 0 {
+		return nil, nil
+	}
+	sweep := Sweep{
+		ID:    fmt.Sprintf("%s-%d", s.cfg.Hot, acc.GetSequence()),
+		Time:  s.now(),
+		From:  s.cfg.Hot.String(),
+		To:    s.cfg.Cold.String(),
+		Coins: coins,
+	}
+	m := msg.CreateSendMsg(s.cfg.Hot, coins, []msg.Transfer{{ToAddr: s.cfg.Cold, Coins: coins}})
+	if s.cfg.OfflineSigner != nil {
+		signMsg := tx.StdSignMsg{
+			ChainID:       s.cfg.ChainID,
+			AccountNumber: acc.GetAccountNumber(),
+			Sequence:      acc.GetSequence(),
+			Msgs:          []msg.Msg{m},
+		}
+		req := SignatureRequest{ID: sweep.ID, SignMsg: signMsg, Preview: tx.Preview(signMsg, nil)}
+		if err := s.cfg.OfflineSigner.RequestSignature(req); err != nil {
+			return nil, fmt.Errorf("request signature of sweep %s: %v", sweep.ID, err)
+		}
+		sweep.Status = Requested
+		s.pending = &pendingSweep{sweep: sweep, signMsg: signMsg}
+		s.report(sweep)
+		return &sweep, nil
+	}
+	signedTx, err := s.client.SignMsg(m, tx.WithAcNumAndSequence(acc.GetAccountNumber(), acc.GetSequence()))
+	if err != nil {
+		return nil, err
+	}
+	s.broadcast(&sweep, signedTx)
+	return &sweep, nil
+}
+
+// SubmitSigned broadcasts the signed tx of the pending sweep. The tx must carry
+// the msgs requested, a tx for anything else is refused.
+func (s *Sweeper) SubmitSigned(id string, signedTx []byte) (*Sweep, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.pending == nil || s.pending.sweep.ID != id {
+		return nil, fmt.Errorf("no sweep %s waiting for a signature", id)
+	}
+	parsed, err := rpc.ParseTx(tx.Cdc, signedTx)
+	if err != nil {
+		return nil, err
+	}
+	stdTx, ok := parsed.(tx.StdTx)
+	if !ok || !sameMsgs(stdTx.Msgs, s.pending.signMsg.Msgs) {
+		return nil, fmt.Errorf("signed tx is not sweep %s", id)
+	}
+	sweep := s.pending.sweep
+	s.pending = nil
+	s.broadcast(&sweep, signedTx)
+	return &sweep, nil
+}
+
+// Pending returns the sweep waiting for an offline signature, if any.
+func (s *Sweeper) Pending() (Sweep, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.pending == nil {
+		return Sweep{}, false
+	}
+	return s.pending.sweep, true
+}
+
+func (s *Sweeper) broadcast(sweep *Sweep, signedTx []byte) {
+	sweep.TxHash = fmt.Sprintf("%X", tx.Hash(signedTx))
+	res, err := s.client.BroadcastIdempotent(signedTx, rpc.Sync)
+	switch {
+	case err != nil:
+		sweep.Status, sweep.Error = Failed, err.Error()
+	case res.Code != 0:
+		sweep.Status, sweep.Error = Failed, res.Log
+	default:
+		sweep.Status = Broadcast
+	}
+	s.report(*sweep)
+}
+
+func (s *Sweeper) report(sweep Sweep) {
+	if s.cfg.OnSweep != nil {
+		s.cfg.OnSweep(sweep)
+	}
+}
+
+// excess returns what exceeds the thresholds in free, sorted by denom.
+func (s *Sweeper) excess(free types.Coins) types.Coins {
+	var coins types.Coins
+	for _, t := range s.cfg.Thresholds {
+		balance := free.AmountOf(t.Denom)
+		if balance <= t.Max {
+			continue
+		}
+		target := t.Target
+		if target == 0 {
+			target = t.Max
+		}
+		if amount := balance - target; amount > 0 && amount >= t.MinSweep {
+			coins = append(coins, types.Coin{Denom: t.Denom, Amount: amount})
+		}
+	}
+	sort.Sort(coins)
+	return coins
+}
+
+func sameMsgs(a, b []msg.Msg) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i].GetSignBytes(), b[i].GetSignBytes()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package sweep
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	ctypes "github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type fakeClient struct {
+	sequence   int64
+	coins      ctypes.Coins
+	signed     []tx.StdSignMsg
+	broadcasts [][]byte
+}
+
+func (c *fakeClient) GetAccount(addr ctypes.AccAddress) (ctypes.Account, error) {
+	return &ctypes.AppAccount{BaseAccount: ctypes.BaseAccount{Address: addr, AccountNumber: 3, Sequence: c.sequence, Coins: c.coins}}, nil
+}
+
+func (c *fakeClient) SignMsg(m msg.Msg, options ...tx.Option) ([]byte, error) {
+	signMsg := &tx.StdSignMsg{Msgs: []msg.Msg{m}}
+	for _, option := range options {
+		signMsg = option(signMsg)
+	}
+	c.signed = append(c.signed, *signMsg)
+	return json.Marshal(signMsg)
+}
+
+func (c *fakeClient) BroadcastIdempotent(signedTx []byte, syncType rpc.SyncType) (*core_types.ResultBroadcastTx, error) {
+	c.broadcasts = append(c.broadcasts, signedTx)
+	return &core_types.ResultBroadcastTx{Hash: tx.Hash(signedTx)}, nil
+}
+
+type requests []SignatureRequest
+
+func (r *requests) RequestSignature(req SignatureRequest) error {
+	*r = append(*r, req)
+	return nil
+}
+
+func addr(s string) ctypes.AccAddress {
+	return ctypes.AccAddress([]byte(s + "-address-bytes-padding")[:ctypes.AddrLen])
+}
+
+var thresholds = []Threshold{
+	{Denom: "BNB", Max: 1000, Target: 200},
+	{Denom: "XYZ-000", Max: 500, MinSweep: 50},
+}
+
+func TestSweepWithClientKey(t *testing.T) {
+	client := &fakeClient{sequence: 4, coins: ctypes.Coins{{Denom: "BNB", Amount: 900}, {Denom: "XYZ-000", Amount: 520}}}
+	var reports []Sweep
+	s, err := New(client, Config{Hot: addr("hot"), Cold: addr("cold"), Thresholds: thresholds, OnSweep: func(sw Sweep) { reports = append(reports, sw) }})
+	assert.NoError(t, err)
+
+	// below the threshold, or not worth a sweep
+	sweep, err := s.Check()
+	assert.NoError(t, err)
+	assert.Nil(t, sweep)
+
+	client.coins = ctypes.Coins{{Denom: "BNB", Amount: 1500}, {Denom: "XYZ-000", Amount: 600}}
+	sweep, err = s.Check()
+	assert.NoError(t, err)
+	assert.Equal(t, Broadcast, sweep.Status)
+	assert.Equal(t, ctypes.Coins{{Denom: "BNB", Amount: 1300}, {Denom: "XYZ-000", Amount: 100}}, sweep.Coins)
+	assert.Len(t, client.signed, 1)
+	assert.Equal(t, int64(4), client.signed[0].Sequence)
+	send := client.signed[0].Msgs[0].(msg.SendMsg)
+	assert.Equal(t, addr("cold"), send.Outputs[0].Address)
+	assert.Len(t, reports, 1)
+}
+
+func TestSweepWithOfflineSigner(t *testing.T) {
+	km, err := keys.NewPrivateKeyManager("9579fff0cab07a4379e845a890105004ba4c8276f8ad9d22082b2acbf02d884b")
+	assert.NoError(t, err)
+	hot := km.GetAddr()
+	client := &fakeClient{sequence: 9, coins: ctypes.Coins{{Denom: "BNB", Amount: 5000}}}
+	var signer requests
+	_, err = New(client, Config{Hot: hot, Cold: addr("cold"), Thresholds: thresholds, OfflineSigner: &signer})
+	assert.Error(t, err, "no chain id")
+	s, err := New(client, Config{Hot: hot, Cold: addr("cold"), Thresholds: thresholds, ChainID: "Binance-Chain-Tigris", OfflineSigner: &signer})
+	assert.NoError(t, err)
+
+	sweep, err := s.Check()
+	assert.NoError(t, err)
+	assert.Equal(t, Requested, sweep.Status)
+	assert.Len(t, signer, 1)
+	assert.Equal(t, int64(9), signer[0].SignMsg.Sequence)
+	assert.Equal(t, "Binance-Chain-Tigris", signer[0].Preview.ChainID)
+
+	// nothing else is swept while the signature is pending
+	sweep, err = s.Check()
+	assert.NoError(t, err)
+	assert.Nil(t, sweep)
+	assert.Len(t, signer, 1)
+
+	// a tx signed for other msgs is refused
+	other := signer[0].SignMsg
+	other.Msgs = []msg.Msg{msg.CreateSendMsg(hot, ctypes.Coins{{Denom: "BNB", Amount: 4800}}, []msg.Transfer{{ToAddr: addr("mallory"), Coins: ctypes.Coins{{Denom: "BNB", Amount: 4800}}}})}
+	forged, err := km.Sign(other)
+	assert.NoError(t, err)
+	_, err = s.SubmitSigned(signer[0].ID, forged)
+	assert.Error(t, err)
+
+	signed, err := km.Sign(signer[0].SignMsg)
+	assert.NoError(t, err)
+	sweep, err = s.SubmitSigned(signer[0].ID, signed)
+	assert.NoError(t, err)
+	assert.Equal(t, Broadcast, sweep.Status)
+	assert.Equal(t, [][]byte{signed}, client.broadcasts)
+	_, pending := s.Pending()
+	assert.False(t, pending)
+}
+
+func TestPendingSweepExpires(t *testing.T) {
+	client := &fakeClient{sequence: 1, coins: ctypes.Coins{{Denom: "BNB", Amount: 5000}}}
+	var signer requests
+	var reports []Sweep
+	s, err := New(client, Config{Hot: addr("hot"), Cold: addr("cold"), Thresholds: thresholds, ChainID: "test", OfflineSigner: &signer,
+		OnSweep: func(sw Sweep) { reports = append(reports, sw) }})
+	assert.NoError(t, err)
+	_, err = s.Check()
+	assert.NoError(t, err)
+
+	// another tx used the sequence, the request is dropped and made again
+	client.sequence = 2
+	sweep, err := s.Check()
+	assert.NoError(t, err)
+	assert.Equal(t, Requested, sweep.Status)
+	assert.Len(t, signer, 2)
+	assert.Equal(t, int64(2), signer[1].SignMsg.Sequence)
+	assert.Equal(t, Expired, reports[1].Status)
+	_, err = s.SubmitSigned(signer[0].ID, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,242 @@
+// Package tape rebuilds the trades of the DEX from the trade history of the
+// api server, so strategies can be replayed against the executions that really
+// happened. Block results carry no trades, the api server has them from the
+// publisher of the node.
+package tape
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// DefaultWindow is how much history Replay fetches at a time.
+const DefaultWindow = time.Hour
+
+// tradePageSize is the most trades the api server returns per call.
+const tradePageSize = 1000
+
+// TradeClient is the part of the query client the tape reads trades with,
+// query.QueryClient satisfies it.
+type TradeClient interface {
+	GetTrades(query *types.TradesQuery) (*types.Trades, error)
+}
+
+// TickType tells which side took liquidity, following the numbering of the matching engine.
+type TickType int
+
+const (
+	TickUnknown TickType = iota
+	TickSellTaker
+	TickBuyTaker
+	TickBuySurplus
+	TickSellSurplus
+	TickNeutral
+)
+
+func (t TickType) String() string {
+	switch t {
+	case TickSellTaker:
+		return "SellTaker"
+	case TickBuyTaker:
+		return "BuyTaker"
+	case TickBuySurplus:
+		return "BuySurplus"
+	case TickSellSurplus:
+		return "SellSurplus"
+	case TickNeutral:
+		return "Neutral"
+	}
+	return "Unknown"
+}
+
+// ParseTickType reads the tick type of the api, TickUnknown if it is not one.
+func ParseTickType(s string) TickType {
+	for t := TickSellTaker; t <= TickNeutral; t++ {
+		if t.String() == s {
+			return t
+		}
+	}
+	return TickUnknown
+}
+
+// Trade is one match. Price and Quantity are 1e8 fixed point like on chain,
+// Buyer and Seller are the addresses of the orders.
+type Trade struct {
+	Height int64
+	Time   time.Time
+	// Index is the position of the trade among the trades of its block.
+	Index         int
+	ID            string
+	Symbol        string
+	Price         int64
+	Quantity      int64
+	BuyerOrderID  string
+	SellerOrderID string
+	Buyer         string
+	Seller        string
+	TickType      TickType
+}
+
+// MakerOrderID is the order that rested in the book, empty if neither side took.
+func (t Trade) MakerOrderID() string {
+	switch t.TickType {
+	case TickBuyTaker, TickSellSurplus:
+		return t.SellerOrderID
+	case TickSellTaker, TickBuySurplus:
+		return t.BuyerOrderID
+	}
+	return ""
+}
+
+// TakerOrderID is the order that took liquidity, empty if neither side took.
+func (t Trade) TakerOrderID() string {
+	switch t.TickType {
+	case TickBuyTaker, TickSellSurplus:
+		return t.BuyerOrderID
+	case TickSellTaker, TickBuySurplus:
+		return t.SellerOrderID
+	}
+	return ""
+}
+
+// Tape reads trades from the api server.
+type Tape struct {
+	client TradeClient
+	window time.Duration
+}
+
+// NewTape reads trades in windows of window, DefaultWindow if zero. Each window
+// is held in memory to be sorted, narrow it for busy pairs.
+func NewTape(client TradeClient, window time.Duration) *Tape {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Tape{client: client, window: window}
+}
+
+// WindowSize is how much history the tape fetches at a time.
+func (t *Tape) WindowSize() time.Duration {
+	return t.window
+}
+
+// Replay hands the trades of symbol, or of all pairs if symbol is empty, from
+// from to to inclusive to onTrade in execution order. It stops at the first
+// error of onTrade.
+func (t *Tape) Replay(symbol string, from, to time.Time, onTrade func(Trade) error) error {
+	if to.Before(from) {
+		return fmt.Errorf("invalid time range [%s, %s]", from, to)
+	}
+	for start := from; !start.After(to); start = start.Add(t.window) {
+		end := start.Add(t.window - time.Millisecond)
+		if end.After(to) {
+			end = to
+		}
+		trades, err := t.Window(symbol, start, end)
+		if err != nil {
+			return err
+		}
+		for _, trade := range trades {
+			if err := onTrade(trade); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Collect returns the trades Replay would deliver.
+func (t *Tape) Collect(symbol string, from, to time.Time) ([]Trade, error) {
+	var trades []Trade
+	err := t.Replay(symbol, from, to, func(trade Trade) error {
+		trades = append(trades, trade)
+		return nil
+	})
+	return trades, err
+}
+
+// Window returns the trades from from to to inclusive, at millisecond
+// precision, in execution order: by height, then by their position in the
+// block.
+func (t *Tape) Window(symbol string, from, to time.Time) ([]Trade, error) {
+	start, end := toMs(from), toMs(to)
+	if start <= 0 || end < start {
+		return nil, fmt.Errorf("invalid time range [%s, %s]", from, to)
+	}
+	var trades []Trade
+	limit := uint32(tradePageSize)
+	for offset := uint32(0); ; offset += limit {
+		q := types.NewTradesQuery(false)
+		q.Symbol = symbol
+		q.Start, q.End = &start, &end
+		q.Offset, q.Limit = &offset, &limit
+		page, err := t.client.GetTrades(q)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiTrade := range page.Trade {
+			trade, err := fromAPI(apiTrade)
+			if err != nil {
+				return nil, err
+			}
+			trades = append(trades, trade)
+		}
+		if len(page.Trade) < tradePageSize {
+			break
+		}
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		if trades[i].Height != trades[j].Height {
+			return trades[i].Height < trades[j].Height
+		}
+		return trades[i].Index < trades[j].Index
+	})
+	return trades, nil
+}
+
+func fromAPI(t types.Trade) (Trade, error) {
+	price, err := types.Fixed8DecodeString(t.Price)
+	if err != nil {
+		return Trade{}, fmt.Errorf("trade %s: invalid price %q", t.TradeID, t.Price)
+	}
+	quantity, err := types.Fixed8DecodeString(t.Quantity)
+	if err != nil {
+		return Trade{}, fmt.Errorf("trade %s: invalid quantity %q", t.TradeID, t.Quantity)
+	}
+	return Trade{
+		Height:        t.BlockHeight,
+		Time:          time.Unix(0, t.Time*int64(time.Millisecond)),
+		Index:         tradeIndex(t.TradeID),
+		ID:            t.TradeID,
+		Symbol:        t.Symbol,
+		Price:         price.ToInt64(),
+		Quantity:      quantity.ToInt64(),
+		BuyerOrderID:  t.BuyerOrderID,
+		SellerOrderID: t.SellerOrderID,
+		Buyer:         t.BuyerId,
+		Seller:        t.SellerId,
+		TickType:      ParseTickType(t.TickType),
+	}, nil
+}
+
+// tradeIndex reads the position of a trade in its block from its id, which the
+// match engine makes of the height and the position, "<height>-<index>".
+func tradeIndex(id string) int {
+	i := strings.LastIndexByte(id, '-')
+	if i < 0 {
+		return 0
+	}
+	index, err := strconv.Atoi(id[i+1:])
+	if err != nil {
+		return 0
+	}
+	return index
+}
+
+func toMs(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,94 @@
+package tape
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// fakeTrades serves trades like the api server, newest first.
+type fakeTrades struct {
+	trades []types.Trade
+	calls  int
+}
+
+func (f *fakeTrades) GetTrades(q *types.TradesQuery) (*types.Trades, error) {
+	f.calls++
+	var matched []types.Trade
+	for i := len(f.trades) - 1; i >= 0; i-- {
+		tr := f.trades[i]
+		if (q.Symbol == "" || tr.Symbol == q.Symbol) && tr.Time >= *q.Start && tr.Time <= *q.End {
+			matched = append(matched, tr)
+		}
+	}
+	offset, limit := int(*q.Offset), int(*q.Limit)
+	if offset > len(matched) {
+		offset = len(matched)
+	}
+	if offset+limit < len(matched) {
+		matched = matched[:offset+limit]
+	}
+	return &types.Trades{Trade: matched[offset:], Total: -1}, nil
+}
+
+func apiTrade(height int64, id, symbol, price, buyer, seller, tick string) types.Trade {
+	return types.Trade{BlockHeight: height, Time: height * 1000, TradeID: id, Symbol: symbol, Price: price, Quantity: "0.00000005",
+		BuyerOrderID: buyer, SellerOrderID: seller, BuyerId: "bnb1buyer", SellerId: "bnb1seller", TickType: tick}
+}
+
+func TestReplay(t *testing.T) {
+	client := &fakeTrades{trades: []types.Trade{
+		apiTrade(2, "2-0", "BTC-86A_BNB", "1.00000000", "buyer-1", "seller-1", "BuyTaker"),
+		apiTrade(2, "2-1", "ETH-1C9_BNB", "0.00000007", "buyer-2", "seller-2", "SellTaker"),
+		apiTrade(4, "4-0", "BTC-86A_BNB", "1.10000000", "buyer-3", "seller-3", "SellTaker"),
+	}}
+	tape := NewTape(client, 2*time.Second)
+
+	trades, err := tape.Collect("BTC-86A_BNB", time.Unix(1, 0), time.Unix(5, 0))
+	assert.NoError(t, err)
+	assert.Len(t, trades, 2)
+	assert.Equal(t, Trade{
+		Height: 2, Time: time.Unix(2, 0), ID: "2-0", Symbol: "BTC-86A_BNB", Price: 100000000, Quantity: 5,
+		BuyerOrderID: "buyer-1", SellerOrderID: "seller-1", Buyer: "bnb1buyer", Seller: "bnb1seller", TickType: TickBuyTaker,
+	}, trades[0])
+	assert.Equal(t, "buyer-1", trades[0].TakerOrderID())
+	assert.Equal(t, "seller-3", trades[1].TakerOrderID())
+	assert.Equal(t, "buyer-3", trades[1].MakerOrderID())
+	assert.Equal(t, 3, client.calls, "one call per window")
+
+	all, err := tape.Collect("", time.Unix(2, 0), time.Unix(2, 0))
+	assert.NoError(t, err)
+	if assert.Len(t, all, 2) {
+		assert.Equal(t, "2-0", all[0].ID, "match order, not the newest first order of the api")
+		assert.Equal(t, 1, all[1].Index)
+	}
+
+	stop := errors.New("stop")
+	assert.Equal(t, stop, tape.Replay("", time.Unix(1, 0), time.Unix(5, 0), func(Trade) error { return stop }))
+	_, err = tape.Collect("", time.Unix(5, 0), time.Unix(1, 0))
+	assert.Error(t, err)
+}
+
+func TestWindowPages(t *testing.T) {
+	client := &fakeTrades{}
+	for i := 0; i < tradePageSize+5; i++ {
+		client.trades = append(client.trades, apiTrade(3, "3-"+strconv.Itoa(i), "BNB_BTC", "1.00000000", "b", "s", "Neutral"))
+	}
+	trades, err := NewTape(client, 0).Window("BNB_BTC", time.Unix(1, 0), time.Unix(4, 0))
+	assert.NoError(t, err)
+	assert.Len(t, trades, tradePageSize+5)
+	assert.Equal(t, 2, client.calls)
+	for i, trade := range trades {
+		assert.Equal(t, i, trade.Index)
+	}
+	assert.Equal(t, TickNeutral, trades[0].TickType)
+
+	client.trades = []types.Trade{apiTrade(3, "3-0", "BNB_BTC", "x", "b", "s", "")}
+	_, err = NewTape(client, 0).Window("", time.Unix(1, 0), time.Unix(4, 0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,215 @@
+// Package testutil runs integration tests against a single-node local chain. It
+// either starts the node in Docker or attaches to one that is already running,
+// funds fresh test accounts from a genesis account and hands out ready-made clients.
+//
+// The harness is configured explicitly or through the environment:
+//
+//	BNC_LOCALNET_RPC       attach to this node instead of starting one, e.g. tcp://127.0.0.1:26657
+//	BNC_LOCALNET_IMAGE     docker image of the node
+//	BNC_LOCALNET_CHAIN_ID  chain id of the node
+//	BNC_LOCALNET_MNEMONIC  mnemonic of the funded genesis account
+package testutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+const (
+	DefaultChainID      = "bnbchain-localnet"
+	DefaultRPCPort      = 26657
+	DefaultStartTimeout = time.Minute
+)
+
+type Config struct {
+	// RPCAddr attaches to a running node. When empty a container is started from Image.
+	RPCAddr string
+	Image   string
+	RPCPort int
+	ChainID string
+	// FaucetMnemonic is the mnemonic of a genesis account holding the native token.
+	FaucetMnemonic string
+	StartTimeout   time.Duration
+}
+
+// ConfigFromEnv reads the BNC_LOCALNET_* environment variables.
+func ConfigFromEnv() Config {
+	return Config{
+		RPCAddr:        os.Getenv("BNC_LOCALNET_RPC"),
+		Image:          os.Getenv("BNC_LOCALNET_IMAGE"),
+		ChainID:        os.Getenv("BNC_LOCALNET_CHAIN_ID"),
+		FaucetMnemonic: os.Getenv("BNC_LOCALNET_MNEMONIC"),
+	}
+}
+
+type Localnet struct {
+	cfg         Config
+	containerID string
+	client      *rpc.HTTP
+
+	// the faucet sends one tx at a time so its sequence stays consistent
+	faucetMtx    sync.Mutex
+	faucet       keys.KeyManager
+	faucetClient *rpc.HTTP
+
+	RPCAddr string
+}
+
+// Start attaches to cfg.RPCAddr or starts a container and waits until the node
+// produces blocks. Addresses use the testnet prefix.
+func Start(cfg Config) (*Localnet, error) {
+	if cfg.ChainID == "" {
+		cfg.ChainID = DefaultChainID
+	}
+	if cfg.RPCPort == 0 {
+		cfg.RPCPort = DefaultRPCPort
+	}
+	if cfg.StartTimeout == 0 {
+		cfg.StartTimeout = DefaultStartTimeout
+	}
+	if cfg.FaucetMnemonic == "" {
+		return nil, errors.New("faucet mnemonic is required to fund test accounts")
+	}
+	faucet, err := keys.NewMnemonicKeyManager(cfg.FaucetMnemonic)
+	if err != nil {
+		return nil, err
+	}
+	l := &Localnet{cfg: cfg, faucet: faucet, RPCAddr: cfg.RPCAddr}
+
+	if l.RPCAddr == "" {
+		if cfg.Image == "" {
+			return nil, errors.New("either an rpc address or a docker image is required")
+		}
+		out, err := docker("run", "-d", "-p", fmt.Sprintf("%d:26657", cfg.RPCPort), cfg.Image)
+		if err != nil {
+			return nil, err
+		}
+		l.containerID = strings.TrimSpace(out)
+		l.RPCAddr = fmt.Sprintf("tcp://127.0.0.1:%d", cfg.RPCPort)
+	}
+	if err := l.waitForBlocks(); err != nil {
+		l.Stop()
+		return nil, err
+	}
+	l.client = rpc.NewRPCClient(l.RPCAddr, types.TestNetwork)
+	l.faucetClient = rpc.NewRPCClient(l.RPCAddr, types.TestNetwork)
+	l.faucetClient.SetKeyManager(l.faucet)
+	return l, nil
+}
+
+// Require starts the localnet for a test and skips the test when neither Docker
+// nor an attachable node is available, so integration tests stay optional in CI.
+func Require(t testing.TB, cfg Config) *Localnet {
+	if cfg.RPCAddr == "" {
+		if _, err := exec.LookPath("docker"); err != nil {
+			t.Skip("docker is not available and BNC_LOCALNET_RPC is not set")
+		}
+		if cfg.Image == "" {
+			t.Skip("neither BNC_LOCALNET_RPC nor BNC_LOCALNET_IMAGE is set")
+		}
+	}
+	if cfg.FaucetMnemonic == "" {
+		t.Skip("BNC_LOCALNET_MNEMONIC is not set")
+	}
+	l, err := Start(cfg)
+	if err != nil {
+		t.Fatalf("start localnet: %v", err)
+	}
+	return l
+}
+
+// Stop removes the container if the harness started one.
+func (l *Localnet) Stop() error {
+	if l.client != nil {
+		l.client.Stop()
+		l.faucetClient.Stop()
+	}
+	if l.containerID == "" {
+		return nil
+	}
+	_, err := docker("rm", "-f", l.containerID)
+	return err
+}
+
+// Client returns a client without a key manager.
+func (l *Localnet) Client() *rpc.HTTP {
+	return l.client
+}
+
+// ChainID returns the option that signs txs for the local chain. It has to be
+// passed to every tx sent through the clients of the harness.
+func (l *Localnet) ChainID() tx.Option {
+	return tx.WithChainID(l.cfg.ChainID)
+}
+
+// Fund sends coins from the faucet account and waits for the tx to be committed.
+func (l *Localnet) Fund(addr types.AccAddress, coins types.Coins) error {
+	l.faucetMtx.Lock()
+	defer l.faucetMtx.Unlock()
+	res, err := l.faucetClient.SendToken([]msg.Transfer{{ToAddr: addr, Coins: coins}}, rpc.Commit, l.ChainID())
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return fmt.Errorf("fund %s failed: %s", addr, res.Log)
+	}
+	return nil
+}
+
+// NewAccount creates a random key, funds it with coins and returns a client that
+// signs with it. The caller should Stop the client when done.
+func (l *Localnet) NewAccount(coins types.Coins) (keys.KeyManager, *rpc.HTTP, error) {
+	key, err := keys.NewKeyManager()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := l.Fund(key.GetAddr(), coins); err != nil {
+		return nil, nil, err
+	}
+	client := rpc.NewRPCClient(l.RPCAddr, types.TestNetwork)
+	client.SetKeyManager(key)
+	return key, client, nil
+}
+
+func (l *Localnet) waitForBlocks() error {
+	url := strings.Replace(l.RPCAddr, "tcp://", "http://", 1) + "/status"
+	deadline := time.Now().Add(l.cfg.StartTimeout)
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(url)
+		if err == nil {
+			var body bytes.Buffer
+			body.ReadFrom(resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK && !strings.Contains(body.String(), `"latest_block_height":"0"`) {
+				return nil
+			}
+		}
+		time.Sleep(time.Second)
+	}
+	return fmt.Errorf("localnet at %s did not produce blocks within %s", l.RPCAddr, l.cfg.StartTimeout)
+}
+
+func docker(args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd := exec.Command("docker", args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %v: %s", strings.Join(args, " "), err, out.String())
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,167 @@
+// Package testvectors exports golden fixtures produced by this SDK: mnemonic to
+// key and address derivations, msg sign bytes and fully signed txs. Other
+// implementations and hardware wallets can check byte-for-byte compatibility
+// against them, and the package test guards the SDK itself against regressions.
+//
+// All keys use the default derivation path 44'/714'/0'/0/0 and sign bytes use
+// mainnet addresses. Signatures are RFC 6979 deterministic, so signed txs are
+// reproducible.
+package testvectors
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+// KeyVector is a mnemonic and everything derived from it.
+type KeyVector struct {
+	Mnemonic       string `json:"mnemonic"`
+	PrivateKey     string `json:"private_key"`
+	PublicKey      string `json:"public_key"` // compressed secp256k1
+	AddressHex     string `json:"address_hex"`
+	Address        string `json:"address"`         // mainnet, "bnb" prefix
+	TestnetAddress string `json:"testnet_address"` // testnet, "tbnb" prefix
+}
+
+// TxVector is a single-msg tx signed by the key of Keys[KeyIndex].
+type TxVector struct {
+	Name          string  `json:"name"`
+	KeyIndex      int     `json:"key_index"`
+	ChainID       string  `json:"chain_id"`
+	AccountNumber int64   `json:"account_number"`
+	Sequence      int64   `json:"sequence"`
+	Memo          string  `json:"memo"`
+	Source        int64   `json:"source"`
+	Msg           msg.Msg `json:"msg"`
+	SignBytes     string  `json:"sign_bytes"` // the canonical JSON that is signed
+	SignedTxHex   string  `json:"signed_tx_hex"`
+	TxHash        string  `json:"tx_hash"`
+}
+
+var Keys = []KeyVector{
+	{
+		Mnemonic:       "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		PrivateKey:     "3955f430d8372b601f3a70c10a707f94c509fb3c51c1e94ddbb7ab9906cb659d",
+		PublicKey:      "02a5c1a09e80070d4f42e4c577b1cd840e12f775b83afd07dc01dde138adf64ea9",
+		AddressHex:     "19ae2a31acaa58d913274180c4b1e46214f92fee",
+		Address:        "bnb1rxhz5vdv4fvdjye8gxqvfv0yvg20jtlwf4f38d",
+		TestnetAddress: "tbnb1rxhz5vdv4fvdjye8gxqvfv0yvg20jtlw8qq48u",
+	},
+	{
+		Mnemonic:       "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		PrivateKey:     "48fc2c0f5d99fd5e76e2405552f4ed57a1f794771ccd49e0e133b75e983b7d73",
+		PublicKey:      "02be842f7702c0909767da61b079018a11e3ac3159f10cb09e3e01ffa4f8e461d9",
+		AddressHex:     "1b278bcd42f8c4da38f8c8fe7b0760e974525a84",
+		Address:        "bnb1rvnchn2zlrzd5w8cerl8kpmqa969yk5ywzgc22",
+		TestnetAddress: "tbnb1rvnchn2zlrzd5w8cerl8kpmqa969yk5yqhpu2m",
+	},
+	{
+		Mnemonic:       "letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+		PrivateKey:     "cfc0fa230c3bfc75d53cd2c2d6da1869803905b2f6903f3657cba79b1640b3ea",
+		PublicKey:      "02b9f5cbd87da433355a2ed596a0d853bf5a0866d23e4408414b9df7c7dfa3112b",
+		AddressHex:     "7c43fb0a3a40873b71cd68d08e3a89a29c774ce0",
+		Address:        "bnb103plkz36gzrnkuwddrgguw5f52w8wn8qyj5qdq",
+		TestnetAddress: "tbnb103plkz36gzrnkuwddrgguw5f52w8wn8q28ayd3",
+	},
+}
+
+const chainID = "Binance-Chain-Tigris"
+
+var Txs []TxVector
+
+func init() {
+	from := addr(0)
+	to := addr(1)
+	coins := types.Coins{{Denom: "BNB", Amount: 100000000}}
+	randomNumberHash, _ := hex.DecodeString("e8eae926261ab77d018202434791a335249b470246a7b02e28c3b2fb6ffad8f3")
+	swapID, _ := hex.DecodeString("a1bd27ff0b5a4eb4ddf21af58a0e0ea7c4a6ff1dc1cbbed3f0c2f74b4d8d7b0e")
+
+	Txs = []TxVector{
+		{
+			Name:          "send",
+			Msg:           msg.CreateSendMsg(from, coins, []msg.Transfer{{ToAddr: to, Coins: coins}}),
+			AccountNumber: 34, Sequence: 0, Memo: "test vector",
+			SignBytes:   `{"account_number":"34","chain_id":"Binance-Chain-Tigris","data":null,"memo":"test vector","msgs":[{"inputs":[{"address":"bnb1rxhz5vdv4fvdjye8gxqvfv0yvg20jtlwf4f38d","coins":[{"amount":100000000,"denom":"BNB"}]}],"outputs":[{"address":"bnb1rvnchn2zlrzd5w8cerl8kpmqa969yk5ywzgc22","coins":[{"amount":100000000,"denom":"BNB"}]}]}],"sequence":"0","source":"0"}`,
+			SignedTxHex: "cd01f0625dee0a4c2a2c87fa0a220a1419ae2a31acaa58d913274180c4b1e46214f92fee120a0a03424e421080c2d72f12220a141b278bcd42f8c4da38f8c8fe7b0760e974525a84120a0a03424e421080c2d72f126c0a26eb5ae9872102a5c1a09e80070d4f42e4c577b1cd840e12f775b83afd07dc01dde138adf64ea912409daba7994e63625a1ce7e23722804e31a58387cb4c10a5158cc6ba7f8bcc6402021742b775f318c87e76bedca83c8d4d0eb59a3a5e926028fa69825494ae127118221a0b7465737420766563746f72",
+			TxHash:      "34AF04B301D1631D8A9D093B7E288FFB287543983078CDD3163251EDAE149E33",
+		},
+		{
+			Name:          "new_order",
+			Msg:           msg.NewCreateOrderMsg(from, orderID(from, 1), msg.OrderSide.BUY, "BTC-5C4_BNB", 100000000, 1000000),
+			AccountNumber: 34, Sequence: 0,
+			SignBytes:   `{"account_number":"34","chain_id":"Binance-Chain-Tigris","data":null,"memo":"","msgs":[{"id":"19AE2A31ACAA58D913274180C4B1E46214F92FEE-1","ordertype":2,"price":100000000,"quantity":1000000,"sender":"bnb1rxhz5vdv4fvdjye8gxqvfv0yvg20jtlwf4f38d","side":1,"symbol":"BTC-5C4_BNB","timeinforce":1}],"sequence":"0","source":"0"}`,
+			SignedTxHex: "d601f0625dee0a62ce6dc0430a1419ae2a31acaa58d913274180c4b1e46214f92fee122a313941453241333141434141353844393133323734313830433442314534363231344639324645452d311a0b4254432d3543345f424e42200228013080c2d72f38c0843d4001126c0a26eb5ae9872102a5c1a09e80070d4f42e4c577b1cd840e12f775b83afd07dc01dde138adf64ea912403dfd96b11b8c18350ba3333acbb1b423fd3f9659489eca10d3da3da76c0b94211a16f1de189cbac54244123bf9f68e4433272dc685351d8e53f14f10fb76a7ef1822",
+			TxHash:      "A03740C4B5B01B2A5B843FB303E84B149C3E0D3773B6FE5AACCC674DDED6BA84",
+		},
+		{
+			Name:          "cancel_order",
+			Msg:           msg.NewCancelOrderMsg(from, "BTC-5C4_BNB", orderID(from, 1)),
+			AccountNumber: 34, Sequence: 1,
+			SignBytes:   `{"account_number":"34","chain_id":"Binance-Chain-Tigris","data":null,"memo":"","msgs":[{"refid":"19AE2A31ACAA58D913274180C4B1E46214F92FEE-1","sender":"bnb1rxhz5vdv4fvdjye8gxqvfv0yvg20jtlwf4f38d","symbol":"BTC-5C4_BNB"}],"sequence":"1","source":"0"}`,
+			SignedTxHex: "c901f0625dee0a53166e681b0a1419ae2a31acaa58d913274180c4b1e46214f92fee120b4254432d3543345f424e421a2a313941453241333141434141353844393133323734313830433442314534363231344639324645452d31126e0a26eb5ae9872102a5c1a09e80070d4f42e4c577b1cd840e12f775b83afd07dc01dde138adf64ea91240a578659b4b5b3337ee52a423055110daf6ac988fd6843388ac544948329003a567eedd081ae4ec44f7be3f16e5372c0cbdf4fdbe7c6b9835089cbb6f4b47f00c18222001",
+			TxHash:      "F355E594264B4FFF03B91BDAF25FEED1EC96862530D591A558BF0DE097361F83",
+		},
+		{
+			Name: "htlt",
+			Msg: msg.NewHTLTMsg(from, to, "", "", randomNumberHash, 1600000000, coins,
+				"100000000:BNB", 1000, false),
+			AccountNumber: 34, Sequence: 2,
+			SignBytes:   `{"account_number":"34","chain_id":"Binance-Chain-Tigris","data":null,"memo":"","msgs":[{"amount":[{"amount":100000000,"denom":"BNB"}],"cross_chain":false,"expected_income":"100000000:BNB","from":"bnb1rxhz5vdv4fvdjye8gxqvfv0yvg20jtlwf4f38d","height_span":1000,"random_number_hash":"e8eae926261ab77d018202434791a335249b470246a7b02e28c3b2fb6ffad8f3","recipient_other_chain":"","sender_other_chain":"","timestamp":1600000000,"to":"bnb1rvnchn2zlrzd5w8cerl8kpmqa969yk5ywzgc22"}],"sequence":"2","source":"0"}`,
+			SignedTxHex: "ec01f0625dee0a76b33f9a240a1419ae2a31acaa58d913274180c4b1e46214f92fee12141b278bcd42f8c4da38f8c8fe7b0760e974525a842a20e8eae926261ab77d018202434791a335249b470246a7b02e28c3b2fb6ffad8f33080a0f8fa053a0a0a03424e421080c2d72f420d3130303030303030303a424e4248e807126e0a26eb5ae9872102a5c1a09e80070d4f42e4c577b1cd840e12f775b83afd07dc01dde138adf64ea9124054f7c93d3ccb72371720b0711d31b59df21c5e6500559217a257bbc3a40c77c33e11bb5041cc744d57a0b57621c4de00fcb10cce3f6756a3372ba5a1738086f118222002",
+			TxHash:      "CC5E1AA6030F898B47F802A54CE6C9D75AFCFD117E409B4CA78C536A050DBC93",
+		},
+		{
+			Name:          "claim_htlt",
+			Msg:           msg.NewClaimHTLTMsg(to, swapID, make([]byte, 32)),
+			KeyIndex:      1,
+			AccountNumber: 35, Sequence: 0,
+			SignBytes:   `{"account_number":"35","chain_id":"Binance-Chain-Tigris","data":null,"memo":"","msgs":[{"from":"bnb1rvnchn2zlrzd5w8cerl8kpmqa969yk5ywzgc22","random_number":"0000000000000000000000000000000000000000000000000000000000000000","swap_id":"a1bd27ff0b5a4eb4ddf21af58a0e0ea7c4a6ff1dc1cbbed3f0c2f74b4d8d7b0e"}],"sequence":"0","source":"0"}`,
+			SignedTxHex: "d201f0625dee0a5ec16653000a141b278bcd42f8c4da38f8c8fe7b0760e974525a841220a1bd27ff0b5a4eb4ddf21af58a0e0ea7c4a6ff1dc1cbbed3f0c2f74b4d8d7b0e1a200000000000000000000000000000000000000000000000000000000000000000126c0a26eb5ae9872102be842f7702c0909767da61b079018a11e3ac3159f10cb09e3e01ffa4f8e461d91240362f79a29da1da0297cab2d764612452bf854ece1b76dc38de7dcc92c5963f360e75d426b1629f89b385ee093c9830a914c7ba8bf7f51544ba46df3148d278861823",
+			TxHash:      "3B07B78481F017E790FD191CF074087EDA502FF2A4BFCA48ACBFE913313057BD",
+		},
+		{
+			Name:          "vote",
+			Msg:           msg.NewMsgVote(from, 1, msg.OptionYes),
+			AccountNumber: 34, Sequence: 3,
+			SignBytes:   `{"account_number":"34","chain_id":"Binance-Chain-Tigris","data":null,"memo":"","msgs":[{"option":"Yes","proposal_id":"1","voter":"bnb1rxhz5vdv4fvdjye8gxqvfv0yvg20jtlwf4f38d"}],"sequence":"3","source":"0"}`,
+			SignedTxHex: "9401f0625dee0a1ea1cadd360801121419ae2a31acaa58d913274180c4b1e46214f92fee1801126e0a26eb5ae9872102a5c1a09e80070d4f42e4c577b1cd840e12f775b83afd07dc01dde138adf64ea91240dc7d8cdbbf9224962068e5ef58991277b32433b61cd754dd641183f273bf887b0e072faa42c0aa1c917a7db6a9b3b7fa1bf1a94ab5079f234e2bc1b5dcba27e918222003",
+			TxHash:      "6E1A2100DDC8D4AAF94A319353D922A2C2E8139BF71A187B81EFBB0F0C0B51AC",
+		},
+		{
+			Name:          "freeze",
+			Msg:           msg.NewFreezeMsg(from, "BNB", 100000000),
+			AccountNumber: 34, Sequence: 4,
+			SignBytes:   `{"account_number":"34","chain_id":"Binance-Chain-Tigris","data":null,"memo":"","msgs":[{"amount":100000000,"from":"bnb1rxhz5vdv4fvdjye8gxqvfv0yvg20jtlwf4f38d","symbol":"BNB"}],"sequence":"4","source":"0"}`,
+			SignedTxHex: "9a01f0625dee0a24e774b32d0a1419ae2a31acaa58d913274180c4b1e46214f92fee1203424e421880c2d72f126e0a26eb5ae9872102a5c1a09e80070d4f42e4c577b1cd840e12f775b83afd07dc01dde138adf64ea91240b25c357ca6adbc6a53d4c77eba71410eb56a516ed3633681573b00134a8b806d35f045dcc97527de08cfb26141cf8854d081193c9a06c47e87a70729bf4599aa18222004",
+			TxHash:      "6B809C9920401396A2EEF767B20E33A1C02707FA24D99CB023C0C49324F5F3FF",
+		},
+	}
+	for i := range Txs {
+		Txs[i].ChainID = chainID
+	}
+}
+
+// JSON returns every vector as one JSON document for consumption outside Go.
+func JSON() ([]byte, error) {
+	return json.MarshalIndent(struct {
+		Keys []KeyVector `json:"keys"`
+		Txs  []TxVector  `json:"txs"`
+	}{Keys, Txs}, "", "  ")
+}
+
+// orderID follows the "<sender hex>-<sequence>" convention of the order book.
+func orderID(sender types.AccAddress, sequence int64) string {
+	return fmt.Sprintf("%X-%d", sender.Bytes(), sequence)
+}
+
+func addr(keyIndex int) types.AccAddress {
+	bz, err := hex.DecodeString(Keys[keyIndex].AddressHex)
+	if err != nil {
+		panic(err)
+	}
+	return types.AccAddress(bz)
+}
@@ -0,0 +1,66 @@
+package testvectors
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/binance-chain/go-sdk/common/bech32"
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+func TestKeyVectors(t *testing.T) {
+	for _, v := range Keys {
+		km, err := keys.NewMnemonicKeyManager(v.Mnemonic)
+		assert.NoError(t, err)
+		priv, err := km.ExportAsPrivateKey()
+		assert.NoError(t, err)
+		assert.Equal(t, v.PrivateKey, priv)
+		pub := km.GetPrivKey().PubKey().Bytes()
+		assert.Equal(t, v.PublicKey, hex.EncodeToString(pub[len(pub)-33:]))
+		assert.Equal(t, v.AddressHex, hex.EncodeToString(km.GetAddr()))
+		addr, _ := bech32.ConvertAndEncode("bnb", km.GetAddr())
+		assert.Equal(t, v.Address, addr)
+		testnetAddr, _ := bech32.ConvertAndEncode("tbnb", km.GetAddr())
+		assert.Equal(t, v.TestnetAddress, testnetAddr)
+	}
+}
+
+func TestTxVectors(t *testing.T) {
+	defer func(network types.ChainNetwork) { types.Network = network }(types.Network)
+	types.Network = types.ProdNetwork
+	for _, v := range Txs {
+		signBytes, signedTx, hash := sign(t, v)
+		assert.Equal(t, v.SignBytes, signBytes, v.Name)
+		assert.Equal(t, v.SignedTxHex, signedTx, v.Name)
+		assert.Equal(t, v.TxHash, hash, v.Name)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	bz, err := JSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(bz), Txs[0].SignedTxHex)
+}
+
+func sign(t *testing.T, v TxVector) (signBytes, signedTx, hash string) {
+	km, err := keys.NewMnemonicKeyManager(Keys[v.KeyIndex].Mnemonic)
+	assert.NoError(t, err)
+	signMsg := tx.StdSignMsg{
+		ChainID:       v.ChainID,
+		AccountNumber: v.AccountNumber,
+		Sequence:      v.Sequence,
+		Memo:          v.Memo,
+		Source:        v.Source,
+		Msgs:          []msg.Msg{v.Msg},
+	}
+	bz, err := km.Sign(signMsg)
+	assert.NoError(t, err)
+	return string(signMsg.Bytes()), hex.EncodeToString(bz), fmt.Sprintf("%X", tmhash.Sum(bz))
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ABCILog is the structured log of a failed tx or query. The chain encodes it as
+// JSON into the log string, e.g.
+//
+//	{"codespace":1,"code":3,"abci_code":65539,"message":"Invalid sequence. Got 5, expected 6"}
+type ABCILog struct {
+	// Module names the codespace, see RegisterCodespace.
+	Module    string
+	Codespace uint32
+	// Code is the code within the codespace, ABCICode the combined code of the response.
+	Code     uint32
+	ABCICode uint32
+	Message  string
+}
+
+func (l *ABCILog) Error() string {
+	return fmt.Sprintf("%s error %d: %s", l.Module, l.Code, l.Message)
+}
+
+var (
+	codespacesMtx sync.RWMutex
+	codespaces    = map[uint32]string{rootCodespace: "sdk"}
+)
+
+// RegisterCodespace names the module that owns a codespace in parsed logs.
+func RegisterCodespace(codespace uint32, module string) {
+	codespacesMtx.Lock()
+	defer codespacesMtx.Unlock()
+	codespaces[codespace] = module
+}
+
+func codespaceModule(codespace uint32) string {
+	codespacesMtx.RLock()
+	defer codespacesMtx.RUnlock()
+	if module, ok := codespaces[codespace]; ok {
+		return module
+	}
+	return "codespace " + strconv.FormatUint(uint64(codespace), 10)
+}
+
+type rawABCILog struct {
+	Codespace json.RawMessage `json:"codespace"`
+	Code      *uint32         `json:"code"`
+	ABCICode  uint32          `json:"abci_code"`
+	Message   string          `json:"message"`
+}
+
+// ParseABCILog extracts the structured log from log, which may be surrounded by
+// other text as in the errors of broadcast calls. It returns false if log holds
+// no structured log.
+func ParseABCILog(log string) (*ABCILog, bool) {
+	start, end := strings.Index(log, "{"), strings.LastIndex(log, "}")
+	if start < 0 || end < start {
+		return nil, false
+	}
+	var raw rawABCILog
+	if err := json.Unmarshal([]byte(log[start:end+1]), &raw); err != nil || raw.Code == nil {
+		return nil, false
+	}
+	parsed := &ABCILog{Code: *raw.Code, ABCICode: raw.ABCICode, Message: raw.Message}
+	var codespace uint32
+	if err := json.Unmarshal(raw.Codespace, &codespace); err == nil {
+		parsed.Codespace = codespace
+		parsed.Module = codespaceModule(codespace)
+	} else if err := json.Unmarshal(raw.Codespace, &parsed.Module); err != nil || parsed.Module == "" {
+		return nil, false
+	}
+	if parsed.ABCICode == 0 && parsed.Codespace != 0 {
+		parsed.ABCICode = parsed.Codespace<<abciCodespaceBitOffset | parsed.Code
+	}
+	if parsed.Codespace == 0 && parsed.ABCICode != 0 {
+		parsed.Codespace = parsed.ABCICode >> abciCodespaceBitOffset
+	}
+	return parsed, true
+}
@@ -14,4 +14,8 @@ const (
 
 	RialtoNet = "rialto"
 	ChapelNet = "chapel"
+
+	// DefaultMaxResponseSize caps how many bytes a client reads for a single
+	// response or websocket frame.
+	DefaultMaxResponseSize = 16 * 1024 * 1024
 )
@@ -0,0 +1,207 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"sync"
+	"syscall"
+)
+
+// ErrorClass tells whether an operation that failed with an error can be retried.
+type ErrorClass int
+
+const (
+	ErrorClassUnknown ErrorClass = iota
+	// ErrorClassNetwork is a failure to reach the node or api server.
+	ErrorClassNetwork
+	// ErrorClassTimeout is a request that got no answer in time.
+	ErrorClassTimeout
+	// ErrorClassUnavailable is a server that answered with 5xx or 429.
+	ErrorClassUnavailable
+	// ErrorClassSequence is a tx signed with a stale sequence. It can be retried
+	// once the account sequence has been refreshed.
+	ErrorClassSequence
+	ErrorClassInsufficientFunds
+	ErrorClassInvalidSymbol
+	ErrorClassUnauthorized
+	ErrorClassInvalidRequest
+)
+
+var errorClassNames = map[ErrorClass]string{
+	ErrorClassUnknown:           "unknown",
+	ErrorClassNetwork:           "network",
+	ErrorClassTimeout:           "timeout",
+	ErrorClassUnavailable:       "unavailable",
+	ErrorClassSequence:          "sequence",
+	ErrorClassInsufficientFunds: "insufficient_funds",
+	ErrorClassInvalidSymbol:     "invalid_symbol",
+	ErrorClassUnauthorized:      "unauthorized",
+	ErrorClassInvalidRequest:    "invalid_request",
+}
+
+func (c ErrorClass) String() string {
+	if name, ok := errorClassNames[c]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Retryable is true for transient failures. Unknown errors are not retried.
+func (c ErrorClass) Retryable() bool {
+	switch c {
+	case ErrorClassNetwork, ErrorClassTimeout, ErrorClassUnavailable, ErrorClassSequence:
+		return true
+	}
+	return false
+}
+
+// Error attaches a class, and the abci code for errors returned by the chain, to an error.
+type Error struct {
+	Class ErrorClass
+	Code  uint32
+	Err   error
+	// RequestID identifies the call that failed, see WithRequestID.
+	RequestID string
+}
+
+func NewError(class ErrorClass, err error) *Error {
+	return &Error{Class: class, Err: err}
+}
+
+// NewABCIError classifies a failed abci query or tx by its code. A structured log
+// becomes an *ABCILog, which also supplies the code if code is 0.
+func NewABCIError(code uint32, log string) *Error {
+	parsed, ok := ParseABCILog(log)
+	if !ok {
+		return &Error{Class: ClassifyABCICode(code), Code: code, Err: errors.New(log)}
+	}
+	if code == 0 {
+		code = parsed.ABCICode
+	}
+	return &Error{Class: ClassifyABCICode(code), Code: code, Err: parsed}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Cause lets github.com/pkg/errors unwrap the error too.
+func (e *Error) Cause() error {
+	return e.Err
+}
+
+// abci codes of the root codespace of the chain
+const (
+	rootCodespace          = 1
+	codeTxDecode           = 2
+	codeInvalidSequence    = 3
+	codeUnauthorized       = 4
+	codeInsufficientFunds  = 5
+	codeUnknownRequest     = 6
+	codeInvalidAddress     = 7
+	codeInvalidPubKey      = 8
+	codeUnknownAddress     = 9
+	codeInsufficientCoins  = 10
+	codeInvalidCoins       = 11
+	codeMemoTooLarge       = 13
+	codeInsufficientFee    = 14
+	abciCodespaceBitOffset = 16
+)
+
+// ClassifyABCICode maps an abci code, codespace<<16 | code, to its class.
+func ClassifyABCICode(code uint32) ErrorClass {
+	if code>>abciCodespaceBitOffset != rootCodespace {
+		return ErrorClassUnknown
+	}
+	switch code & (1<<abciCodespaceBitOffset - 1) {
+	case codeInvalidSequence:
+		return ErrorClassSequence
+	case codeInsufficientFunds, codeInsufficientCoins, codeInsufficientFee:
+		return ErrorClassInsufficientFunds
+	case codeUnauthorized, codeInvalidPubKey:
+		return ErrorClassUnauthorized
+	case codeTxDecode, codeUnknownRequest, codeInvalidAddress, codeUnknownAddress, codeInvalidCoins, codeMemoTooLarge:
+		return ErrorClassInvalidRequest
+	}
+	return ErrorClassUnknown
+}
+
+var (
+	classesMtx sync.RWMutex
+	classes    = map[error]ErrorClass{}
+)
+
+// RegisterErrorClass classifies sentinel errors that are compared by identity,
+// like the validation errors of the rpc client.
+func RegisterErrorClass(class ErrorClass, errs ...error) {
+	classesMtx.Lock()
+	defer classesMtx.Unlock()
+	for _, err := range errs {
+		classes[err] = class
+	}
+}
+
+// Classify walks the chain of wrapped errors and returns the first class it finds.
+func Classify(err error) ErrorClass {
+	for ; err != nil; err = unwrap(err) {
+		if class := classify(err); class != ErrorClassUnknown {
+			return class
+		}
+	}
+	return ErrorClassUnknown
+}
+
+// IsRetryable reports whether the operation that failed with err may succeed if retried.
+func IsRetryable(err error) bool {
+	return Classify(err).Retryable()
+}
+
+func classify(err error) ErrorClass {
+	switch e := err.(type) {
+	case *Error:
+		return e.Class
+	case *ThrottledError:
+		return ErrorClassUnavailable
+	}
+	if reflect.TypeOf(err).Comparable() {
+		classesMtx.RLock()
+		class, ok := classes[err]
+		classesMtx.RUnlock()
+		if ok {
+			return class
+		}
+	}
+	switch err {
+	case context.DeadlineExceeded:
+		return ErrorClassTimeout
+	case io.EOF, io.ErrUnexpectedEOF, syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.EPIPE:
+		return ErrorClassNetwork
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return ErrorClassTimeout
+	}
+	switch err.(type) {
+	case *net.OpError, *net.DNSError:
+		return ErrorClassNetwork
+	}
+	return ErrorClassUnknown
+}
+
+func unwrap(err error) error {
+	if next := errors.Unwrap(err); next != nil {
+		return next
+	}
+	if causer, ok := err.(interface{ Cause() error }); ok {
+		if cause := causer.Cause(); cause != err {
+			return cause
+		}
+	}
+	return nil
+}
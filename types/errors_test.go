@@ -0,0 +1,106 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	sentinel := errors.New("invalid symbol")
+	RegisterErrorClass(ErrorClassInvalidSymbol, sentinel)
+
+	cases := []struct {
+		err       error
+		class     ErrorClass
+		retryable bool
+	}{
+		{nil, ErrorClassUnknown, false},
+		{errors.New("boom"), ErrorClassUnknown, false},
+		{context.DeadlineExceeded, ErrorClassTimeout, true},
+		{pkgerrors.Wrap(context.DeadlineExceeded, "call"), ErrorClassTimeout, true},
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, ErrorClassNetwork, true},
+		{fmt.Errorf("query: %w", sentinel), ErrorClassInvalidSymbol, false},
+		{NewABCIError(65539, "Invalid sequence. Got 5, expected 6"), ErrorClassSequence, true},
+		{NewABCIError(65546, "1BNB < 2BNB"), ErrorClassInsufficientFunds, false},
+		{NewABCIError(3<<16|3, "other codespace"), ErrorClassUnknown, false},
+		{NewError(ErrorClassUnavailable, errors.New("503")), ErrorClassUnavailable, true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.class, Classify(c.err), "%v", c.err)
+		assert.Equal(t, c.retryable, IsRetryable(c.err), "%v", c.err)
+	}
+}
+
+func TestParseABCILog(t *testing.T) {
+	parsed, ok := ParseABCILog(`{"codespace":1,"code":3,"abci_code":65539,"message":"Invalid sequence. Got 5, expected 6"}`)
+	assert.True(t, ok)
+	assert.Equal(t, &ABCILog{Module: "sdk", Codespace: 1, Code: 3, ABCICode: 65539, Message: "Invalid sequence. Got 5, expected 6"}, parsed)
+
+	RegisterCodespace(6, "dex")
+	parsed, ok = ParseABCILog(`broadcast_tx_sync: Error: {"codespace":6,"code":2,"message":"Failed to find order"}`)
+	assert.True(t, ok)
+	assert.Equal(t, "dex", parsed.Module)
+	assert.Equal(t, uint32(6<<16|2), parsed.ABCICode)
+
+	parsed, ok = ParseABCILog(`{"codespace":"bank","code":10,"message":"insufficient coins"}`)
+	assert.True(t, ok)
+	assert.Equal(t, "bank error 10: insufficient coins", parsed.Error())
+
+	_, ok = ParseABCILog("Invalid sequence")
+	assert.False(t, ok)
+
+	err := NewABCIError(0, `{"codespace":1,"code":5,"abci_code":65541,"message":"insufficient funds"}`)
+	assert.Equal(t, uint32(65541), err.Code)
+	assert.Equal(t, ErrorClassInsufficientFunds, Classify(err))
+	assert.IsType(t, &ABCILog{}, errors.Unwrap(err))
+}
+
+func TestThrottledError(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	wait, ok := ParseRetryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, wait)
+	wait, ok = ParseRetryAfter("Wed, 01 Jan 2020 00:00:30 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, wait)
+	_, ok = ParseRetryAfter("soon", now)
+	assert.False(t, ok)
+
+	header := http.Header{}
+	assert.Nil(t, NewThrottledError(http.StatusServiceUnavailable, header, errors.New("down"), now))
+	throttled := NewThrottledError(http.StatusTooManyRequests, header, errors.New("slow down"), now)
+	assert.Equal(t, DefaultRetryAfter, throttled.RetryAfter)
+
+	header.Set("Retry-After", "5")
+	err := fmt.Errorf("query: %w", NewThrottledError(http.StatusServiceUnavailable, header, errors.New("down"), now))
+	assert.Equal(t, ErrorClassUnavailable, Classify(err))
+	wait, ok = RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, wait)
+}
+
+func TestRequestID(t *testing.T) {
+	assert.Nil(t, WithRequestID(nil, "id"))
+	assert.Equal(t, "", RequestID(errors.New("plain")))
+
+	err := WithRequestID(syscall.ECONNREFUSED, "a")
+	assert.Equal(t, "a", RequestID(err))
+	assert.Equal(t, ErrorClassNetwork, Classify(err))
+	assert.Equal(t, syscall.ECONNREFUSED.Error(), err.Error())
+
+	throttled := &ThrottledError{StatusCode: http.StatusTooManyRequests, Err: errors.New("slow down")}
+	err = WithRequestID(throttled, "b")
+	assert.IsType(t, &ThrottledError{}, err)
+	assert.Empty(t, throttled.RequestID, "the original is left alone")
+	assert.Equal(t, "b", RequestID(fmt.Errorf("order: %w", err)))
+	assert.Equal(t, "b", RequestID(WithRequestID(err, "c")), "the first id is kept")
+}
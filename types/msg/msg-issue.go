@@ -50,7 +50,7 @@ func (msg TokenIssueMsg) ValidateBasic() error {
 	}
 
 	if msg.TotalSupply < 0 || msg.TotalSupply > MaxTotalSupply {
-		return fmt.Errorf("Total supply should be <= " + string(MaxTotalSupply/int64(math.Pow10(int(Decimals)))))
+		return fmt.Errorf("Total supply should be <= %d", MaxTotalSupply/int64(math.Pow10(int(Decimals))))
 	}
 
 	return nil
@@ -75,7 +75,7 @@ func (msg ClaimMsg) GetSigners() []sdk.AccAddress {
 }
 
 func (msg ClaimMsg) String() string {
-	return fmt.Sprintf("Claim{%v#%v#%v%v%x}",
+	return fmt.Sprintf("Claim{%v#%v#%v#%x}",
 		msg.ChainId, msg.Sequence, msg.ValidatorAddress.String(), msg.Payload)
 }
 
@@ -289,6 +289,32 @@ func (s *SCChangeParams) Check() error {
 	return nil
 }
 
+// SideChainParams sorts the param sets of a side chain by module, a set the chain
+// did not return is left nil.
+type SideChainParams struct {
+	Stake  *StakeParams  `json:"staking,omitempty"`
+	Slash  *SlashParams  `json:"slash,omitempty"`
+	Oracle *OracleParams `json:"oracle,omitempty"`
+	Ibc    *IbcParams    `json:"ibc,omitempty"`
+}
+
+func NewSideChainParams(params []SCParam) SideChainParams {
+	var sc SideChainParams
+	for _, param := range params {
+		switch p := param.(type) {
+		case *StakeParams:
+			sc.Stake = p
+		case *SlashParams:
+			sc.Slash = p
+		case *OracleParams:
+			sc.Oracle = p
+		case *IbcParams:
+			sc.Ibc = p
+		}
+	}
+	return sc
+}
+
 type IbcParams struct {
 	RelayerFee int64 `json:"relayer_fee"`
 }
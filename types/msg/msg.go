@@ -61,6 +61,12 @@ type Msg interface {
 	GetInvolvedAddresses() []types.AccAddress
 }
 
+// NetworkSignBytes is the GetSignBytes of m with the account addresses in the
+// prefix of network, rather than of the process wide types.Network.
+func NetworkSignBytes(m Msg, network types.ChainNetwork) []byte {
+	return network.AddressesJSON(m.GetSignBytes())
+}
+
 // ValidateSymbol utility
 func ValidateSymbol(symbol string) error {
 	if len(symbol) == 0 {
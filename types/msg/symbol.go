@@ -0,0 +1,43 @@
+package msg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseSymbol splits a suffixed BEP2 or mini token symbol such as "BUSD-BD1" into
+// its base name ("BUSD") and random suffix ("BD1"). The native token has no suffix.
+func ParseSymbol(symbol string) (base string, suffix string, err error) {
+	parts, err := splitSuffixedTokenSymbol(symbol)
+	if err != nil {
+		return "", "", err
+	}
+	return parts[0], parts[1], nil
+}
+
+// BaseSymbol returns the base name of the symbol, or the symbol itself if it is not suffixed.
+func BaseSymbol(symbol string) string {
+	base, _, err := ParseSymbol(symbol)
+	if err != nil {
+		return symbol
+	}
+	return base
+}
+
+// NormalizeSymbol trims spaces and upper-cases user-entered symbols.
+func NormalizeSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+// ValidateUserSymbol normalizes a user-entered symbol and checks that it is a
+// well-formed BEP2 or mini token symbol. The normalized symbol is returned.
+func ValidateUserSymbol(symbol string) (string, error) {
+	normalized := NormalizeSymbol(symbol)
+	if IsValidMiniTokenSymbol(normalized) {
+		return normalized, nil
+	}
+	if err := ValidateSymbol(normalized); err != nil {
+		return "", fmt.Errorf("invalid symbol %q: %v", symbol, err)
+	}
+	return normalized, nil
+}
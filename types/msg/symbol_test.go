@@ -0,0 +1,95 @@
+package msg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSymbol(t *testing.T) {
+	tests := []struct {
+		symbol string
+		base   string
+		suffix string
+		valid  bool
+	}{
+		{"BUSD-BD1", "BUSD", "BD1", true},
+		{"XYZ-000M", "XYZ", "000M", true},
+		{"BNB", "BNB", "", true},
+		{"BUSD", "", "", false},
+		{"BUSD-BD1-BD1", "", "", false},
+	}
+	for _, test := range tests {
+		base, suffix, err := ParseSymbol(test.symbol)
+		if !test.valid {
+			assert.Error(t, err, test.symbol)
+			continue
+		}
+		assert.NoError(t, err, test.symbol)
+		assert.Equal(t, test.base, base, test.symbol)
+		assert.Equal(t, test.suffix, suffix, test.symbol)
+	}
+}
+
+func TestBaseSymbol(t *testing.T) {
+	tests := []struct {
+		symbol string
+		base   string
+	}{
+		{"BUSD-BD1", "BUSD"},
+		{"XYZ-000M", "XYZ"},
+		{"BNB", "BNB"},
+		{"BUSD", "BUSD"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.base, BaseSymbol(test.symbol), test.symbol)
+	}
+}
+
+func TestNormalizeSymbol(t *testing.T) {
+	tests := []struct {
+		symbol     string
+		normalized string
+	}{
+		{"busd-bd1", "BUSD-BD1"},
+		{" \tBusd-bd1 \n", "BUSD-BD1"},
+		{"xyz-000m", "XYZ-000M"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.normalized, NormalizeSymbol(test.symbol), test.symbol)
+	}
+}
+
+func TestValidateUserSymbol(t *testing.T) {
+	tests := []struct {
+		symbol     string
+		normalized string
+		valid      bool
+	}{
+		{"BUSD-BD1", "BUSD-BD1", true},
+		{" busd-bd1 ", "BUSD-BD1", true},
+		{"bnb", "BNB", true},
+		{"xyz-000m", "XYZ-000M", true},
+		{" XYZ-0A9M\t", "XYZ-0A9M", true},
+		{"BUSD", "", false},
+		{"", "", false},
+		{"BUSD-BD", "", false},
+		{"BUSD-BD12", "", false},
+		{"BUSD-BG1", "", false},
+		{"XYZ-00GM", "", false},
+		{"XYZ-000X", "", false},
+		{"BU$D-BD1", "", false},
+		{"BU SD-BD1", "", false},
+		{"BNB-BD1", "", false},
+	}
+	for _, test := range tests {
+		normalized, err := ValidateUserSymbol(test.symbol)
+		if !test.valid {
+			assert.Error(t, err, test.symbol)
+			continue
+		}
+		assert.NoError(t, err, test.symbol)
+		assert.Equal(t, test.normalized, normalized, test.symbol)
+	}
+}
@@ -1,6 +1,8 @@
 package msg
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
@@ -49,6 +51,33 @@ func CalculateRandomHash(randomNumber []byte, timestamp int64) []byte {
 	return tmhash.Sum(data)
 }
 
+// GenerateRandomNumber returns a random number for an HTLT read from crypto/rand.
+func GenerateRandomNumber() ([]byte, error) {
+	randomNumber := make([]byte, RandomNumberLength)
+	if _, err := rand.Read(randomNumber); err != nil {
+		return nil, err
+	}
+	return randomNumber, nil
+}
+
+// GenerateRandomNumberHash returns a new random number and its hash bound to timestamp.
+func GenerateRandomNumberHash(timestamp int64) (randomNumber []byte, randomNumberHash []byte, err error) {
+	randomNumber, err = GenerateRandomNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	return randomNumber, CalculateRandomHash(randomNumber, timestamp), nil
+}
+
+// VerifyRandomNumber reports whether randomNumber is the preimage of randomNumberHash
+// at timestamp, in constant time so it can check claims from untrusted parties.
+func VerifyRandomNumber(randomNumber []byte, randomNumberHash []byte, timestamp int64) bool {
+	if len(randomNumber) != RandomNumberLength || len(randomNumberHash) != RandomNumberHashLength {
+		return false
+	}
+	return subtle.ConstantTimeCompare(CalculateRandomHash(randomNumber, timestamp), randomNumberHash) == 1
+}
+
 func CalculateSwapID(randomNumberHash []byte, sender types.AccAddress, senderOtherChain string) []byte {
 	senderOtherChain = strings.ToLower(senderOtherChain)
 	data := randomNumberHash
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"github.com/binance-chain/go-sdk/common/uuid"
+)
+
+// RequestIDHeader carries the request id of the calls to the api, so the
+// requests can be found in the logs of the gateway.
+const RequestIDHeader = "X-Request-Id"
+
+// NewRequestID returns a random request id.
+func NewRequestID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+// WithRequestID attaches the id of the call that failed to err, without
+// changing its type for *Error and *ThrottledError, other errors are wrapped in
+// an *Error of their class. Errors with an id keep it.
+func WithRequestID(err error, id string) error {
+	if err == nil || id == "" || RequestID(err) != "" {
+		return err
+	}
+	switch e := err.(type) {
+	case *Error:
+		tagged := *e
+		tagged.RequestID = id
+		return &tagged
+	case *ThrottledError:
+		tagged := *e
+		tagged.RequestID = id
+		return &tagged
+	}
+	return &Error{Class: Classify(err), Err: err, RequestID: id}
+}
+
+// RequestID returns the id of the call that failed with err, "" if unknown.
+func RequestID(err error) string {
+	for ; err != nil; err = unwrap(err) {
+		switch e := err.(type) {
+		case *Error:
+			if e.RequestID != "" {
+				return e.RequestID
+			}
+		case *ThrottledError:
+			if e.RequestID != "" {
+				return e.RequestID
+			}
+		}
+	}
+	return ""
+}
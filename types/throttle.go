@@ -0,0 +1,84 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRetryAfter is the wait assumed for a throttled response without Retry-After.
+const DefaultRetryAfter = time.Second
+
+// ThrottledError is a request refused by a rate limiting gateway, with 429 or
+// with 503 and a Retry-After header. It is classified as ErrorClassUnavailable.
+type ThrottledError struct {
+	StatusCode int
+	// RetryAfter is how long the gateway asked to wait before the next request.
+	RetryAfter time.Duration
+	Err        error
+	RequestID  string
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("throttled, retry after %s: %v", e.RetryAfter, e.Err)
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ThrottledError) Cause() error {
+	return e.Err
+}
+
+// NewThrottledError returns the ThrottledError for a response, or nil if the
+// response does not ask the client to slow down.
+func NewThrottledError(statusCode int, header http.Header, err error, now time.Time) *ThrottledError {
+	retryAfter, ok := ParseRetryAfter(header.Get("Retry-After"), now)
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		if !ok {
+			retryAfter = DefaultRetryAfter
+		}
+	case statusCode == http.StatusServiceUnavailable && ok:
+	default:
+		return nil
+	}
+	return &ThrottledError{StatusCode: statusCode, RetryAfter: retryAfter, Err: err}
+}
+
+// ParseRetryAfter reads a Retry-After header, given either in seconds or as an
+// http date. A date in the past is a wait of 0.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait := at.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
+// RetryAfter returns the wait asked for by the gateway if err, or an error it
+// wraps, is a ThrottledError.
+func RetryAfter(err error) (time.Duration, bool) {
+	for ; err != nil; err = unwrap(err) {
+		if throttled, ok := err.(*ThrottledError); ok {
+			return throttled.RetryAfter, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,13 @@
+package tx
+
+import (
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+// Hash computes the hash the chain indexes a tx under from the signed bytes as
+// returned by KeyManager.Sign, so the hash is known before the tx is broadcast.
+// Its String form is the upper case hex of node responses and explorers.
+func Hash(signedBytes []byte) cmn.HexBytes {
+	return tmhash.Sum(signedBytes)
+}
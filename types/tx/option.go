@@ -30,3 +30,12 @@ func WithChainID(id string) Option {
 		return txMsg
 	}
 }
+
+// WithData attaches arbitrary bytes to the tx, e.g. structured metadata of a
+// payment. It is signed with the tx and counts towards its size.
+func WithData(data []byte) Option {
+	return func(txMsg *StdSignMsg) *StdSignMsg {
+		txMsg.Data = data
+		return txMsg
+	}
+}
@@ -0,0 +1,198 @@
+package tx
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+// PreviewField is one labelled line of a msg preview.
+type PreviewField struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// MsgPreview describes a single msg of a tx.
+type MsgPreview struct {
+	Type    string         `json:"type"`
+	Summary string         `json:"summary"`
+	Fields  []PreviewField `json:"fields"`
+}
+
+// SignPreview is what a signer is about to approve, in a form fit for a
+// confirmation screen. Amounts are rendered with 8 decimals.
+type SignPreview struct {
+	ChainID       string       `json:"chain_id"`
+	AccountNumber int64        `json:"account_number"`
+	Sequence      int64        `json:"sequence"`
+	Memo          string       `json:"memo,omitempty"`
+	Source        int64        `json:"source"`
+	Data          string       `json:"data,omitempty"`
+	Fee           string       `json:"fee,omitempty"`
+	Msgs          []MsgPreview `json:"msgs"`
+}
+
+// Preview renders signMsg for display before signing, addresses with the
+// prefix of its network like the sign bytes. The fee is not part of the sign doc,
+// pass the fee the tx will be charged or nil if it is unknown.
+func Preview(signMsg StdSignMsg, fee types.Coins) *SignPreview {
+	p := &SignPreview{
+		ChainID:       signMsg.ChainID,
+		AccountNumber: signMsg.AccountNumber,
+		Sequence:      signMsg.Sequence,
+		Memo:          signMsg.Memo,
+		Source:        signMsg.Source,
+		Fee:           formatCoins(fee),
+	}
+	if len(signMsg.Data) > 0 {
+		p.Data = hex.EncodeToString(signMsg.Data)
+	}
+	for _, m := range signMsg.Msgs {
+		p.Msgs = append(p.Msgs, previewMsg(m, signMsg.network()))
+	}
+	return p
+}
+
+// String renders the preview as plain text, one field per line.
+func (p *SignPreview) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Chain: %s\n", p.ChainID)
+	fmt.Fprintf(&b, "Account: %d, sequence %d\n", p.AccountNumber, p.Sequence)
+	for i, m := range p.Msgs {
+		fmt.Fprintf(&b, "Msg %d: %s\n", i+1, m.Summary)
+		for _, f := range m.Fields {
+			fmt.Fprintf(&b, "  %s: %s\n", f.Label, f.Value)
+		}
+	}
+	if p.Memo != "" {
+		fmt.Fprintf(&b, "Memo: %s\n", p.Memo)
+	}
+	if p.Data != "" {
+		fmt.Fprintf(&b, "Data: %s\n", p.Data)
+	}
+	if p.Fee != "" {
+		fmt.Fprintf(&b, "Fee: %s\n", p.Fee)
+	}
+	return b.String()
+}
+
+func previewMsg(m msg.Msg, network types.ChainNetwork) MsgPreview {
+	p := MsgPreview{Type: m.Type()}
+	addr := network.FormatAddress
+	add := func(label, value string) {
+		p.Fields = append(p.Fields, PreviewField{Label: label, Value: value})
+	}
+	switch m := m.(type) {
+	case msg.SendMsg:
+		var total types.Coins
+		for _, in := range m.Inputs {
+			add("From", addr(in.Address))
+		}
+		for _, out := range m.Outputs {
+			add("To", fmt.Sprintf("%s receives %s", addr(out.Address), formatCoins(out.Coins)))
+			total = total.Plus(out.Coins)
+		}
+		p.Summary = fmt.Sprintf("Send %s to %d recipient(s)", formatCoins(total), len(m.Outputs))
+	case msg.CreateOrderMsg:
+		side := strings.ToLower(msg.IToSide(m.Side))
+		p.Summary = fmt.Sprintf("Place %s order for %s %s at %s", side, formatAmount(m.Quantity), m.Symbol, formatAmount(m.Price))
+		add("Sender", addr(m.Sender))
+		add("Symbol", m.Symbol)
+		add("Side", side)
+		add("Price", formatAmount(m.Price))
+		add("Quantity", formatAmount(m.Quantity))
+		add("Time in force", msg.IToTimeInForce(m.TimeInForce))
+	case msg.CancelOrderMsg:
+		p.Summary = fmt.Sprintf("Cancel order %s on %s", m.RefID, m.Symbol)
+		add("Sender", addr(m.Sender))
+		add("Order", m.RefID)
+	case msg.TokenIssueMsg:
+		p.Summary = fmt.Sprintf("Issue %s %s", formatAmount(m.TotalSupply), m.Symbol)
+		add("From", addr(m.From))
+		add("Name", m.Name)
+		add("Mintable", fmt.Sprint(m.Mintable))
+	case msg.MintMsg:
+		p.Summary = fmt.Sprintf("Mint %s %s", formatAmount(m.Amount), m.Symbol)
+		add("From", addr(m.From))
+	case msg.TokenBurnMsg:
+		p.Summary = fmt.Sprintf("Burn %s %s", formatAmount(m.Amount), m.Symbol)
+		add("From", addr(m.From))
+	case msg.TokenFreezeMsg:
+		p.Summary = fmt.Sprintf("Freeze %s %s", formatAmount(m.Amount), m.Symbol)
+		add("From", addr(m.From))
+	case msg.TokenUnfreezeMsg:
+		p.Summary = fmt.Sprintf("Unfreeze %s %s", formatAmount(m.Amount), m.Symbol)
+		add("From", addr(m.From))
+	case msg.VoteMsg:
+		p.Summary = fmt.Sprintf("Vote %s on proposal %d", m.Option.String(), m.ProposalID)
+		add("Voter", addr(m.Voter))
+	case msg.DepositMsg:
+		p.Summary = fmt.Sprintf("Deposit %s on proposal %d", formatCoins(m.Amount), m.ProposalID)
+		add("Depositor", addr(m.Depositer))
+	case msg.HTLTMsg:
+		p.Summary = fmt.Sprintf("Lock %s for %s for %d blocks", formatCoins(m.Amount), addr(m.To), m.HeightSpan)
+		add("From", addr(m.From))
+		add("To", addr(m.To))
+		add("Random number hash", hex.EncodeToString(m.RandomNumberHash))
+		add("Expected income", m.ExpectedIncome)
+		if m.CrossChain {
+			add("Recipient on other chain", m.RecipientOtherChain)
+			add("Sender on other chain", m.SenderOtherChain)
+		}
+	case msg.ClaimHTLTMsg:
+		p.Summary = fmt.Sprintf("Claim swap %s", hex.EncodeToString(m.SwapID))
+		add("From", addr(m.From))
+	case msg.RefundHTLTMsg:
+		p.Summary = fmt.Sprintf("Refund swap %s", hex.EncodeToString(m.SwapID))
+		add("From", addr(m.From))
+	case msg.TransferOutMsg:
+		p.Summary = fmt.Sprintf("Transfer %s to %s on Binance Smart Chain", formatCoins(types.Coins{m.Amount}), m.To.String())
+		add("From", addr(m.From))
+		add("Expire time", fmt.Sprint(m.ExpireTime))
+	default:
+		p.Summary = m.Type()
+		p.Fields = genericFields(msg.NetworkSignBytes(m, network))
+	}
+	return p
+}
+
+// genericFields lists the top level fields of the sign bytes of msgs without a
+// dedicated preview.
+func genericFields(signBytes []byte) []PreviewField {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(signBytes, &doc); err != nil {
+		return []PreviewField{{Label: "Raw", Value: string(signBytes)}}
+	}
+	labels := make([]string, 0, len(doc))
+	for label := range doc {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	fields := make([]PreviewField, 0, len(labels))
+	for _, label := range labels {
+		value := string(doc[label])
+		var s string
+		if json.Unmarshal(doc[label], &s) == nil {
+			value = s
+		}
+		fields = append(fields, PreviewField{Label: label, Value: value})
+	}
+	return fields
+}
+
+func formatAmount(amount int64) string {
+	return types.Fixed8(amount).String()
+}
+
+func formatCoins(coins types.Coins) string {
+	parts := make([]string, 0, len(coins))
+	for _, coin := range coins {
+		parts = append(parts, formatAmount(coin.Amount)+" "+coin.Denom)
+	}
+	return strings.Join(parts, ", ")
+}
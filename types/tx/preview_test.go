@@ -0,0 +1,35 @@
+package tx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func TestPreview(t *testing.T) {
+	from := types.AccAddress([]byte("preview-sender-addr-"))
+	to := types.AccAddress([]byte("preview-recipient---"))
+	send := msg.CreateSendMsg(from, types.Coins{{Denom: "BNB", Amount: 150000000}}, []msg.Transfer{{ToAddr: to, Coins: types.Coins{{Denom: "BNB", Amount: 150000000}}}})
+	order := msg.NewCreateOrderMsg(from, "id", msg.OrderSide.BUY, "BTC-86A_BNB", 250000000, 100000000)
+
+	p := Preview(StdSignMsg{
+		ChainID:  "Binance-Chain-Tigris",
+		Sequence: 7,
+		Memo:     "invoice 42",
+		Msgs:     []msg.Msg{send, order},
+	}, types.Coins{{Denom: "BNB", Amount: 37500}})
+
+	assert.Len(t, p.Msgs, 2)
+	assert.Equal(t, "Send 1.50000000 BNB to 1 recipient(s)", p.Msgs[0].Summary)
+	assert.Equal(t, PreviewField{Label: "To", Value: to.String() + " receives 1.50000000 BNB"}, p.Msgs[0].Fields[1])
+	assert.Equal(t, "Place buy order for 1.00000000 BTC-86A_BNB at 2.50000000", p.Msgs[1].Summary)
+	assert.Equal(t, "0.00037500 BNB", p.Fee)
+
+	text := p.String()
+	assert.True(t, strings.Contains(text, "Memo: invoice 42\n"))
+	assert.True(t, strings.Contains(text, "Fee: 0.00037500 BNB\n"))
+}
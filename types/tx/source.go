@@ -0,0 +1,67 @@
+package tx
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Source codes of well known applications. Source, the code this SDK signs with by
+// default, is SourceDefault.
+const (
+	SourceDefault     int64 = 0
+	SourceWebWallet   int64 = 1
+	SourceTrustWallet int64 = 2
+)
+
+// SourceInfo names the application behind a source code.
+type SourceInfo struct {
+	Code int64  `json:"code"`
+	Name string `json:"name"`
+}
+
+var sources = struct {
+	sync.RWMutex
+	names map[int64]string
+}{names: map[int64]string{
+	SourceDefault:     "Default (SDK or command line)",
+	SourceWebWallet:   "Binance DEX Web Wallet",
+	SourceTrustWallet: "Trust Wallet",
+}}
+
+// RegisterSource records the application name of a source code so SourceName can
+// resolve it. Codes already registered cannot be renamed.
+func RegisterSource(code int64, name string) error {
+	if name == "" {
+		return fmt.Errorf("source name is empty")
+	}
+	sources.Lock()
+	defer sources.Unlock()
+	if existing, ok := sources.names[code]; ok && existing != name {
+		return fmt.Errorf("source %d is already registered as %q", code, existing)
+	}
+	sources.names[code] = name
+	return nil
+}
+
+// SourceName returns the application name of a source code, or "unknown".
+func SourceName(code int64) string {
+	sources.RLock()
+	defer sources.RUnlock()
+	if name, ok := sources.names[code]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Sources lists the registered source codes in ascending order.
+func Sources() []SourceInfo {
+	sources.RLock()
+	defer sources.RUnlock()
+	out := make([]SourceInfo, 0, len(sources.names))
+	for code, name := range sources.names {
+		out = append(out, SourceInfo{Code: code, Name: name})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
@@ -0,0 +1,22 @@
+package tx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterSource(t *testing.T) {
+	assert.Equal(t, "Trust Wallet", SourceName(SourceTrustWallet))
+	assert.Equal(t, "unknown", SourceName(9001))
+
+	assert.NoError(t, RegisterSource(9001, "my app"))
+	assert.NoError(t, RegisterSource(9001, "my app"))
+	assert.Error(t, RegisterSource(9001, "other app"))
+	assert.Error(t, RegisterSource(9002, ""))
+	assert.Equal(t, "my app", SourceName(9001))
+
+	all := Sources()
+	assert.Equal(t, SourceInfo{Code: SourceDefault, Name: SourceName(SourceDefault)}, all[0])
+	assert.Equal(t, SourceInfo{Code: 9001, Name: "my app"}, all[len(all)-1])
+}
@@ -3,6 +3,7 @@ package tx
 import (
 	"encoding/json"
 
+	"github.com/binance-chain/go-sdk/common/types"
 	"github.com/binance-chain/go-sdk/types/msg"
 	"github.com/tendermint/tendermint/crypto"
 )
@@ -27,6 +28,9 @@ type StdSignMsg struct {
 	Memo          string    `json:"memo"`
 	Source        int64     `json:"source"`
 	Data          []byte    `json:"data"`
+	// Network renders the addresses of the msgs in the sign bytes, nil for
+	// the process wide types.Network.
+	Network *types.ChainNetwork `json:"-"`
 }
 
 // StdSignature Signature
@@ -39,14 +43,27 @@ type StdSignature struct {
 
 // Bytes gets message bytes
 func (msg StdSignMsg) Bytes() []byte {
-	return StdSignBytes(msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Msgs, msg.Memo, msg.Source, msg.Data)
+	return NetworkSignBytes(msg.network(), msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Msgs, msg.Memo, msg.Source, msg.Data)
+}
+
+func (msg StdSignMsg) network() types.ChainNetwork {
+	if msg.Network != nil {
+		return *msg.Network
+	}
+	return types.Network
 }
 
 // StdSignBytes returns the bytes to sign for a transaction.
 func StdSignBytes(chainID string, accnum int64, sequence int64, msgs []msg.Msg, memo string, source int64, data []byte) []byte {
+	return NetworkSignBytes(types.Network, chainID, accnum, sequence, msgs, memo, source, data)
+}
+
+// NetworkSignBytes is StdSignBytes with the addresses of the msgs in the prefix
+// of network.
+func NetworkSignBytes(network types.ChainNetwork, chainID string, accnum int64, sequence int64, msgs []msg.Msg, memo string, source int64, data []byte) []byte {
 	var msgsBytes []json.RawMessage
-	for _, msg := range msgs {
-		msgsBytes = append(msgsBytes, json.RawMessage(msg.GetSignBytes()))
+	for _, m := range msgs {
+		msgsBytes = append(msgsBytes, json.RawMessage(msg.NetworkSignBytes(m, network)))
 	}
 	bz, err := Cdc.MarshalJSON(StdSignDoc{
 		AccountNumber: accnum,
@@ -0,0 +1,31 @@
+package tx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+func TestStdSignBytesUseNetworkPrefix(t *testing.T) {
+	defer func(network types.ChainNetwork) { types.Network = network }(types.Network)
+	types.Network = types.ProdNetwork
+	from := types.AccAddress([]byte("sign-bytes-sender---"))
+	coins := types.Coins{{Denom: "BNB", Amount: 1}}
+	send := msg.CreateSendMsg(from, coins, []msg.Transfer{{ToAddr: from, Coins: coins}})
+	testNetwork := types.TestNetwork
+
+	testnet := string(StdSignMsg{ChainID: "Binance-Chain-Ganges", Msgs: []msg.Msg{send}, Memo: from.String(), Network: &testNetwork}.Bytes())
+	assert.Equal(t, 2, strings.Count(testnet, types.TestNetwork.FormatAddress(from)))
+	assert.Equal(t, 1, strings.Count(testnet, from.String()), "only the memo keeps its text")
+
+	types.Network = types.TestNetwork
+	assert.Equal(t, testnet, string(StdSignMsg{ChainID: "Binance-Chain-Ganges", Msgs: []msg.Msg{send}, Memo: types.ProdNetwork.FormatAddress(from)}.Bytes()),
+		"the sign bytes of the network are those of types.Network set to it")
+
+	local := string(StdSignMsg{ChainID: "local-chain", Msgs: []msg.Msg{send}}.Bytes())
+	assert.Equal(t, 2, strings.Count(local, from.String()), "without a network the msgs use types.Network")
+}
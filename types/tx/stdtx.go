@@ -4,7 +4,8 @@ import (
 	"github.com/binance-chain/go-sdk/types/msg"
 )
 
-const Source int64 = 0
+// Source is the source code txs are signed with unless another one is set.
+const Source = SourceDefault
 
 type Tx interface {
 
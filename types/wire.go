@@ -4,12 +4,15 @@ import (
 	ntypes "github.com/binance-chain/go-sdk/common/types"
 	"github.com/binance-chain/go-sdk/types/tx"
 	"github.com/tendermint/go-amino"
-	types "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 func NewCodec() *amino.Codec {
 	cdc := amino.NewCodec()
-	types.RegisterAmino(cdc)
+	// what rpc/core/types.RegisterAmino registers, without importing the node
+	// packages behind it that do not build for js/wasm
+	tmtypes.RegisterEventDatas(cdc)
+	tmtypes.RegisterBlockAmino(cdc)
 	ntypes.RegisterWire(cdc)
 	tx.RegisterCodec(cdc)
 	return cdc
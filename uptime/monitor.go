@@ -0,0 +1,213 @@
+// Package uptime tracks whether a validator signs blocks and warns before it
+// misses enough of them to be slashed for downtime.
+package uptime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+const (
+	DefaultWindow       = 1000
+	DefaultWarnFraction = 0.5
+)
+
+// CommitClient is the part of the rpc client the monitor needs. *rpc.HTTP satisfies it.
+type CommitClient interface {
+	Status() (*ctypes.ResultStatus, error)
+	Commit(height *int64) (*ctypes.ResultCommit, error)
+}
+
+type Config struct {
+	// Window is how many of the latest blocks the missed ratio is computed over.
+	Window int64
+	// MaxMissedRatio is the ratio of missed blocks in the window at which the
+	// validator is slashed.
+	MaxMissedRatio float64
+	// WarnFraction is the share of MaxMissedRatio from which the monitor warns.
+	WarnFraction float64
+}
+
+// ConfigFromSlashParams takes the window and threshold from the slash params of
+// the chain the validator runs on.
+func ConfigFromSlashParams(p *msg.SlashParams, warnFraction float64) Config {
+	raw, _ := p.MinSignedPerWindow.MarshalAmino()
+	minSigned := float64(raw) / math.Pow10(types.Precision)
+	return Config{Window: p.SignedBlocksWindow, MaxMissedRatio: 1 - minSigned, WarnFraction: warnFraction}
+}
+
+type Level int
+
+const (
+	LevelOK Level = iota
+	LevelWarning
+	// LevelCritical means the validator missed enough blocks to be slashed.
+	LevelCritical
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelOK:
+		return "ok"
+	case LevelWarning:
+		return "warning"
+	case LevelCritical:
+		return "critical"
+	}
+	return "unknown"
+}
+
+// Status is the signing record of the validator up to Height.
+type Status struct {
+	Height int64
+	// Blocks is how many blocks of the window were seen, less than the window
+	// right after the monitor starts.
+	Blocks      int64
+	Missed      int64
+	MissedRatio float64
+	Level       Level
+}
+
+// Monitor keeps a sliding window of which blocks the validator signed.
+type Monitor struct {
+	client  CommitClient
+	address []byte
+	cfg     Config
+
+	mtx    sync.Mutex
+	signed []bool
+	next   int
+	blocks int64
+	missed int64
+	height int64
+}
+
+// NewMonitor watches the validator with the given consensus address, the one in
+// the precommits of a block and not the operator address.
+func NewMonitor(client CommitClient, consensusAddress []byte, cfg Config) *Monitor {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultWindow
+	}
+	if cfg.WarnFraction <= 0 {
+		cfg.WarnFraction = DefaultWarnFraction
+	}
+	return &Monitor{client: client, address: consensusAddress, cfg: cfg, signed: make([]bool, cfg.Window)}
+}
+
+// Observe records whether the validator signed the block at height. Heights at or
+// below the last observed one are ignored.
+func (m *Monitor) Observe(height int64, signed bool) Status {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if height > m.height {
+		if m.blocks == m.cfg.Window {
+			if !m.signed[m.next] {
+				m.missed--
+			}
+		} else {
+			m.blocks++
+		}
+		m.signed[m.next] = signed
+		if !signed {
+			m.missed++
+		}
+		m.next = (m.next + 1) % len(m.signed)
+		m.height = height
+	}
+	return m.status()
+}
+
+func (m *Monitor) Status() Status {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.status()
+}
+
+func (m *Monitor) status() Status {
+	s := Status{Height: m.height, Blocks: m.blocks, Missed: m.missed}
+	// the ratio is over the whole window so the monitor does not raise alarms on
+	// the first few blocks it sees
+	s.MissedRatio = float64(m.missed) / float64(m.cfg.Window)
+	switch {
+	case m.cfg.MaxMissedRatio <= 0:
+	case s.MissedRatio >= m.cfg.MaxMissedRatio:
+		s.Level = LevelCritical
+	case s.MissedRatio >= m.cfg.MaxMissedRatio*m.cfg.WarnFraction:
+		s.Level = LevelWarning
+	}
+	return s
+}
+
+// Poll reads the commits of the blocks since the last poll, at most a window of
+// them, and returns the updated status.
+func (m *Monitor) Poll() (Status, error) {
+	status, err := m.client.Status()
+	if err != nil {
+		return Status{}, err
+	}
+	latest := status.SyncInfo.LatestBlockHeight
+	from := m.Status().Height + 1
+	if from <= latest-m.cfg.Window {
+		from = latest - m.cfg.Window + 1
+	}
+	if from < 1 {
+		from = 1
+	}
+	for height := from; height <= latest; height++ {
+		signed, err := m.signedAt(height)
+		if err != nil {
+			return m.Status(), err
+		}
+		m.Observe(height, signed)
+	}
+	return m.Status(), nil
+}
+
+func (m *Monitor) signedAt(height int64) (bool, error) {
+	commit, err := m.client.Commit(&height)
+	if err != nil {
+		return false, err
+	}
+	if commit.SignedHeader.Commit == nil {
+		return false, fmt.Errorf("no commit at height %d", height)
+	}
+	for _, vote := range commit.SignedHeader.Commit.Precommits {
+		if vote != nil && bytes.Equal(vote.ValidatorAddress, m.address) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Run polls every interval until ctx is done. onAlert gets the status whenever
+// its level changes, including back to LevelOK, and onError, if not nil, the
+// errors of the polls.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration, onAlert func(Status), onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := LevelOK
+	for {
+		status, err := m.Poll()
+		if err != nil && onError != nil {
+			onError(err)
+		}
+		if status.Level != last {
+			onAlert(status)
+			last = status.Level
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
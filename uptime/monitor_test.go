@@ -0,0 +1,86 @@
+package uptime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+)
+
+var (
+	testValidator = []byte("validator-consensus-")
+	otherSigner   = []byte("other-consensus-addr")
+)
+
+// fakeChain has the validator miss every block whose height is in missed.
+type fakeChain struct {
+	latest int64
+	missed map[int64]bool
+	asked  []int64
+}
+
+func (f *fakeChain) Status() (*ctypes.ResultStatus, error) {
+	status := &ctypes.ResultStatus{}
+	status.SyncInfo.LatestBlockHeight = f.latest
+	return status, nil
+}
+
+func (f *fakeChain) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	f.asked = append(f.asked, *height)
+	precommits := []*tmtypes.CommitSig{{ValidatorAddress: otherSigner}, nil}
+	if !f.missed[*height] {
+		precommits = append(precommits, &tmtypes.CommitSig{ValidatorAddress: testValidator})
+	}
+	return &ctypes.ResultCommit{SignedHeader: tmtypes.SignedHeader{Commit: &tmtypes.Commit{Precommits: precommits}}}, nil
+}
+
+func TestMonitorWindow(t *testing.T) {
+	m := NewMonitor(nil, testValidator, Config{Window: 4, MaxMissedRatio: 0.5})
+	assert.Equal(t, LevelOK, m.Observe(1, true).Level)
+	assert.Equal(t, LevelWarning, m.Observe(2, false).Level)
+	status := m.Observe(3, false)
+	assert.Equal(t, LevelCritical, status.Level)
+	assert.Equal(t, int64(2), status.Missed)
+	assert.Equal(t, int64(3), status.Blocks)
+
+	m.Observe(4, true)
+	m.Observe(5, true)
+	status = m.Observe(6, true)
+	assert.Equal(t, int64(4), status.Blocks)
+	assert.Equal(t, int64(1), status.Missed)
+	assert.Equal(t, 0.25, status.MissedRatio)
+	assert.Equal(t, LevelWarning, status.Level)
+
+	// old heights do not count twice
+	assert.Equal(t, status, m.Observe(6, false))
+}
+
+func TestMonitorPoll(t *testing.T) {
+	chain := &fakeChain{latest: 10, missed: map[int64]bool{9: true}}
+	m := NewMonitor(chain, testValidator, Config{Window: 5, MaxMissedRatio: 0.6})
+	status, err := m.Poll()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{6, 7, 8, 9, 10}, chain.asked)
+	assert.Equal(t, int64(10), status.Height)
+	assert.Equal(t, int64(1), status.Missed)
+	assert.Equal(t, LevelOK, status.Level)
+
+	chain.latest, chain.asked = 12, nil
+	chain.missed[11], chain.missed[12] = true, true
+	status, err = m.Poll()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{11, 12}, chain.asked)
+	assert.Equal(t, int64(3), status.Missed)
+	assert.Equal(t, LevelCritical, status.Level)
+}
+
+func TestConfigFromSlashParams(t *testing.T) {
+	cfg := ConfigFromSlashParams(&msg.SlashParams{SignedBlocksWindow: 100, MinSignedPerWindow: types.NewDecWithPrec(5, 1)}, 0.8)
+	assert.Equal(t, int64(100), cfg.Window)
+	assert.InDelta(t, 0.5, cfg.MaxMissedRatio, 1e-9)
+	assert.Equal(t, 0.8, cfg.WarnFraction)
+}
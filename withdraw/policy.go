@@ -0,0 +1,141 @@
+package withdraw
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/go-sdk/common/types"
+)
+
+// Policy decides which withdrawals may be sent. Check is called for every
+// policy first, Accept only once all of them passed.
+type Policy interface {
+	// Check returns why req may not be sent, nil if it may.
+	Check(req Request) error
+	// Accept records an accepted request, for policies that limit over time.
+	Accept(req Request)
+}
+
+// AddressList rejects withdrawals to denied addresses and, once any address
+// is allowed, to every address that is not. It is safe for concurrent use.
+type AddressList struct {
+	mtx   sync.RWMutex
+	allow map[string]bool
+	deny  map[string]bool
+}
+
+func NewAddressList() *AddressList {
+	return &AddressList{allow: map[string]bool{}, deny: map[string]bool{}}
+}
+
+// Allow adds addrs to the allow list, which then restricts withdrawals to it.
+func (l *AddressList) Allow(addrs ...types.AccAddress) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	for _, addr := range addrs {
+		l.allow[string(addr)] = true
+	}
+}
+
+// Deny adds addrs to the deny list, which wins over the allow list.
+func (l *AddressList) Deny(addrs ...types.AccAddress) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	for _, addr := range addrs {
+		l.deny[string(addr)] = true
+	}
+}
+
+func (l *AddressList) Check(req Request) error {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	if l.deny[string(req.To)] {
+		return fmt.Errorf("address %s is denied", req.To)
+	}
+	if len(l.allow) > 0 && !l.allow[string(req.To)] {
+		return fmt.Errorf("address %s is not allowed", req.To)
+	}
+	return nil
+}
+
+func (l *AddressList) Accept(Request) {}
+
+// Limit caps the withdrawals of a denom, amounts in units of 1e-8. Zero
+// values do not cap.
+type Limit struct {
+	Denom         string
+	MaxPerRequest int64
+	// MaxPerWindow caps the total accepted within any span of Window.
+	MaxPerWindow int64
+	Window       time.Duration
+}
+
+// Limits applies a Limit per denom, denoms without one are not limited. It is
+// safe for concurrent use.
+type Limits struct {
+	limits map[string]Limit
+	now    func() time.Time
+
+	mtx      sync.Mutex
+	accepted map[string][]accepted
+}
+
+type accepted struct {
+	at     time.Time
+	amount int64
+}
+
+func NewLimits(limits ...Limit) *Limits {
+	l := &Limits{limits: map[string]Limit{}, now: time.Now, accepted: map[string][]accepted{}}
+	for _, limit := range limits {
+		l.limits[limit.Denom] = limit
+	}
+	return l
+}
+
+func (l *Limits) Check(req Request) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	now := l.now()
+	for _, coin := range req.Coins {
+		limit, ok := l.limits[coin.Denom]
+		if !ok {
+			continue
+		}
+		if limit.MaxPerRequest > 0 && coin.Amount > limit.MaxPerRequest {
+			return fmt.Errorf("%s exceeds the limit of %d per withdrawal", coin, limit.MaxPerRequest)
+		}
+		if limit.MaxPerWindow > 0 {
+			if used := l.used(coin.Denom, limit.Window, now); used+coin.Amount > limit.MaxPerWindow {
+				return fmt.Errorf("%s exceeds the limit of %d per %s, %d used", coin, limit.MaxPerWindow, limit.Window, used)
+			}
+		}
+	}
+	return nil
+}
+
+func (l *Limits) Accept(req Request) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	now := l.now()
+	for _, coin := range req.Coins {
+		if _, ok := l.limits[coin.Denom]; ok {
+			l.accepted[coin.Denom] = append(l.accepted[coin.Denom], accepted{at: now, amount: coin.Amount})
+		}
+	}
+}
+
+// used sums the amounts accepted within window and forgets older ones.
+func (l *Limits) used(denom string, window time.Duration, now time.Time) int64 {
+	entries := l.accepted[denom]
+	for len(entries) > 0 && !entries[0].at.After(now.Add(-window)) {
+		entries = entries[1:]
+	}
+	l.accepted[denom] = entries
+	var used int64
+	for _, e := range entries {
+		used += e.amount
+	}
+	return used
+}
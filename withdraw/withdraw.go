@@ -0,0 +1,512 @@
+// Package withdraw sends the withdrawals of an exchange from its hot wallet.
+// Requests are checked against policies, queued, and sent in multi-send
+// batches signed with a locally tracked sequence. A batch is broadcast again,
+// byte for byte, until it commits or its sequence is taken by another tx, so a
+// withdrawal is never paid twice; only then is it queued again.
+package withdraw
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	ctypes "github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+const (
+	// DefaultMaxBatch is the most withdrawals sent in one multi-send.
+	DefaultMaxBatch = 50
+	// DefaultMaxAttempts is how many times a withdrawal is sent before it fails.
+	DefaultMaxAttempts = 3
+)
+
+// Client is the part of the rpc client the pipeline needs, with the key of the
+// hot wallet set. *rpc.HTTP satisfies it.
+type Client interface {
+	GetAccount(addr ctypes.AccAddress) (ctypes.Account, error)
+	SignMsg(m msg.Msg, options ...tx.Option) ([]byte, error)
+	BroadcastIdempotent(signedTx []byte, syncType rpc.SyncType) (*core_types.ResultBroadcastTx, error)
+	Tx(hash []byte, prove bool) (*rpc.ResultTx, error)
+}
+
+type Status string
+
+const (
+	Queued    Status = "queued"
+	Sent      Status = "sent"
+	Confirmed Status = "confirmed"
+	Failed    Status = "failed"
+	Rejected  Status = "rejected"
+)
+
+// Request asks to send coins to an address. ID is chosen by the caller and
+// must be unique, submitting it again returns the existing withdrawal.
+type Request struct {
+	ID    string            `json:"id"`
+	To    ctypes.AccAddress `json:"to"`
+	Coins ctypes.Coins      `json:"coins"`
+	// Memo is set on the tx, withdrawals with a memo are sent alone.
+	Memo string `json:"memo,omitempty"`
+}
+
+// Withdrawal is a request and its progress.
+type Withdrawal struct {
+	Request
+	Status Status `json:"status"`
+	// Attempts is the number of times it was sent and failed.
+	Attempts int `json:"attempts"`
+	// Error is why the last attempt failed or the request was rejected.
+	Error string `json:"error,omitempty"`
+	// TxHash, Sequence and Tx are the batch the withdrawal was last sent in,
+	// Height the block it committed in.
+	TxHash    string    `json:"tx_hash,omitempty"`
+	Sequence  int64     `json:"sequence,omitempty"`
+	Tx        []byte    `json:"tx,omitempty"`
+	Height    int64     `json:"height,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Done reports whether the withdrawal will not change anymore.
+func (w Withdrawal) Done() bool {
+	return w.Status == Confirmed || w.Status == Failed || w.Status == Rejected
+}
+
+// Store persists withdrawals, so a restarted pipeline resumes where it stopped.
+type Store interface {
+	Save(w Withdrawal) error
+	// Load returns the withdrawals that are not done, in the order they were submitted.
+	Load() ([]Withdrawal, error)
+}
+
+// MemoryStore is a Store that keeps nothing across restarts.
+type MemoryStore struct{}
+
+func (MemoryStore) Save(Withdrawal) error       { return nil }
+func (MemoryStore) Load() ([]Withdrawal, error) { return nil, nil }
+
+// RejectedError is a request the policies refused.
+type RejectedError struct {
+	ID     string
+	Reason error
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("withdrawal %s rejected: %v", e.ID, e.Reason)
+}
+
+type Config struct {
+	// From is the hot wallet, the address of the key of the client.
+	From     ctypes.AccAddress
+	Policies []Policy
+	// MaxBatch is DefaultMaxBatch and MaxAttempts DefaultMaxAttempts if 0.
+	MaxBatch    int
+	MaxAttempts int
+	// Store is a MemoryStore if nil.
+	Store Store
+	// OnUpdate, if set, gets every change of status.
+	OnUpdate func(Withdrawal)
+}
+
+// Pipeline queues and sends withdrawals. It is safe for concurrent use,
+// Process runs one cycle at a time.
+type Pipeline struct {
+	client Client
+	cfg    Config
+	now    func() time.Time
+
+	process sync.Mutex
+
+	mtx         sync.Mutex
+	withdrawals map[string]*Withdrawal
+	queue       []string
+	// sequence is the next sequence to sign with, valid while synced
+	accountNumber int64
+	sequence      int64
+	synced        bool
+}
+
+// New returns a pipeline resuming the withdrawals of the store.
+func New(client Client, cfg Config) (*Pipeline, error) {
+	if cfg.MaxBatch <= 0 {
+		cfg.MaxBatch = DefaultMaxBatch
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	if cfg.Store == nil {
+		cfg.Store = MemoryStore{}
+	}
+	p := &Pipeline{client: client, cfg: cfg, now: time.Now, withdrawals: map[string]*Withdrawal{}}
+	pending, err := cfg.Store.Load()
+	if err != nil {
+		return nil, err
+	}
+	for i := range pending {
+		w := pending[i]
+		p.withdrawals[w.ID] = &w
+		if w.Status == Queued {
+			p.queue = append(p.queue, w.ID)
+		}
+	}
+	return p, nil
+}
+
+// Submit checks a request against the policies and queues it.
+func (p *Pipeline) Submit(req Request) (Withdrawal, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if w, ok := p.withdrawals[req.ID]; ok {
+		return *w, nil
+	}
+	if err := validate(req); err != nil {
+		return Withdrawal{}, err
+	}
+	req.Coins = req.Coins.Sort()
+	w := &Withdrawal{Request: req, Status: Queued}
+	for _, policy := range p.cfg.Policies {
+		if err := policy.Check(req); err != nil {
+			w.Status, w.Error = Rejected, err.Error()
+			if err := p.update(w); err != nil {
+				return Withdrawal{}, err
+			}
+			return *w, &RejectedError{ID: req.ID, Reason: err}
+		}
+	}
+	for _, policy := range p.cfg.Policies {
+		policy.Accept(req)
+	}
+	if err := p.update(w); err != nil {
+		return Withdrawal{}, err
+	}
+	p.withdrawals[req.ID] = w
+	p.queue = append(p.queue, req.ID)
+	return *w, nil
+}
+
+func validate(req Request) error {
+	switch {
+	case req.ID == "":
+		return fmt.Errorf("withdrawal without id")
+	case len(req.To) != ctypes.AddrLen:
+		return fmt.Errorf("withdrawal %s: invalid address", req.ID)
+	case len(req.Coins) == 0 || !req.Coins.Sort().IsValid():
+		return fmt.Errorf("withdrawal %s: invalid coins %s", req.ID, req.Coins)
+	}
+	return nil
+}
+
+// Get returns a withdrawal still known to the pipeline.
+func (p *Pipeline) Get(id string) (Withdrawal, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	w, ok := p.withdrawals[id]
+	if !ok {
+		return Withdrawal{}, false
+	}
+	return *w, true
+}
+
+// Run processes every interval until ctx is done. Errors of a cycle go to
+// onError if it is set, the next cycle tries again.
+func (p *Pipeline) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Process(); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process runs one cycle: it settles the batches sent before and sends the
+// queue.
+func (p *Pipeline) Process() error {
+	p.process.Lock()
+	defer p.process.Unlock()
+	if err := p.settle(); err != nil {
+		return err
+	}
+	return p.send()
+}
+
+// settle confirms the sent batches that committed, queues again the ones that
+// failed or can no longer commit, and broadcasts the others again.
+func (p *Pipeline) settle() error {
+	batches := p.sentBatches()
+	if len(batches) == 0 {
+		return nil
+	}
+	// the account first: a batch still missing after it was read, with a
+	// sequence below the one of the account, is lost for good
+	acc, err := p.client.GetAccount(p.cfg.From)
+	if err != nil {
+		return err
+	}
+	for _, b := range batches {
+		res, err := p.client.Tx(b.hash, false)
+		switch {
+		case err == nil && res.TxResult.Code == 0:
+			err = p.finish(b, res.Height)
+		case err == nil:
+			err = p.retry(b, fmt.Errorf("tx %X failed: %s", b.hash, res.TxResult.Log), true)
+		case !strings.Contains(err.Error(), "not found"):
+		case acc != nil && acc.GetSequence() > b.sequence:
+			p.synced = false
+			err = p.retry(b, fmt.Errorf("tx %X dropped, sequence %d was used by another tx", b.hash, b.sequence), true)
+		default:
+			var broadcast *core_types.ResultBroadcastTx
+			if broadcast, err = p.client.BroadcastIdempotent(b.tx, rpc.Sync); err == nil && broadcast.Code != 0 {
+				err = p.rejected(b, broadcast)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send signs and broadcasts the queue in batches.
+func (p *Pipeline) send() error {
+	if err := p.sync(); err != nil {
+		return err
+	}
+	for {
+		b := p.nextBatch()
+		if b == nil {
+			return nil
+		}
+		signedTx, err := p.client.SignMsg(b.msg(p.cfg.From), tx.WithAcNumAndSequence(p.accountNumber, b.sequence), tx.WithMemo(b.memo))
+		if err != nil {
+			p.requeue(b)
+			return err
+		}
+		b.tx, b.hash = signedTx, tx.Hash(signedTx)
+		res, err := p.client.BroadcastIdempotent(signedTx, rpc.Sync)
+		if err != nil {
+			if ambiguous(err) {
+				// it may have reached the node, settle finds out
+				p.sequence++
+				if sentErr := p.sent(b); sentErr != nil {
+					return sentErr
+				}
+				return err
+			}
+			p.synced = false
+			if retryErr := p.retry(b, err, types.Classify(err) != types.ErrorClassSequence); retryErr != nil {
+				return retryErr
+			}
+			return err
+		}
+		if res.Code != 0 {
+			return p.rejected(b, res)
+		}
+		p.sequence++
+		if err := p.sent(b); err != nil {
+			return err
+		}
+	}
+}
+
+// ambiguous reports whether a broadcast that failed with err may still have
+// reached the node.
+func ambiguous(err error) bool {
+	switch types.Classify(err) {
+	case types.ErrorClassNetwork, types.ErrorClassTimeout, types.ErrorClassUnavailable, types.ErrorClassUnknown:
+		return true
+	}
+	return false
+}
+
+func (p *Pipeline) sync() error {
+	if p.synced {
+		return nil
+	}
+	acc, err := p.client.GetAccount(p.cfg.From)
+	if err != nil {
+		return err
+	}
+	if acc == nil {
+		return fmt.Errorf("hot wallet %s does not exist", p.cfg.From)
+	}
+	p.accountNumber, p.sequence, p.synced = acc.GetAccountNumber(), acc.GetSequence(), true
+	return nil
+}
+
+// rejected handles a batch that failed CheckTx, the sequence is synced again.
+// Stale sequences are not the fault of the withdrawals and do not count as an
+// attempt.
+func (p *Pipeline) rejected(b *batch, res *core_types.ResultBroadcastTx) error {
+	p.synced = false
+	class := types.ClassifyABCICode(res.Code)
+	return p.retry(b, fmt.Errorf("tx %X rejected: %s", b.hash, res.Log), class != types.ErrorClassSequence)
+}
+
+// batch is withdrawals sent in one tx.
+type batch struct {
+	ids       []string
+	transfers []msg.Transfer
+	memo      string
+	sequence  int64
+	tx        []byte
+	hash      []byte
+}
+
+func (b *batch) msg(from ctypes.AccAddress) msg.Msg {
+	var total ctypes.Coins
+	for _, t := range b.transfers {
+		total = total.Plus(t.Coins)
+	}
+	return msg.CreateSendMsg(from, total, b.transfers)
+}
+
+// nextBatch takes withdrawals off the queue: one with a memo alone, or up to
+// MaxBatch without.
+func (p *Pipeline) nextBatch() *batch {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if len(p.queue) == 0 {
+		return nil
+	}
+	b := &batch{sequence: p.sequence}
+	add := func(w *Withdrawal) {
+		b.ids = append(b.ids, w.ID)
+		b.transfers = append(b.transfers, msg.Transfer{ToAddr: w.To, Coins: w.Coins})
+	}
+	if first := p.withdrawals[p.queue[0]]; first.Memo != "" {
+		add(first)
+		b.memo = first.Memo
+		p.queue = p.queue[1:]
+		return b
+	}
+	rest := p.queue[:0]
+	for _, id := range p.queue {
+		if w := p.withdrawals[id]; len(b.ids) < p.cfg.MaxBatch && w.Memo == "" {
+			add(w)
+		} else {
+			rest = append(rest, id)
+		}
+	}
+	p.queue = rest
+	return b
+}
+
+// sentBatches groups the sent withdrawals by tx, lowest sequence first: a
+// batch cannot commit before the ones signed ahead of it.
+func (p *Pipeline) sentBatches() []*batch {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	var batches []*batch
+	byHash := map[string]*batch{}
+	for _, w := range p.withdrawals {
+		if w.Status != Sent {
+			continue
+		}
+		b, ok := byHash[w.TxHash]
+		if !ok {
+			b = &batch{memo: w.Memo, sequence: w.Sequence, tx: w.Tx, hash: tx.Hash(w.Tx)}
+			byHash[w.TxHash] = b
+			batches = append(batches, b)
+		}
+		b.ids = append(b.ids, w.ID)
+	}
+	sort.Slice(batches, func(i, j int) bool { return batches[i].sequence < batches[j].sequence })
+	return batches
+}
+
+func (p *Pipeline) sent(b *batch) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, id := range b.ids {
+		w := p.withdrawals[id]
+		w.Status, w.Error = Sent, ""
+		w.TxHash, w.Sequence, w.Tx = fmt.Sprintf("%X", b.hash), b.sequence, b.tx
+		if err := p.update(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Pipeline) finish(b *batch, height int64) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, id := range b.ids {
+		w := p.withdrawals[id]
+		w.Status, w.Height, w.Tx = Confirmed, height, nil
+		if err := p.update(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retry queues the withdrawals of a failed batch again, ahead of the queue,
+// or fails the ones out of attempts.
+func (p *Pipeline) retry(b *batch, cause error, counts bool) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	var requeued []string
+	for _, id := range b.ids {
+		w := p.withdrawals[id]
+		w.Error, w.Tx = cause.Error(), nil
+		if counts {
+			w.Attempts++
+		}
+		if w.Attempts >= p.cfg.MaxAttempts {
+			w.Status = Failed
+		} else {
+			w.Status = Queued
+			requeued = append(requeued, id)
+		}
+		if err := p.update(w); err != nil {
+			return err
+		}
+	}
+	p.queue = append(requeued, p.queue...)
+	return nil
+}
+
+// requeue puts back a batch that was never sent.
+func (p *Pipeline) requeue(b *batch) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.queue = append(append([]string(nil), b.ids...), p.queue...)
+}
+
+// update stamps, saves and reports a change of w. It is called locked.
+func (p *Pipeline) update(w *Withdrawal) error {
+	w.UpdatedAt = p.now()
+	if err := p.cfg.Store.Save(*w); err != nil {
+		return fmt.Errorf("save withdrawal %s: %v", w.ID, err)
+	}
+	if p.cfg.OnUpdate != nil {
+		p.cfg.OnUpdate(*w)
+	}
+	return nil
+}
+
+// Prune forgets the withdrawals done before t, the store keeps them.
+func (p *Pipeline) Prune(t time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for id, w := range p.withdrawals {
+		if w.Done() && w.UpdatedAt.Before(t) {
+			delete(p.withdrawals, id)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package withdraw
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/binance-chain/go-sdk/client/rpc"
+	ctypes "github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+type fakeClient struct {
+	sequence   int64
+	signed     []tx.StdSignMsg
+	broadcasts int
+	committed  map[string]*rpc.ResultTx
+}
+
+func (c *fakeClient) GetAccount(addr ctypes.AccAddress) (ctypes.Account, error) {
+	return &ctypes.AppAccount{BaseAccount: ctypes.BaseAccount{Address: addr, AccountNumber: 3, Sequence: c.sequence}}, nil
+}
+
+func (c *fakeClient) SignMsg(m msg.Msg, options ...tx.Option) ([]byte, error) {
+	signMsg := &tx.StdSignMsg{Msgs: []msg.Msg{m}}
+	for _, option := range options {
+		signMsg = option(signMsg)
+	}
+	c.signed = append(c.signed, *signMsg)
+	return json.Marshal(signMsg)
+}
+
+func (c *fakeClient) BroadcastIdempotent(signedTx []byte, syncType rpc.SyncType) (*core_types.ResultBroadcastTx, error) {
+	c.broadcasts++
+	return &core_types.ResultBroadcastTx{Hash: tx.Hash(signedTx)}, nil
+}
+
+func (c *fakeClient) Tx(hash []byte, prove bool) (*rpc.ResultTx, error) {
+	if res, ok := c.committed[string(hash)]; ok {
+		return res, nil
+	}
+	return nil, errors.New("tx not found")
+}
+
+func addr(s string) ctypes.AccAddress {
+	return ctypes.AccAddress([]byte(s + "-address-bytes-padding")[:ctypes.AddrLen])
+}
+
+func TestPipeline(t *testing.T) {
+	client := &fakeClient{sequence: 5, committed: map[string]*rpc.ResultTx{}}
+	addresses := NewAddressList()
+	addresses.Deny(addr("mallory"))
+	var updates []Status
+	p, err := New(client, Config{
+		From:     addr("hot"),
+		Policies: []Policy{addresses, NewLimits(Limit{Denom: "BNB", MaxPerWindow: 100, Window: time.Hour})},
+		OnUpdate: func(w Withdrawal) { updates = append(updates, w.Status) },
+	})
+	assert.NoError(t, err)
+
+	bnb := func(amount int64) ctypes.Coins { return ctypes.Coins{{Denom: "BNB", Amount: amount}} }
+	_, err = p.Submit(Request{ID: "1", To: addr("alice"), Coins: bnb(40)})
+	assert.NoError(t, err)
+	_, err = p.Submit(Request{ID: "2", To: addr("bob"), Coins: bnb(30), Memo: "tag 9"})
+	assert.NoError(t, err)
+	_, err = p.Submit(Request{ID: "3", To: addr("carol"), Coins: bnb(20)})
+	assert.NoError(t, err)
+	rejected, err := p.Submit(Request{ID: "4", To: addr("mallory"), Coins: bnb(1)})
+	assert.IsType(t, &RejectedError{}, err)
+	assert.Equal(t, Rejected, rejected.Status)
+	_, err = p.Submit(Request{ID: "5", To: addr("dave"), Coins: bnb(11)})
+	assert.Error(t, err, "over the hourly limit")
+	again, err := p.Submit(Request{ID: "1", To: addr("alice"), Coins: bnb(40)})
+	assert.NoError(t, err)
+	assert.Equal(t, Queued, again.Status)
+
+	// the withdrawals without memo share a batch, the one with a memo goes alone
+	assert.NoError(t, p.Process())
+	assert.Len(t, client.signed, 2)
+	assert.Equal(t, int64(5), client.signed[0].Sequence)
+	assert.Len(t, client.signed[0].Msgs[0].(msg.SendMsg).Outputs, 2)
+	assert.Equal(t, int64(6), client.signed[1].Sequence)
+	assert.Equal(t, "tag 9", client.signed[1].Memo)
+	w1, _ := p.Get("1")
+	assert.Equal(t, Sent, w1.Status)
+
+	// the first batch commits, another tx takes the sequence of the second
+	hash, _ := p.Get("3")
+	assert.Equal(t, w1.TxHash, hash.TxHash)
+	client.committed[string(tx.Hash(w1.Tx))] = &rpc.ResultTx{Height: 70}
+	client.sequence = 7
+	assert.NoError(t, p.Process())
+	w1, _ = p.Get("1")
+	assert.Equal(t, Confirmed, w1.Status)
+	assert.Equal(t, int64(70), w1.Height)
+	w2, _ := p.Get("2")
+	assert.Equal(t, Sent, w2.Status)
+	assert.Equal(t, 1, w2.Attempts)
+	assert.Equal(t, int64(7), w2.Sequence, "signed again after a resync")
+	assert.Len(t, client.signed, 3)
+
+	// pending batches are broadcast again as they are
+	broadcasts := client.broadcasts
+	assert.NoError(t, p.Process())
+	assert.Equal(t, broadcasts+1, client.broadcasts)
+	assert.Len(t, client.signed, 3)
+	assert.Contains(t, updates, Rejected)
+}
+
+func TestLimitsWindow(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	limits := NewLimits(Limit{Denom: "BNB", MaxPerRequest: 50, MaxPerWindow: 80, Window: time.Hour})
+	limits.now = func() time.Time { return now }
+	req := Request{Coins: ctypes.Coins{{Denom: "BNB", Amount: 50}}}
+
+	assert.NoError(t, limits.Check(req))
+	limits.Accept(req)
+	assert.Error(t, limits.Check(req))
+	assert.Error(t, limits.Check(Request{Coins: ctypes.Coins{{Denom: "BNB", Amount: 51}}}))
+	assert.NoError(t, limits.Check(Request{Coins: ctypes.Coins{{Denom: "XYZ-000", Amount: 1000}}}))
+
+	now = now.Add(time.Hour)
+	assert.NoError(t, limits.Check(req))
+}